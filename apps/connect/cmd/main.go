@@ -1 +1,112 @@
-package cmd
\ No newline at end of file
+package main
+
+import (
+	"context"
+	"log"
+	"net"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"ChatServer/apps/connect/internal/conn"
+	"ChatServer/apps/connect/internal/handler"
+	"ChatServer/apps/connect/internal/presence"
+	"ChatServer/apps/connect/internal/repository"
+	"ChatServer/apps/connect/internal/server"
+	"ChatServer/apps/connect/internal/service"
+	connectpb "ChatServer/apps/connect/pb"
+	"ChatServer/config"
+	"ChatServer/pkg/logger"
+	pkgredis "ChatServer/pkg/redis"
+	"ChatServer/pkg/registry"
+
+	"google.golang.org/grpc"
+)
+
+func main() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// 1. 初始化日志
+	logCfg := config.DefaultLoggerConfig()
+	zl, err := logger.Build(logCfg)
+	if err != nil {
+		log.Fatalf("初始化日志失败: %v", err)
+	}
+	logger.ReplaceGlobal(zl)
+	defer zl.Sync()
+
+	// 2. 初始化Redis（长连接鉴权强依赖 Redis 中的 Token 状态，失败直接退出）
+	redisCfg := config.DefaultRedisConfig()
+	redisClient, err := pkgredis.Build(redisCfg)
+	if err != nil {
+		log.Fatalf("初始化Redis失败: %v", err)
+	}
+	pkgredis.ReplaceGlobal(redisClient)
+
+	// 3. 组装依赖 - Repository 层
+	deviceRepo := repository.NewDeviceRepository(redisClient)
+
+	// 4. 连接管理器（心跳 + 死链清理）+ 连接路由同步（上下线写注册表 + 租约续期）
+	manager := conn.NewManager(conn.DefaultOptions())
+	presenceOpts := presence.DefaultOptions()
+	connPresence := presence.New(presenceOpts, registry.New(redisClient, registry.DefaultTTL), manager)
+	manager.SetHooks(connPresence.Hooks())
+	go manager.Run(ctx)
+	go connPresence.Run(ctx)
+
+	// 5. 组装依赖 - Service 层
+	authService := service.NewAuthService(deviceRepo)
+	connectService := service.NewConnectService(manager)
+
+	// 6. 启动 WebSocket Server
+	wsOpts := server.DefaultWSOptions()
+	wsServer := server.NewWSServer(wsOpts, authService, manager)
+	go func() {
+		if err := wsServer.Start(ctx); err != nil {
+			log.Fatalf("启动WebSocket服务失败: %v", err)
+		}
+	}()
+
+	// 7. 启动 TCP Server（原生客户端）
+	tcpOpts := server.DefaultTCPOptions()
+	tcpServer := server.NewTCPServer(tcpOpts, authService, manager)
+	go func() {
+		if err := tcpServer.Start(ctx); err != nil {
+			log.Fatalf("启动TCP服务失败: %v", err)
+		}
+	}()
+
+	// 8. 启动 gRPC Server（供其他服务按连接路由回调本节点）
+	grpcAddr := ":9092"
+	lis, err := net.Listen("tcp", grpcAddr)
+	if err != nil {
+		log.Fatalf("监听gRPC端口失败: %v", err)
+	}
+	grpcServer := grpc.NewServer()
+	connectpb.RegisterConnectServiceServer(grpcServer, handler.NewConnectHandler(connectService))
+	go func() {
+		if err := grpcServer.Serve(lis); err != nil {
+			log.Fatalf("启动gRPC服务失败: %v", err)
+		}
+	}()
+
+	logger.Info(ctx, "Connect 服务启动成功",
+		logger.String("node_id", presenceOpts.NodeID),
+		logger.String("ws_address", wsOpts.Address),
+		logger.String("ws_path", wsOpts.Path),
+		logger.String("tcp_address", tcpOpts.Address),
+		logger.String("grpc_address", grpcAddr),
+	)
+
+	// 9. 优雅停机
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
+	sig := <-quit
+	logger.Info(ctx, "收到关闭信号，开始优雅停机...", logger.String("signal", sig.String()))
+
+	cancel()
+	grpcServer.GracefulStop()
+	manager.CloseAll("server shutdown")
+	logger.Info(ctx, "Connect 服务已退出")
+}
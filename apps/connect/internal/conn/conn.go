@@ -0,0 +1,177 @@
+package conn
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"ChatServer/pkg/logger"
+	"ChatServer/pkg/util"
+)
+
+var (
+	// ErrConnClosed 连接已关闭
+	ErrConnClosed = errors.New("connection closed")
+
+	// ErrSendQueueFull 发送队列已满（客户端消费过慢）
+	ErrSendQueueFull = errors.New("send queue full")
+)
+
+// kickFlushTimeout 踢下线通知的最长写出等待时间，超时后直接关闭
+const kickFlushTimeout = 3 * time.Second
+
+// kick 踢下线请求：写出最后一条通知后关闭连接
+type kick struct {
+	data   []byte
+	reason string
+}
+
+// Transport 底层传输抽象，屏蔽 WebSocket / TCP 的差异。
+// WriteMessage 与 Ping 只会在连接自己的写协程中被调用，无需实现方自行加锁。
+type Transport interface {
+	// WriteMessage 写出一条完整的消息
+	WriteMessage(data []byte) error
+	// Ping 服务端主动心跳（不支持时返回 nil 即可）
+	Ping() error
+	// Close 关闭底层连接
+	Close() error
+	// RemoteAddr 对端地址
+	RemoteAddr() string
+}
+
+// Conn 一条已鉴权的长连接，同一 (user_uuid, device_id) 在节点内只保留一条。
+type Conn struct {
+	ctx       context.Context
+	userUUID  string
+	deviceID  string
+	transport Transport
+
+	sendCh     chan []byte
+	kickCh     chan kick
+	lastActive atomic.Int64 // 最后一次收到客户端数据的时间（UnixNano）
+	closeOnce  sync.Once
+	closed     chan struct{}
+	onClose    func(c *Conn)
+}
+
+// newConn 创建连接（由 Manager 调用）
+func newConn(userUUID, deviceID string, transport Transport, sendQueueSize int) *Conn {
+	ctx := context.WithValue(context.Background(), util.ContextKeyUserUUID, userUUID)
+	ctx = context.WithValue(ctx, util.ContextKeyDeviceID, deviceID)
+
+	c := &Conn{
+		ctx:       ctx,
+		userUUID:  userUUID,
+		deviceID:  deviceID,
+		transport: transport,
+		sendCh:    make(chan []byte, sendQueueSize),
+		kickCh:    make(chan kick, 1),
+		closed:    make(chan struct{}),
+	}
+	c.Touch()
+	return c
+}
+
+// UserUUID 连接所属用户
+func (c *Conn) UserUUID() string { return c.userUUID }
+
+// DeviceID 连接所属设备
+func (c *Conn) DeviceID() string { return c.deviceID }
+
+// Context 携带 user_uuid / device_id 的日志上下文
+func (c *Conn) Context() context.Context { return c.ctx }
+
+// Done 连接关闭时被关闭
+func (c *Conn) Done() <-chan struct{} { return c.closed }
+
+// Touch 刷新活跃时间（收到客户端任意数据时调用）
+func (c *Conn) Touch() {
+	c.lastActive.Store(time.Now().UnixNano())
+}
+
+// LastActive 最后活跃时间
+func (c *Conn) LastActive() time.Time {
+	return time.Unix(0, c.lastActive.Load())
+}
+
+// Send 异步投递一条消息
+// 发送队列满时直接关闭连接，由客户端重连后通过 seq 拉取补齐，避免慢连接拖垮节点内存。
+func (c *Conn) Send(data []byte) error {
+	select {
+	case <-c.closed:
+		return ErrConnClosed
+	default:
+	}
+
+	select {
+	case c.sendCh <- data:
+		return nil
+	case <-c.closed:
+		return ErrConnClosed
+	default:
+		logger.Warn(c.ctx, "长连接发送队列已满，关闭连接",
+			logger.String("remote_addr", c.transport.RemoteAddr()),
+		)
+		c.Close("send queue full")
+		return ErrSendQueueFull
+	}
+}
+
+// Kick 优先写出一条通知（如踢下线帧）后关闭连接，队列中尚未写出的消息直接丢弃
+func (c *Conn) Kick(data []byte, reason string) {
+	select {
+	case c.kickCh <- kick{data: data, reason: reason}:
+		// 写协程阻塞在慢连接上时兜底关闭
+		time.AfterFunc(kickFlushTimeout, func() { c.Close(reason) })
+	default:
+		// 已有踢下线请求在处理
+	}
+}
+
+// Close 关闭连接（幂等）
+func (c *Conn) Close(reason string) {
+	c.closeOnce.Do(func() {
+		close(c.closed)
+		_ = c.transport.Close()
+		logger.Info(c.ctx, "长连接关闭",
+			logger.String("remote_addr", c.transport.RemoteAddr()),
+			logger.String("reason", reason),
+		)
+		if c.onClose != nil {
+			c.onClose(c)
+		}
+	})
+}
+
+// writeLoop 连接的唯一写协程：发送业务消息 + 定时服务端心跳
+func (c *Conn) writeLoop(pingInterval time.Duration) {
+	var tick <-chan time.Time
+	if pingInterval > 0 {
+		ticker := time.NewTicker(pingInterval)
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+
+	for {
+		select {
+		case data := <-c.sendCh:
+			if err := c.transport.WriteMessage(data); err != nil {
+				c.Close("write error: " + err.Error())
+				return
+			}
+		case k := <-c.kickCh:
+			_ = c.transport.WriteMessage(k.data)
+			c.Close(k.reason)
+			return
+		case <-tick:
+			if err := c.transport.Ping(); err != nil {
+				c.Close("ping error: " + err.Error())
+				return
+			}
+		case <-c.closed:
+			return
+		}
+	}
+}
@@ -0,0 +1,197 @@
+package conn
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"ChatServer/pkg/logger"
+)
+
+// Options 连接管理参数
+type Options struct {
+	HeartbeatInterval time.Duration // 服务端心跳 / 死链扫描间隔
+	HeartbeatTimeout  time.Duration // 超过该时间未收到客户端数据视为死链
+	SendQueueSize     int           // 单连接发送队列长度
+}
+
+// DefaultOptions 返回默认连接管理参数
+func DefaultOptions() Options {
+	return Options{
+		HeartbeatInterval: 30 * time.Second,
+		HeartbeatTimeout:  90 * time.Second, // 容忍丢失 2 次心跳
+		SendQueueSize:     256,
+	}
+}
+
+// Hooks 连接上下线回调，在触发方协程中同步调用
+// 同设备新连接替换旧连接时只触发新连接的 OnConnect，旧连接不触发 OnDisconnect。
+type Hooks struct {
+	OnConnect    func(c *Conn)
+	OnDisconnect func(c *Conn)
+}
+
+// Manager 节点内连接管理器
+// 索引结构：user_uuid -> device_id -> *Conn，保证同一 (user_uuid, device_id) 只保留一条连接。
+type Manager struct {
+	opts  Options
+	hooks Hooks
+
+	mu    sync.RWMutex
+	conns map[string]map[string]*Conn
+	count int
+}
+
+// NewManager 创建连接管理器
+func NewManager(opts Options) *Manager {
+	def := DefaultOptions()
+	if opts.HeartbeatInterval <= 0 {
+		opts.HeartbeatInterval = def.HeartbeatInterval
+	}
+	if opts.HeartbeatTimeout <= 0 {
+		opts.HeartbeatTimeout = def.HeartbeatTimeout
+	}
+	if opts.SendQueueSize <= 0 {
+		opts.SendQueueSize = def.SendQueueSize
+	}
+	return &Manager{
+		opts:  opts,
+		conns: make(map[string]map[string]*Conn),
+	}
+}
+
+// SetHooks 设置连接上下线回调，需在接入服务启动前调用
+func (m *Manager) SetHooks(hooks Hooks) {
+	m.hooks = hooks
+}
+
+// Register 注册一条已鉴权的连接并启动写协程
+// 若同一设备已有旧连接（断线重连、重复登录），旧连接会被关闭。
+func (m *Manager) Register(userUUID, deviceID string, transport Transport) *Conn {
+	c := newConn(userUUID, deviceID, transport, m.opts.SendQueueSize)
+	c.onClose = m.unregister
+
+	m.mu.Lock()
+	devices, ok := m.conns[userUUID]
+	if !ok {
+		devices = make(map[string]*Conn)
+		m.conns[userUUID] = devices
+	}
+	old := devices[deviceID]
+	devices[deviceID] = c
+	if old == nil {
+		m.count++
+	}
+	m.mu.Unlock()
+
+	if old != nil {
+		// 先替换索引再关闭，旧连接的 onClose 不会误删新连接
+		old.Close("replaced by new connection")
+	}
+	if m.hooks.OnConnect != nil {
+		m.hooks.OnConnect(c)
+	}
+
+	go c.writeLoop(m.opts.HeartbeatInterval)
+	return c
+}
+
+// unregister 连接关闭时回调，只移除索引中仍指向自身的连接
+func (m *Manager) unregister(c *Conn) {
+	m.mu.Lock()
+	devices, ok := m.conns[c.userUUID]
+	if !ok || devices[c.deviceID] != c {
+		m.mu.Unlock()
+		return
+	}
+	delete(devices, c.deviceID)
+	if len(devices) == 0 {
+		delete(m.conns, c.userUUID)
+	}
+	m.count--
+	m.mu.Unlock()
+
+	if m.hooks.OnDisconnect != nil {
+		m.hooks.OnDisconnect(c)
+	}
+}
+
+// Get 获取指定设备的连接，不存在返回 nil
+func (m *Manager) Get(userUUID, deviceID string) *Conn {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.conns[userUUID][deviceID]
+}
+
+// GetByUser 获取用户在本节点的所有连接
+func (m *Manager) GetByUser(userUUID string) []*Conn {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	devices := m.conns[userUUID]
+	result := make([]*Conn, 0, len(devices))
+	for _, c := range devices {
+		result = append(result, c)
+	}
+	return result
+}
+
+// Count 当前连接数
+func (m *Manager) Count() int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.count
+}
+
+// Snapshot 复制一份连接列表，避免持锁执行耗时操作（死链扫描、路由续期）
+func (m *Manager) Snapshot() []*Conn {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	result := make([]*Conn, 0, m.count)
+	for _, devices := range m.conns {
+		for _, c := range devices {
+			result = append(result, c)
+		}
+	}
+	return result
+}
+
+// Run 定时扫描并关闭死链，直到 ctx 取消
+func (m *Manager) Run(ctx context.Context) {
+	ticker := time.NewTicker(m.opts.HeartbeatInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			m.closeDeadConns(ctx, now)
+		}
+	}
+}
+
+// closeDeadConns 关闭超过心跳超时时间未活跃的连接
+func (m *Manager) closeDeadConns(ctx context.Context, now time.Time) {
+	dead := 0
+	for _, c := range m.Snapshot() {
+		if now.Sub(c.LastActive()) > m.opts.HeartbeatTimeout {
+			c.Close("heartbeat timeout")
+			dead++
+		}
+	}
+	if dead > 0 {
+		logger.Info(ctx, "清理死链完成",
+			logger.Int("dead", dead),
+			logger.Int("online", m.Count()),
+		)
+	}
+}
+
+// CloseAll 关闭所有连接（节点下线时调用）
+func (m *Manager) CloseAll(reason string) {
+	for _, c := range m.Snapshot() {
+		c.Close(reason)
+	}
+}
@@ -0,0 +1,154 @@
+package conn
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	"ChatServer/pkg/logger"
+)
+
+// init 初始化 logger（测试模式，不输出日志）
+func init() {
+	logger.ReplaceGlobal(zap.NewNop())
+}
+
+// fakeTransport 记录写入数据的内存传输
+type fakeTransport struct {
+	mu       sync.Mutex
+	messages [][]byte
+	closed   bool
+}
+
+func (t *fakeTransport) WriteMessage(data []byte) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.messages = append(t.messages, data)
+	return nil
+}
+
+func (t *fakeTransport) Ping() error { return nil }
+
+func (t *fakeTransport) Close() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.closed = true
+	return nil
+}
+
+func (t *fakeTransport) RemoteAddr() string { return "127.0.0.1:0" }
+
+func (t *fakeTransport) isClosed() bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.closed
+}
+
+func (t *fakeTransport) messageCount() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return len(t.messages)
+}
+
+// TestManager_RegisterReplacesSameDevice 测试同一设备重复连接时旧连接被踢掉
+func TestManager_RegisterReplacesSameDevice(t *testing.T) {
+	m := NewManager(DefaultOptions())
+
+	t1 := &fakeTransport{}
+	c1 := m.Register("u1", "d1", t1)
+	t2 := &fakeTransport{}
+	c2 := m.Register("u1", "d1", t2)
+
+	assert.True(t, t1.isClosed(), "旧连接应该被关闭")
+	assert.False(t, t2.isClosed(), "新连接不应该被关闭")
+	assert.Same(t, c2, m.Get("u1", "d1"), "索引应该指向新连接")
+	assert.Equal(t, 1, m.Count(), "同一设备只保留一条连接")
+
+	// 旧连接的关闭回调不能误删新连接
+	c1.Close("again")
+	assert.Same(t, c2, m.Get("u1", "d1"))
+
+	// 其他设备互不影响
+	m.Register("u1", "d2", &fakeTransport{})
+	assert.Len(t, m.GetByUser("u1"), 2)
+	assert.Equal(t, 2, m.Count())
+
+	c2.Close("bye")
+	assert.Nil(t, m.Get("u1", "d1"))
+	assert.Equal(t, 1, m.Count())
+}
+
+// TestManager_CloseDeadConns 测试心跳超时的连接被清理
+func TestManager_CloseDeadConns(t *testing.T) {
+	m := NewManager(Options{HeartbeatInterval: time.Hour, HeartbeatTimeout: time.Minute})
+
+	deadTransport := &fakeTransport{}
+	dead := m.Register("u1", "d1", deadTransport)
+	aliveTransport := &fakeTransport{}
+	m.Register("u2", "d1", aliveTransport)
+
+	dead.lastActive.Store(time.Now().Add(-2 * time.Minute).UnixNano())
+	m.closeDeadConns(context.Background(), time.Now())
+
+	assert.True(t, deadTransport.isClosed(), "超时连接应该被关闭")
+	assert.False(t, aliveTransport.isClosed(), "活跃连接不应该被关闭")
+	assert.Equal(t, 1, m.Count())
+}
+
+// TestConn_Send 测试消息经写协程写出
+func TestConn_Send(t *testing.T) {
+	m := NewManager(DefaultOptions())
+	transport := &fakeTransport{}
+	c := m.Register("u1", "d1", transport)
+
+	assert.NoError(t, c.Send([]byte("hello")))
+	assert.Eventually(t, func() bool { return transport.messageCount() == 1 }, time.Second, 10*time.Millisecond)
+
+	c.Close("bye")
+	assert.ErrorIs(t, c.Send([]byte("late")), ErrConnClosed)
+}
+
+// TestManager_Hooks 测试上下线回调（同设备替换时旧连接不触发下线）
+func TestManager_Hooks(t *testing.T) {
+	m := NewManager(DefaultOptions())
+
+	var mu sync.Mutex
+	var events []string
+	m.SetHooks(Hooks{
+		OnConnect: func(c *Conn) {
+			mu.Lock()
+			defer mu.Unlock()
+			events = append(events, "up:"+c.DeviceID())
+		},
+		OnDisconnect: func(c *Conn) {
+			mu.Lock()
+			defer mu.Unlock()
+			events = append(events, "down:"+c.DeviceID())
+		},
+	})
+
+	m.Register("u1", "d1", &fakeTransport{})
+	c2 := m.Register("u1", "d1", &fakeTransport{})
+	c2.Close("bye")
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, []string{"up:d1", "up:d1", "down:d1"}, events)
+}
+
+// TestConn_Kick 测试踢下线通知写出后连接关闭
+func TestConn_Kick(t *testing.T) {
+	m := NewManager(DefaultOptions())
+	transport := &fakeTransport{}
+	c := m.Register("u1", "d1", transport)
+
+	c.Kick([]byte("kick"), "kicked")
+
+	assert.Eventually(t, transport.isClosed, time.Second, 10*time.Millisecond)
+	assert.Equal(t, 1, transport.messageCount(), "踢下线通知应该在关闭前写出")
+	assert.Nil(t, m.Get("u1", "d1"))
+}
@@ -0,0 +1,31 @@
+package handler
+
+import (
+	"ChatServer/apps/connect/internal/service"
+	pb "ChatServer/apps/connect/pb"
+	"context"
+)
+
+// ConnectHandler 节点连接操作服务Handler
+type ConnectHandler struct {
+	pb.UnimplementedConnectServiceServer
+
+	connectService service.IConnectService
+}
+
+// NewConnectHandler 创建节点连接操作Handler实例
+func NewConnectHandler(connectService service.IConnectService) *ConnectHandler {
+	return &ConnectHandler{
+		connectService: connectService,
+	}
+}
+
+// KickConnection 踢下线指定设备的长连接
+func (h *ConnectHandler) KickConnection(ctx context.Context, req *pb.KickConnectionRequest) (*pb.KickConnectionResponse, error) {
+	return h.connectService.KickConnection(ctx, req)
+}
+
+// PushMessage 向本节点上指定用户的全部设备下发 Push 帧
+func (h *ConnectHandler) PushMessage(ctx context.Context, req *pb.PushMessageRequest) (*pb.PushMessageResponse, error) {
+	return h.connectService.PushMessage(ctx, req)
+}
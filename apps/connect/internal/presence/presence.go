@@ -0,0 +1,134 @@
+// Package presence 将本节点的连接上下线同步到分布式连接路由注册表，并定期续期租约。
+package presence
+
+import (
+	"context"
+	"os"
+	"time"
+
+	"ChatServer/apps/connect/internal/conn"
+	"ChatServer/pkg/logger"
+	"ChatServer/pkg/registry"
+)
+
+const (
+	// registryOpTimeout 单次注册/注销的超时时间，避免 Redis 抖动拖住连接建立与关闭
+	registryOpTimeout = 2 * time.Second
+
+	// refreshBatchSize 续期时每批路由数
+	refreshBatchSize = 500
+)
+
+// Options 路由同步参数
+type Options struct {
+	NodeID          string        // 节点ID，集群内唯一
+	AdvertiseAddr   string        // 对其他服务暴露的 gRPC 地址（host:port）
+	RefreshInterval time.Duration // 租约续期间隔，应明显小于注册表 TTL
+}
+
+// DefaultOptions 返回默认路由同步参数：节点ID取主机名，gRPC 端口 9092
+func DefaultOptions() Options {
+	hostname, err := os.Hostname()
+	if err != nil || hostname == "" {
+		hostname = "localhost"
+	}
+	return Options{
+		NodeID:          hostname,
+		AdvertiseAddr:   hostname + ":9092",
+		RefreshInterval: registry.DefaultTTL / 3,
+	}
+}
+
+// Presence 连接路由同步器
+type Presence struct {
+	opts     Options
+	registry *registry.Registry
+	manager  *conn.Manager
+}
+
+// New 创建连接路由同步器
+func New(opts Options, reg *registry.Registry, manager *conn.Manager) *Presence {
+	if opts.RefreshInterval <= 0 {
+		opts.RefreshInterval = reg.TTL() / 3
+	}
+	return &Presence{
+		opts:     opts,
+		registry: reg,
+		manager:  manager,
+	}
+}
+
+// Hooks 返回连接上下线回调，交给 conn.Manager
+func (p *Presence) Hooks() conn.Hooks {
+	return conn.Hooks{
+		OnConnect:    p.onConnect,
+		OnDisconnect: p.onDisconnect,
+	}
+}
+
+// onConnect 连接建立时写入路由
+// 写入失败只记录日志，下一轮续期时补写
+func (p *Presence) onConnect(c *conn.Conn) {
+	ctx, cancel := context.WithTimeout(c.Context(), registryOpTimeout)
+	defer cancel()
+
+	if err := p.registry.Register(ctx, p.opts.NodeID, c.UserUUID(), c.DeviceID()); err != nil {
+		logger.Error(ctx, "写入连接路由失败", logger.ErrorField("error", err))
+	}
+}
+
+// onDisconnect 连接关闭时删除路由（仅当路由仍指向本节点）
+func (p *Presence) onDisconnect(c *conn.Conn) {
+	ctx, cancel := context.WithTimeout(c.Context(), registryOpTimeout)
+	defer cancel()
+
+	if err := p.registry.Unregister(ctx, p.opts.NodeID, c.UserUUID(), c.DeviceID()); err != nil {
+		logger.Warn(ctx, "删除连接路由失败，等待租约过期", logger.ErrorField("error", err))
+	}
+}
+
+// Run 注册节点地址并定期续期节点与连接租约，直到 ctx 取消
+// ctx 取消后删除节点地址，连接路由由 CloseAll 触发的下线回调删除。
+func (p *Presence) Run(ctx context.Context) {
+	p.refresh(ctx)
+
+	ticker := time.NewTicker(p.opts.RefreshInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			cleanupCtx, cancel := context.WithTimeout(context.Background(), registryOpTimeout)
+			if err := p.registry.UnregisterNode(cleanupCtx, p.opts.NodeID); err != nil {
+				logger.Warn(cleanupCtx, "删除节点地址失败", logger.ErrorField("error", err))
+			}
+			cancel()
+			return
+		case <-ticker.C:
+			p.refresh(ctx)
+		}
+	}
+}
+
+// refresh 续期节点地址与本节点持有的全部连接路由
+func (p *Presence) refresh(ctx context.Context) {
+	if err := p.registry.RegisterNode(ctx, p.opts.NodeID, p.opts.AdvertiseAddr); err != nil {
+		logger.Error(ctx, "续期节点地址失败", logger.ErrorField("error", err))
+	}
+
+	conns := p.manager.Snapshot()
+	routes := make([]registry.Route, 0, refreshBatchSize)
+	for i, c := range conns {
+		routes = append(routes, registry.Route{UserUUID: c.UserUUID(), DeviceID: c.DeviceID()})
+		if len(routes) < refreshBatchSize && i != len(conns)-1 {
+			continue
+		}
+		if err := p.registry.Refresh(ctx, p.opts.NodeID, routes); err != nil {
+			logger.Error(ctx, "续期连接路由失败",
+				logger.Int("batch", len(routes)),
+				logger.ErrorField("error", err),
+			)
+		}
+		routes = routes[:0]
+	}
+}
@@ -0,0 +1,49 @@
+package repository
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// deviceRepositoryImpl 设备会话数据访问层实现（只读 Redis）
+type deviceRepositoryImpl struct {
+	redisClient *redis.Client
+}
+
+// NewDeviceRepository 创建设备会话仓储实例
+func NewDeviceRepository(redisClient *redis.Client) IDeviceRepository {
+	return &deviceRepositoryImpl{redisClient: redisClient}
+}
+
+// accessTokenKey 构造 AccessToken 的 Redis Key
+// 注意：必须与 user 服务 deviceRepositoryImpl.accessTokenKey 保持一致
+func (r *deviceRepositoryImpl) accessTokenKey(userUUID, deviceID string) string {
+	return fmt.Sprintf("auth:at:%s:%s", userUUID, deviceID)
+}
+
+// md5Hash 计算字符串的 MD5 哈希（user 服务写入 Redis 时存储的是 Token 的 MD5）
+func md5Hash(s string) string {
+	h := md5.New()
+	h.Write([]byte(s))
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// VerifyAccessToken 验证 AccessToken 是否有效
+// 返回 true 表示 Token 有效且未被踢出
+func (r *deviceRepositoryImpl) VerifyAccessToken(ctx context.Context, userUUID, deviceID, accessToken string) (bool, error) {
+	key := r.accessTokenKey(userUUID, deviceID)
+	storedHash, err := r.redisClient.Get(ctx, key).Result()
+	if err != nil {
+		if err == redis.Nil {
+			// Key 不存在，说明 Token 已过期或被踢出
+			return false, nil
+		}
+		return false, WrapRedisError(err)
+	}
+
+	return storedHash == md5Hash(accessToken), nil
+}
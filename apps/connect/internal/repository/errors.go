@@ -0,0 +1,30 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// ==================== Repository 层统一错误定义 ====================
+
+var (
+	// ErrRedisNil Redis Key 不存在
+	ErrRedisNil = errors.New("redis: key not found")
+
+	// ErrRedis Redis 操作错误
+	ErrRedis = errors.New("redis error")
+)
+
+// WrapRedisError 包装 Redis 错误
+func WrapRedisError(err error) error {
+	if err == nil {
+		return nil
+	}
+	if errors.Is(err, redis.Nil) {
+		return ErrRedisNil
+	}
+	// 保留原始错误信息用于日志
+	return fmt.Errorf("%w: %v", ErrRedis, err)
+}
@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"context"
+)
+
+// ==================== 设备会话 Repository ====================
+
+// IDeviceRepository 接入层使用的设备会话数据访问接口
+// 说明：Connect 服务只做连接维护与消息转发，不读写 MySQL，这里仅依赖 Redis 中的 Token 状态。
+type IDeviceRepository interface {
+	// VerifyAccessToken 验证 AccessToken 是否有效（与 user 服务写入的 Redis Token 对比）
+	VerifyAccessToken(ctx context.Context, userUUID, deviceID, accessToken string) (bool, error)
+}
@@ -0,0 +1,26 @@
+package server
+
+import (
+	"ChatServer/apps/connect/internal/conn"
+	"ChatServer/apps/connect/protocol"
+	"ChatServer/pkg/logger"
+)
+
+// handleFrame 处理已鉴权连接上收到的上行帧（WebSocket 与 TCP 共用）
+// 调用方负责在收到任意数据后刷新连接活跃时间。
+func handleFrame(c *conn.Conn, f *protocol.Frame) {
+	switch f.Cmd {
+	case protocol.CmdHeartbeat:
+		if err := c.Send(protocol.Encode(protocol.NewFrame(protocol.CmdHeartbeatAck, f.Seq, nil))); err != nil {
+			logger.Debug(c.Context(), "心跳应答发送失败", logger.ErrorField("error", err))
+		}
+	case protocol.CmdPushAck:
+		// 推送确认暂只记录，投递状态跟踪由消息服务接入后处理
+		logger.Debug(c.Context(), "收到推送确认", logger.Int64("seq", int64(f.Seq)))
+	case protocol.CmdAuth:
+		// 连接已鉴权，重复鉴权帧直接忽略
+		logger.Debug(c.Context(), "忽略重复鉴权帧")
+	default:
+		logger.Debug(c.Context(), "忽略未知命令帧", logger.String("cmd", f.Cmd.String()))
+	}
+}
@@ -0,0 +1,211 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"strconv"
+	"time"
+
+	"ChatServer/apps/connect/internal/conn"
+	"ChatServer/apps/connect/internal/service"
+	"ChatServer/apps/connect/pb"
+	"ChatServer/apps/connect/protocol"
+	"ChatServer/consts"
+	"ChatServer/pkg/logger"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// errUnauthorized 首帧缺失、超时或不是合法的鉴权帧
+var errUnauthorized = status.Error(codes.Unauthenticated, strconv.Itoa(consts.CodeUnauthorized))
+
+// TCPOptions TCP 接入参数
+type TCPOptions struct {
+	Address          string        // 监听地址，例 :8082
+	AuthTimeout      time.Duration // 建连后等待鉴权帧的超时时间
+	MaxBodyLen       uint32        // 单帧最大包体长度
+	WriteTimeout     time.Duration // 单次写超时
+	HeartbeatTimeout time.Duration // 读超时（超过该时间未收到任何帧视为死链）
+}
+
+// DefaultTCPOptions 返回默认 TCP 接入参数
+func DefaultTCPOptions() TCPOptions {
+	return TCPOptions{
+		Address:          ":8082",
+		AuthTimeout:      10 * time.Second,
+		MaxBodyLen:       protocol.DefaultMaxBodyLen,
+		WriteTimeout:     10 * time.Second,
+		HeartbeatTimeout: conn.DefaultOptions().HeartbeatTimeout,
+	}
+}
+
+// TCPServer 原生客户端（移动端/桌面端）TCP 长连接服务
+// 建连后第一个帧必须是 CmdAuth，超时或鉴权失败回复 CmdAuthAck 后直接断开；
+// 鉴权通过后与 WebSocket 连接一样交给 conn.Manager 管理。
+// TCP 下由客户端主动发送 CmdHeartbeat 保活，服务端回复 CmdHeartbeatAck。
+type TCPServer struct {
+	opts        TCPOptions
+	authService service.IAuthService
+	manager     *conn.Manager
+}
+
+// NewTCPServer 创建 TCP 长连接服务
+func NewTCPServer(opts TCPOptions, authService service.IAuthService, manager *conn.Manager) *TCPServer {
+	return &TCPServer{
+		opts:        opts,
+		authService: authService,
+		manager:     manager,
+	}
+}
+
+// Start 监听并阻塞，ctx 取消时关闭监听
+func (s *TCPServer) Start(ctx context.Context) error {
+	ln, err := net.Listen("tcp", s.opts.Address)
+	if err != nil {
+		return err
+	}
+	logger.Info(ctx, "TCP server start", logger.String("addr", ln.Addr().String()))
+	return s.Serve(ctx, ln)
+}
+
+// Serve 在指定监听器上接收连接并阻塞，ctx 取消时关闭监听
+func (s *TCPServer) Serve(ctx context.Context, ln net.Listener) error {
+	go func() {
+		<-ctx.Done()
+		_ = ln.Close()
+	}()
+
+	var backoff time.Duration
+	for {
+		nc, err := ln.Accept()
+		if err != nil {
+			if ctx.Err() != nil || errors.Is(err, net.ErrClosed) {
+				return nil
+			}
+			// 临时错误（如文件描述符耗尽）退避重试，避免空转
+			if backoff == 0 {
+				backoff = 5 * time.Millisecond
+			} else if backoff *= 2; backoff > time.Second {
+				backoff = time.Second
+			}
+			logger.Warn(ctx, "TCP Accept 失败", logger.ErrorField("error", err), logger.Duration("backoff", backoff))
+			time.Sleep(backoff)
+			continue
+		}
+		backoff = 0
+		go s.handleConn(ctx, nc)
+	}
+}
+
+// handleConn 鉴权并进入读循环
+func (s *TCPServer) handleConn(ctx context.Context, nc net.Conn) {
+	reader := protocol.NewReader(nc, s.opts.MaxBodyLen)
+
+	identity, authSeq, err := s.authenticate(ctx, nc, reader)
+	if err != nil {
+		code := extractBusinessCode(err)
+		logger.Debug(ctx, "TCP 连接鉴权失败",
+			logger.String("remote_addr", nc.RemoteAddr().String()),
+			logger.Int("code", code),
+		)
+		_ = nc.SetWriteDeadline(time.Now().Add(s.opts.WriteTimeout))
+		_ = protocol.WriteFrame(nc, authAckFrame(authSeq, code, ""))
+		_ = nc.Close()
+		return
+	}
+
+	transport := &tcpTransport{conn: nc, writeTimeout: s.opts.WriteTimeout}
+	c := s.manager.Register(identity.UserUUID, identity.DeviceID, transport)
+	// 鉴权应答走写协程，保证与后续推送的顺序
+	if err := c.Send(protocol.Encode(authAckFrame(authSeq, consts.CodeSuccess, identity.UserUUID))); err != nil {
+		return
+	}
+	logger.Info(c.Context(), "TCP 连接建立", logger.String("remote_addr", transport.RemoteAddr()))
+
+	s.readLoop(c, nc, reader)
+}
+
+// authenticate 读取并校验第一个帧
+func (s *TCPServer) authenticate(ctx context.Context, nc net.Conn, reader *protocol.Reader) (*service.Identity, uint32, error) {
+	_ = nc.SetReadDeadline(time.Now().Add(s.opts.AuthTimeout))
+	f, err := reader.ReadFrame()
+	if err != nil {
+		return nil, 0, errUnauthorized
+	}
+	if f.Cmd != protocol.CmdAuth {
+		return nil, f.Seq, errUnauthorized
+	}
+
+	req := &pb.AuthRequest{}
+	if err := proto.Unmarshal(f.Body, req); err != nil {
+		return nil, f.Seq, errUnauthorized
+	}
+
+	identity, err := s.authService.Authenticate(ctx, req.Token, req.DeviceId)
+	if err != nil {
+		return nil, f.Seq, err
+	}
+	return identity, f.Seq, nil
+}
+
+// readLoop 读协程：每收到一个帧刷新活跃时间，读超时/出错/协议错误即关闭连接
+func (s *TCPServer) readLoop(c *conn.Conn, nc net.Conn, reader *protocol.Reader) {
+	defer c.Close("read loop exit")
+
+	for {
+		_ = nc.SetReadDeadline(time.Now().Add(s.opts.HeartbeatTimeout))
+		f, err := reader.ReadFrame()
+		if err != nil {
+			if !errors.Is(err, io.EOF) && !errors.Is(err, net.ErrClosed) {
+				logger.Debug(c.Context(), "TCP 读取失败", logger.ErrorField("error", err))
+			}
+			return
+		}
+		c.Touch()
+		handleFrame(c, f)
+	}
+}
+
+// authAckFrame 构造鉴权应答帧
+func authAckFrame(seq uint32, code int, userUUID string) *protocol.Frame {
+	resp := &pb.AuthResponse{
+		Code:       int32(code),
+		Message:    consts.GetMessage(code),
+		UserUuid:   userUUID,
+		ServerTime: time.Now().UnixMilli(),
+	}
+	body, _ := proto.Marshal(resp)
+	return protocol.NewFrame(protocol.CmdAuthAck, seq, body)
+}
+
+// tcpTransport TCP 传输实现
+type tcpTransport struct {
+	conn         net.Conn
+	writeTimeout time.Duration
+}
+
+// WriteMessage 写出已编码的帧
+func (t *tcpTransport) WriteMessage(data []byte) error {
+	_ = t.conn.SetWriteDeadline(time.Now().Add(t.writeTimeout))
+	_, err := t.conn.Write(data)
+	return err
+}
+
+// Ping TCP 由客户端主动发心跳，服务端无需探测
+func (t *tcpTransport) Ping() error {
+	return nil
+}
+
+// Close 关闭底层连接
+func (t *tcpTransport) Close() error {
+	return t.conn.Close()
+}
+
+// RemoteAddr 对端地址
+func (t *tcpTransport) RemoteAddr() string {
+	return t.conn.RemoteAddr().String()
+}
@@ -0,0 +1,149 @@
+package server
+
+import (
+	"context"
+	"net"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	"ChatServer/apps/connect/internal/conn"
+	"ChatServer/apps/connect/internal/service"
+	"ChatServer/apps/connect/pb"
+	"ChatServer/apps/connect/protocol"
+	"ChatServer/apps/connect/tcpclient"
+	"ChatServer/consts"
+	"ChatServer/pkg/logger"
+)
+
+// init 初始化 logger（测试模式，不输出日志）
+func init() {
+	logger.ReplaceGlobal(zap.NewNop())
+}
+
+// fakeAuthService 只接受 token 为 "good" 的请求
+type fakeAuthService struct{}
+
+func (fakeAuthService) Authenticate(ctx context.Context, token, deviceID string) (*service.Identity, error) {
+	if token != "good" {
+		return nil, status.Error(codes.Unauthenticated, strconv.Itoa(consts.CodeInvalidToken))
+	}
+	return &service.Identity{UserUUID: "u1", DeviceID: deviceID}, nil
+}
+
+// startTCPServer 在随机端口启动 TCP 服务
+func startTCPServer(t *testing.T, opts TCPOptions) (string, *conn.Manager) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	manager := conn.NewManager(conn.DefaultOptions())
+	s := NewTCPServer(opts, fakeAuthService{}, manager)
+	go s.Serve(ctx, ln)
+
+	t.Cleanup(func() {
+		cancel()
+		manager.CloseAll("test done")
+	})
+	return ln.Addr().String(), manager
+}
+
+// TestTCPServer_AuthHeartbeatPush 测试鉴权、心跳应答与下行推送
+func TestTCPServer_AuthHeartbeatPush(t *testing.T) {
+	addr, manager := startTCPServer(t, DefaultTCPOptions())
+
+	client, err := tcpclient.Dial(context.Background(), addr)
+	require.NoError(t, err)
+	defer client.Close()
+
+	resp, err := client.Auth("good", "d1", time.Second)
+	require.NoError(t, err)
+	assert.Equal(t, "u1", resp.UserUuid)
+	assert.Eventually(t, func() bool { return manager.Get("u1", "d1") != nil }, time.Second, 10*time.Millisecond)
+
+	_ = client.SetReadDeadline(time.Now().Add(time.Second))
+
+	seq, err := client.Heartbeat()
+	require.NoError(t, err)
+	f, err := client.ReadFrame()
+	require.NoError(t, err)
+	assert.Equal(t, protocol.CmdHeartbeatAck, f.Cmd)
+	assert.Equal(t, seq, f.Seq, "心跳应答应该携带相同序号")
+
+	require.NoError(t, manager.Get("u1", "d1").Send(protocol.Encode(protocol.NewFrame(protocol.CmdPush, 100, []byte("hi")))))
+	f, err = client.ReadFrame()
+	require.NoError(t, err)
+	assert.Equal(t, protocol.CmdPush, f.Cmd)
+	assert.Equal(t, uint32(100), f.Seq)
+	assert.Equal(t, []byte("hi"), f.Body)
+
+	// 客户端断开后连接从管理器移除
+	client.Close()
+	assert.Eventually(t, func() bool { return manager.Count() == 0 }, time.Second, 10*time.Millisecond)
+}
+
+// TestTCPServer_AuthFailed 测试鉴权失败返回错误码并断开
+func TestTCPServer_AuthFailed(t *testing.T) {
+	addr, manager := startTCPServer(t, DefaultTCPOptions())
+
+	client, err := tcpclient.Dial(context.Background(), addr)
+	require.NoError(t, err)
+	defer client.Close()
+
+	resp, err := client.Auth("bad", "d1", time.Second)
+	require.Error(t, err)
+	require.NotNil(t, resp)
+	assert.Equal(t, int32(consts.CodeInvalidToken), resp.Code)
+
+	_ = client.SetReadDeadline(time.Now().Add(time.Second))
+	_, err = client.ReadFrame()
+	assert.Error(t, err, "鉴权失败后连接应该被关闭")
+	assert.Equal(t, 0, manager.Count())
+}
+
+// TestTCPServer_FirstFrameMustBeAuth 测试首帧不是鉴权帧时拒绝连接
+func TestTCPServer_FirstFrameMustBeAuth(t *testing.T) {
+	addr, _ := startTCPServer(t, DefaultTCPOptions())
+
+	client, err := tcpclient.Dial(context.Background(), addr)
+	require.NoError(t, err)
+	defer client.Close()
+
+	_, err = client.Heartbeat()
+	require.NoError(t, err)
+
+	_ = client.SetReadDeadline(time.Now().Add(time.Second))
+	f, err := client.ReadFrame()
+	require.NoError(t, err)
+	assert.Equal(t, protocol.CmdAuthAck, f.Cmd)
+
+	resp := &pb.AuthResponse{}
+	require.NoError(t, proto.Unmarshal(f.Body, resp))
+	assert.Equal(t, int32(consts.CodeUnauthorized), resp.Code)
+}
+
+// TestTCPServer_AuthTimeout 测试建连后不发送鉴权帧会被超时断开
+func TestTCPServer_AuthTimeout(t *testing.T) {
+	opts := DefaultTCPOptions()
+	opts.AuthTimeout = 50 * time.Millisecond
+	addr, _ := startTCPServer(t, opts)
+
+	client, err := tcpclient.Dial(context.Background(), addr)
+	require.NoError(t, err)
+	defer client.Close()
+
+	_ = client.SetReadDeadline(time.Now().Add(time.Second))
+	f, err := client.ReadFrame()
+	require.NoError(t, err)
+	assert.Equal(t, protocol.CmdAuthAck, f.Cmd)
+
+	_, err = client.ReadFrame()
+	assert.Error(t, err, "鉴权超时后连接应该被关闭")
+}
@@ -0,0 +1,284 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"ChatServer/apps/connect/internal/conn"
+	"ChatServer/apps/connect/internal/service"
+	"ChatServer/apps/connect/protocol"
+	"ChatServer/consts"
+	"ChatServer/pkg/logger"
+
+	"github.com/gorilla/websocket"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// errTrailingData 一条 WebSocket 消息中包含多余数据
+var errTrailingData = errors.New("websocket message contains trailing data")
+
+// WSOptions WebSocket 接入参数
+type WSOptions struct {
+	Address          string        // 监听地址，例 :8081
+	Path             string        // 升级路径，例 /ws
+	ReadLimit        int64         // 单条消息最大字节数
+	WriteTimeout     time.Duration // 单次写超时
+	HeartbeatTimeout time.Duration // 读超时（超过该时间未收到任何数据视为死链）
+	AllowedOrigins   []string      // 允许的浏览器 Origin（例 https://chat.example.com），为空时仅允许与 Host 同源
+	AllowQueryToken  bool          // 是否接受 ?token= 传递 Token（浏览器无法自定义握手头时开启，须同时配置 AllowedOrigins）
+}
+
+// DefaultWSOptions 返回默认 WebSocket 接入参数
+func DefaultWSOptions() WSOptions {
+	return WSOptions{
+		Address:          ":8081",
+		Path:             "/ws",
+		ReadLimit:        64 * 1024,
+		WriteTimeout:     10 * time.Second,
+		HeartbeatTimeout: conn.DefaultOptions().HeartbeatTimeout,
+	}
+}
+
+// WSServer WebSocket 长连接服务
+// 鉴权在升级前完成：未通过鉴权的请求直接返回 401，不占用长连接资源。
+type WSServer struct {
+	opts        WSOptions
+	authService service.IAuthService
+	manager     *conn.Manager
+	upgrader    websocket.Upgrader
+	httpServer  *http.Server
+}
+
+// NewWSServer 创建 WebSocket 长连接服务
+func NewWSServer(opts WSOptions, authService service.IAuthService, manager *conn.Manager) *WSServer {
+	s := &WSServer{
+		opts:        opts,
+		authService: authService,
+		manager:     manager,
+		upgrader: websocket.Upgrader{
+			ReadBufferSize:  4096,
+			WriteBufferSize: 4096,
+		},
+	}
+	s.upgrader.CheckOrigin = s.checkOrigin
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(opts.Path, s.handleUpgrade)
+	mux.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"status": "ok",
+			"online": manager.Count(),
+		})
+	})
+
+	s.httpServer = &http.Server{
+		Addr:              opts.Address,
+		Handler:           mux,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+	return s
+}
+
+// Start 启动服务并阻塞，ctx 取消时优雅关闭监听
+func (s *WSServer) Start(ctx context.Context) error {
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = s.httpServer.Shutdown(shutdownCtx)
+	}()
+
+	logger.Info(ctx, "WebSocket server start",
+		logger.String("addr", s.opts.Address),
+		logger.String("path", s.opts.Path),
+	)
+	if err := s.httpServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// handleUpgrade 校验来源、鉴权并升级为 WebSocket 连接
+// 来源校验先于鉴权：不允许的 Origin 直接返回 403，避免跨站页面借用户 Token 建立连接
+// Token 读取顺序：Authorization: Bearer <token> -> ?token=（仅 AllowQueryToken 开启时）
+// 设备ID读取顺序：X-Device-ID -> ?device_id=（浏览器无法自定义握手头，因此支持 query 参数）
+func (s *WSServer) handleUpgrade(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	if !s.checkOrigin(r) {
+		logger.Warn(ctx, "WebSocket 握手来源不允许", logger.String("origin", r.Header.Get("Origin")))
+		writeJSON(w, http.StatusForbidden, map[string]interface{}{
+			"code":    consts.CodeNoPermission,
+			"message": consts.GetMessage(consts.CodeNoPermission),
+		})
+		return
+	}
+
+	token := extractBearerToken(r.Header.Get("Authorization"))
+	if token == "" && s.opts.AllowQueryToken {
+		token = r.URL.Query().Get("token")
+	}
+	deviceID := r.Header.Get("X-Device-ID")
+	if deviceID == "" {
+		deviceID = r.URL.Query().Get("device_id")
+	}
+
+	identity, err := s.authService.Authenticate(ctx, token, deviceID)
+	if err != nil {
+		code := extractBusinessCode(err)
+		httpStatus := http.StatusUnauthorized
+		if !consts.IsNonServerError(code) {
+			httpStatus = http.StatusInternalServerError
+		}
+		writeJSON(w, httpStatus, map[string]interface{}{
+			"code":    code,
+			"message": consts.GetMessage(code),
+		})
+		return
+	}
+
+	ws, err := s.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		// Upgrade 失败时 gorilla 已写回 HTTP 错误
+		logger.Warn(ctx, "WebSocket 升级失败",
+			logger.String("user_uuid", identity.UserUUID),
+			logger.ErrorField("error", err),
+		)
+		return
+	}
+
+	transport := &wsTransport{ws: ws, writeTimeout: s.opts.WriteTimeout}
+	c := s.manager.Register(identity.UserUUID, identity.DeviceID, transport)
+	logger.Info(c.Context(), "WebSocket 连接建立",
+		logger.String("remote_addr", transport.RemoteAddr()),
+	)
+
+	s.readLoop(c, ws)
+}
+
+// checkOrigin 校验握手请求的 Origin
+// 未携带 Origin 的请求来自原生客户端，直接放行；配置了 AllowedOrigins 时须命中其一，否则仅允许与 Host 同源
+func (s *WSServer) checkOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+	if len(s.opts.AllowedOrigins) == 0 {
+		u, err := url.Parse(origin)
+		return err == nil && strings.EqualFold(u.Host, r.Host)
+	}
+	for _, allowed := range s.opts.AllowedOrigins {
+		if strings.EqualFold(origin, allowed) {
+			return true
+		}
+	}
+	return false
+}
+
+// readLoop 读协程：刷新活跃时间并处理上行帧，读超时/出错/协议错误即关闭连接
+// 一条二进制消息恰好承载一个协议帧；文本消息不属于协议，直接忽略。
+func (s *WSServer) readLoop(c *conn.Conn, ws *websocket.Conn) {
+	defer c.Close("read loop exit")
+
+	ws.SetReadLimit(s.opts.ReadLimit)
+	extendDeadline := func() {
+		c.Touch()
+		_ = ws.SetReadDeadline(time.Now().Add(s.opts.HeartbeatTimeout))
+	}
+	extendDeadline()
+	// 服务端 Ping 后客户端回 Pong（浏览器自动应答），视为一次心跳
+	ws.SetPongHandler(func(string) error {
+		extendDeadline()
+		return nil
+	})
+
+	for {
+		messageType, data, err := ws.ReadMessage()
+		if err != nil {
+			if websocket.IsUnexpectedCloseError(err, websocket.CloseNormalClosure, websocket.CloseGoingAway) {
+				logger.Debug(c.Context(), "WebSocket 读取失败", logger.ErrorField("error", err))
+			}
+			return
+		}
+		extendDeadline()
+
+		if messageType != websocket.BinaryMessage {
+			continue
+		}
+		f, n, err := protocol.Decode(data, uint32(s.opts.ReadLimit))
+		if err == nil && n != len(data) {
+			err = errTrailingData
+		}
+		if err != nil {
+			logger.Warn(c.Context(), "WebSocket 帧解析失败", logger.ErrorField("error", err))
+			return
+		}
+		handleFrame(c, f)
+	}
+}
+
+// wsTransport WebSocket 传输实现
+type wsTransport struct {
+	ws           *websocket.Conn
+	writeTimeout time.Duration
+}
+
+// WriteMessage 以二进制帧写出消息
+func (t *wsTransport) WriteMessage(data []byte) error {
+	_ = t.ws.SetWriteDeadline(time.Now().Add(t.writeTimeout))
+	return t.ws.WriteMessage(websocket.BinaryMessage, data)
+}
+
+// Ping 发送 WebSocket Ping 控制帧
+func (t *wsTransport) Ping() error {
+	return t.ws.WriteControl(websocket.PingMessage, nil, time.Now().Add(t.writeTimeout))
+}
+
+// Close 发送 Close 帧后关闭底层连接
+func (t *wsTransport) Close() error {
+	_ = t.ws.WriteControl(websocket.CloseMessage,
+		websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""),
+		time.Now().Add(time.Second))
+	return t.ws.Close()
+}
+
+// RemoteAddr 对端地址
+func (t *wsTransport) RemoteAddr() string {
+	return t.ws.RemoteAddr().String()
+}
+
+// extractBearerToken 解析 "Bearer <token>"
+func extractBearerToken(header string) string {
+	parts := strings.SplitN(header, " ", 2)
+	if len(parts) != 2 || parts[0] != "Bearer" {
+		return ""
+	}
+	return parts[1]
+}
+
+// extractBusinessCode 从 service 层返回的 gRPC status 中提取业务错误码
+func extractBusinessCode(err error) int {
+	st, ok := status.FromError(err)
+	if !ok || st.Code() == codes.Unknown {
+		return consts.CodeInternalError
+	}
+	code, convErr := strconv.Atoi(st.Message())
+	if convErr != nil {
+		return consts.CodeInternalError
+	}
+	return code
+}
+
+// writeJSON 写出 JSON 响应
+func writeJSON(w http.ResponseWriter, httpStatus int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(httpStatus)
+	_ = json.NewEncoder(w).Encode(body)
+}
@@ -0,0 +1,67 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"ChatServer/apps/connect/internal/conn"
+)
+
+// startWSServer 启动只挂载升级接口的 WebSocket 服务，返回 ws:// 地址
+func startWSServer(t *testing.T, opts WSOptions) string {
+	manager := conn.NewManager(conn.DefaultOptions())
+	s := NewWSServer(opts, fakeAuthService{}, manager)
+	ts := httptest.NewServer(http.HandlerFunc(s.handleUpgrade))
+	t.Cleanup(func() {
+		manager.CloseAll("test done")
+		ts.Close()
+	})
+	return "ws" + strings.TrimPrefix(ts.URL, "http")
+}
+
+// dialStatus 发起握手，返回 HTTP 状态码
+func dialStatus(t *testing.T, rawURL string, header http.Header) int {
+	ws, resp, err := websocket.DefaultDialer.Dial(rawURL, header)
+	if err == nil {
+		ws.Close()
+	}
+	require.NotNil(t, resp)
+	return resp.StatusCode
+}
+
+// TestWSServer_Origin 测试握手来源校验：原生客户端放行，跨站页面即使带有效 Token 也拒绝
+func TestWSServer_Origin(t *testing.T) {
+	addr := startWSServer(t, DefaultWSOptions())
+	bearer := http.Header{"Authorization": {"Bearer good"}}
+
+	assert.Equal(t, http.StatusSwitchingProtocols, dialStatus(t, addr, bearer))
+
+	crossSite := bearer.Clone()
+	crossSite.Set("Origin", "https://evil.example.com")
+	assert.Equal(t, http.StatusForbidden, dialStatus(t, addr, crossSite))
+
+	opts := DefaultWSOptions()
+	opts.AllowedOrigins = []string{"https://chat.example.com"}
+	addr = startWSServer(t, opts)
+	allowed := bearer.Clone()
+	allowed.Set("Origin", "https://chat.example.com")
+	assert.Equal(t, http.StatusSwitchingProtocols, dialStatus(t, addr, allowed))
+	assert.Equal(t, http.StatusForbidden, dialStatus(t, addr, crossSite))
+}
+
+// TestWSServer_QueryToken 测试 ?token= 仅在 AllowQueryToken 开启时生效
+func TestWSServer_QueryToken(t *testing.T) {
+	addr := startWSServer(t, DefaultWSOptions())
+	assert.Equal(t, http.StatusUnauthorized, dialStatus(t, addr+"?token=good", nil))
+
+	opts := DefaultWSOptions()
+	opts.AllowQueryToken = true
+	addr = startWSServer(t, opts)
+	assert.Equal(t, http.StatusSwitchingProtocols, dialStatus(t, addr+"?token=good", nil))
+}
@@ -0,0 +1,83 @@
+package service
+
+import (
+	"ChatServer/apps/connect/internal/repository"
+	"ChatServer/consts"
+	"ChatServer/pkg/logger"
+	"ChatServer/pkg/util"
+	"context"
+	"errors"
+	"strconv"
+
+	"github.com/golang-jwt/jwt/v5"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// authServiceImpl 连接鉴权服务实现
+type authServiceImpl struct {
+	deviceRepo repository.IDeviceRepository
+}
+
+// NewAuthService 创建连接鉴权服务实例
+func NewAuthService(deviceRepo repository.IDeviceRepository) AuthService {
+	return &authServiceImpl{
+		deviceRepo: deviceRepo,
+	}
+}
+
+// Authenticate 校验 AccessToken
+// 业务流程：
+//  1. 解析 JWT（签名、过期时间）
+//  2. 校验客户端声明的设备ID与 Token 中的设备ID一致
+//  3. 校验 Redis 中的 Token 状态（被踢出/登出后 Token 立即失效）
+//
+// 错误码映射：
+//   - codes.Unauthenticated: Token 无效 / 已过期 / 已被踢出
+//   - codes.Internal: 系统内部错误
+func (s *authServiceImpl) Authenticate(ctx context.Context, token, deviceID string) (*Identity, error) {
+	if token == "" {
+		return nil, status.Error(codes.Unauthenticated, strconv.Itoa(consts.CodeUnauthorized))
+	}
+
+	// 1. 解析 JWT
+	claims, err := util.ParseToken(token)
+	if err != nil {
+		if errors.Is(err, jwt.ErrTokenExpired) {
+			return nil, status.Error(codes.Unauthenticated, strconv.Itoa(consts.CodeTokenExpired))
+		}
+		return nil, status.Error(codes.Unauthenticated, strconv.Itoa(consts.CodeInvalidToken))
+	}
+
+	// 2. 校验设备ID（客户端未声明时以 Token 为准）
+	if deviceID == "" {
+		deviceID = claims.DeviceID
+	}
+	if deviceID != claims.DeviceID {
+		logger.Warn(ctx, "长连接鉴权设备ID不一致",
+			logger.String("user_uuid", claims.UserUUID),
+			logger.String("token_device_id", claims.DeviceID),
+			logger.String("device_id", deviceID),
+		)
+		return nil, status.Error(codes.Unauthenticated, strconv.Itoa(consts.CodeInvalidToken))
+	}
+
+	// 3. 校验 Redis 中的 Token 状态
+	valid, err := s.deviceRepo.VerifyAccessToken(ctx, claims.UserUUID, deviceID, token)
+	if err != nil {
+		logger.Error(ctx, "校验 AccessToken 失败",
+			logger.String("user_uuid", claims.UserUUID),
+			logger.String("device_id", deviceID),
+			logger.ErrorField("error", err),
+		)
+		return nil, status.Error(codes.Internal, strconv.Itoa(consts.CodeInternalError))
+	}
+	if !valid {
+		return nil, status.Error(codes.Unauthenticated, strconv.Itoa(consts.CodeInvalidToken))
+	}
+
+	return &Identity{
+		UserUUID: claims.UserUUID,
+		DeviceID: deviceID,
+	}, nil
+}
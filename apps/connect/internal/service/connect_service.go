@@ -0,0 +1,64 @@
+package service
+
+import (
+	"ChatServer/apps/connect/internal/conn"
+	pb "ChatServer/apps/connect/pb"
+	"ChatServer/apps/connect/protocol"
+	"ChatServer/pkg/logger"
+	"context"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// connectServiceImpl 节点连接操作服务实现
+type connectServiceImpl struct {
+	manager *conn.Manager
+}
+
+// NewConnectService 创建节点连接操作服务实例
+func NewConnectService(manager *conn.Manager) ConnectService {
+	return &connectServiceImpl{
+		manager: manager,
+	}
+}
+
+// KickConnection 下发踢下线通知并关闭指定设备的连接
+// 连接不在本节点（已断开或已迁移到其他节点）时返回 kicked=false，不视为错误。
+func (s *connectServiceImpl) KickConnection(ctx context.Context, req *pb.KickConnectionRequest) (*pb.KickConnectionResponse, error) {
+	c := s.manager.Get(req.UserUuid, req.DeviceId)
+	if c == nil {
+		return &pb.KickConnectionResponse{Kicked: false}, nil
+	}
+
+	body, _ := proto.Marshal(&pb.KickNotify{Code: req.Code, Reason: req.Reason})
+	c.Kick(protocol.Encode(protocol.NewFrame(protocol.CmdKick, 0, body)), "kicked: "+req.Reason)
+
+	logger.Info(ctx, "长连接被踢下线",
+		logger.String("user_uuid", req.UserUuid),
+		logger.String("device_id", req.DeviceId),
+		logger.String("reason", req.Reason),
+	)
+	return &pb.KickConnectionResponse{Kicked: true}, nil
+}
+
+// PushMessage 向本节点上指定用户的全部设备下发 Push 帧
+// 用户不在本节点时跳过；发送队列满的连接会被关闭，客户端重连后按 seq 补拉。
+func (s *connectServiceImpl) PushMessage(ctx context.Context, req *pb.PushMessageRequest) (*pb.PushMessageResponse, error) {
+	data := protocol.Encode(protocol.NewFrame(protocol.CmdPush, 0, req.Body))
+
+	var delivered int32
+	for _, userUUID := range req.UserUuids {
+		for _, c := range s.manager.GetByUser(userUUID) {
+			if err := c.Send(data); err != nil {
+				logger.Warn(ctx, "下发 Push 帧失败",
+					logger.String("user_uuid", c.UserUUID()),
+					logger.String("device_id", c.DeviceID()),
+					logger.ErrorField("error", err),
+				)
+				continue
+			}
+			delivered++
+		}
+	}
+	return &pb.PushMessageResponse{Delivered: delivered}, nil
+}
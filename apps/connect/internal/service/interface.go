@@ -0,0 +1,44 @@
+package service
+
+import (
+	"context"
+
+	pb "ChatServer/apps/connect/pb"
+)
+
+// ==================== 连接鉴权服务接口 ====================
+
+// Identity 鉴权通过后的连接身份
+type Identity struct {
+	UserUUID string // 用户UUID
+	DeviceID string // 设备ID
+}
+
+// IAuthService 连接鉴权服务接口
+// 职责：在建立长连接（WebSocket 升级 / TCP 首包）时校验 AccessToken
+type IAuthService interface {
+	// Authenticate 校验 AccessToken
+	// token: 客户端携带的 AccessToken（不含 Bearer 前缀）
+	// deviceID: 客户端声明的设备ID，可为空（为空时以 Token 中的设备ID为准）
+	Authenticate(ctx context.Context, token, deviceID string) (*Identity, error)
+}
+
+// ==================== 节点连接操作服务接口 ====================
+
+// IConnectService 节点连接操作服务接口
+// 职责：响应其他服务经连接路由发来的回调，操作本节点持有的长连接
+type IConnectService interface {
+	// KickConnection 下发踢下线通知并关闭指定设备的连接
+	KickConnection(ctx context.Context, req *pb.KickConnectionRequest) (*pb.KickConnectionResponse, error)
+
+	// PushMessage 向本节点上指定用户的全部设备下发 Push 帧
+	PushMessage(ctx context.Context, req *pb.PushMessageRequest) (*pb.PushMessageResponse, error)
+}
+
+// ==================== 别名类型定义 ====================
+
+// AuthService 别名 IAuthService
+type AuthService = IAuthService
+
+// ConnectService 别名 IConnectService
+type ConnectService = IConnectService
@@ -0,0 +1,151 @@
+// Package nodeclient 供后端服务（用户、消息）按连接路由回调 Connect 节点。
+// 先经 pkg/registry 查询设备所在节点与节点地址，再复用到该节点的 gRPC 连接发起调用。
+package nodeclient
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	pb "ChatServer/apps/connect/pb"
+	"ChatServer/pkg/registry"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// Client Connect 节点客户端（按节点地址缓存 gRPC 连接，并发安全）
+type Client struct {
+	registry *registry.Registry
+
+	mu    sync.Mutex
+	conns map[string]*grpc.ClientConn // addr -> conn
+}
+
+// New 创建 Connect 节点客户端
+func New(reg *registry.Registry) *Client {
+	return &Client{
+		registry: reg,
+		conns:    make(map[string]*grpc.ClientConn),
+	}
+}
+
+// Registry 连接路由注册表（查询在线路由用）
+func (c *Client) Registry() *registry.Registry {
+	return c.registry
+}
+
+// Kick 踢下线指定设备的长连接
+// 设备不在线或所在节点已下线时返回 false 且不报错（连接会随节点租约一起失效）。
+func (c *Client) Kick(ctx context.Context, userUUID, deviceID string, code int32, reason string) (bool, error) {
+	nodeID, err := c.registry.LookupDevice(ctx, userUUID, deviceID)
+	if err != nil {
+		if errors.Is(err, registry.ErrNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	client, err := c.nodeClient(ctx, nodeID)
+	if err != nil {
+		if errors.Is(err, registry.ErrNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	resp, err := client.KickConnection(ctx, &pb.KickConnectionRequest{
+		UserUuid: userUUID,
+		DeviceId: deviceID,
+		Code:     code,
+		Reason:   reason,
+	})
+	if err != nil {
+		return false, err
+	}
+	return resp.Kicked, nil
+}
+
+// Push 向用户的全部在线设备下发 Push 帧
+// 先按连接路由将用户归并到所在节点，每个节点只调用一次；单个节点失败不影响其他节点。
+// 返回成功投递的连接数，以及遇到的第一个错误（不在线的用户不视为错误）。
+func (c *Client) Push(ctx context.Context, userUUIDs []string, body []byte) (int, error) {
+	if len(userUUIDs) == 0 {
+		return 0, nil
+	}
+	routes, err := c.registry.BatchLookup(ctx, userUUIDs)
+	if err != nil {
+		return 0, err
+	}
+
+	// 按节点归并用户（同一用户多设备在同一节点时只需下发一次）
+	nodeUsers := make(map[string][]string)
+	for userUUID, userRoutes := range routes {
+		seen := make(map[string]struct{}, len(userRoutes))
+		for _, route := range userRoutes {
+			if _, ok := seen[route.NodeID]; ok {
+				continue
+			}
+			seen[route.NodeID] = struct{}{}
+			nodeUsers[route.NodeID] = append(nodeUsers[route.NodeID], userUUID)
+		}
+	}
+
+	var (
+		delivered int
+		firstErr  error
+	)
+	for nodeID, users := range nodeUsers {
+		client, err := c.nodeClient(ctx, nodeID)
+		if err != nil {
+			if !errors.Is(err, registry.ErrNotFound) && firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		resp, err := client.PushMessage(ctx, &pb.PushMessageRequest{
+			UserUuids: users,
+			Body:      body,
+		})
+		if err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		delivered += int(resp.Delivered)
+	}
+	return delivered, firstErr
+}
+
+// nodeClient 获取指定节点的 gRPC 客户端
+func (c *Client) nodeClient(ctx context.Context, nodeID string) (pb.ConnectServiceClient, error) {
+	addr, err := c.registry.NodeAddr(ctx, nodeID)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	conn, ok := c.conns[addr]
+	if !ok {
+		conn, err = grpc.NewClient(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+		if err != nil {
+			return nil, err
+		}
+		c.conns[addr] = conn
+	}
+	return pb.NewConnectServiceClient(conn), nil
+}
+
+// Close 关闭所有节点连接
+func (c *Client) Close() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for addr, conn := range c.conns {
+		_ = conn.Close()
+		delete(c.conns, addr)
+	}
+}
@@ -0,0 +1,194 @@
+// Package protocol 定义 Connect 服务的二进制帧协议，供服务端与 Go 客户端共用。
+//
+// 帧格式（大端序）：
+//
+//	+---------+---------+---------+------------+------------------+
+//	| version | command |   seq   |  body_len  |       body       |
+//	|  1 byte | 2 bytes | 4 bytes |  4 bytes   |  body_len bytes  |
+//	+---------+---------+---------+------------+------------------+
+//
+// body 为 Protobuf 编码的业务数据（见 apps/connect/pb），心跳等控制帧 body 可为空。
+// TCP 是字节流，一次 Read 可能只拿到半个帧（拆包）或多个帧（粘包），
+// 统一通过 body_len 定界：Reader 负责流式读取，Decode 负责从缓冲区中切帧。
+// WebSocket 自带消息边界，一条二进制消息恰好承载一个帧。
+package protocol
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+const (
+	// Version1 当前协议版本
+	Version1 uint8 = 1
+
+	// HeaderLen 帧头长度
+	HeaderLen = 11
+
+	// DefaultMaxBodyLen 默认最大包体长度（1MB），防止恶意长度字段导致内存耗尽
+	DefaultMaxBodyLen uint32 = 1 << 20
+)
+
+// Command 帧命令字
+type Command uint16
+
+const (
+	CmdAuth         Command = 1 // 客户端 -> 服务端：鉴权（body: pb.AuthRequest）
+	CmdAuthAck      Command = 2 // 服务端 -> 客户端：鉴权结果（body: pb.AuthResponse）
+	CmdHeartbeat    Command = 3 // 客户端 -> 服务端：心跳（body 为空）
+	CmdHeartbeatAck Command = 4 // 服务端 -> 客户端：心跳应答（body 为空）
+	CmdPush         Command = 5 // 服务端 -> 客户端：下行推送
+	CmdPushAck      Command = 6 // 客户端 -> 服务端：推送确认（seq 与 CmdPush 对应）
+	CmdKick         Command = 7 // 服务端 -> 客户端：踢下线（body: pb.KickNotify）
+)
+
+// String 命令字可读名称（日志用）
+func (c Command) String() string {
+	switch c {
+	case CmdAuth:
+		return "Auth"
+	case CmdAuthAck:
+		return "AuthAck"
+	case CmdHeartbeat:
+		return "Heartbeat"
+	case CmdHeartbeatAck:
+		return "HeartbeatAck"
+	case CmdPush:
+		return "Push"
+	case CmdPushAck:
+		return "PushAck"
+	case CmdKick:
+		return "Kick"
+	default:
+		return fmt.Sprintf("Command(%d)", uint16(c))
+	}
+}
+
+var (
+	// ErrIncomplete 缓冲区数据不足一个完整帧（拆包），需要继续读取
+	ErrIncomplete = errors.New("protocol: incomplete frame")
+
+	// ErrBodyTooLarge 包体长度超过上限
+	ErrBodyTooLarge = errors.New("protocol: body too large")
+
+	// ErrUnsupportedVersion 不支持的协议版本
+	ErrUnsupportedVersion = errors.New("protocol: unsupported version")
+)
+
+// Frame 一个完整的协议帧
+type Frame struct {
+	Version uint8   // 协议版本
+	Cmd     Command // 命令字
+	Seq     uint32  // 帧序号（请求/应答配对，服务端推送时为推送序号）
+	Body    []byte  // Protobuf 包体
+}
+
+// NewFrame 使用当前协议版本创建帧
+func NewFrame(cmd Command, seq uint32, body []byte) *Frame {
+	return &Frame{Version: Version1, Cmd: cmd, Seq: seq, Body: body}
+}
+
+// AppendFrame 将帧编码后追加到 dst
+func AppendFrame(dst []byte, f *Frame) []byte {
+	var header [HeaderLen]byte
+	header[0] = f.Version
+	binary.BigEndian.PutUint16(header[1:3], uint16(f.Cmd))
+	binary.BigEndian.PutUint32(header[3:7], f.Seq)
+	binary.BigEndian.PutUint32(header[7:11], uint32(len(f.Body)))
+	dst = append(dst, header[:]...)
+	return append(dst, f.Body...)
+}
+
+// Encode 编码帧
+func Encode(f *Frame) []byte {
+	return AppendFrame(make([]byte, 0, HeaderLen+len(f.Body)), f)
+}
+
+// WriteFrame 编码并写出帧
+func WriteFrame(w io.Writer, f *Frame) error {
+	_, err := w.Write(Encode(f))
+	return err
+}
+
+// parseHeader 解析帧头并校验版本与长度
+func parseHeader(header []byte, maxBodyLen uint32) (*Frame, uint32, error) {
+	f := &Frame{
+		Version: header[0],
+		Cmd:     Command(binary.BigEndian.Uint16(header[1:3])),
+		Seq:     binary.BigEndian.Uint32(header[3:7]),
+	}
+	if f.Version != Version1 {
+		return nil, 0, ErrUnsupportedVersion
+	}
+	bodyLen := binary.BigEndian.Uint32(header[7:11])
+	if bodyLen > maxBodyLen {
+		return nil, 0, ErrBodyTooLarge
+	}
+	return f, bodyLen, nil
+}
+
+// Decode 从缓冲区头部解出一个帧
+// 返回帧与消耗的字节数；数据不足时返回 ErrIncomplete（调用方保留缓冲区继续读取）。
+// 缓冲区中有多个帧（粘包）时循环调用即可，返回的 Body 会拷贝一份，不引用 data。
+func Decode(data []byte, maxBodyLen uint32) (*Frame, int, error) {
+	if len(data) < HeaderLen {
+		return nil, 0, ErrIncomplete
+	}
+	f, bodyLen, err := parseHeader(data[:HeaderLen], maxBodyLen)
+	if err != nil {
+		return nil, 0, err
+	}
+	total := HeaderLen + int(bodyLen)
+	if len(data) < total {
+		return nil, 0, ErrIncomplete
+	}
+	if bodyLen > 0 {
+		f.Body = make([]byte, bodyLen)
+		copy(f.Body, data[HeaderLen:total])
+	}
+	return f, total, nil
+}
+
+// Reader 流式读帧，自动处理 TCP 拆包/粘包
+type Reader struct {
+	r          *bufio.Reader
+	maxBodyLen uint32
+	header     [HeaderLen]byte
+}
+
+// NewReader 创建流式读帧器，maxBodyLen 为 0 时使用 DefaultMaxBodyLen
+func NewReader(r io.Reader, maxBodyLen uint32) *Reader {
+	if maxBodyLen == 0 {
+		maxBodyLen = DefaultMaxBodyLen
+	}
+	br, ok := r.(*bufio.Reader)
+	if !ok {
+		br = bufio.NewReader(r)
+	}
+	return &Reader{r: br, maxBodyLen: maxBodyLen}
+}
+
+// ReadFrame 阻塞读取下一个完整帧
+// 连接在帧边界处正常关闭返回 io.EOF，帧中途断开返回 io.ErrUnexpectedEOF。
+func (r *Reader) ReadFrame() (*Frame, error) {
+	if _, err := io.ReadFull(r.r, r.header[:]); err != nil {
+		return nil, err
+	}
+	f, bodyLen, err := parseHeader(r.header[:], r.maxBodyLen)
+	if err != nil {
+		return nil, err
+	}
+	if bodyLen > 0 {
+		f.Body = make([]byte, bodyLen)
+		if _, err := io.ReadFull(r.r, f.Body); err != nil {
+			if err == io.EOF {
+				err = io.ErrUnexpectedEOF
+			}
+			return nil, err
+		}
+	}
+	return f, nil
+}
@@ -0,0 +1,146 @@
+package protocol
+
+import (
+	"bytes"
+	"io"
+	"testing"
+	"testing/iotest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestEncodeDecode_RoundTrip 测试编码后解码一致
+func TestEncodeDecode_RoundTrip(t *testing.T) {
+	cases := []*Frame{
+		NewFrame(CmdHeartbeat, 1, nil),
+		NewFrame(CmdAuth, 2, []byte("token")),
+		NewFrame(CmdPush, 1<<31, bytes.Repeat([]byte{0xab}, 4096)),
+	}
+
+	for _, want := range cases {
+		data := Encode(want)
+		assert.Len(t, data, HeaderLen+len(want.Body))
+
+		got, n, err := Decode(data, DefaultMaxBodyLen)
+		require.NoError(t, err)
+		assert.Equal(t, len(data), n, "应该消耗整个帧")
+		assert.Equal(t, want.Version, got.Version)
+		assert.Equal(t, want.Cmd, got.Cmd)
+		assert.Equal(t, want.Seq, got.Seq)
+		assert.Equal(t, len(want.Body), len(got.Body))
+		assert.True(t, bytes.Equal(want.Body, got.Body))
+	}
+}
+
+// TestDecode_SplitPacket 测试拆包：任意前缀都应返回 ErrIncomplete
+func TestDecode_SplitPacket(t *testing.T) {
+	data := Encode(NewFrame(CmdPush, 7, []byte("hello world")))
+
+	for i := 0; i < len(data); i++ {
+		_, n, err := Decode(data[:i], DefaultMaxBodyLen)
+		assert.ErrorIs(t, err, ErrIncomplete, "前缀长度 %d 应该不完整", i)
+		assert.Equal(t, 0, n)
+	}
+}
+
+// TestDecode_CoalescedPackets 测试粘包：一个缓冲区中连续解出多个帧
+func TestDecode_CoalescedPackets(t *testing.T) {
+	var buf []byte
+	buf = AppendFrame(buf, NewFrame(CmdHeartbeat, 1, nil))
+	buf = AppendFrame(buf, NewFrame(CmdPush, 2, []byte("a")))
+	buf = AppendFrame(buf, NewFrame(CmdPushAck, 3, []byte("bc")))
+	// 第四个帧只到达一半
+	tail := Encode(NewFrame(CmdPush, 4, []byte("def")))
+	buf = append(buf, tail[:5]...)
+
+	var seqs []uint32
+	for {
+		f, n, err := Decode(buf, DefaultMaxBodyLen)
+		if err == ErrIncomplete {
+			break
+		}
+		require.NoError(t, err)
+		seqs = append(seqs, f.Seq)
+		buf = buf[n:]
+	}
+
+	assert.Equal(t, []uint32{1, 2, 3}, seqs)
+	assert.Len(t, buf, 5, "不完整的帧应该保留在缓冲区")
+}
+
+// TestDecode_Invalid 测试非法帧
+func TestDecode_Invalid(t *testing.T) {
+	data := Encode(NewFrame(CmdPush, 1, []byte("hello")))
+
+	_, _, err := Decode(data, 4)
+	assert.ErrorIs(t, err, ErrBodyTooLarge)
+
+	badVersion := append([]byte(nil), data...)
+	badVersion[0] = 99
+	_, _, err = Decode(badVersion, DefaultMaxBodyLen)
+	assert.ErrorIs(t, err, ErrUnsupportedVersion)
+}
+
+// TestReader_ReadFrame 测试流式读取（逐字节到达 + 连续多帧）
+func TestReader_ReadFrame(t *testing.T) {
+	var stream []byte
+	for i := uint32(1); i <= 3; i++ {
+		stream = AppendFrame(stream, NewFrame(CmdPush, i, bytes.Repeat([]byte{byte(i)}, int(i)*10)))
+	}
+
+	r := NewReader(iotest.OneByteReader(bytes.NewReader(stream)), 0)
+	for i := uint32(1); i <= 3; i++ {
+		f, err := r.ReadFrame()
+		require.NoError(t, err)
+		assert.Equal(t, i, f.Seq)
+		assert.Len(t, f.Body, int(i)*10)
+	}
+
+	_, err := r.ReadFrame()
+	assert.ErrorIs(t, err, io.EOF, "帧边界处结束应该返回 EOF")
+}
+
+// TestReader_TruncatedBody 测试帧中途断开
+func TestReader_TruncatedBody(t *testing.T) {
+	data := Encode(NewFrame(CmdPush, 1, []byte("hello")))
+
+	r := NewReader(bytes.NewReader(data[:HeaderLen+2]), 0)
+	_, err := r.ReadFrame()
+	assert.ErrorIs(t, err, io.ErrUnexpectedEOF)
+}
+
+// FuzzDecode 模糊测试：任意输入不 panic，解出的帧重新编码后与原始字节一致
+func FuzzDecode(f *testing.F) {
+	f.Add(Encode(NewFrame(CmdHeartbeat, 0, nil)))
+	f.Add(Encode(NewFrame(CmdAuth, 1, []byte("token"))))
+	f.Add(AppendFrame(Encode(NewFrame(CmdPush, 2, []byte("a"))), NewFrame(CmdPushAck, 3, nil)))
+	f.Add([]byte{1, 0, 5, 0, 0, 0, 1, 0xff, 0xff, 0xff, 0xff})
+	f.Add([]byte{})
+
+	const maxBodyLen = 64 * 1024
+	f.Fuzz(func(t *testing.T, data []byte) {
+		frame, n, err := Decode(data, maxBodyLen)
+		if err != nil {
+			if n != 0 {
+				t.Fatalf("出错时不应该消耗字节, n=%d", n)
+			}
+			return
+		}
+		if n < HeaderLen || n > len(data) {
+			t.Fatalf("消耗字节数越界, n=%d len=%d", n, len(data))
+		}
+		if !bytes.Equal(Encode(frame), data[:n]) {
+			t.Fatalf("重新编码结果不一致")
+		}
+
+		// 流式读取应与缓冲区解码结果一致
+		streamFrame, err := NewReader(bytes.NewReader(data), maxBodyLen).ReadFrame()
+		if err != nil {
+			t.Fatalf("流式读取失败: %v", err)
+		}
+		if streamFrame.Cmd != frame.Cmd || streamFrame.Seq != frame.Seq || !bytes.Equal(streamFrame.Body, frame.Body) {
+			t.Fatalf("流式读取结果不一致")
+		}
+	})
+}
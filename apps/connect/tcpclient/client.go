@@ -0,0 +1,109 @@
+// Package tcpclient Connect 服务 TCP 长连接的 Go 客户端，用于联调、压测与集成测试。
+package tcpclient
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"ChatServer/apps/connect/pb"
+	"ChatServer/apps/connect/protocol"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// Client TCP 长连接客户端
+// 写操作并发安全；ReadFrame 只允许单个协程调用。
+type Client struct {
+	conn   net.Conn
+	reader *protocol.Reader
+	seq    atomic.Uint32
+	wmu    sync.Mutex
+}
+
+// Dial 建立 TCP 连接（未鉴权）
+func Dial(ctx context.Context, addr string) (*Client, error) {
+	var d net.Dialer
+	nc, err := d.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+	return &Client{
+		conn:   nc,
+		reader: protocol.NewReader(nc, 0),
+	}, nil
+}
+
+// Auth 发送鉴权帧并等待鉴权应答
+// 鉴权失败时同时返回服务端应答与错误，服务端随后会关闭连接。
+func (c *Client) Auth(token, deviceID string, timeout time.Duration) (*pb.AuthResponse, error) {
+	body, err := proto.Marshal(&pb.AuthRequest{Token: token, DeviceId: deviceID})
+	if err != nil {
+		return nil, err
+	}
+	seq, err := c.WriteFrame(protocol.CmdAuth, body)
+	if err != nil {
+		return nil, err
+	}
+
+	_ = c.conn.SetReadDeadline(time.Now().Add(timeout))
+	defer c.conn.SetReadDeadline(time.Time{})
+
+	f, err := c.reader.ReadFrame()
+	if err != nil {
+		return nil, err
+	}
+	if f.Cmd != protocol.CmdAuthAck || f.Seq != seq {
+		return nil, fmt.Errorf("tcpclient: unexpected frame %s seq=%d", f.Cmd, f.Seq)
+	}
+
+	resp := &pb.AuthResponse{}
+	if err := proto.Unmarshal(f.Body, resp); err != nil {
+		return nil, err
+	}
+	if resp.Code != 0 {
+		return resp, fmt.Errorf("tcpclient: auth failed, code=%d message=%s", resp.Code, resp.Message)
+	}
+	return resp, nil
+}
+
+// Heartbeat 发送心跳帧，返回帧序号（应答帧 CmdHeartbeatAck 携带相同序号）
+func (c *Client) Heartbeat() (uint32, error) {
+	return c.WriteFrame(protocol.CmdHeartbeat, nil)
+}
+
+// Ack 确认服务端推送
+func (c *Client) Ack(pushSeq uint32) error {
+	return c.writeFrame(protocol.NewFrame(protocol.CmdPushAck, pushSeq, nil))
+}
+
+// WriteFrame 分配帧序号并写出帧
+func (c *Client) WriteFrame(cmd protocol.Command, body []byte) (uint32, error) {
+	seq := c.seq.Add(1)
+	return seq, c.writeFrame(protocol.NewFrame(cmd, seq, body))
+}
+
+// writeFrame 写出帧（串行化，避免多个帧交错）
+func (c *Client) writeFrame(f *protocol.Frame) error {
+	c.wmu.Lock()
+	defer c.wmu.Unlock()
+	return protocol.WriteFrame(c.conn, f)
+}
+
+// ReadFrame 阻塞读取下一个下行帧
+func (c *Client) ReadFrame() (*protocol.Frame, error) {
+	return c.reader.ReadFrame()
+}
+
+// SetReadDeadline 设置读超时
+func (c *Client) SetReadDeadline(t time.Time) error {
+	return c.conn.SetReadDeadline(t)
+}
+
+// Close 关闭连接
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
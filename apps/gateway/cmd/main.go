@@ -74,21 +74,44 @@ func main() {
 	logger.Info(ctx, "用户服务 gRPC 连接创建成功", logger.String("address", userServiceAddr))
 
 	// 3.3 创建 gRPC 客户端
-	userClient := pb.NewUserServiceClient(userServiceConn, userServiceConn, userServiceConn, userServiceConn, userServiceConn, userServiceBreaker)
+	userClient := pb.NewUserServiceClient(userServiceConn, userServiceConn, userServiceConn, userServiceConn, userServiceConn, userServiceConn, userServiceBreaker)
 	logger.Info(ctx, "用户服务 gRPC 客户端初始化完成", logger.String("address", userServiceAddr))
 
+	// 3.4 创建消息服务 gRPC 客户端
+	// TODO: 从配置文件读取msg服务地址
+	msgServiceAddr := "localhost:9093"
+	msgServiceBreaker := pb.CreateCircuitBreaker("msg-service")
+	msgServiceConn, err := pb.CreateMsgServiceConnection(msgServiceAddr, msgServiceBreaker)
+	if err != nil {
+		logger.Error(ctx, "创建消息服务 gRPC 连接失败", logger.ErrorField("error", err))
+		os.Exit(1)
+	}
+	defer func() {
+		if err := msgServiceConn.Close(); err != nil {
+			logger.Error(ctx, "关闭消息服务 gRPC 连接失败", logger.ErrorField("error", err))
+		}
+	}()
+	msgClient := pb.NewMsgServiceClient(msgServiceConn, msgServiceConn, msgServiceBreaker)
+	logger.Info(ctx, "消息服务 gRPC 客户端初始化完成", logger.String("address", msgServiceAddr))
+
 	// 4. 初始化 Service 层（依赖注入）
 	authService := service.NewAuthService(userClient)
+	messageService := service.NewMessageService(msgClient)
+	conversationService := service.NewConversationService(msgClient)
+	groupService := service.NewGroupService(userClient)
 	logger.Info(ctx, "认证服务初始化完成")
 
 	// 5. 初始化 Handler 层（依赖注入）
 	authHandler := v1.NewAuthHandler(authService)
+	messageHandler := v1.NewMessageHandler(messageService)
+	conversationHandler := v1.NewConversationHandler(conversationService)
+	groupHandler := v1.NewGroupHandler(groupService)
 	logger.Info(ctx, "认证处理器初始化完成")
 
 	// 6. 初始化路由（依赖注入）
 	// Gin 模式设置: ReleaseMode/DebugMode/TestMode
 	gin.SetMode(gin.ReleaseMode)
-	r := router.InitRouter(authHandler)
+	r := router.InitRouter(authHandler, messageHandler, conversationHandler, groupHandler)
 	logger.Info(ctx, "路由初始化完成")
 
 	// 7. 配置服务器
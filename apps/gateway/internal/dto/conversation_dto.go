@@ -0,0 +1,207 @@
+package dto
+
+import (
+	msgpb "ChatServer/apps/msg/pb"
+)
+
+// ==================== 会话服务相关 DTO ====================
+
+// ConversationItem 会话 DTO
+type ConversationItem struct {
+	ConvID         string `json:"convId"`         // 会话ID
+	Type           int32  `json:"type"`           // 0单聊 1群聊
+	TargetUUID     string `json:"targetUuid"`     // 单聊为对端UUID，群聊为群UUID
+	LastMsgID      string `json:"lastMsgId"`      // 最后消息ID
+	LastMsgPreview string `json:"lastMsgPreview"` // 最后消息预览
+	LastMsgTime    int64  `json:"lastMsgTime"`    // 最后消息时间（毫秒时间戳）
+	UnreadCount    int32  `json:"unreadCount"`    // 未读数
+	ReadSeq        int64  `json:"readSeq"`        // 已读游标
+	Mute           bool   `json:"mute"`           // 免打扰
+	Pin            bool   `json:"pin"`            // 置顶
+	UpdatedAt      int64  `json:"updatedAt"`      // 更新时间（毫秒时间戳）
+	Version        int64  `json:"version"`        // 变更版本号
+	Mentioned      bool   `json:"mentioned"`      // 有未读的@我消息（不受免打扰影响）
+}
+
+// GetConversationListRequest 获取会话列表请求 DTO
+type GetConversationListRequest struct {
+	Page     int32 `json:"page" binding:"required,min=1"`             // 页码
+	PageSize int32 `json:"pageSize" binding:"required,min=1,max=100"` // 每页大小
+}
+
+// GetConversationListResponse 获取会话列表响应 DTO
+type GetConversationListResponse struct {
+	Items           []*ConversationItem `json:"items"`           // 会话列表（置顶在前，其余按更新时间倒序）
+	Pagination      *PaginationInfo     `json:"pagination"`      // 分页信息
+	Version         int64               `json:"version"`         // 用于增量同步的版本号
+	TimelineVersion int64               `json:"timelineVersion"` // 用于增量同步的群时间线版本号
+}
+
+// SyncConversationsRequest 增量同步会话请求 DTO
+type SyncConversationsRequest struct {
+	Version         int64 `json:"version" binding:"min=0"`                 // 上次同步得到的版本号，0 表示全量
+	Limit           int32 `json:"limit" binding:"omitempty,min=1,max=500"` // 单次最多返回的变更数（默认100）
+	TimelineVersion int64 `json:"timelineVersion" binding:"min=0"`         // 上次同步得到的群时间线版本号，0 表示下发全部读扩散群会话
+}
+
+// SyncConversationsResponse 增量同步会话响应 DTO
+type SyncConversationsResponse struct {
+	Upserts         []*ConversationItem `json:"upserts"`         // 新增或更新的会话
+	DeletedConvIDs  []string            `json:"deletedConvIds"`  // 被删除的会话ID
+	HasMore         bool                `json:"hasMore"`         // 是否还有更多变更
+	Version         int64               `json:"version"`         // 本次同步到的版本号
+	TimelineVersion int64               `json:"timelineVersion"` // 本次同步到的群时间线版本号
+}
+
+// SetConversationPinRequest 置顶会话请求 DTO
+type SetConversationPinRequest struct {
+	ConvID string `json:"convId" binding:"required,max=40"` // 会话ID
+	Pin    bool   `json:"pin"`                              // true置顶 false取消置顶
+}
+
+// SetConversationPinResponse 置顶会话响应 DTO
+type SetConversationPinResponse struct{}
+
+// SetConversationMuteRequest 会话免打扰请求 DTO
+type SetConversationMuteRequest struct {
+	ConvID string `json:"convId" binding:"required,max=40"` // 会话ID
+	Mute   bool   `json:"mute"`                             // true开启 false关闭
+}
+
+// SetConversationMuteResponse 会话免打扰响应 DTO
+type SetConversationMuteResponse struct{}
+
+// DeleteConversationRequest 删除会话请求 DTO
+type DeleteConversationRequest struct {
+	ConvID string `json:"convId" binding:"required,max=40"` // 会话ID
+}
+
+// DeleteConversationResponse 删除会话响应 DTO
+type DeleteConversationResponse struct{}
+
+// ==================== DTO 转换函数 ====================
+
+// ConvertToProtoGetConversationListRequest 将 DTO 转换为 Protobuf 请求
+func ConvertToProtoGetConversationListRequest(dto *GetConversationListRequest) *msgpb.GetConversationListRequest {
+	if dto == nil {
+		return nil
+	}
+	return &msgpb.GetConversationListRequest{
+		Page:     dto.Page,
+		PageSize: dto.PageSize,
+	}
+}
+
+// ConvertToProtoSyncConversationsRequest 将 DTO 转换为 Protobuf 请求
+func ConvertToProtoSyncConversationsRequest(dto *SyncConversationsRequest) *msgpb.SyncConversationsRequest {
+	if dto == nil {
+		return nil
+	}
+	return &msgpb.SyncConversationsRequest{
+		Version:         dto.Version,
+		Limit:           dto.Limit,
+		TimelineVersion: dto.TimelineVersion,
+	}
+}
+
+// ConvertToProtoSetConversationPinRequest 将 DTO 转换为 Protobuf 请求
+func ConvertToProtoSetConversationPinRequest(dto *SetConversationPinRequest) *msgpb.SetConversationPinRequest {
+	if dto == nil {
+		return nil
+	}
+	return &msgpb.SetConversationPinRequest{
+		ConvId: dto.ConvID,
+		Pin:    dto.Pin,
+	}
+}
+
+// ConvertToProtoSetConversationMuteRequest 将 DTO 转换为 Protobuf 请求
+func ConvertToProtoSetConversationMuteRequest(dto *SetConversationMuteRequest) *msgpb.SetConversationMuteRequest {
+	if dto == nil {
+		return nil
+	}
+	return &msgpb.SetConversationMuteRequest{
+		ConvId: dto.ConvID,
+		Mute:   dto.Mute,
+	}
+}
+
+// ConvertToProtoDeleteConversationRequest 将 DTO 转换为 Protobuf 请求
+func ConvertToProtoDeleteConversationRequest(dto *DeleteConversationRequest) *msgpb.DeleteConversationRequest {
+	if dto == nil {
+		return nil
+	}
+	return &msgpb.DeleteConversationRequest{
+		ConvId: dto.ConvID,
+	}
+}
+
+// ConvertConversationItemFromProto 将 Protobuf 会话转换为 DTO
+func ConvertConversationItemFromProto(pb *msgpb.ConversationItem) *ConversationItem {
+	if pb == nil {
+		return nil
+	}
+	return &ConversationItem{
+		ConvID:         pb.ConvId,
+		Type:           pb.Type,
+		TargetUUID:     pb.TargetUuid,
+		LastMsgID:      pb.LastMsgId,
+		LastMsgPreview: pb.LastMsgPreview,
+		LastMsgTime:    pb.LastMsgTime,
+		UnreadCount:    pb.UnreadCount,
+		ReadSeq:        pb.ReadSeq,
+		Mute:           pb.Mute,
+		Pin:            pb.Pin,
+		UpdatedAt:      pb.UpdatedAt,
+		Version:        pb.Version,
+		Mentioned:      pb.Mentioned,
+	}
+}
+
+// ConvertConversationItemsFromProto 批量转换会话
+func ConvertConversationItemsFromProto(pbs []*msgpb.ConversationItem) []*ConversationItem {
+	items := make([]*ConversationItem, 0, len(pbs))
+	for _, pb := range pbs {
+		items = append(items, ConvertConversationItemFromProto(pb))
+	}
+	return items
+}
+
+// ConvertGetConversationListResponseFromProto 将 Protobuf 响应转换为 DTO
+func ConvertGetConversationListResponseFromProto(pb *msgpb.GetConversationListResponse) *GetConversationListResponse {
+	if pb == nil {
+		return &GetConversationListResponse{Items: []*ConversationItem{}}
+	}
+	var pagination *PaginationInfo
+	if pb.Pagination != nil {
+		pagination = &PaginationInfo{
+			Page:     pb.Pagination.Page,
+			PageSize: pb.Pagination.PageSize,
+			Total:    pb.Pagination.Total,
+		}
+	}
+	return &GetConversationListResponse{
+		Items:           ConvertConversationItemsFromProto(pb.Items),
+		Pagination:      pagination,
+		Version:         pb.Version,
+		TimelineVersion: pb.TimelineVersion,
+	}
+}
+
+// ConvertSyncConversationsResponseFromProto 将 Protobuf 响应转换为 DTO
+func ConvertSyncConversationsResponseFromProto(pb *msgpb.SyncConversationsResponse) *SyncConversationsResponse {
+	if pb == nil {
+		return &SyncConversationsResponse{Upserts: []*ConversationItem{}, DeletedConvIDs: []string{}}
+	}
+	deleted := pb.DeletedConvIds
+	if deleted == nil {
+		deleted = []string{}
+	}
+	return &SyncConversationsResponse{
+		Upserts:         ConvertConversationItemsFromProto(pb.Upserts),
+		DeletedConvIDs:  deleted,
+		HasMore:         pb.HasMore,
+		Version:         pb.Version,
+		TimelineVersion: pb.TimelineVersion,
+	}
+}
@@ -0,0 +1,807 @@
+package dto
+
+import (
+	userpb "ChatServer/apps/user/pb"
+)
+
+// ==================== 群组服务相关 DTO ====================
+
+// GroupInfo 群资料 DTO
+type GroupInfo struct {
+	UUID      string `json:"uuid"`      // 群UUID
+	Name      string `json:"name"`      // 群名称
+	Notice    string `json:"notice"`    // 群公告
+	Avatar    string `json:"avatar"`    // 群头像
+	OwnerUUID string `json:"ownerUuid"` // 群主UUID
+	MemberCnt int32  `json:"memberCnt"` // 群人数
+	AddMode   int32  `json:"addMode"`   // 0直接加入 1需审核
+	Status    int32  `json:"status"`    // 0正常 1禁用 2解散
+	CreatedAt int64  `json:"createdAt"` // 创建时间（毫秒时间戳）
+	MuteAll   bool   `json:"muteAll"`   // 是否全员禁言
+}
+
+// GroupMemberItem 群成员 DTO
+type GroupMemberItem struct {
+	UserUUID    string `json:"userUuid"`    // 成员UUID
+	Role        int32  `json:"role"`        // 0成员 1管理员 2群主
+	Remark      string `json:"remark"`      // 群名片
+	MuteUntil   int64  `json:"muteUntil"`   // 禁言到期时间（毫秒时间戳，0表示未禁言）
+	InviterUUID string `json:"inviterUuid"` // 邀请人UUID
+	JoinedAt    int64  `json:"joinedAt"`    // 入群时间（毫秒时间戳）
+}
+
+// CreateGroupRequest 创建群组请求 DTO
+type CreateGroupRequest struct {
+	Name        string   `json:"name" binding:"required,max=64"` // 群名称
+	Avatar      string   `json:"avatar" binding:"max=255"`       // 群头像
+	MemberUUIDs []string `json:"memberUuids"`                    // 初始成员（不含创建者）
+}
+
+// CreateGroupResponse 创建群组响应 DTO
+type CreateGroupResponse struct {
+	Group *GroupInfo `json:"group"` // 群资料
+}
+
+// GetGroupInfoRequest 获取群资料请求 DTO
+type GetGroupInfoRequest struct {
+	GroupUUID string `json:"groupUuid" binding:"required,max=20"` // 群UUID
+}
+
+// GetGroupInfoResponse 获取群资料响应 DTO
+type GetGroupInfoResponse struct {
+	Group *GroupInfo `json:"group"` // 群资料
+}
+
+// GetGroupMembersRequest 获取群成员列表请求 DTO
+type GetGroupMembersRequest struct {
+	GroupUUID string `json:"groupUuid" binding:"required,max=20"`       // 群UUID
+	Page      int32  `json:"page" binding:"required,min=1"`             // 页码
+	PageSize  int32  `json:"pageSize" binding:"required,min=1,max=100"` // 每页大小
+}
+
+// GetGroupMembersResponse 获取群成员列表响应 DTO
+type GetGroupMembersResponse struct {
+	Items      []*GroupMemberItem `json:"items"`      // 群成员（群主、管理员在前）
+	Pagination *PaginationInfo    `json:"pagination"` // 分页信息
+}
+
+// InviteMembersRequest 邀请成员请求 DTO
+type InviteMembersRequest struct {
+	GroupUUID   string   `json:"groupUuid" binding:"required,max=20"`  // 群UUID
+	MemberUUIDs []string `json:"memberUuids" binding:"required,min=1"` // 被邀请人UUID列表
+}
+
+// InviteMembersResponse 邀请成员响应 DTO
+type InviteMembersResponse struct {
+	AddedUUIDs   []string `json:"addedUuids"`   // 本次新加入的成员
+	SkippedUUIDs []string `json:"skippedUuids"` // 已是群成员或用户不存在而跳过的UUID
+	MemberCnt    int32    `json:"memberCnt"`    // 邀请后的群人数
+}
+
+// JoinGroupRequest 申请入群请求 DTO
+type JoinGroupRequest struct {
+	GroupUUID string `json:"groupUuid" binding:"required,max=20"` // 群UUID
+	Reason    string `json:"reason" binding:"max=255"`            // 申请附言
+}
+
+// JoinGroupResponse 申请入群响应 DTO
+type JoinGroupResponse struct {
+	Joined  bool  `json:"joined"`  // true 已直接入群；false 已提交申请，等待审核
+	ApplyID int64 `json:"applyId"` // 入群申请ID（joined 为 false 时有效）
+}
+
+// GroupApplyItem 入群申请 DTO
+type GroupApplyItem struct {
+	ApplyID        int64           `json:"applyId"`        // 申请ID
+	GroupUUID      string          `json:"groupUuid"`      // 群UUID
+	ApplicantInfo  *SimpleUserInfo `json:"applicantInfo"`  // 申请人信息
+	Reason         string          `json:"reason"`         // 申请附言
+	Status         int32           `json:"status"`         // 0待处理 1通过 2拒绝 3过期
+	HandleUserUUID string          `json:"handleUserUuid"` // 处理人UUID
+	HandleRemark   string          `json:"handleRemark"`   // 处理备注
+	CreatedAt      int64           `json:"createdAt"`      // 申请时间（毫秒时间戳）
+}
+
+// GetGroupApplyListRequest 获取入群申请列表请求 DTO
+type GetGroupApplyListRequest struct {
+	GroupUUID string `json:"groupUuid" binding:"required,max=20"`       // 群UUID
+	Status    int32  `json:"status" binding:"min=-1,max=3"`             // -1全部 0待处理 1通过 2拒绝 3过期
+	Page      int32  `json:"page" binding:"required,min=1"`             // 页码
+	PageSize  int32  `json:"pageSize" binding:"required,min=1,max=100"` // 每页大小
+}
+
+// GetGroupApplyListResponse 获取入群申请列表响应 DTO
+type GetGroupApplyListResponse struct {
+	Items      []*GroupApplyItem `json:"items"`      // 入群申请（按申请时间倒序）
+	Pagination *PaginationInfo   `json:"pagination"` // 分页信息
+}
+
+// HandleGroupApplyRequest 处理入群申请请求 DTO
+type HandleGroupApplyRequest struct {
+	ApplyID int64  `json:"applyId" binding:"required,min=1"`    // 申请ID
+	Action  int32  `json:"action" binding:"required,oneof=1 2"` // 1:同意 2:拒绝
+	Remark  string `json:"remark" binding:"max=64"`             // 处理备注
+}
+
+// HandleGroupApplyResponse 处理入群申请响应 DTO
+type HandleGroupApplyResponse struct{}
+
+// SetAdminRequest 设置管理员请求 DTO
+type SetAdminRequest struct {
+	GroupUUID string `json:"groupUuid" binding:"required,max=20"` // 群UUID
+	UserUUID  string `json:"userUuid" binding:"required,max=20"`  // 目标成员UUID
+}
+
+// SetAdminResponse 设置管理员响应 DTO
+type SetAdminResponse struct{}
+
+// UnsetAdminRequest 取消管理员请求 DTO
+type UnsetAdminRequest struct {
+	GroupUUID string `json:"groupUuid" binding:"required,max=20"` // 群UUID
+	UserUUID  string `json:"userUuid" binding:"required,max=20"`  // 目标成员UUID
+}
+
+// UnsetAdminResponse 取消管理员响应 DTO
+type UnsetAdminResponse struct{}
+
+// KickMemberRequest 踢出群成员请求 DTO
+type KickMemberRequest struct {
+	GroupUUID string `json:"groupUuid" binding:"required,max=20"` // 群UUID
+	UserUUID  string `json:"userUuid" binding:"required,max=20"`  // 目标成员UUID
+}
+
+// KickMemberResponse 踢出群成员响应 DTO
+type KickMemberResponse struct{}
+
+// TransferOwnershipRequest 转让群主请求 DTO
+type TransferOwnershipRequest struct {
+	GroupUUID    string `json:"groupUuid" binding:"required,max=20"`    // 群UUID
+	NewOwnerUUID string `json:"newOwnerUuid" binding:"required,max=20"` // 新群主UUID
+}
+
+// TransferOwnershipResponse 转让群主响应 DTO
+type TransferOwnershipResponse struct{}
+
+// MuteMemberRequest 禁言成员请求 DTO
+type MuteMemberRequest struct {
+	GroupUUID string `json:"groupUuid" binding:"required,max=20"`  // 群UUID
+	UserUUID  string `json:"userUuid" binding:"required,max=20"`   // 目标成员UUID
+	Duration  int64  `json:"duration" binding:"min=0,max=2592000"` // 禁言时长（秒，最长30天），0表示解除禁言
+}
+
+// MuteMemberResponse 禁言成员响应 DTO
+type MuteMemberResponse struct {
+	MuteUntil int64 `json:"muteUntil"` // 禁言到期时间（毫秒时间戳，0表示已解除禁言）
+}
+
+// SetGroupMuteAllRequest 全员禁言请求 DTO
+type SetGroupMuteAllRequest struct {
+	GroupUUID string `json:"groupUuid" binding:"required,max=20"` // 群UUID
+	MuteAll   bool   `json:"muteAll"`                             // true开启 false关闭
+}
+
+// SetGroupMuteAllResponse 全员禁言响应 DTO
+type SetGroupMuteAllResponse struct{}
+
+// GroupAnnouncement 群公告 DTO
+type GroupAnnouncement struct {
+	ID            int64  `json:"id"`            // 公告ID
+	GroupUUID     string `json:"groupUuid"`     // 群UUID
+	PublisherUUID string `json:"publisherUuid"` // 发布人UUID
+	Content       string `json:"content"`       // 公告内容
+	Pinned        bool   `json:"pinned"`        // 是否置顶
+	RequireAck    bool   `json:"requireAck"`    // 是否需要成员确认已读
+	AckCount      int32  `json:"ackCount"`      // 已确认人数
+	Acked         bool   `json:"acked"`         // 当前用户是否已确认
+	CreatedAt     int64  `json:"createdAt"`     // 发布时间（毫秒时间戳）
+}
+
+// PostGroupAnnouncementRequest 发布群公告请求 DTO
+type PostGroupAnnouncementRequest struct {
+	GroupUUID  string `json:"groupUuid" binding:"required,max=20"` // 群UUID
+	Content    string `json:"content" binding:"required"`          // 公告内容（最长500字符）
+	Pinned     bool   `json:"pinned"`                              // 是否置顶
+	RequireAck bool   `json:"requireAck"`                          // 是否需要成员确认已读
+}
+
+// PostGroupAnnouncementResponse 发布群公告响应 DTO
+type PostGroupAnnouncementResponse struct {
+	Announcement *GroupAnnouncement `json:"announcement"` // 群公告
+}
+
+// GetGroupAnnouncementsRequest 获取群公告历史请求 DTO
+type GetGroupAnnouncementsRequest struct {
+	GroupUUID string `json:"groupUuid" binding:"required,max=20"`       // 群UUID
+	Page      int32  `json:"page" binding:"required,min=1"`             // 页码
+	PageSize  int32  `json:"pageSize" binding:"required,min=1,max=100"` // 每页大小
+}
+
+// GetGroupAnnouncementsResponse 获取群公告历史响应 DTO
+type GetGroupAnnouncementsResponse struct {
+	Items      []*GroupAnnouncement `json:"items"`      // 群公告（置顶在前，其余按发布时间倒序）
+	Pagination *PaginationInfo      `json:"pagination"` // 分页信息
+}
+
+// PinGroupAnnouncementRequest 置顶群公告请求 DTO
+type PinGroupAnnouncementRequest struct {
+	GroupUUID      string `json:"groupUuid" binding:"required,max=20"`     // 群UUID
+	AnnouncementID int64  `json:"announcementId" binding:"required,min=1"` // 公告ID
+	Pinned         bool   `json:"pinned"`                                  // true置顶 false取消置顶
+}
+
+// PinGroupAnnouncementResponse 置顶群公告响应 DTO
+type PinGroupAnnouncementResponse struct{}
+
+// AckGroupAnnouncementRequest 确认群公告请求 DTO
+type AckGroupAnnouncementRequest struct {
+	GroupUUID      string `json:"groupUuid" binding:"required,max=20"`     // 群UUID
+	AnnouncementID int64  `json:"announcementId" binding:"required,min=1"` // 公告ID
+}
+
+// AckGroupAnnouncementResponse 确认群公告响应 DTO
+type AckGroupAnnouncementResponse struct {
+	AckCount int32 `json:"ackCount"` // 确认后的已确认人数
+}
+
+// GroupInvite 群邀请 DTO
+type GroupInvite struct {
+	ID          int64  `json:"id"`          // 邀请ID
+	GroupUUID   string `json:"groupUuid"`   // 群UUID
+	CreatorUUID string `json:"creatorUuid"` // 创建人UUID
+	Token       string `json:"token"`       // 签名邀请令牌
+	QRCode      string `json:"qrcode"`      // 二维码内容
+	MaxUses     int32  `json:"maxUses"`     // 最大使用次数（0表示不限）
+	UsedCount   int32  `json:"usedCount"`   // 已使用次数
+	ExpireAt    int64  `json:"expireAt"`    // 过期时间（毫秒时间戳）
+	CreatedAt   int64  `json:"createdAt"`   // 创建时间（毫秒时间戳）
+}
+
+// CreateGroupInviteRequest 创建群邀请请求 DTO
+type CreateGroupInviteRequest struct {
+	GroupUUID     string `json:"groupUuid" binding:"required,max=20"`       // 群UUID
+	ExpireSeconds int64  `json:"expireSeconds" binding:"min=0,max=2592000"` // 有效期（秒，最长30天），0使用默认有效期
+	MaxUses       int32  `json:"maxUses" binding:"min=0,max=10000"`         // 最大使用次数，0表示不限
+}
+
+// CreateGroupInviteResponse 创建群邀请响应 DTO
+type CreateGroupInviteResponse struct {
+	Invite *GroupInvite `json:"invite"` // 群邀请
+}
+
+// GetGroupInviteListRequest 获取群邀请列表请求 DTO
+type GetGroupInviteListRequest struct {
+	GroupUUID string `json:"groupUuid" binding:"required,max=20"` // 群UUID
+}
+
+// GetGroupInviteListResponse 获取群邀请列表响应 DTO
+type GetGroupInviteListResponse struct {
+	Items []*GroupInvite `json:"items"` // 未撤销且未过期的邀请（按创建时间倒序）
+}
+
+// RevokeGroupInviteRequest 撤销群邀请请求 DTO
+type RevokeGroupInviteRequest struct {
+	GroupUUID string `json:"groupUuid" binding:"required,max=20"` // 群UUID
+	InviteID  int64  `json:"inviteId" binding:"required,min=1"`   // 邀请ID
+}
+
+// RevokeGroupInviteResponse 撤销群邀请响应 DTO
+type RevokeGroupInviteResponse struct{}
+
+// JoinByInviteTokenRequest 通过邀请令牌入群请求 DTO
+type JoinByInviteTokenRequest struct {
+	Token  string `json:"token" binding:"required"` // 邀请令牌
+	Reason string `json:"reason" binding:"max=255"` // 申请附言（需审核的群使用）
+}
+
+// JoinByInviteTokenResponse 通过邀请令牌入群响应 DTO
+type JoinByInviteTokenResponse struct {
+	GroupUUID string `json:"groupUuid"` // 群UUID
+	Joined    bool   `json:"joined"`    // true已直接入群；false已提交申请，等待审核
+	ApplyID   int64  `json:"applyId"`   // 入群申请ID（joined为false时有效）
+}
+
+// QuitGroupRequest 退群请求 DTO
+type QuitGroupRequest struct {
+	GroupUUID string `json:"groupUuid" binding:"required,max=20"` // 群UUID
+}
+
+// QuitGroupResponse 退群响应 DTO
+type QuitGroupResponse struct{}
+
+// DismissGroupRequest 解散群组请求 DTO
+type DismissGroupRequest struct {
+	GroupUUID string `json:"groupUuid" binding:"required,max=20"` // 群UUID
+}
+
+// DismissGroupResponse 解散群组响应 DTO
+type DismissGroupResponse struct{}
+
+// ==================== DTO 转换函数 ====================
+
+// ConvertToProtoCreateGroupRequest 将 DTO 转换为 Protobuf 请求
+func ConvertToProtoCreateGroupRequest(dto *CreateGroupRequest) *userpb.CreateGroupRequest {
+	if dto == nil {
+		return nil
+	}
+	return &userpb.CreateGroupRequest{
+		Name:        dto.Name,
+		Avatar:      dto.Avatar,
+		MemberUuids: dto.MemberUUIDs,
+	}
+}
+
+// ConvertToProtoGetGroupInfoRequest 将 DTO 转换为 Protobuf 请求
+func ConvertToProtoGetGroupInfoRequest(dto *GetGroupInfoRequest) *userpb.GetGroupInfoRequest {
+	if dto == nil {
+		return nil
+	}
+	return &userpb.GetGroupInfoRequest{
+		GroupUuid: dto.GroupUUID,
+	}
+}
+
+// ConvertToProtoGetGroupMembersRequest 将 DTO 转换为 Protobuf 请求
+func ConvertToProtoGetGroupMembersRequest(dto *GetGroupMembersRequest) *userpb.GetGroupMembersRequest {
+	if dto == nil {
+		return nil
+	}
+	return &userpb.GetGroupMembersRequest{
+		GroupUuid: dto.GroupUUID,
+		Page:      dto.Page,
+		PageSize:  dto.PageSize,
+	}
+}
+
+// ConvertToProtoInviteMembersRequest 将 DTO 转换为 Protobuf 请求
+func ConvertToProtoInviteMembersRequest(dto *InviteMembersRequest) *userpb.InviteMembersRequest {
+	if dto == nil {
+		return nil
+	}
+	return &userpb.InviteMembersRequest{
+		GroupUuid:   dto.GroupUUID,
+		MemberUuids: dto.MemberUUIDs,
+	}
+}
+
+// ConvertToProtoJoinGroupRequest 将 DTO 转换为 Protobuf 请求
+func ConvertToProtoJoinGroupRequest(dto *JoinGroupRequest) *userpb.JoinGroupRequest {
+	if dto == nil {
+		return nil
+	}
+	return &userpb.JoinGroupRequest{
+		GroupUuid: dto.GroupUUID,
+		Reason:    dto.Reason,
+	}
+}
+
+// ConvertToProtoGetGroupApplyListRequest 将 DTO 转换为 Protobuf 请求
+func ConvertToProtoGetGroupApplyListRequest(dto *GetGroupApplyListRequest) *userpb.GetGroupApplyListRequest {
+	if dto == nil {
+		return nil
+	}
+	return &userpb.GetGroupApplyListRequest{
+		GroupUuid: dto.GroupUUID,
+		Status:    dto.Status,
+		Page:      dto.Page,
+		PageSize:  dto.PageSize,
+	}
+}
+
+// ConvertToProtoHandleGroupApplyRequest 将 DTO 转换为 Protobuf 请求
+func ConvertToProtoHandleGroupApplyRequest(dto *HandleGroupApplyRequest) *userpb.HandleGroupApplyRequest {
+	if dto == nil {
+		return nil
+	}
+	return &userpb.HandleGroupApplyRequest{
+		ApplyId: dto.ApplyID,
+		Action:  dto.Action,
+		Remark:  dto.Remark,
+	}
+}
+
+// ConvertToProtoSetAdminRequest 将 DTO 转换为 Protobuf 请求
+func ConvertToProtoSetAdminRequest(dto *SetAdminRequest) *userpb.SetAdminRequest {
+	if dto == nil {
+		return nil
+	}
+	return &userpb.SetAdminRequest{
+		GroupUuid: dto.GroupUUID,
+		UserUuid:  dto.UserUUID,
+	}
+}
+
+// ConvertToProtoUnsetAdminRequest 将 DTO 转换为 Protobuf 请求
+func ConvertToProtoUnsetAdminRequest(dto *UnsetAdminRequest) *userpb.UnsetAdminRequest {
+	if dto == nil {
+		return nil
+	}
+	return &userpb.UnsetAdminRequest{
+		GroupUuid: dto.GroupUUID,
+		UserUuid:  dto.UserUUID,
+	}
+}
+
+// ConvertToProtoKickMemberRequest 将 DTO 转换为 Protobuf 请求
+func ConvertToProtoKickMemberRequest(dto *KickMemberRequest) *userpb.KickMemberRequest {
+	if dto == nil {
+		return nil
+	}
+	return &userpb.KickMemberRequest{
+		GroupUuid: dto.GroupUUID,
+		UserUuid:  dto.UserUUID,
+	}
+}
+
+// ConvertToProtoTransferOwnershipRequest 将 DTO 转换为 Protobuf 请求
+func ConvertToProtoTransferOwnershipRequest(dto *TransferOwnershipRequest) *userpb.TransferOwnershipRequest {
+	if dto == nil {
+		return nil
+	}
+	return &userpb.TransferOwnershipRequest{
+		GroupUuid:    dto.GroupUUID,
+		NewOwnerUuid: dto.NewOwnerUUID,
+	}
+}
+
+// ConvertToProtoMuteMemberRequest 将 DTO 转换为 Protobuf 请求
+func ConvertToProtoMuteMemberRequest(dto *MuteMemberRequest) *userpb.MuteMemberRequest {
+	if dto == nil {
+		return nil
+	}
+	return &userpb.MuteMemberRequest{
+		GroupUuid: dto.GroupUUID,
+		UserUuid:  dto.UserUUID,
+		Duration:  dto.Duration,
+	}
+}
+
+// ConvertToProtoSetGroupMuteAllRequest 将 DTO 转换为 Protobuf 请求
+func ConvertToProtoSetGroupMuteAllRequest(dto *SetGroupMuteAllRequest) *userpb.SetGroupMuteAllRequest {
+	if dto == nil {
+		return nil
+	}
+	return &userpb.SetGroupMuteAllRequest{
+		GroupUuid: dto.GroupUUID,
+		MuteAll:   dto.MuteAll,
+	}
+}
+
+// ConvertToProtoPostGroupAnnouncementRequest 将 DTO 转换为 Protobuf 请求
+func ConvertToProtoPostGroupAnnouncementRequest(dto *PostGroupAnnouncementRequest) *userpb.PostGroupAnnouncementRequest {
+	if dto == nil {
+		return nil
+	}
+	return &userpb.PostGroupAnnouncementRequest{
+		GroupUuid:  dto.GroupUUID,
+		Content:    dto.Content,
+		Pinned:     dto.Pinned,
+		RequireAck: dto.RequireAck,
+	}
+}
+
+// ConvertToProtoGetGroupAnnouncementsRequest 将 DTO 转换为 Protobuf 请求
+func ConvertToProtoGetGroupAnnouncementsRequest(dto *GetGroupAnnouncementsRequest) *userpb.GetGroupAnnouncementsRequest {
+	if dto == nil {
+		return nil
+	}
+	return &userpb.GetGroupAnnouncementsRequest{
+		GroupUuid: dto.GroupUUID,
+		Page:      dto.Page,
+		PageSize:  dto.PageSize,
+	}
+}
+
+// ConvertToProtoPinGroupAnnouncementRequest 将 DTO 转换为 Protobuf 请求
+func ConvertToProtoPinGroupAnnouncementRequest(dto *PinGroupAnnouncementRequest) *userpb.PinGroupAnnouncementRequest {
+	if dto == nil {
+		return nil
+	}
+	return &userpb.PinGroupAnnouncementRequest{
+		GroupUuid:      dto.GroupUUID,
+		AnnouncementId: dto.AnnouncementID,
+		Pinned:         dto.Pinned,
+	}
+}
+
+// ConvertToProtoAckGroupAnnouncementRequest 将 DTO 转换为 Protobuf 请求
+func ConvertToProtoAckGroupAnnouncementRequest(dto *AckGroupAnnouncementRequest) *userpb.AckGroupAnnouncementRequest {
+	if dto == nil {
+		return nil
+	}
+	return &userpb.AckGroupAnnouncementRequest{
+		GroupUuid:      dto.GroupUUID,
+		AnnouncementId: dto.AnnouncementID,
+	}
+}
+
+// ConvertToProtoCreateGroupInviteRequest 将 DTO 转换为 Protobuf 请求
+func ConvertToProtoCreateGroupInviteRequest(dto *CreateGroupInviteRequest) *userpb.CreateGroupInviteRequest {
+	if dto == nil {
+		return nil
+	}
+	return &userpb.CreateGroupInviteRequest{
+		GroupUuid:     dto.GroupUUID,
+		ExpireSeconds: dto.ExpireSeconds,
+		MaxUses:       dto.MaxUses,
+	}
+}
+
+// ConvertToProtoGetGroupInviteListRequest 将 DTO 转换为 Protobuf 请求
+func ConvertToProtoGetGroupInviteListRequest(dto *GetGroupInviteListRequest) *userpb.GetGroupInviteListRequest {
+	if dto == nil {
+		return nil
+	}
+	return &userpb.GetGroupInviteListRequest{
+		GroupUuid: dto.GroupUUID,
+	}
+}
+
+// ConvertToProtoRevokeGroupInviteRequest 将 DTO 转换为 Protobuf 请求
+func ConvertToProtoRevokeGroupInviteRequest(dto *RevokeGroupInviteRequest) *userpb.RevokeGroupInviteRequest {
+	if dto == nil {
+		return nil
+	}
+	return &userpb.RevokeGroupInviteRequest{
+		GroupUuid: dto.GroupUUID,
+		InviteId:  dto.InviteID,
+	}
+}
+
+// ConvertToProtoJoinByInviteTokenRequest 将 DTO 转换为 Protobuf 请求
+func ConvertToProtoJoinByInviteTokenRequest(dto *JoinByInviteTokenRequest) *userpb.JoinByInviteTokenRequest {
+	if dto == nil {
+		return nil
+	}
+	return &userpb.JoinByInviteTokenRequest{
+		Token:  dto.Token,
+		Reason: dto.Reason,
+	}
+}
+
+// ConvertToProtoQuitGroupRequest 将 DTO 转换为 Protobuf 请求
+func ConvertToProtoQuitGroupRequest(dto *QuitGroupRequest) *userpb.QuitGroupRequest {
+	if dto == nil {
+		return nil
+	}
+	return &userpb.QuitGroupRequest{
+		GroupUuid: dto.GroupUUID,
+	}
+}
+
+// ConvertToProtoDismissGroupRequest 将 DTO 转换为 Protobuf 请求
+func ConvertToProtoDismissGroupRequest(dto *DismissGroupRequest) *userpb.DismissGroupRequest {
+	if dto == nil {
+		return nil
+	}
+	return &userpb.DismissGroupRequest{
+		GroupUuid: dto.GroupUUID,
+	}
+}
+
+// ConvertGroupInfoFromProto 将 Protobuf 群资料转换为 DTO
+func ConvertGroupInfoFromProto(pb *userpb.GroupInfo) *GroupInfo {
+	if pb == nil {
+		return nil
+	}
+	return &GroupInfo{
+		UUID:      pb.Uuid,
+		Name:      pb.Name,
+		Notice:    pb.Notice,
+		Avatar:    pb.Avatar,
+		OwnerUUID: pb.OwnerUuid,
+		MemberCnt: pb.MemberCnt,
+		AddMode:   pb.AddMode,
+		Status:    pb.Status,
+		CreatedAt: pb.CreatedAt,
+		MuteAll:   pb.MuteAll,
+	}
+}
+
+// ConvertGroupMemberItemsFromProto 批量转换群成员
+func ConvertGroupMemberItemsFromProto(pbs []*userpb.GroupMemberItem) []*GroupMemberItem {
+	items := make([]*GroupMemberItem, 0, len(pbs))
+	for _, pb := range pbs {
+		if pb == nil {
+			continue
+		}
+		items = append(items, &GroupMemberItem{
+			UserUUID:    pb.UserUuid,
+			Role:        pb.Role,
+			Remark:      pb.Remark,
+			MuteUntil:   pb.MuteUntil,
+			InviterUUID: pb.InviterUuid,
+			JoinedAt:    pb.JoinedAt,
+		})
+	}
+	return items
+}
+
+// ConvertGetGroupMembersResponseFromProto 将 Protobuf 响应转换为 DTO
+func ConvertGetGroupMembersResponseFromProto(pb *userpb.GetGroupMembersResponse) *GetGroupMembersResponse {
+	if pb == nil {
+		return &GetGroupMembersResponse{Items: []*GroupMemberItem{}}
+	}
+	return &GetGroupMembersResponse{
+		Items:      ConvertGroupMemberItemsFromProto(pb.Items),
+		Pagination: ConvertPaginationInfoFromProto(pb.Pagination),
+	}
+}
+
+// ConvertInviteMembersResponseFromProto 将 Protobuf 响应转换为 DTO
+func ConvertInviteMembersResponseFromProto(pb *userpb.InviteMembersResponse) *InviteMembersResponse {
+	if pb == nil {
+		return &InviteMembersResponse{AddedUUIDs: []string{}, SkippedUUIDs: []string{}}
+	}
+	added := pb.AddedUuids
+	if added == nil {
+		added = []string{}
+	}
+	skipped := pb.SkippedUuids
+	if skipped == nil {
+		skipped = []string{}
+	}
+	return &InviteMembersResponse{
+		AddedUUIDs:   added,
+		SkippedUUIDs: skipped,
+		MemberCnt:    pb.MemberCnt,
+	}
+}
+
+// ConvertJoinGroupResponseFromProto 将 Protobuf 响应转换为 DTO
+func ConvertJoinGroupResponseFromProto(pb *userpb.JoinGroupResponse) *JoinGroupResponse {
+	if pb == nil {
+		return &JoinGroupResponse{}
+	}
+	return &JoinGroupResponse{
+		Joined:  pb.Joined,
+		ApplyID: pb.ApplyId,
+	}
+}
+
+// ConvertMuteMemberResponseFromProto 将 Protobuf 响应转换为 DTO
+func ConvertMuteMemberResponseFromProto(pb *userpb.MuteMemberResponse) *MuteMemberResponse {
+	if pb == nil {
+		return &MuteMemberResponse{}
+	}
+	return &MuteMemberResponse{
+		MuteUntil: pb.MuteUntil,
+	}
+}
+
+// ConvertGetGroupApplyListResponseFromProto 将 Protobuf 响应转换为 DTO
+func ConvertGetGroupApplyListResponseFromProto(pb *userpb.GetGroupApplyListResponse) *GetGroupApplyListResponse {
+	if pb == nil {
+		return &GetGroupApplyListResponse{Items: []*GroupApplyItem{}}
+	}
+	items := make([]*GroupApplyItem, 0, len(pb.Items))
+	for _, item := range pb.Items {
+		if item == nil {
+			continue
+		}
+		items = append(items, &GroupApplyItem{
+			ApplyID:        item.ApplyId,
+			GroupUUID:      item.GroupUuid,
+			ApplicantInfo:  ConvertSimpleUserInfoFromProto(item.ApplicantInfo),
+			Reason:         item.Reason,
+			Status:         item.Status,
+			HandleUserUUID: item.HandleUserUuid,
+			HandleRemark:   item.HandleRemark,
+			CreatedAt:      item.CreatedAt,
+		})
+	}
+	return &GetGroupApplyListResponse{
+		Items:      items,
+		Pagination: ConvertPaginationInfoFromProto(pb.Pagination),
+	}
+}
+
+// ConvertGroupAnnouncementFromProto 将 Protobuf 群公告转换为 DTO
+func ConvertGroupAnnouncementFromProto(pb *userpb.GroupAnnouncement) *GroupAnnouncement {
+	if pb == nil {
+		return nil
+	}
+	return &GroupAnnouncement{
+		ID:            pb.Id,
+		GroupUUID:     pb.GroupUuid,
+		PublisherUUID: pb.PublisherUuid,
+		Content:       pb.Content,
+		Pinned:        pb.Pinned,
+		RequireAck:    pb.RequireAck,
+		AckCount:      pb.AckCount,
+		Acked:         pb.Acked,
+		CreatedAt:     pb.CreatedAt,
+	}
+}
+
+// ConvertPostGroupAnnouncementResponseFromProto 将 Protobuf 响应转换为 DTO
+func ConvertPostGroupAnnouncementResponseFromProto(pb *userpb.PostGroupAnnouncementResponse) *PostGroupAnnouncementResponse {
+	if pb == nil {
+		return &PostGroupAnnouncementResponse{}
+	}
+	return &PostGroupAnnouncementResponse{
+		Announcement: ConvertGroupAnnouncementFromProto(pb.Announcement),
+	}
+}
+
+// ConvertGetGroupAnnouncementsResponseFromProto 将 Protobuf 响应转换为 DTO
+func ConvertGetGroupAnnouncementsResponseFromProto(pb *userpb.GetGroupAnnouncementsResponse) *GetGroupAnnouncementsResponse {
+	if pb == nil {
+		return &GetGroupAnnouncementsResponse{Items: []*GroupAnnouncement{}}
+	}
+	items := make([]*GroupAnnouncement, 0, len(pb.Items))
+	for _, item := range pb.Items {
+		if item == nil {
+			continue
+		}
+		items = append(items, ConvertGroupAnnouncementFromProto(item))
+	}
+	return &GetGroupAnnouncementsResponse{
+		Items:      items,
+		Pagination: ConvertPaginationInfoFromProto(pb.Pagination),
+	}
+}
+
+// ConvertAckGroupAnnouncementResponseFromProto 将 Protobuf 响应转换为 DTO
+func ConvertAckGroupAnnouncementResponseFromProto(pb *userpb.AckGroupAnnouncementResponse) *AckGroupAnnouncementResponse {
+	if pb == nil {
+		return &AckGroupAnnouncementResponse{}
+	}
+	return &AckGroupAnnouncementResponse{
+		AckCount: pb.AckCount,
+	}
+}
+
+// ConvertGroupInviteFromProto 将 Protobuf 群邀请转换为 DTO
+func ConvertGroupInviteFromProto(pb *userpb.GroupInvite) *GroupInvite {
+	if pb == nil {
+		return nil
+	}
+	return &GroupInvite{
+		ID:          pb.Id,
+		GroupUUID:   pb.GroupUuid,
+		CreatorUUID: pb.CreatorUuid,
+		Token:       pb.Token,
+		QRCode:      pb.Qrcode,
+		MaxUses:     pb.MaxUses,
+		UsedCount:   pb.UsedCount,
+		ExpireAt:    pb.ExpireAt,
+		CreatedAt:   pb.CreatedAt,
+	}
+}
+
+// ConvertCreateGroupInviteResponseFromProto 将 Protobuf 响应转换为 DTO
+func ConvertCreateGroupInviteResponseFromProto(pb *userpb.CreateGroupInviteResponse) *CreateGroupInviteResponse {
+	if pb == nil {
+		return &CreateGroupInviteResponse{}
+	}
+	return &CreateGroupInviteResponse{
+		Invite: ConvertGroupInviteFromProto(pb.Invite),
+	}
+}
+
+// ConvertGetGroupInviteListResponseFromProto 将 Protobuf 响应转换为 DTO
+func ConvertGetGroupInviteListResponseFromProto(pb *userpb.GetGroupInviteListResponse) *GetGroupInviteListResponse {
+	if pb == nil {
+		return &GetGroupInviteListResponse{Items: []*GroupInvite{}}
+	}
+	items := make([]*GroupInvite, 0, len(pb.Items))
+	for _, item := range pb.Items {
+		if item == nil {
+			continue
+		}
+		items = append(items, ConvertGroupInviteFromProto(item))
+	}
+	return &GetGroupInviteListResponse{Items: items}
+}
+
+// ConvertJoinByInviteTokenResponseFromProto 将 Protobuf 响应转换为 DTO
+func ConvertJoinByInviteTokenResponseFromProto(pb *userpb.JoinByInviteTokenResponse) *JoinByInviteTokenResponse {
+	if pb == nil {
+		return &JoinByInviteTokenResponse{}
+	}
+	return &JoinByInviteTokenResponse{
+		GroupUUID: pb.GroupUuid,
+		Joined:    pb.Joined,
+		ApplyID:   pb.ApplyId,
+	}
+}
@@ -0,0 +1,849 @@
+package dto
+
+import (
+	msgpb "ChatServer/apps/msg/pb"
+)
+
+// ==================== 消息服务相关 DTO ====================
+
+// MessageItem 消息 DTO
+type MessageItem struct {
+	MsgID       string             `json:"msgId"`       // 全局消息ID
+	ConvID      string             `json:"convId"`      // 会话ID
+	Seq         int64              `json:"seq"`         // 会话内序号
+	ClientMsgID string             `json:"clientMsgId"` // 客户端幂等ID
+	FromUUID    string             `json:"fromUuid"`    // 发送者UUID
+	MsgType     int32              `json:"msgType"`     // 消息类型
+	Content     string             `json:"content"`     // 消息内容（JSON）
+	Status      int32              `json:"status"`      // 0正常 1撤回 2删除
+	SendTime    int64              `json:"sendTime"`    // 发送时间（毫秒时间戳）
+	AtUUIDs     []string           `json:"atUuids"`     // @的成员UUID
+	AtAll       bool               `json:"atAll"`       // 是否@所有人
+	Reactions   []*ReactionSummary `json:"reactions"`   // 表情回应汇总
+	Version     int32              `json:"version"`     // 编辑版本号（未编辑为0）
+	EditedAt    int64              `json:"editedAt"`    // 最后编辑时间（毫秒时间戳，未编辑为0）
+	ExpireAt    int64              `json:"expireAt"`    // 过期时间（毫秒时间戳，0表示永久保存）
+}
+
+// ReactionSummary 单个表情的回应汇总 DTO
+type ReactionSummary struct {
+	Emoji   string `json:"emoji"`   // 表情
+	Count   int64  `json:"count"`   // 回应人数
+	Reacted bool   `json:"reacted"` // 当前用户是否回应了该表情
+}
+
+// SentMessage 发送成功的消息 DTO
+type SentMessage struct {
+	MsgID      string `json:"msgId"`      // 全局消息ID
+	ConvID     string `json:"convId"`     // 会话ID
+	Seq        int64  `json:"seq"`        // 会话内序号
+	SendTime   int64  `json:"sendTime"`   // 发送时间（毫秒时间戳）
+	Duplicated bool   `json:"duplicated"` // 是否命中幂等（重复请求返回首次结果）
+}
+
+// PullHistoryRequest 拉取历史消息请求 DTO
+type PullHistoryRequest struct {
+	ConvID    string `json:"convId" binding:"required,max=40"`        // 会话ID
+	AnchorSeq int64  `json:"anchorSeq" binding:"min=0"`               // 锚点序号（不包含），向前拉取时 0 表示从最新消息开始
+	Direction int32  `json:"direction" binding:"oneof=0 1"`           // 0向前（更早的消息） 1向后（更新的消息）
+	Limit     int32  `json:"limit" binding:"omitempty,min=1,max=100"` // 每页条数（默认20）
+}
+
+// PullHistoryResponse 拉取历史消息响应 DTO
+type PullHistoryResponse struct {
+	Messages []*MessageItem `json:"messages"` // 消息列表（按 seq 升序）
+	HasMore  bool           `json:"hasMore"`  // 拉取方向上是否还有更多消息
+}
+
+// GetMissingMessagesRequest 按序号补拉消息请求 DTO
+type GetMissingMessagesRequest struct {
+	ConvID string  `json:"convId" binding:"required,max=40"`                 // 会话ID
+	Seqs   []int64 `json:"seqs" binding:"required,min=1,max=100,dive,min=1"` // 缺失的序号列表
+}
+
+// GetMissingMessagesResponse 按序号补拉消息响应 DTO
+type GetMissingMessagesResponse struct {
+	Messages   []*MessageItem `json:"messages"`   // 消息列表（按 seq 升序）
+	AbsentSeqs []int64        `json:"absentSeqs"` // 不存在的序号（无需再补拉）
+}
+
+// RevokeMessageRequest 撤回消息请求 DTO
+type RevokeMessageRequest struct {
+	MsgID string `json:"msgId" binding:"required,max=64"` // 被撤回的消息ID
+}
+
+// RevokeMessageResponse 撤回消息响应 DTO
+type RevokeMessageResponse struct {
+	ConvID     string `json:"convId"`     // 会话ID
+	NotifySeq  int64  `json:"notifySeq"`  // 撤回通知的会话内序号
+	RevokeTime int64  `json:"revokeTime"` // 撤回时间（毫秒时间戳）
+}
+
+// MarkReadRequest 上报已读请求 DTO
+type MarkReadRequest struct {
+	ConvID  string `json:"convId" binding:"required,max=40"` // 会话ID
+	ReadSeq int64  `json:"readSeq" binding:"required,min=1"` // 已读到的会话内序号（包含）
+}
+
+// MarkReadResponse 上报已读响应 DTO
+type MarkReadResponse struct {
+	ReadSeq int64 `json:"readSeq"` // 生效后的已读序号
+}
+
+// GetMessageReadCountRequest 查询消息已读人数请求 DTO
+type GetMessageReadCountRequest struct {
+	MsgID string `json:"msgId" binding:"required,max=64"` // 消息ID
+}
+
+// GetMessageReadCountResponse 查询消息已读人数响应 DTO
+type GetMessageReadCountResponse struct {
+	ReadCount  int64 `json:"readCount"`  // 已读人数
+	TotalCount int64 `json:"totalCount"` // 应读人数（不含发送者）
+}
+
+// ForwardTarget 转发目标会话 DTO
+type ForwardTarget struct {
+	ConvType   int32  `json:"convType" binding:"oneof=0 1"`         // 会话类型：0单聊 1群聊
+	TargetUUID string `json:"targetUuid" binding:"required,max=20"` // 单聊为对端UUID，群聊为群UUID
+}
+
+// ForwardMessagesRequest 转发消息请求 DTO
+type ForwardMessagesRequest struct {
+	ConvID      string           `json:"convId" binding:"required,max=40"`                      // 来源会话ID
+	MsgIDs      []string         `json:"msgIds" binding:"required,min=1,max=100,dive,required"` // 被转发的消息ID
+	Mode        int32            `json:"mode" binding:"oneof=0 1"`                              // 0逐条转发 1合并转发
+	Targets     []*ForwardTarget `json:"targets" binding:"required,min=1,max=9,dive"`           // 目标会话
+	ClientMsgID string           `json:"clientMsgId" binding:"required,max=40"`                 // 客户端幂等ID
+	Title       string           `json:"title" binding:"max=64"`                                // 合并转发标题（默认“聊天记录”）
+}
+
+// ForwardResult 单个目标会话的转发结果 DTO
+type ForwardResult struct {
+	ConvType   int32          `json:"convType"`   // 会话类型
+	TargetUUID string         `json:"targetUuid"` // 目标UUID
+	Code       int32          `json:"code"`       // 0成功，否则为业务错误码
+	Messages   []*SentMessage `json:"messages"`   // 转发生成的消息
+}
+
+// ForwardMessagesResponse 转发消息响应 DTO
+type ForwardMessagesResponse struct {
+	Results []*ForwardResult `json:"results"` // 各目标会话的转发结果（与请求顺序一致）
+}
+
+// GetUnreadMentionsRequest 查询未读@消息请求 DTO
+type GetUnreadMentionsRequest struct {
+	ConvID string `json:"convId" binding:"required,max=40"`        // 会话ID（群聊）
+	Limit  int32  `json:"limit" binding:"omitempty,min=1,max=100"` // 最多返回条数（默认20）
+}
+
+// GetUnreadMentionsResponse 查询未读@消息响应 DTO
+type GetUnreadMentionsResponse struct {
+	Messages []*MessageItem `json:"messages"` // 未读的@消息（按 seq 升序）
+}
+
+// EditMessageRequest 编辑消息请求 DTO
+type EditMessageRequest struct {
+	MsgID   string `json:"msgId" binding:"required,max=64"` // 被编辑的消息ID
+	Content string `json:"content" binding:"required"`      // 编辑后的内容（JSON，按原消息类型解析）
+}
+
+// EditMessageResponse 编辑消息响应 DTO
+type EditMessageResponse struct {
+	ConvID    string `json:"convId"`    // 会话ID
+	Version   int32  `json:"version"`   // 编辑后的版本号
+	NotifySeq int64  `json:"notifySeq"` // 编辑通知的会话内序号
+	EditTime  int64  `json:"editTime"`  // 编辑时间（毫秒时间戳）
+}
+
+// PinMessageRequest 置顶消息请求 DTO
+type PinMessageRequest struct {
+	MsgID string `json:"msgId" binding:"required,max=64"` // 被置顶的消息ID
+}
+
+// PinMessageResponse 置顶消息响应 DTO
+type PinMessageResponse struct {
+	ConvID    string `json:"convId"`    // 会话ID
+	Pinned    bool   `json:"pinned"`    // 是否新置顶（false 表示此前已置顶）
+	NotifySeq int64  `json:"notifySeq"` // 置顶通知的会话内序号
+	PinTime   int64  `json:"pinTime"`   // 置顶时间（毫秒时间戳）
+}
+
+// UnpinMessageRequest 取消置顶消息请求 DTO
+type UnpinMessageRequest struct {
+	MsgID string `json:"msgId" binding:"required,max=64"` // 被取消置顶的消息ID
+}
+
+// UnpinMessageResponse 取消置顶消息响应 DTO
+type UnpinMessageResponse struct {
+	ConvID    string `json:"convId"`    // 会话ID
+	Unpinned  bool   `json:"unpinned"`  // 是否取消（false 表示此前未置顶）
+	NotifySeq int64  `json:"notifySeq"` // 取消置顶通知的会话内序号
+}
+
+// ListPinnedMessagesRequest 查询置顶消息请求 DTO
+type ListPinnedMessagesRequest struct {
+	ConvID string `json:"convId" binding:"required,max=40"` // 会话ID
+}
+
+// PinnedMessageItem 置顶消息 DTO
+type PinnedMessageItem struct {
+	Message  *MessageItem `json:"message"`  // 被置顶的消息
+	PinnedBy string       `json:"pinnedBy"` // 置顶操作人UUID
+	PinnedAt int64        `json:"pinnedAt"` // 置顶时间（毫秒时间戳）
+}
+
+// ListPinnedMessagesResponse 查询置顶消息响应 DTO
+type ListPinnedMessagesResponse struct {
+	Items []*PinnedMessageItem `json:"items"` // 置顶消息（最近置顶的在前）
+}
+
+// ScheduleMessageRequest 预约定时消息请求 DTO
+type ScheduleMessageRequest struct {
+	ConvType    int32    `json:"convType" binding:"oneof=0 1"`                     // 会话类型：0单聊 1群聊
+	TargetUUID  string   `json:"targetUuid" binding:"required,max=20"`             // 单聊为对端UUID，群聊为群UUID
+	ClientMsgID string   `json:"clientMsgId" binding:"required,max=64"`            // 客户端幂等ID（到期发送时沿用）
+	MsgType     int32    `json:"msgType" binding:"min=1"`                          // 消息类型
+	Content     string   `json:"content" binding:"required"`                       // 消息内容（JSON）
+	AtUUIDs     []string `json:"atUuids" binding:"omitempty,max=50,dive,required"` // @的成员UUID（仅群聊）
+	AtAll       bool     `json:"atAll"`                                            // @所有人（仅群聊，仅群主/管理员）
+	SendAt      int64    `json:"sendAt" binding:"required,min=1"`                  // 预约发送时间（毫秒时间戳）
+}
+
+// ScheduledMessageItem 定时消息 DTO
+type ScheduledMessageItem struct {
+	ScheduleID  int64    `json:"scheduleId"`  // 定时消息ID
+	ConvType    int32    `json:"convType"`    // 会话类型
+	TargetUUID  string   `json:"targetUuid"`  // 单聊为对端UUID，群聊为群UUID
+	ClientMsgID string   `json:"clientMsgId"` // 客户端幂等ID
+	MsgType     int32    `json:"msgType"`     // 消息类型
+	Content     string   `json:"content"`     // 消息内容（JSON）
+	AtUUIDs     []string `json:"atUuids"`     // @的成员UUID
+	AtAll       bool     `json:"atAll"`       // 是否@所有人
+	SendAt      int64    `json:"sendAt"`      // 预约发送时间（毫秒时间戳）
+	Status      int32    `json:"status"`      // 0待发送 1发送中 2已发送 3已取消 4发送失败
+	FailCode    int32    `json:"failCode"`    // 发送失败的业务错误码
+	MsgID       string   `json:"msgId"`       // 发送成功后的消息ID
+	Seq         int64    `json:"seq"`         // 发送成功后的会话内序号
+	CreateTime  int64    `json:"createTime"`  // 预约时间（毫秒时间戳）
+}
+
+// ScheduleMessageResponse 预约定时消息响应 DTO
+type ScheduleMessageResponse struct {
+	Scheduled  *ScheduledMessageItem `json:"scheduled"`  // 定时消息
+	Duplicated bool                  `json:"duplicated"` // 是否命中幂等（重复请求返回首次结果）
+}
+
+// ListScheduledMessagesRequest 查询定时消息请求 DTO
+type ListScheduledMessagesRequest struct {
+	Page     int32 `json:"page" binding:"omitempty,min=1"`             // 页码（默认1）
+	PageSize int32 `json:"pageSize" binding:"omitempty,min=1,max=100"` // 每页条数（默认20）
+}
+
+// ListScheduledMessagesResponse 查询定时消息响应 DTO
+type ListScheduledMessagesResponse struct {
+	Items []*ScheduledMessageItem `json:"items"` // 待发送与发送失败的定时消息（按预约发送时间升序）
+	Total int64                   `json:"total"` // 总数
+}
+
+// CancelScheduledMessageRequest 取消定时消息请求 DTO
+type CancelScheduledMessageRequest struct {
+	ScheduleID int64 `json:"scheduleId" binding:"required,min=1"` // 定时消息ID
+}
+
+// CancelScheduledMessageResponse 取消定时消息响应 DTO
+type CancelScheduledMessageResponse struct{}
+
+// SetDisappearingTimerRequest 设置会话定时销毁请求 DTO
+type SetDisappearingTimerRequest struct {
+	ConvID string `json:"convId" binding:"required,max=40"` // 会话ID
+	TTL    int32  `json:"ttl" binding:"min=0"`              // 消息存活时间（秒），0表示关闭
+}
+
+// SetDisappearingTimerResponse 设置会话定时销毁响应 DTO
+type SetDisappearingTimerResponse struct {
+	ConvID    string `json:"convId"`    // 会话ID
+	TTL       int32  `json:"ttl"`       // 生效的存活时间（秒）
+	Changed   bool   `json:"changed"`   // 是否发生变更（false 表示与当前设置相同）
+	NotifySeq int64  `json:"notifySeq"` // 设置变更通知的会话内序号
+}
+
+// GetDisappearingTimerRequest 查询会话定时销毁请求 DTO
+type GetDisappearingTimerRequest struct {
+	ConvID string `json:"convId" binding:"required,max=40"` // 会话ID
+}
+
+// GetDisappearingTimerResponse 查询会话定时销毁响应 DTO
+type GetDisappearingTimerResponse struct {
+	TTL          int32  `json:"ttl"`          // 消息存活时间（秒），0表示未开启
+	OperatorUUID string `json:"operatorUuid"` // 最后修改人UUID
+	UpdateTime   int64  `json:"updateTime"`   // 最后修改时间（毫秒时间戳）
+}
+
+// AddReactionRequest 添加表情回应请求 DTO
+type AddReactionRequest struct {
+	MsgID string `json:"msgId" binding:"required,max=64"` // 消息ID
+	Emoji string `json:"emoji" binding:"required,max=32"` // 表情
+}
+
+// AddReactionResponse 添加表情回应响应 DTO
+type AddReactionResponse struct {
+	Added bool  `json:"added"` // 是否新增（false 表示此前已回应过）
+	Count int64 `json:"count"` // 该表情当前的回应人数
+}
+
+// RemoveReactionRequest 取消表情回应请求 DTO
+type RemoveReactionRequest struct {
+	MsgID string `json:"msgId" binding:"required,max=64"` // 消息ID
+	Emoji string `json:"emoji" binding:"required,max=32"` // 表情
+}
+
+// RemoveReactionResponse 取消表情回应响应 DTO
+type RemoveReactionResponse struct {
+	Removed bool  `json:"removed"` // 是否删除（false 表示此前未回应）
+	Count   int64 `json:"count"`   // 该表情当前的回应人数
+}
+
+// GetReactionUsersRequest 查询表情回应用户请求 DTO
+type GetReactionUsersRequest struct {
+	MsgID    string `json:"msgId" binding:"required,max=64"`            // 消息ID
+	Emoji    string `json:"emoji" binding:"required,max=32"`            // 表情
+	Page     int32  `json:"page" binding:"omitempty,min=1"`             // 页码（默认1）
+	PageSize int32  `json:"pageSize" binding:"omitempty,min=1,max=100"` // 每页条数（默认20）
+}
+
+// GetReactionUsersResponse 查询表情回应用户响应 DTO
+type GetReactionUsersResponse struct {
+	UserUUIDs []string `json:"userUuids"` // 回应用户（按回应时间升序）
+	Total     int64    `json:"total"`     // 回应总人数
+}
+
+// ==================== DTO 转换函数 ====================
+
+// ConvertToProtoPullMessagesRequest 将 DTO 转换为 Protobuf 请求
+func ConvertToProtoPullMessagesRequest(dto *PullHistoryRequest) *msgpb.PullMessagesRequest {
+	if dto == nil {
+		return nil
+	}
+	return &msgpb.PullMessagesRequest{
+		ConvId:    dto.ConvID,
+		AnchorSeq: dto.AnchorSeq,
+		Direction: dto.Direction,
+		Limit:     dto.Limit,
+	}
+}
+
+// ConvertToProtoGetMessagesBySeqsRequest 将 DTO 转换为 Protobuf 请求
+func ConvertToProtoGetMessagesBySeqsRequest(dto *GetMissingMessagesRequest) *msgpb.GetMessagesBySeqsRequest {
+	if dto == nil {
+		return nil
+	}
+	return &msgpb.GetMessagesBySeqsRequest{
+		ConvId: dto.ConvID,
+		Seqs:   dto.Seqs,
+	}
+}
+
+// ConvertMessageItemFromProto 将 Protobuf 消息转换为 DTO
+func ConvertMessageItemFromProto(pb *msgpb.MessageItem) *MessageItem {
+	if pb == nil {
+		return nil
+	}
+	return &MessageItem{
+		MsgID:       pb.MsgId,
+		ConvID:      pb.ConvId,
+		Seq:         pb.Seq,
+		ClientMsgID: pb.ClientMsgId,
+		FromUUID:    pb.FromUuid,
+		MsgType:     pb.MsgType,
+		Content:     pb.Content,
+		Status:      pb.Status,
+		SendTime:    pb.SendTime,
+		AtUUIDs:     pb.AtUuids,
+		AtAll:       pb.AtAll,
+		Reactions:   ConvertReactionSummariesFromProto(pb.Reactions),
+		Version:     pb.Version,
+		EditedAt:    pb.EditedAt,
+		ExpireAt:    pb.ExpireAt,
+	}
+}
+
+// ConvertReactionSummariesFromProto 批量转换表情回应汇总
+func ConvertReactionSummariesFromProto(pbs []*msgpb.ReactionSummary) []*ReactionSummary {
+	summaries := make([]*ReactionSummary, 0, len(pbs))
+	for _, pb := range pbs {
+		summaries = append(summaries, &ReactionSummary{
+			Emoji:   pb.Emoji,
+			Count:   pb.Count,
+			Reacted: pb.Reacted,
+		})
+	}
+	return summaries
+}
+
+// ConvertMessageItemsFromProto 批量转换消息
+func ConvertMessageItemsFromProto(pbs []*msgpb.MessageItem) []*MessageItem {
+	items := make([]*MessageItem, 0, len(pbs))
+	for _, pb := range pbs {
+		items = append(items, ConvertMessageItemFromProto(pb))
+	}
+	return items
+}
+
+// ConvertPullHistoryResponseFromProto 将 Protobuf 响应转换为 DTO
+func ConvertPullHistoryResponseFromProto(pb *msgpb.PullMessagesResponse) *PullHistoryResponse {
+	if pb == nil {
+		return &PullHistoryResponse{Messages: []*MessageItem{}}
+	}
+	return &PullHistoryResponse{
+		Messages: ConvertMessageItemsFromProto(pb.Messages),
+		HasMore:  pb.HasMore,
+	}
+}
+
+// ConvertGetMissingMessagesResponseFromProto 将 Protobuf 响应转换为 DTO
+func ConvertGetMissingMessagesResponseFromProto(pb *msgpb.GetMessagesBySeqsResponse) *GetMissingMessagesResponse {
+	if pb == nil {
+		return &GetMissingMessagesResponse{Messages: []*MessageItem{}, AbsentSeqs: []int64{}}
+	}
+	absent := pb.AbsentSeqs
+	if absent == nil {
+		absent = []int64{}
+	}
+	return &GetMissingMessagesResponse{
+		Messages:   ConvertMessageItemsFromProto(pb.Messages),
+		AbsentSeqs: absent,
+	}
+}
+
+// ConvertToProtoRevokeMessageRequest 将 DTO 转换为 Protobuf 请求
+func ConvertToProtoRevokeMessageRequest(dto *RevokeMessageRequest) *msgpb.RevokeMessageRequest {
+	if dto == nil {
+		return nil
+	}
+	return &msgpb.RevokeMessageRequest{
+		MsgId: dto.MsgID,
+	}
+}
+
+// ConvertRevokeMessageResponseFromProto 将 Protobuf 响应转换为 DTO
+func ConvertRevokeMessageResponseFromProto(pb *msgpb.RevokeMessageResponse) *RevokeMessageResponse {
+	if pb == nil {
+		return nil
+	}
+	return &RevokeMessageResponse{
+		ConvID:     pb.ConvId,
+		NotifySeq:  pb.NotifySeq,
+		RevokeTime: pb.RevokeTime,
+	}
+}
+
+// ConvertToProtoMarkReadRequest 将 DTO 转换为 Protobuf 请求
+func ConvertToProtoMarkReadRequest(dto *MarkReadRequest) *msgpb.MarkReadRequest {
+	if dto == nil {
+		return nil
+	}
+	return &msgpb.MarkReadRequest{
+		ConvId:  dto.ConvID,
+		ReadSeq: dto.ReadSeq,
+	}
+}
+
+// ConvertMarkReadResponseFromProto 将 Protobuf 响应转换为 DTO
+func ConvertMarkReadResponseFromProto(pb *msgpb.MarkReadResponse) *MarkReadResponse {
+	if pb == nil {
+		return nil
+	}
+	return &MarkReadResponse{
+		ReadSeq: pb.ReadSeq,
+	}
+}
+
+// ConvertToProtoGetMessageReadCountRequest 将 DTO 转换为 Protobuf 请求
+func ConvertToProtoGetMessageReadCountRequest(dto *GetMessageReadCountRequest) *msgpb.GetMessageReadCountRequest {
+	if dto == nil {
+		return nil
+	}
+	return &msgpb.GetMessageReadCountRequest{
+		MsgId: dto.MsgID,
+	}
+}
+
+// ConvertGetMessageReadCountResponseFromProto 将 Protobuf 响应转换为 DTO
+func ConvertGetMessageReadCountResponseFromProto(pb *msgpb.GetMessageReadCountResponse) *GetMessageReadCountResponse {
+	if pb == nil {
+		return nil
+	}
+	return &GetMessageReadCountResponse{
+		ReadCount:  pb.ReadCount,
+		TotalCount: pb.TotalCount,
+	}
+}
+
+// ConvertToProtoGetUnreadMentionsRequest 将 DTO 转换为 Protobuf 请求
+func ConvertToProtoGetUnreadMentionsRequest(dto *GetUnreadMentionsRequest) *msgpb.GetUnreadMentionsRequest {
+	if dto == nil {
+		return nil
+	}
+	return &msgpb.GetUnreadMentionsRequest{
+		ConvId: dto.ConvID,
+		Limit:  dto.Limit,
+	}
+}
+
+// ConvertGetUnreadMentionsResponseFromProto 将 Protobuf 响应转换为 DTO
+func ConvertGetUnreadMentionsResponseFromProto(pb *msgpb.GetUnreadMentionsResponse) *GetUnreadMentionsResponse {
+	if pb == nil {
+		return &GetUnreadMentionsResponse{Messages: []*MessageItem{}}
+	}
+	return &GetUnreadMentionsResponse{
+		Messages: ConvertMessageItemsFromProto(pb.Messages),
+	}
+}
+
+// ConvertToProtoForwardMessagesRequest 将 DTO 转换为 Protobuf 请求
+func ConvertToProtoForwardMessagesRequest(dto *ForwardMessagesRequest) *msgpb.ForwardMessagesRequest {
+	if dto == nil {
+		return nil
+	}
+	targets := make([]*msgpb.ForwardTarget, 0, len(dto.Targets))
+	for _, target := range dto.Targets {
+		targets = append(targets, &msgpb.ForwardTarget{
+			ConvType:   target.ConvType,
+			TargetUuid: target.TargetUUID,
+		})
+	}
+	return &msgpb.ForwardMessagesRequest{
+		ConvId:      dto.ConvID,
+		MsgIds:      dto.MsgIDs,
+		Mode:        dto.Mode,
+		Targets:     targets,
+		ClientMsgId: dto.ClientMsgID,
+		Title:       dto.Title,
+	}
+}
+
+// ConvertSentMessageFromProto 将 Protobuf 发送结果转换为 DTO
+func ConvertSentMessageFromProto(pb *msgpb.SendMessageResponse) *SentMessage {
+	if pb == nil {
+		return nil
+	}
+	return &SentMessage{
+		MsgID:      pb.MsgId,
+		ConvID:     pb.ConvId,
+		Seq:        pb.Seq,
+		SendTime:   pb.SendTime,
+		Duplicated: pb.Duplicated,
+	}
+}
+
+// ConvertForwardMessagesResponseFromProto 将 Protobuf 响应转换为 DTO
+func ConvertForwardMessagesResponseFromProto(pb *msgpb.ForwardMessagesResponse) *ForwardMessagesResponse {
+	if pb == nil {
+		return &ForwardMessagesResponse{Results: []*ForwardResult{}}
+	}
+	results := make([]*ForwardResult, 0, len(pb.Results))
+	for _, r := range pb.Results {
+		messages := make([]*SentMessage, 0, len(r.Messages))
+		for _, m := range r.Messages {
+			messages = append(messages, ConvertSentMessageFromProto(m))
+		}
+		results = append(results, &ForwardResult{
+			ConvType:   r.ConvType,
+			TargetUUID: r.TargetUuid,
+			Code:       r.Code,
+			Messages:   messages,
+		})
+	}
+	return &ForwardMessagesResponse{Results: results}
+}
+
+// ConvertToProtoAddReactionRequest 将 DTO 转换为 Protobuf 请求
+func ConvertToProtoAddReactionRequest(dto *AddReactionRequest) *msgpb.AddReactionRequest {
+	if dto == nil {
+		return nil
+	}
+	return &msgpb.AddReactionRequest{
+		MsgId: dto.MsgID,
+		Emoji: dto.Emoji,
+	}
+}
+
+// ConvertAddReactionResponseFromProto 将 Protobuf 响应转换为 DTO
+func ConvertAddReactionResponseFromProto(pb *msgpb.AddReactionResponse) *AddReactionResponse {
+	if pb == nil {
+		return &AddReactionResponse{}
+	}
+	return &AddReactionResponse{
+		Added: pb.Added,
+		Count: pb.Count,
+	}
+}
+
+// ConvertToProtoRemoveReactionRequest 将 DTO 转换为 Protobuf 请求
+func ConvertToProtoRemoveReactionRequest(dto *RemoveReactionRequest) *msgpb.RemoveReactionRequest {
+	if dto == nil {
+		return nil
+	}
+	return &msgpb.RemoveReactionRequest{
+		MsgId: dto.MsgID,
+		Emoji: dto.Emoji,
+	}
+}
+
+// ConvertRemoveReactionResponseFromProto 将 Protobuf 响应转换为 DTO
+func ConvertRemoveReactionResponseFromProto(pb *msgpb.RemoveReactionResponse) *RemoveReactionResponse {
+	if pb == nil {
+		return &RemoveReactionResponse{}
+	}
+	return &RemoveReactionResponse{
+		Removed: pb.Removed,
+		Count:   pb.Count,
+	}
+}
+
+// ConvertToProtoGetReactionUsersRequest 将 DTO 转换为 Protobuf 请求
+func ConvertToProtoGetReactionUsersRequest(dto *GetReactionUsersRequest) *msgpb.GetReactionUsersRequest {
+	if dto == nil {
+		return nil
+	}
+	return &msgpb.GetReactionUsersRequest{
+		MsgId:    dto.MsgID,
+		Emoji:    dto.Emoji,
+		Page:     dto.Page,
+		PageSize: dto.PageSize,
+	}
+}
+
+// ConvertGetReactionUsersResponseFromProto 将 Protobuf 响应转换为 DTO
+func ConvertGetReactionUsersResponseFromProto(pb *msgpb.GetReactionUsersResponse) *GetReactionUsersResponse {
+	if pb == nil {
+		return &GetReactionUsersResponse{UserUUIDs: []string{}}
+	}
+	userUUIDs := pb.UserUuids
+	if userUUIDs == nil {
+		userUUIDs = []string{}
+	}
+	return &GetReactionUsersResponse{
+		UserUUIDs: userUUIDs,
+		Total:     pb.Total,
+	}
+}
+
+// ConvertToProtoEditMessageRequest 将 DTO 转换为 Protobuf 请求
+func ConvertToProtoEditMessageRequest(dto *EditMessageRequest) *msgpb.EditMessageRequest {
+	if dto == nil {
+		return nil
+	}
+	return &msgpb.EditMessageRequest{
+		MsgId:   dto.MsgID,
+		Content: dto.Content,
+	}
+}
+
+// ConvertEditMessageResponseFromProto 将 Protobuf 响应转换为 DTO
+func ConvertEditMessageResponseFromProto(pb *msgpb.EditMessageResponse) *EditMessageResponse {
+	if pb == nil {
+		return &EditMessageResponse{}
+	}
+	return &EditMessageResponse{
+		ConvID:    pb.ConvId,
+		Version:   pb.Version,
+		NotifySeq: pb.NotifySeq,
+		EditTime:  pb.EditTime,
+	}
+}
+
+// ConvertToProtoPinMessageRequest 将 DTO 转换为 Protobuf 请求
+func ConvertToProtoPinMessageRequest(dto *PinMessageRequest) *msgpb.PinMessageRequest {
+	if dto == nil {
+		return nil
+	}
+	return &msgpb.PinMessageRequest{MsgId: dto.MsgID}
+}
+
+// ConvertPinMessageResponseFromProto 将 Protobuf 响应转换为 DTO
+func ConvertPinMessageResponseFromProto(pb *msgpb.PinMessageResponse) *PinMessageResponse {
+	if pb == nil {
+		return &PinMessageResponse{}
+	}
+	return &PinMessageResponse{
+		ConvID:    pb.ConvId,
+		Pinned:    pb.Pinned,
+		NotifySeq: pb.NotifySeq,
+		PinTime:   pb.PinTime,
+	}
+}
+
+// ConvertToProtoUnpinMessageRequest 将 DTO 转换为 Protobuf 请求
+func ConvertToProtoUnpinMessageRequest(dto *UnpinMessageRequest) *msgpb.UnpinMessageRequest {
+	if dto == nil {
+		return nil
+	}
+	return &msgpb.UnpinMessageRequest{MsgId: dto.MsgID}
+}
+
+// ConvertUnpinMessageResponseFromProto 将 Protobuf 响应转换为 DTO
+func ConvertUnpinMessageResponseFromProto(pb *msgpb.UnpinMessageResponse) *UnpinMessageResponse {
+	if pb == nil {
+		return &UnpinMessageResponse{}
+	}
+	return &UnpinMessageResponse{
+		ConvID:    pb.ConvId,
+		Unpinned:  pb.Unpinned,
+		NotifySeq: pb.NotifySeq,
+	}
+}
+
+// ConvertToProtoListPinnedMessagesRequest 将 DTO 转换为 Protobuf 请求
+func ConvertToProtoListPinnedMessagesRequest(dto *ListPinnedMessagesRequest) *msgpb.ListPinnedMessagesRequest {
+	if dto == nil {
+		return nil
+	}
+	return &msgpb.ListPinnedMessagesRequest{ConvId: dto.ConvID}
+}
+
+// ConvertListPinnedMessagesResponseFromProto 将 Protobuf 响应转换为 DTO
+func ConvertListPinnedMessagesResponseFromProto(pb *msgpb.ListPinnedMessagesResponse) *ListPinnedMessagesResponse {
+	if pb == nil {
+		return &ListPinnedMessagesResponse{Items: []*PinnedMessageItem{}}
+	}
+	items := make([]*PinnedMessageItem, 0, len(pb.Items))
+	for _, item := range pb.Items {
+		items = append(items, &PinnedMessageItem{
+			Message:  ConvertMessageItemFromProto(item.Message),
+			PinnedBy: item.PinnedBy,
+			PinnedAt: item.PinnedAt,
+		})
+	}
+	return &ListPinnedMessagesResponse{Items: items}
+}
+
+// ConvertToProtoScheduleMessageRequest 将 DTO 转换为 Protobuf 请求
+func ConvertToProtoScheduleMessageRequest(dto *ScheduleMessageRequest) *msgpb.ScheduleMessageRequest {
+	if dto == nil {
+		return nil
+	}
+	return &msgpb.ScheduleMessageRequest{
+		ConvType:    dto.ConvType,
+		TargetUuid:  dto.TargetUUID,
+		ClientMsgId: dto.ClientMsgID,
+		MsgType:     dto.MsgType,
+		Content:     dto.Content,
+		AtUuids:     dto.AtUUIDs,
+		AtAll:       dto.AtAll,
+		SendAt:      dto.SendAt,
+	}
+}
+
+// ConvertScheduleMessageResponseFromProto 将 Protobuf 响应转换为 DTO
+func ConvertScheduleMessageResponseFromProto(pb *msgpb.ScheduleMessageResponse) *ScheduleMessageResponse {
+	if pb == nil {
+		return &ScheduleMessageResponse{}
+	}
+	return &ScheduleMessageResponse{
+		Scheduled:  ConvertScheduledMessageItemFromProto(pb.Scheduled),
+		Duplicated: pb.Duplicated,
+	}
+}
+
+// ConvertScheduledMessageItemFromProto 将 Protobuf 定时消息转换为 DTO
+func ConvertScheduledMessageItemFromProto(pb *msgpb.ScheduledMessageItem) *ScheduledMessageItem {
+	if pb == nil {
+		return nil
+	}
+	atUUIDs := pb.AtUuids
+	if atUUIDs == nil {
+		atUUIDs = []string{}
+	}
+	return &ScheduledMessageItem{
+		ScheduleID:  pb.ScheduleId,
+		ConvType:    pb.ConvType,
+		TargetUUID:  pb.TargetUuid,
+		ClientMsgID: pb.ClientMsgId,
+		MsgType:     pb.MsgType,
+		Content:     pb.Content,
+		AtUUIDs:     atUUIDs,
+		AtAll:       pb.AtAll,
+		SendAt:      pb.SendAt,
+		Status:      pb.Status,
+		FailCode:    pb.FailCode,
+		MsgID:       pb.MsgId,
+		Seq:         pb.Seq,
+		CreateTime:  pb.CreateTime,
+	}
+}
+
+// ConvertToProtoListScheduledMessagesRequest 将 DTO 转换为 Protobuf 请求
+func ConvertToProtoListScheduledMessagesRequest(dto *ListScheduledMessagesRequest) *msgpb.ListScheduledMessagesRequest {
+	if dto == nil {
+		return nil
+	}
+	return &msgpb.ListScheduledMessagesRequest{
+		Page:     dto.Page,
+		PageSize: dto.PageSize,
+	}
+}
+
+// ConvertListScheduledMessagesResponseFromProto 将 Protobuf 响应转换为 DTO
+func ConvertListScheduledMessagesResponseFromProto(pb *msgpb.ListScheduledMessagesResponse) *ListScheduledMessagesResponse {
+	if pb == nil {
+		return &ListScheduledMessagesResponse{Items: []*ScheduledMessageItem{}}
+	}
+	items := make([]*ScheduledMessageItem, 0, len(pb.Items))
+	for _, item := range pb.Items {
+		items = append(items, ConvertScheduledMessageItemFromProto(item))
+	}
+	return &ListScheduledMessagesResponse{
+		Items: items,
+		Total: pb.Total,
+	}
+}
+
+// ConvertToProtoCancelScheduledMessageRequest 将 DTO 转换为 Protobuf 请求
+func ConvertToProtoCancelScheduledMessageRequest(dto *CancelScheduledMessageRequest) *msgpb.CancelScheduledMessageRequest {
+	if dto == nil {
+		return nil
+	}
+	return &msgpb.CancelScheduledMessageRequest{ScheduleId: dto.ScheduleID}
+}
+
+// ConvertToProtoSetDisappearingTimerRequest 将 DTO 转换为 Protobuf 请求
+func ConvertToProtoSetDisappearingTimerRequest(dto *SetDisappearingTimerRequest) *msgpb.SetDisappearingTimerRequest {
+	if dto == nil {
+		return nil
+	}
+	return &msgpb.SetDisappearingTimerRequest{ConvId: dto.ConvID, Ttl: dto.TTL}
+}
+
+// ConvertSetDisappearingTimerResponseFromProto 将 Protobuf 响应转换为 DTO
+func ConvertSetDisappearingTimerResponseFromProto(pb *msgpb.SetDisappearingTimerResponse) *SetDisappearingTimerResponse {
+	if pb == nil {
+		return &SetDisappearingTimerResponse{}
+	}
+	return &SetDisappearingTimerResponse{
+		ConvID:    pb.ConvId,
+		TTL:       pb.Ttl,
+		Changed:   pb.Changed,
+		NotifySeq: pb.NotifySeq,
+	}
+}
+
+// ConvertToProtoGetDisappearingTimerRequest 将 DTO 转换为 Protobuf 请求
+func ConvertToProtoGetDisappearingTimerRequest(dto *GetDisappearingTimerRequest) *msgpb.GetDisappearingTimerRequest {
+	if dto == nil {
+		return nil
+	}
+	return &msgpb.GetDisappearingTimerRequest{ConvId: dto.ConvID}
+}
+
+// ConvertGetDisappearingTimerResponseFromProto 将 Protobuf 响应转换为 DTO
+func ConvertGetDisappearingTimerResponseFromProto(pb *msgpb.GetDisappearingTimerResponse) *GetDisappearingTimerResponse {
+	if pb == nil {
+		return &GetDisappearingTimerResponse{}
+	}
+	return &GetDisappearingTimerResponse{
+		TTL:          pb.Ttl,
+		OperatorUUID: pb.OperatorUuid,
+		UpdateTime:   pb.UpdateTime,
+	}
+}
@@ -0,0 +1,24 @@
+package middleware
+
+import (
+	"context"
+
+	"ChatServer/pkg/util"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// GRPCMetadataInterceptor 创建一个 gRPC 客户端一元拦截器，将当前登录用户透传给下游服务
+// 下游服务通过 util.GetUserUUIDFromContext / util.GetDeviceIDFromContext 从 metadata 读取。
+func GRPCMetadataInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if userUUID := util.GetUserUUIDFromContext(ctx); userUUID != "" {
+			ctx = metadata.AppendToOutgoingContext(ctx, "user-uuid", userUUID)
+		}
+		if deviceID := util.GetDeviceIDFromContext(ctx); deviceID != "" {
+			ctx = metadata.AppendToOutgoingContext(ctx, "device-id", deviceID)
+		}
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
@@ -0,0 +1,42 @@
+package middleware
+
+import (
+	"context"
+	"testing"
+
+	"ChatServer/pkg/util"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// TestGRPCMetadataInterceptor 测试 gRPC 客户端拦截器透传用户身份
+func TestGRPCMetadataInterceptor(t *testing.T) {
+	interceptor := GRPCMetadataInterceptor()
+
+	// captureInvoker 记录下游收到的 outgoing metadata
+	var captured metadata.MD
+	captureInvoker := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		captured, _ = metadata.FromOutgoingContext(ctx)
+		return nil
+	}
+
+	t.Run("已登录请求透传 user-uuid 与 device-id", func(t *testing.T) {
+		captured = nil
+		ctx := context.WithValue(context.Background(), util.ContextKeyUserUUID, "u1")
+		ctx = context.WithValue(ctx, util.ContextKeyDeviceID, "d1")
+
+		err := interceptor(ctx, "/msg.MessageService/PullMessages", nil, nil, nil, captureInvoker)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"u1"}, captured.Get("user-uuid"), "应该透传 user-uuid")
+		assert.Equal(t, []string{"d1"}, captured.Get("device-id"), "应该透传 device-id")
+	})
+
+	t.Run("未登录请求不附加 metadata", func(t *testing.T) {
+		captured = nil
+		err := interceptor(context.Background(), "/user.AuthService/Login", nil, nil, nil, captureInvoker)
+		assert.NoError(t, err)
+		assert.Empty(t, captured.Get("user-uuid"), "不应该透传 user-uuid")
+	})
+}
@@ -20,6 +20,7 @@ type userServiceClientImpl struct {
 	friendClient    userpb.FriendServiceClient
 	blacklistClient userpb.BlacklistServiceClient
 	deviceClient    userpb.DeviceServiceClient
+	groupClient     userpb.GroupServiceClient
 	breaker         *gobreaker.CircuitBreaker
 }
 
@@ -29,14 +30,16 @@ type userServiceClientImpl struct {
 // friendConn: 好友服务gRPC连接
 // blacklistConn: 黑名单服务gRPC连接
 // deviceConn: 设备服务gRPC连接
+// groupConn: 群组服务gRPC连接
 // breaker: 熔断器实例
-func NewUserServiceClient(authConn, userConn, friendConn, blacklistConn, deviceConn *grpc.ClientConn, breaker *gobreaker.CircuitBreaker) UserServiceClient {
+func NewUserServiceClient(authConn, userConn, friendConn, blacklistConn, deviceConn, groupConn *grpc.ClientConn, breaker *gobreaker.CircuitBreaker) UserServiceClient {
 	return &userServiceClientImpl{
 		authClient:      userpb.NewAuthServiceClient(authConn),
 		userClient:      userpb.NewUserServiceClient(userConn),
 		friendClient:    userpb.NewFriendServiceClient(friendConn),
 		blacklistClient: userpb.NewBlacklistServiceClient(blacklistConn),
 		deviceClient:    userpb.NewDeviceServiceClient(deviceConn),
+		groupClient:     userpb.NewGroupServiceClient(groupConn),
 		breaker:         breaker,
 	}
 }
@@ -345,6 +348,169 @@ func (c *userServiceClientImpl) BatchGetOnlineStatus(ctx context.Context, req *u
 	})
 }
 
+// ==================== 群组服务方法实现 ====================
+
+// CreateGroup 创建群组
+func (c *userServiceClientImpl) CreateGroup(ctx context.Context, req *userpb.CreateGroupRequest) (*userpb.CreateGroupResponse, error) {
+	return ExecuteWithBreaker(c.breaker, "CreateGroup", func() (*userpb.CreateGroupResponse, error) {
+		return c.groupClient.CreateGroup(ctx, req)
+	})
+}
+
+// GetGroupInfo 获取群资料
+func (c *userServiceClientImpl) GetGroupInfo(ctx context.Context, req *userpb.GetGroupInfoRequest) (*userpb.GetGroupInfoResponse, error) {
+	return ExecuteWithBreaker(c.breaker, "GetGroupInfo", func() (*userpb.GetGroupInfoResponse, error) {
+		return c.groupClient.GetGroupInfo(ctx, req)
+	})
+}
+
+// GetGroupMembers 获取群成员列表
+func (c *userServiceClientImpl) GetGroupMembers(ctx context.Context, req *userpb.GetGroupMembersRequest) (*userpb.GetGroupMembersResponse, error) {
+	return ExecuteWithBreaker(c.breaker, "GetGroupMembers", func() (*userpb.GetGroupMembersResponse, error) {
+		return c.groupClient.GetGroupMembers(ctx, req)
+	})
+}
+
+// InviteMembers 邀请成员入群
+func (c *userServiceClientImpl) InviteMembers(ctx context.Context, req *userpb.InviteMembersRequest) (*userpb.InviteMembersResponse, error) {
+	return ExecuteWithBreaker(c.breaker, "InviteMembers", func() (*userpb.InviteMembersResponse, error) {
+		return c.groupClient.InviteMembers(ctx, req)
+	})
+}
+
+// JoinGroup 申请入群
+func (c *userServiceClientImpl) JoinGroup(ctx context.Context, req *userpb.JoinGroupRequest) (*userpb.JoinGroupResponse, error) {
+	return ExecuteWithBreaker(c.breaker, "JoinGroup", func() (*userpb.JoinGroupResponse, error) {
+		return c.groupClient.JoinGroup(ctx, req)
+	})
+}
+
+// GetGroupApplyList 获取入群申请列表
+func (c *userServiceClientImpl) GetGroupApplyList(ctx context.Context, req *userpb.GetGroupApplyListRequest) (*userpb.GetGroupApplyListResponse, error) {
+	return ExecuteWithBreaker(c.breaker, "GetGroupApplyList", func() (*userpb.GetGroupApplyListResponse, error) {
+		return c.groupClient.GetGroupApplyList(ctx, req)
+	})
+}
+
+// HandleGroupApply 处理入群申请
+func (c *userServiceClientImpl) HandleGroupApply(ctx context.Context, req *userpb.HandleGroupApplyRequest) (*userpb.HandleGroupApplyResponse, error) {
+	return ExecuteWithBreaker(c.breaker, "HandleGroupApply", func() (*userpb.HandleGroupApplyResponse, error) {
+		return c.groupClient.HandleGroupApply(ctx, req)
+	})
+}
+
+// SetAdmin 设置管理员
+func (c *userServiceClientImpl) SetAdmin(ctx context.Context, req *userpb.SetAdminRequest) (*userpb.SetAdminResponse, error) {
+	return ExecuteWithBreaker(c.breaker, "SetAdmin", func() (*userpb.SetAdminResponse, error) {
+		return c.groupClient.SetAdmin(ctx, req)
+	})
+}
+
+// UnsetAdmin 取消管理员
+func (c *userServiceClientImpl) UnsetAdmin(ctx context.Context, req *userpb.UnsetAdminRequest) (*userpb.UnsetAdminResponse, error) {
+	return ExecuteWithBreaker(c.breaker, "UnsetAdmin", func() (*userpb.UnsetAdminResponse, error) {
+		return c.groupClient.UnsetAdmin(ctx, req)
+	})
+}
+
+// KickMember 踢出群成员
+func (c *userServiceClientImpl) KickMember(ctx context.Context, req *userpb.KickMemberRequest) (*userpb.KickMemberResponse, error) {
+	return ExecuteWithBreaker(c.breaker, "KickMember", func() (*userpb.KickMemberResponse, error) {
+		return c.groupClient.KickMember(ctx, req)
+	})
+}
+
+// TransferOwnership 转让群主
+func (c *userServiceClientImpl) TransferOwnership(ctx context.Context, req *userpb.TransferOwnershipRequest) (*userpb.TransferOwnershipResponse, error) {
+	return ExecuteWithBreaker(c.breaker, "TransferOwnership", func() (*userpb.TransferOwnershipResponse, error) {
+		return c.groupClient.TransferOwnership(ctx, req)
+	})
+}
+
+// MuteMember 禁言成员
+func (c *userServiceClientImpl) MuteMember(ctx context.Context, req *userpb.MuteMemberRequest) (*userpb.MuteMemberResponse, error) {
+	return ExecuteWithBreaker(c.breaker, "MuteMember", func() (*userpb.MuteMemberResponse, error) {
+		return c.groupClient.MuteMember(ctx, req)
+	})
+}
+
+// SetGroupMuteAll 设置全员禁言
+func (c *userServiceClientImpl) SetGroupMuteAll(ctx context.Context, req *userpb.SetGroupMuteAllRequest) (*userpb.SetGroupMuteAllResponse, error) {
+	return ExecuteWithBreaker(c.breaker, "SetGroupMuteAll", func() (*userpb.SetGroupMuteAllResponse, error) {
+		return c.groupClient.SetGroupMuteAll(ctx, req)
+	})
+}
+
+// PostGroupAnnouncement 发布群公告
+func (c *userServiceClientImpl) PostGroupAnnouncement(ctx context.Context, req *userpb.PostGroupAnnouncementRequest) (*userpb.PostGroupAnnouncementResponse, error) {
+	return ExecuteWithBreaker(c.breaker, "PostGroupAnnouncement", func() (*userpb.PostGroupAnnouncementResponse, error) {
+		return c.groupClient.PostGroupAnnouncement(ctx, req)
+	})
+}
+
+// GetGroupAnnouncements 获取群公告历史
+func (c *userServiceClientImpl) GetGroupAnnouncements(ctx context.Context, req *userpb.GetGroupAnnouncementsRequest) (*userpb.GetGroupAnnouncementsResponse, error) {
+	return ExecuteWithBreaker(c.breaker, "GetGroupAnnouncements", func() (*userpb.GetGroupAnnouncementsResponse, error) {
+		return c.groupClient.GetGroupAnnouncements(ctx, req)
+	})
+}
+
+// PinGroupAnnouncement 置顶群公告
+func (c *userServiceClientImpl) PinGroupAnnouncement(ctx context.Context, req *userpb.PinGroupAnnouncementRequest) (*userpb.PinGroupAnnouncementResponse, error) {
+	return ExecuteWithBreaker(c.breaker, "PinGroupAnnouncement", func() (*userpb.PinGroupAnnouncementResponse, error) {
+		return c.groupClient.PinGroupAnnouncement(ctx, req)
+	})
+}
+
+// AckGroupAnnouncement 确认群公告
+func (c *userServiceClientImpl) AckGroupAnnouncement(ctx context.Context, req *userpb.AckGroupAnnouncementRequest) (*userpb.AckGroupAnnouncementResponse, error) {
+	return ExecuteWithBreaker(c.breaker, "AckGroupAnnouncement", func() (*userpb.AckGroupAnnouncementResponse, error) {
+		return c.groupClient.AckGroupAnnouncement(ctx, req)
+	})
+}
+
+// CreateGroupInvite 创建群邀请
+func (c *userServiceClientImpl) CreateGroupInvite(ctx context.Context, req *userpb.CreateGroupInviteRequest) (*userpb.CreateGroupInviteResponse, error) {
+	return ExecuteWithBreaker(c.breaker, "CreateGroupInvite", func() (*userpb.CreateGroupInviteResponse, error) {
+		return c.groupClient.CreateGroupInvite(ctx, req)
+	})
+}
+
+// GetGroupInviteList 获取群邀请列表
+func (c *userServiceClientImpl) GetGroupInviteList(ctx context.Context, req *userpb.GetGroupInviteListRequest) (*userpb.GetGroupInviteListResponse, error) {
+	return ExecuteWithBreaker(c.breaker, "GetGroupInviteList", func() (*userpb.GetGroupInviteListResponse, error) {
+		return c.groupClient.GetGroupInviteList(ctx, req)
+	})
+}
+
+// RevokeGroupInvite 撤销群邀请
+func (c *userServiceClientImpl) RevokeGroupInvite(ctx context.Context, req *userpb.RevokeGroupInviteRequest) (*userpb.RevokeGroupInviteResponse, error) {
+	return ExecuteWithBreaker(c.breaker, "RevokeGroupInvite", func() (*userpb.RevokeGroupInviteResponse, error) {
+		return c.groupClient.RevokeGroupInvite(ctx, req)
+	})
+}
+
+// JoinByInviteToken 通过邀请入群
+func (c *userServiceClientImpl) JoinByInviteToken(ctx context.Context, req *userpb.JoinByInviteTokenRequest) (*userpb.JoinByInviteTokenResponse, error) {
+	return ExecuteWithBreaker(c.breaker, "JoinByInviteToken", func() (*userpb.JoinByInviteTokenResponse, error) {
+		return c.groupClient.JoinByInviteToken(ctx, req)
+	})
+}
+
+// QuitGroup 退出群组
+func (c *userServiceClientImpl) QuitGroup(ctx context.Context, req *userpb.QuitGroupRequest) (*userpb.QuitGroupResponse, error) {
+	return ExecuteWithBreaker(c.breaker, "QuitGroup", func() (*userpb.QuitGroupResponse, error) {
+		return c.groupClient.QuitGroup(ctx, req)
+	})
+}
+
+// DismissGroup 解散群组
+func (c *userServiceClientImpl) DismissGroup(ctx context.Context, req *userpb.DismissGroupRequest) (*userpb.DismissGroupResponse, error) {
+	return ExecuteWithBreaker(c.breaker, "DismissGroup", func() (*userpb.DismissGroupResponse, error) {
+		return c.groupClient.DismissGroup(ctx, req)
+	})
+}
+
 // ==================== 通用工具函数 ====================
 // CreateConnection 通用的 gRPC 连接创建函数
 // addr: 服务地址，格式为 "host:port"
@@ -362,6 +528,7 @@ func CreateConnection(addr string, serviceName string, breaker *gobreaker.Circui
 		// 注入熔断拦截器
 		grpc.WithChainUnaryInterceptor(
 			middleware.GRPCLoggerInterceptor(),// 记录请求日志
+			middleware.GRPCMetadataInterceptor(), // 透传当前用户身份
 			middleware.CircuitBreakerInterceptor(breaker),// 熔断器拦截器
 		),
 	)
@@ -487,3 +654,11 @@ func CreateBlacklistServiceConnection(addr string, breaker *gobreaker.CircuitBre
 func CreateDeviceServiceConnection(addr string, breaker *gobreaker.CircuitBreaker) (*grpc.ClientConn, error) {
 	return CreateConnection(addr, "user.DeviceService", breaker)
 }
+
+// CreateGroupServiceConnection 创建群组服务 gRPC 连接
+// addr: 用户服务地址，格式为 "host:port"
+// breaker: 熔断器实例
+// 返回: gRPC 连接和错误
+func CreateGroupServiceConnection(addr string, breaker *gobreaker.CircuitBreaker) (*grpc.ClientConn, error) {
+	return CreateConnection(addr, "user.GroupService", breaker)
+}
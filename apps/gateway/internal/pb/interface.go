@@ -1,6 +1,7 @@
 package pb
 
 import (
+	msgpb "ChatServer/apps/msg/pb"
 	userpb "ChatServer/apps/user/pb"
 	"context"
 )
@@ -138,4 +139,154 @@ type UserServiceClient interface {
 
 	// BatchGetOnlineStatus 批量获取在线状态
 	BatchGetOnlineStatus(ctx context.Context, req *userpb.BatchGetOnlineStatusRequest) (*userpb.BatchGetOnlineStatusResponse, error)
+
+	// ==================== 群组服务 ====================
+	// CreateGroup 创建群组
+	CreateGroup(ctx context.Context, req *userpb.CreateGroupRequest) (*userpb.CreateGroupResponse, error)
+
+	// GetGroupInfo 获取群资料
+	GetGroupInfo(ctx context.Context, req *userpb.GetGroupInfoRequest) (*userpb.GetGroupInfoResponse, error)
+
+	// GetGroupMembers 获取群成员列表
+	GetGroupMembers(ctx context.Context, req *userpb.GetGroupMembersRequest) (*userpb.GetGroupMembersResponse, error)
+
+	// InviteMembers 邀请成员入群
+	InviteMembers(ctx context.Context, req *userpb.InviteMembersRequest) (*userpb.InviteMembersResponse, error)
+
+	// JoinGroup 申请入群
+	JoinGroup(ctx context.Context, req *userpb.JoinGroupRequest) (*userpb.JoinGroupResponse, error)
+
+	// GetGroupApplyList 获取入群申请列表
+	GetGroupApplyList(ctx context.Context, req *userpb.GetGroupApplyListRequest) (*userpb.GetGroupApplyListResponse, error)
+
+	// HandleGroupApply 处理入群申请
+	HandleGroupApply(ctx context.Context, req *userpb.HandleGroupApplyRequest) (*userpb.HandleGroupApplyResponse, error)
+
+	// SetAdmin 设置管理员
+	SetAdmin(ctx context.Context, req *userpb.SetAdminRequest) (*userpb.SetAdminResponse, error)
+
+	// UnsetAdmin 取消管理员
+	UnsetAdmin(ctx context.Context, req *userpb.UnsetAdminRequest) (*userpb.UnsetAdminResponse, error)
+
+	// KickMember 踢出群成员
+	KickMember(ctx context.Context, req *userpb.KickMemberRequest) (*userpb.KickMemberResponse, error)
+
+	// TransferOwnership 转让群主
+	TransferOwnership(ctx context.Context, req *userpb.TransferOwnershipRequest) (*userpb.TransferOwnershipResponse, error)
+
+	// MuteMember 禁言成员
+	MuteMember(ctx context.Context, req *userpb.MuteMemberRequest) (*userpb.MuteMemberResponse, error)
+
+	// SetGroupMuteAll 设置全员禁言
+	SetGroupMuteAll(ctx context.Context, req *userpb.SetGroupMuteAllRequest) (*userpb.SetGroupMuteAllResponse, error)
+
+	// PostGroupAnnouncement 发布群公告
+	PostGroupAnnouncement(ctx context.Context, req *userpb.PostGroupAnnouncementRequest) (*userpb.PostGroupAnnouncementResponse, error)
+
+	// GetGroupAnnouncements 获取群公告历史
+	GetGroupAnnouncements(ctx context.Context, req *userpb.GetGroupAnnouncementsRequest) (*userpb.GetGroupAnnouncementsResponse, error)
+
+	// PinGroupAnnouncement 置顶群公告
+	PinGroupAnnouncement(ctx context.Context, req *userpb.PinGroupAnnouncementRequest) (*userpb.PinGroupAnnouncementResponse, error)
+
+	// AckGroupAnnouncement 确认群公告
+	AckGroupAnnouncement(ctx context.Context, req *userpb.AckGroupAnnouncementRequest) (*userpb.AckGroupAnnouncementResponse, error)
+
+	// CreateGroupInvite 创建群邀请
+	CreateGroupInvite(ctx context.Context, req *userpb.CreateGroupInviteRequest) (*userpb.CreateGroupInviteResponse, error)
+
+	// GetGroupInviteList 获取群邀请列表
+	GetGroupInviteList(ctx context.Context, req *userpb.GetGroupInviteListRequest) (*userpb.GetGroupInviteListResponse, error)
+
+	// RevokeGroupInvite 撤销群邀请
+	RevokeGroupInvite(ctx context.Context, req *userpb.RevokeGroupInviteRequest) (*userpb.RevokeGroupInviteResponse, error)
+
+	// JoinByInviteToken 通过邀请入群
+	JoinByInviteToken(ctx context.Context, req *userpb.JoinByInviteTokenRequest) (*userpb.JoinByInviteTokenResponse, error)
+
+	// QuitGroup 退出群组
+	QuitGroup(ctx context.Context, req *userpb.QuitGroupRequest) (*userpb.QuitGroupResponse, error)
+
+	// DismissGroup 解散群组
+	DismissGroup(ctx context.Context, req *userpb.DismissGroupRequest) (*userpb.DismissGroupResponse, error)
+}
+
+// MsgServiceClient 消息服务 gRPC 客户端接口
+// 职责：封装对消息服务的 gRPC 调用
+type MsgServiceClient interface {
+	// SendMessage 发送消息
+	SendMessage(ctx context.Context, req *msgpb.SendMessageRequest) (*msgpb.SendMessageResponse, error)
+
+	// PullMessages 拉取历史消息
+	PullMessages(ctx context.Context, req *msgpb.PullMessagesRequest) (*msgpb.PullMessagesResponse, error)
+
+	// GetMessagesBySeqs 按序号拉取消息
+	GetMessagesBySeqs(ctx context.Context, req *msgpb.GetMessagesBySeqsRequest) (*msgpb.GetMessagesBySeqsResponse, error)
+
+	// RevokeMessage 撤回消息
+	RevokeMessage(ctx context.Context, req *msgpb.RevokeMessageRequest) (*msgpb.RevokeMessageResponse, error)
+
+	// MarkRead 上报已读
+	MarkRead(ctx context.Context, req *msgpb.MarkReadRequest) (*msgpb.MarkReadResponse, error)
+
+	// GetMessageReadCount 查询消息已读人数
+	GetMessageReadCount(ctx context.Context, req *msgpb.GetMessageReadCountRequest) (*msgpb.GetMessageReadCountResponse, error)
+
+	// GetUnreadMentions 查询未读@消息
+	GetUnreadMentions(ctx context.Context, req *msgpb.GetUnreadMentionsRequest) (*msgpb.GetUnreadMentionsResponse, error)
+
+	// ForwardMessages 转发消息
+	ForwardMessages(ctx context.Context, req *msgpb.ForwardMessagesRequest) (*msgpb.ForwardMessagesResponse, error)
+
+	// AddReaction 添加表情回应
+	AddReaction(ctx context.Context, req *msgpb.AddReactionRequest) (*msgpb.AddReactionResponse, error)
+
+	// RemoveReaction 取消表情回应
+	RemoveReaction(ctx context.Context, req *msgpb.RemoveReactionRequest) (*msgpb.RemoveReactionResponse, error)
+
+	// GetReactionUsers 查询表情回应用户
+	GetReactionUsers(ctx context.Context, req *msgpb.GetReactionUsersRequest) (*msgpb.GetReactionUsersResponse, error)
+
+	// EditMessage 编辑消息
+	EditMessage(ctx context.Context, req *msgpb.EditMessageRequest) (*msgpb.EditMessageResponse, error)
+
+	// PinMessage 置顶消息
+	PinMessage(ctx context.Context, req *msgpb.PinMessageRequest) (*msgpb.PinMessageResponse, error)
+
+	// UnpinMessage 取消置顶消息
+	UnpinMessage(ctx context.Context, req *msgpb.UnpinMessageRequest) (*msgpb.UnpinMessageResponse, error)
+
+	// ListPinnedMessages 查询置顶消息
+	ListPinnedMessages(ctx context.Context, req *msgpb.ListPinnedMessagesRequest) (*msgpb.ListPinnedMessagesResponse, error)
+
+	// ScheduleMessage 预约定时消息
+	ScheduleMessage(ctx context.Context, req *msgpb.ScheduleMessageRequest) (*msgpb.ScheduleMessageResponse, error)
+
+	// ListScheduledMessages 查询定时消息
+	ListScheduledMessages(ctx context.Context, req *msgpb.ListScheduledMessagesRequest) (*msgpb.ListScheduledMessagesResponse, error)
+
+	// CancelScheduledMessage 取消定时消息
+	CancelScheduledMessage(ctx context.Context, req *msgpb.CancelScheduledMessageRequest) (*msgpb.CancelScheduledMessageResponse, error)
+
+	// SetDisappearingTimer 设置会话定时销毁
+	SetDisappearingTimer(ctx context.Context, req *msgpb.SetDisappearingTimerRequest) (*msgpb.SetDisappearingTimerResponse, error)
+
+	// GetDisappearingTimer 查询会话定时销毁设置
+	GetDisappearingTimer(ctx context.Context, req *msgpb.GetDisappearingTimerRequest) (*msgpb.GetDisappearingTimerResponse, error)
+
+	// ==================== 会话服务 ====================
+	// GetConversationList 获取会话列表
+	GetConversationList(ctx context.Context, req *msgpb.GetConversationListRequest) (*msgpb.GetConversationListResponse, error)
+
+	// SyncConversations 增量同步会话
+	SyncConversations(ctx context.Context, req *msgpb.SyncConversationsRequest) (*msgpb.SyncConversationsResponse, error)
+
+	// SetConversationPin 置顶/取消置顶会话
+	SetConversationPin(ctx context.Context, req *msgpb.SetConversationPinRequest) (*msgpb.SetConversationPinResponse, error)
+
+	// SetConversationMute 开启/关闭会话免打扰
+	SetConversationMute(ctx context.Context, req *msgpb.SetConversationMuteRequest) (*msgpb.SetConversationMuteResponse, error)
+
+	// DeleteConversation 删除会话
+	DeleteConversation(ctx context.Context, req *msgpb.DeleteConversationRequest) (*msgpb.DeleteConversationResponse, error)
 }
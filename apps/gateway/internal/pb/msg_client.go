@@ -0,0 +1,215 @@
+package pb
+
+import (
+	msgpb "ChatServer/apps/msg/pb"
+	"context"
+
+	"github.com/sony/gobreaker"
+	"google.golang.org/grpc"
+)
+
+// msgServiceClientImpl 消息服务 gRPC 客户端实现
+type msgServiceClientImpl struct {
+	messageClient      msgpb.MessageServiceClient
+	conversationClient msgpb.ConversationServiceClient
+	breaker            *gobreaker.CircuitBreaker
+}
+
+// NewMsgServiceClient 创建消息服务 gRPC 客户端实例
+// messageConn: 消息服务gRPC连接
+// conversationConn: 会话服务gRPC连接
+// breaker: 熔断器实例
+func NewMsgServiceClient(messageConn, conversationConn *grpc.ClientConn, breaker *gobreaker.CircuitBreaker) MsgServiceClient {
+	return &msgServiceClientImpl{
+		messageClient:      msgpb.NewMessageServiceClient(messageConn),
+		conversationClient: msgpb.NewConversationServiceClient(conversationConn),
+		breaker:            breaker,
+	}
+}
+
+// ==================== 消息服务方法实现 ====================
+
+// SendMessage 发送消息
+func (c *msgServiceClientImpl) SendMessage(ctx context.Context, req *msgpb.SendMessageRequest) (*msgpb.SendMessageResponse, error) {
+	return ExecuteWithBreaker(c.breaker, "SendMessage", func() (*msgpb.SendMessageResponse, error) {
+		return c.messageClient.SendMessage(ctx, req)
+	})
+}
+
+// PullMessages 拉取历史消息
+func (c *msgServiceClientImpl) PullMessages(ctx context.Context, req *msgpb.PullMessagesRequest) (*msgpb.PullMessagesResponse, error) {
+	return ExecuteWithBreaker(c.breaker, "PullMessages", func() (*msgpb.PullMessagesResponse, error) {
+		return c.messageClient.PullMessages(ctx, req)
+	})
+}
+
+// GetMessagesBySeqs 按序号拉取消息
+func (c *msgServiceClientImpl) GetMessagesBySeqs(ctx context.Context, req *msgpb.GetMessagesBySeqsRequest) (*msgpb.GetMessagesBySeqsResponse, error) {
+	return ExecuteWithBreaker(c.breaker, "GetMessagesBySeqs", func() (*msgpb.GetMessagesBySeqsResponse, error) {
+		return c.messageClient.GetMessagesBySeqs(ctx, req)
+	})
+}
+
+// RevokeMessage 撤回消息
+func (c *msgServiceClientImpl) RevokeMessage(ctx context.Context, req *msgpb.RevokeMessageRequest) (*msgpb.RevokeMessageResponse, error) {
+	return ExecuteWithBreaker(c.breaker, "RevokeMessage", func() (*msgpb.RevokeMessageResponse, error) {
+		return c.messageClient.RevokeMessage(ctx, req)
+	})
+}
+
+// MarkRead 上报已读
+func (c *msgServiceClientImpl) MarkRead(ctx context.Context, req *msgpb.MarkReadRequest) (*msgpb.MarkReadResponse, error) {
+	return ExecuteWithBreaker(c.breaker, "MarkRead", func() (*msgpb.MarkReadResponse, error) {
+		return c.messageClient.MarkRead(ctx, req)
+	})
+}
+
+// GetMessageReadCount 查询消息已读人数
+func (c *msgServiceClientImpl) GetMessageReadCount(ctx context.Context, req *msgpb.GetMessageReadCountRequest) (*msgpb.GetMessageReadCountResponse, error) {
+	return ExecuteWithBreaker(c.breaker, "GetMessageReadCount", func() (*msgpb.GetMessageReadCountResponse, error) {
+		return c.messageClient.GetMessageReadCount(ctx, req)
+	})
+}
+
+// GetUnreadMentions 查询未读@消息
+func (c *msgServiceClientImpl) GetUnreadMentions(ctx context.Context, req *msgpb.GetUnreadMentionsRequest) (*msgpb.GetUnreadMentionsResponse, error) {
+	return ExecuteWithBreaker(c.breaker, "GetUnreadMentions", func() (*msgpb.GetUnreadMentionsResponse, error) {
+		return c.messageClient.GetUnreadMentions(ctx, req)
+	})
+}
+
+// ForwardMessages 转发消息
+func (c *msgServiceClientImpl) ForwardMessages(ctx context.Context, req *msgpb.ForwardMessagesRequest) (*msgpb.ForwardMessagesResponse, error) {
+	return ExecuteWithBreaker(c.breaker, "ForwardMessages", func() (*msgpb.ForwardMessagesResponse, error) {
+		return c.messageClient.ForwardMessages(ctx, req)
+	})
+}
+
+// AddReaction 添加表情回应
+func (c *msgServiceClientImpl) AddReaction(ctx context.Context, req *msgpb.AddReactionRequest) (*msgpb.AddReactionResponse, error) {
+	return ExecuteWithBreaker(c.breaker, "AddReaction", func() (*msgpb.AddReactionResponse, error) {
+		return c.messageClient.AddReaction(ctx, req)
+	})
+}
+
+// RemoveReaction 取消表情回应
+func (c *msgServiceClientImpl) RemoveReaction(ctx context.Context, req *msgpb.RemoveReactionRequest) (*msgpb.RemoveReactionResponse, error) {
+	return ExecuteWithBreaker(c.breaker, "RemoveReaction", func() (*msgpb.RemoveReactionResponse, error) {
+		return c.messageClient.RemoveReaction(ctx, req)
+	})
+}
+
+// GetReactionUsers 查询表情回应用户
+func (c *msgServiceClientImpl) GetReactionUsers(ctx context.Context, req *msgpb.GetReactionUsersRequest) (*msgpb.GetReactionUsersResponse, error) {
+	return ExecuteWithBreaker(c.breaker, "GetReactionUsers", func() (*msgpb.GetReactionUsersResponse, error) {
+		return c.messageClient.GetReactionUsers(ctx, req)
+	})
+}
+
+// EditMessage 编辑消息
+func (c *msgServiceClientImpl) EditMessage(ctx context.Context, req *msgpb.EditMessageRequest) (*msgpb.EditMessageResponse, error) {
+	return ExecuteWithBreaker(c.breaker, "EditMessage", func() (*msgpb.EditMessageResponse, error) {
+		return c.messageClient.EditMessage(ctx, req)
+	})
+}
+
+// PinMessage 置顶消息
+func (c *msgServiceClientImpl) PinMessage(ctx context.Context, req *msgpb.PinMessageRequest) (*msgpb.PinMessageResponse, error) {
+	return ExecuteWithBreaker(c.breaker, "PinMessage", func() (*msgpb.PinMessageResponse, error) {
+		return c.messageClient.PinMessage(ctx, req)
+	})
+}
+
+// UnpinMessage 取消置顶消息
+func (c *msgServiceClientImpl) UnpinMessage(ctx context.Context, req *msgpb.UnpinMessageRequest) (*msgpb.UnpinMessageResponse, error) {
+	return ExecuteWithBreaker(c.breaker, "UnpinMessage", func() (*msgpb.UnpinMessageResponse, error) {
+		return c.messageClient.UnpinMessage(ctx, req)
+	})
+}
+
+// ListPinnedMessages 查询置顶消息
+func (c *msgServiceClientImpl) ListPinnedMessages(ctx context.Context, req *msgpb.ListPinnedMessagesRequest) (*msgpb.ListPinnedMessagesResponse, error) {
+	return ExecuteWithBreaker(c.breaker, "ListPinnedMessages", func() (*msgpb.ListPinnedMessagesResponse, error) {
+		return c.messageClient.ListPinnedMessages(ctx, req)
+	})
+}
+
+// ScheduleMessage 预约定时消息
+func (c *msgServiceClientImpl) ScheduleMessage(ctx context.Context, req *msgpb.ScheduleMessageRequest) (*msgpb.ScheduleMessageResponse, error) {
+	return ExecuteWithBreaker(c.breaker, "ScheduleMessage", func() (*msgpb.ScheduleMessageResponse, error) {
+		return c.messageClient.ScheduleMessage(ctx, req)
+	})
+}
+
+// ListScheduledMessages 查询定时消息
+func (c *msgServiceClientImpl) ListScheduledMessages(ctx context.Context, req *msgpb.ListScheduledMessagesRequest) (*msgpb.ListScheduledMessagesResponse, error) {
+	return ExecuteWithBreaker(c.breaker, "ListScheduledMessages", func() (*msgpb.ListScheduledMessagesResponse, error) {
+		return c.messageClient.ListScheduledMessages(ctx, req)
+	})
+}
+
+// CancelScheduledMessage 取消定时消息
+func (c *msgServiceClientImpl) CancelScheduledMessage(ctx context.Context, req *msgpb.CancelScheduledMessageRequest) (*msgpb.CancelScheduledMessageResponse, error) {
+	return ExecuteWithBreaker(c.breaker, "CancelScheduledMessage", func() (*msgpb.CancelScheduledMessageResponse, error) {
+		return c.messageClient.CancelScheduledMessage(ctx, req)
+	})
+}
+
+// SetDisappearingTimer 设置会话定时销毁
+func (c *msgServiceClientImpl) SetDisappearingTimer(ctx context.Context, req *msgpb.SetDisappearingTimerRequest) (*msgpb.SetDisappearingTimerResponse, error) {
+	return ExecuteWithBreaker(c.breaker, "SetDisappearingTimer", func() (*msgpb.SetDisappearingTimerResponse, error) {
+		return c.messageClient.SetDisappearingTimer(ctx, req)
+	})
+}
+
+// GetDisappearingTimer 查询会话定时销毁设置
+func (c *msgServiceClientImpl) GetDisappearingTimer(ctx context.Context, req *msgpb.GetDisappearingTimerRequest) (*msgpb.GetDisappearingTimerResponse, error) {
+	return ExecuteWithBreaker(c.breaker, "GetDisappearingTimer", func() (*msgpb.GetDisappearingTimerResponse, error) {
+		return c.messageClient.GetDisappearingTimer(ctx, req)
+	})
+}
+
+// ==================== 会话服务方法实现 ====================
+
+// GetConversationList 获取会话列表
+func (c *msgServiceClientImpl) GetConversationList(ctx context.Context, req *msgpb.GetConversationListRequest) (*msgpb.GetConversationListResponse, error) {
+	return ExecuteWithBreaker(c.breaker, "GetConversationList", func() (*msgpb.GetConversationListResponse, error) {
+		return c.conversationClient.GetConversationList(ctx, req)
+	})
+}
+
+// SyncConversations 增量同步会话
+func (c *msgServiceClientImpl) SyncConversations(ctx context.Context, req *msgpb.SyncConversationsRequest) (*msgpb.SyncConversationsResponse, error) {
+	return ExecuteWithBreaker(c.breaker, "SyncConversations", func() (*msgpb.SyncConversationsResponse, error) {
+		return c.conversationClient.SyncConversations(ctx, req)
+	})
+}
+
+// SetConversationPin 置顶/取消置顶会话
+func (c *msgServiceClientImpl) SetConversationPin(ctx context.Context, req *msgpb.SetConversationPinRequest) (*msgpb.SetConversationPinResponse, error) {
+	return ExecuteWithBreaker(c.breaker, "SetConversationPin", func() (*msgpb.SetConversationPinResponse, error) {
+		return c.conversationClient.SetConversationPin(ctx, req)
+	})
+}
+
+// SetConversationMute 开启/关闭会话免打扰
+func (c *msgServiceClientImpl) SetConversationMute(ctx context.Context, req *msgpb.SetConversationMuteRequest) (*msgpb.SetConversationMuteResponse, error) {
+	return ExecuteWithBreaker(c.breaker, "SetConversationMute", func() (*msgpb.SetConversationMuteResponse, error) {
+		return c.conversationClient.SetConversationMute(ctx, req)
+	})
+}
+
+// DeleteConversation 删除会话
+func (c *msgServiceClientImpl) DeleteConversation(ctx context.Context, req *msgpb.DeleteConversationRequest) (*msgpb.DeleteConversationResponse, error) {
+	return ExecuteWithBreaker(c.breaker, "DeleteConversation", func() (*msgpb.DeleteConversationResponse, error) {
+		return c.conversationClient.DeleteConversation(ctx, req)
+	})
+}
+
+// CreateMsgServiceConnection 创建消息服务 gRPC 连接
+// addr: 消息服务地址，格式为 "host:port"
+// breaker: 熔断器实例
+// 返回: gRPC 连接和错误
+func CreateMsgServiceConnection(addr string, breaker *gobreaker.CircuitBreaker) (*grpc.ClientConn, error) {
+	return CreateConnection(addr, "msg.MessageService", breaker)
+}
@@ -10,8 +10,11 @@ import (
 )
 
 // InitRouter 初始化路由
-// loginHandler: 登录处理器（依赖注入）
-func InitRouter(authHandler *v1.AuthHandler) *gin.Engine {
+// authHandler: 认证处理器（依赖注入）
+// messageHandler: 消息处理器（依赖注入）
+// conversationHandler: 会话处理器（依赖注入）
+// groupHandler: 群组处理器（依赖注入）
+func InitRouter(authHandler *v1.AuthHandler, messageHandler *v1.MessageHandler, conversationHandler *v1.ConversationHandler, groupHandler *v1.GroupHandler) *gin.Engine {
 	r := gin.New()
 
 	// 恢复中间件
@@ -68,6 +71,68 @@ func InitRouter(authHandler *v1.AuthHandler) *gin.Engine {
 		// 认证相关接口
 		auth.POST("/logout", authHandler.Logout)
 
+		// 消息相关接口（转发给msg服务）
+		msg := auth.Group("/msg")
+		{
+			msg.POST("/history", messageHandler.PullHistory)
+			msg.POST("/history/missing", messageHandler.GetMissingMessages)
+			msg.POST("/revoke", messageHandler.RevokeMessage)
+			msg.POST("/read", messageHandler.MarkRead)
+			msg.POST("/read/count", messageHandler.GetMessageReadCount)
+			msg.POST("/mention/unread", messageHandler.GetUnreadMentions)
+			msg.POST("/forward", messageHandler.ForwardMessages)
+			msg.POST("/reaction/add", messageHandler.AddReaction)
+			msg.POST("/reaction/remove", messageHandler.RemoveReaction)
+			msg.POST("/reaction/users", messageHandler.GetReactionUsers)
+			msg.POST("/edit", messageHandler.EditMessage)
+			msg.POST("/pin", messageHandler.PinMessage)
+			msg.POST("/unpin", messageHandler.UnpinMessage)
+			msg.POST("/pin/list", messageHandler.ListPinnedMessages)
+			msg.POST("/schedule", messageHandler.ScheduleMessage)
+			msg.POST("/schedule/list", messageHandler.ListScheduledMessages)
+			msg.POST("/schedule/cancel", messageHandler.CancelScheduledMessage)
+			msg.POST("/disappearing/set", messageHandler.SetDisappearingTimer)
+			msg.POST("/disappearing/get", messageHandler.GetDisappearingTimer)
+		}
+
+		// 会话相关接口（转发给msg服务）
+		conversation := auth.Group("/conversation")
+		{
+			conversation.POST("/list", conversationHandler.GetConversationList)
+			conversation.POST("/sync", conversationHandler.SyncConversations)
+			conversation.POST("/pin", conversationHandler.SetConversationPin)
+			conversation.POST("/mute", conversationHandler.SetConversationMute)
+			conversation.POST("/delete", conversationHandler.DeleteConversation)
+		}
+
+		// 群组相关接口（转发给user服务）
+		group := auth.Group("/group")
+		{
+			group.POST("/create", groupHandler.CreateGroup)
+			group.POST("/info", groupHandler.GetGroupInfo)
+			group.POST("/members", groupHandler.GetGroupMembers)
+			group.POST("/invite", groupHandler.InviteMembers)
+			group.POST("/join", groupHandler.JoinGroup)
+			group.POST("/apply/list", groupHandler.GetGroupApplyList)
+			group.POST("/apply/handle", groupHandler.HandleGroupApply)
+			group.POST("/admin/set", groupHandler.SetAdmin)
+			group.POST("/admin/unset", groupHandler.UnsetAdmin)
+			group.POST("/kick", groupHandler.KickMember)
+			group.POST("/transfer", groupHandler.TransferOwnership)
+			group.POST("/mute", groupHandler.MuteMember)
+			group.POST("/mute/all", groupHandler.SetGroupMuteAll)
+			group.POST("/announcement/post", groupHandler.PostGroupAnnouncement)
+			group.POST("/announcement/list", groupHandler.GetGroupAnnouncements)
+			group.POST("/announcement/pin", groupHandler.PinGroupAnnouncement)
+			group.POST("/announcement/ack", groupHandler.AckGroupAnnouncement)
+			group.POST("/invite/create", groupHandler.CreateGroupInvite)
+			group.POST("/invite/list", groupHandler.GetGroupInviteList)
+			group.POST("/invite/revoke", groupHandler.RevokeGroupInvite)
+			group.POST("/invite/join", groupHandler.JoinByInviteToken)
+			group.POST("/quit", groupHandler.QuitGroup)
+			group.POST("/dismiss", groupHandler.DismissGroup)
+		}
+
 		// 用户相关接口（预留，后续添加需要认证的用户接口）
 		_ = api.Group("/user")
 	}
@@ -0,0 +1,236 @@
+package v1
+
+import (
+	"ChatServer/apps/gateway/internal/dto"
+	"ChatServer/apps/gateway/internal/middleware"
+	"ChatServer/apps/gateway/internal/service"
+	"ChatServer/apps/gateway/internal/utils"
+	"ChatServer/consts"
+	"ChatServer/pkg/logger"
+	"ChatServer/pkg/result"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ConversationHandler 会话处理器
+type ConversationHandler struct {
+	conversationService service.ConversationService
+}
+
+// NewConversationHandler 创建会话处理器
+// conversationService: 会话服务
+func NewConversationHandler(conversationService service.ConversationService) *ConversationHandler {
+	return &ConversationHandler{
+		conversationService: conversationService,
+	}
+}
+
+// GetConversationList 获取会话列表接口
+// @Summary 获取会话列表
+// @Description 分页获取会话列表，置顶会话在前，其余按更新时间倒序
+// @Tags 会话接口
+// @Accept json
+// @Produce json
+// @Param request body dto.GetConversationListRequest true "会话列表请求"
+// @Success 200 {object} dto.GetConversationListResponse
+// @Router /api/v1/auth/conversation/list [post]
+func (h *ConversationHandler) GetConversationList(c *gin.Context) {
+	ctx := middleware.NewContextWithGin(c)
+
+	// 1. 绑定请求数据
+	var req dto.GetConversationListRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		// 参数错误由客户端输入导致,属于正常业务流程,不记录日志
+		result.Fail(c, nil, consts.CodeParamError)
+		return
+	}
+
+	// 2. 调用服务层处理业务逻辑（依赖注入）
+	resp, err := h.conversationService.GetConversationList(ctx, &req)
+	if err != nil {
+		// 检查是否为业务错误
+		if consts.IsNonServerError(utils.ExtractErrorCode(err)) {
+			// 业务逻辑失败（如参数校验失败）
+			result.Fail(c, nil, utils.ExtractErrorCode(err))
+			return
+		}
+
+		// 其他内部错误
+		logger.Error(ctx, "获取会话列表服务内部错误",
+			logger.ErrorField("error", err),
+		)
+		result.Fail(c, nil, consts.CodeInternalError)
+		return
+	}
+
+	// 3. 返回成功响应
+	result.Success(c, resp)
+}
+
+// SyncConversations 增量同步会话接口
+// @Summary 增量同步会话
+// @Description 返回版本号之后新增/更新/删除的会话，has_more 为 true 时用返回的 version 继续同步
+// @Tags 会话接口
+// @Accept json
+// @Produce json
+// @Param request body dto.SyncConversationsRequest true "增量同步请求"
+// @Success 200 {object} dto.SyncConversationsResponse
+// @Router /api/v1/auth/conversation/sync [post]
+func (h *ConversationHandler) SyncConversations(c *gin.Context) {
+	ctx := middleware.NewContextWithGin(c)
+
+	// 1. 绑定请求数据
+	var req dto.SyncConversationsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		// 参数错误由客户端输入导致,属于正常业务流程,不记录日志
+		result.Fail(c, nil, consts.CodeParamError)
+		return
+	}
+
+	// 2. 调用服务层处理业务逻辑（依赖注入）
+	resp, err := h.conversationService.SyncConversations(ctx, &req)
+	if err != nil {
+		// 检查是否为业务错误
+		if consts.IsNonServerError(utils.ExtractErrorCode(err)) {
+			// 业务逻辑失败（如参数校验失败）
+			result.Fail(c, nil, utils.ExtractErrorCode(err))
+			return
+		}
+
+		// 其他内部错误
+		logger.Error(ctx, "增量同步会话服务内部错误",
+			logger.ErrorField("error", err),
+		)
+		result.Fail(c, nil, consts.CodeInternalError)
+		return
+	}
+
+	// 3. 返回成功响应
+	result.Success(c, resp)
+}
+
+// SetConversationPin 置顶会话接口
+// @Summary 置顶会话
+// @Description 置顶或取消置顶会话
+// @Tags 会话接口
+// @Accept json
+// @Produce json
+// @Param request body dto.SetConversationPinRequest true "置顶请求"
+// @Success 200 {object} dto.SetConversationPinResponse
+// @Router /api/v1/auth/conversation/pin [post]
+func (h *ConversationHandler) SetConversationPin(c *gin.Context) {
+	ctx := middleware.NewContextWithGin(c)
+
+	// 1. 绑定请求数据
+	var req dto.SetConversationPinRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		// 参数错误由客户端输入导致,属于正常业务流程,不记录日志
+		result.Fail(c, nil, consts.CodeParamError)
+		return
+	}
+
+	// 2. 调用服务层处理业务逻辑（依赖注入）
+	_, err := h.conversationService.SetConversationPin(ctx, &req)
+	if err != nil {
+		// 检查是否为业务错误
+		if consts.IsNonServerError(utils.ExtractErrorCode(err)) {
+			// 业务逻辑失败（如会话不存在）
+			result.Fail(c, nil, utils.ExtractErrorCode(err))
+			return
+		}
+
+		// 其他内部错误
+		logger.Error(ctx, "置顶会话服务内部错误",
+			logger.ErrorField("error", err),
+		)
+		result.Fail(c, nil, consts.CodeInternalError)
+		return
+	}
+
+	// 3. 返回成功响应
+	result.Success(c, nil)
+}
+
+// SetConversationMute 会话免打扰接口
+// @Summary 会话免打扰
+// @Description 开启或关闭会话免打扰（未读数照常累加）
+// @Tags 会话接口
+// @Accept json
+// @Produce json
+// @Param request body dto.SetConversationMuteRequest true "免打扰请求"
+// @Success 200 {object} dto.SetConversationMuteResponse
+// @Router /api/v1/auth/conversation/mute [post]
+func (h *ConversationHandler) SetConversationMute(c *gin.Context) {
+	ctx := middleware.NewContextWithGin(c)
+
+	// 1. 绑定请求数据
+	var req dto.SetConversationMuteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		// 参数错误由客户端输入导致,属于正常业务流程,不记录日志
+		result.Fail(c, nil, consts.CodeParamError)
+		return
+	}
+
+	// 2. 调用服务层处理业务逻辑（依赖注入）
+	_, err := h.conversationService.SetConversationMute(ctx, &req)
+	if err != nil {
+		// 检查是否为业务错误
+		if consts.IsNonServerError(utils.ExtractErrorCode(err)) {
+			// 业务逻辑失败（如会话不存在）
+			result.Fail(c, nil, utils.ExtractErrorCode(err))
+			return
+		}
+
+		// 其他内部错误
+		logger.Error(ctx, "设置免打扰服务内部错误",
+			logger.ErrorField("error", err),
+		)
+		result.Fail(c, nil, consts.CodeInternalError)
+		return
+	}
+
+	// 3. 返回成功响应
+	result.Success(c, nil)
+}
+
+// DeleteConversation 删除会话接口
+// @Summary 删除会话
+// @Description 从会话列表移除会话，不删除消息；收到新消息后会话重新出现
+// @Tags 会话接口
+// @Accept json
+// @Produce json
+// @Param request body dto.DeleteConversationRequest true "删除会话请求"
+// @Success 200 {object} dto.DeleteConversationResponse
+// @Router /api/v1/auth/conversation/delete [post]
+func (h *ConversationHandler) DeleteConversation(c *gin.Context) {
+	ctx := middleware.NewContextWithGin(c)
+
+	// 1. 绑定请求数据
+	var req dto.DeleteConversationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		// 参数错误由客户端输入导致,属于正常业务流程,不记录日志
+		result.Fail(c, nil, consts.CodeParamError)
+		return
+	}
+
+	// 2. 调用服务层处理业务逻辑（依赖注入）
+	_, err := h.conversationService.DeleteConversation(ctx, &req)
+	if err != nil {
+		// 检查是否为业务错误
+		if consts.IsNonServerError(utils.ExtractErrorCode(err)) {
+			// 业务逻辑失败（如会话不存在）
+			result.Fail(c, nil, utils.ExtractErrorCode(err))
+			return
+		}
+
+		// 其他内部错误
+		logger.Error(ctx, "删除会话服务内部错误",
+			logger.ErrorField("error", err),
+		)
+		result.Fail(c, nil, consts.CodeInternalError)
+		return
+	}
+
+	// 3. 返回成功响应
+	result.Success(c, nil)
+}
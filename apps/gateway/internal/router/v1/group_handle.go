@@ -0,0 +1,992 @@
+package v1
+
+import (
+	"ChatServer/apps/gateway/internal/dto"
+	"ChatServer/apps/gateway/internal/middleware"
+	"ChatServer/apps/gateway/internal/service"
+	"ChatServer/apps/gateway/internal/utils"
+	"ChatServer/consts"
+	"ChatServer/pkg/logger"
+	"ChatServer/pkg/result"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GroupHandler 群组处理器
+type GroupHandler struct {
+	groupService service.GroupService
+}
+
+// NewGroupHandler 创建群组处理器
+// groupService: 群组服务
+func NewGroupHandler(groupService service.GroupService) *GroupHandler {
+	return &GroupHandler{
+		groupService: groupService,
+	}
+}
+
+// CreateGroup 创建群组接口
+// @Summary 创建群组
+// @Description 创建群组，创建者为群主，初始成员中不存在的用户会被忽略
+// @Tags 群组接口
+// @Accept json
+// @Produce json
+// @Param request body dto.CreateGroupRequest true "创建群组请求"
+// @Success 200 {object} dto.CreateGroupResponse
+// @Router /api/v1/auth/group/create [post]
+func (h *GroupHandler) CreateGroup(c *gin.Context) {
+	ctx := middleware.NewContextWithGin(c)
+
+	// 1. 绑定请求数据
+	var req dto.CreateGroupRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		// 参数错误由客户端输入导致,属于正常业务流程,不记录日志
+		result.Fail(c, nil, consts.CodeParamError)
+		return
+	}
+
+	// 2. 调用服务层处理业务逻辑（依赖注入）
+	resp, err := h.groupService.CreateGroup(ctx, &req)
+	if err != nil {
+		// 检查是否为业务错误
+		if consts.IsNonServerError(utils.ExtractErrorCode(err)) {
+			// 业务逻辑失败（如群名称过长）
+			result.Fail(c, nil, utils.ExtractErrorCode(err))
+			return
+		}
+
+		// 其他内部错误
+		logger.Error(ctx, "创建群组服务内部错误",
+			logger.ErrorField("error", err),
+		)
+		result.Fail(c, nil, consts.CodeInternalError)
+		return
+	}
+
+	// 3. 返回成功响应
+	result.Success(c, resp)
+}
+
+// GetGroupInfo 获取群资料接口
+// @Summary 获取群资料
+// @Description 获取群资料
+// @Tags 群组接口
+// @Accept json
+// @Produce json
+// @Param request body dto.GetGroupInfoRequest true "群资料请求"
+// @Success 200 {object} dto.GetGroupInfoResponse
+// @Router /api/v1/auth/group/info [post]
+func (h *GroupHandler) GetGroupInfo(c *gin.Context) {
+	ctx := middleware.NewContextWithGin(c)
+
+	// 1. 绑定请求数据
+	var req dto.GetGroupInfoRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		// 参数错误由客户端输入导致,属于正常业务流程,不记录日志
+		result.Fail(c, nil, consts.CodeParamError)
+		return
+	}
+
+	// 2. 调用服务层处理业务逻辑（依赖注入）
+	resp, err := h.groupService.GetGroupInfo(ctx, &req)
+	if err != nil {
+		// 检查是否为业务错误
+		if consts.IsNonServerError(utils.ExtractErrorCode(err)) {
+			// 业务逻辑失败（如群组不存在）
+			result.Fail(c, nil, utils.ExtractErrorCode(err))
+			return
+		}
+
+		// 其他内部错误
+		logger.Error(ctx, "获取群资料服务内部错误",
+			logger.ErrorField("error", err),
+		)
+		result.Fail(c, nil, consts.CodeInternalError)
+		return
+	}
+
+	// 3. 返回成功响应
+	result.Success(c, resp)
+}
+
+// GetGroupMembers 获取群成员列表接口
+// @Summary 获取群成员列表
+// @Description 分页获取群成员列表，仅群成员可查看
+// @Tags 群组接口
+// @Accept json
+// @Produce json
+// @Param request body dto.GetGroupMembersRequest true "群成员列表请求"
+// @Success 200 {object} dto.GetGroupMembersResponse
+// @Router /api/v1/auth/group/members [post]
+func (h *GroupHandler) GetGroupMembers(c *gin.Context) {
+	ctx := middleware.NewContextWithGin(c)
+
+	// 1. 绑定请求数据
+	var req dto.GetGroupMembersRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		// 参数错误由客户端输入导致,属于正常业务流程,不记录日志
+		result.Fail(c, nil, consts.CodeParamError)
+		return
+	}
+
+	// 2. 调用服务层处理业务逻辑（依赖注入）
+	resp, err := h.groupService.GetGroupMembers(ctx, &req)
+	if err != nil {
+		// 检查是否为业务错误
+		if consts.IsNonServerError(utils.ExtractErrorCode(err)) {
+			// 业务逻辑失败（如不是群成员）
+			result.Fail(c, nil, utils.ExtractErrorCode(err))
+			return
+		}
+
+		// 其他内部错误
+		logger.Error(ctx, "获取群成员列表服务内部错误",
+			logger.ErrorField("error", err),
+		)
+		result.Fail(c, nil, consts.CodeInternalError)
+		return
+	}
+
+	// 3. 返回成功响应
+	result.Success(c, resp)
+}
+
+// InviteMembers 邀请成员入群接口
+// @Summary 邀请成员入群
+// @Description 邀请成员入群，已是群成员或不存在的用户会被跳过
+// @Tags 群组接口
+// @Accept json
+// @Produce json
+// @Param request body dto.InviteMembersRequest true "邀请成员请求"
+// @Success 200 {object} dto.InviteMembersResponse
+// @Router /api/v1/auth/group/invite [post]
+func (h *GroupHandler) InviteMembers(c *gin.Context) {
+	ctx := middleware.NewContextWithGin(c)
+
+	// 1. 绑定请求数据
+	var req dto.InviteMembersRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		// 参数错误由客户端输入导致,属于正常业务流程,不记录日志
+		result.Fail(c, nil, consts.CodeParamError)
+		return
+	}
+
+	// 2. 调用服务层处理业务逻辑（依赖注入）
+	resp, err := h.groupService.InviteMembers(ctx, &req)
+	if err != nil {
+		// 检查是否为业务错误
+		if consts.IsNonServerError(utils.ExtractErrorCode(err)) {
+			// 业务逻辑失败（如群成员已满）
+			result.Fail(c, nil, utils.ExtractErrorCode(err))
+			return
+		}
+
+		// 其他内部错误
+		logger.Error(ctx, "邀请成员入群服务内部错误",
+			logger.ErrorField("error", err),
+		)
+		result.Fail(c, nil, consts.CodeInternalError)
+		return
+	}
+
+	// 3. 返回成功响应
+	result.Success(c, resp)
+}
+
+// JoinGroup 申请入群接口
+// @Summary 申请入群
+// @Description 申请入群：直接加入的群立即入群，需审核的群提交入群申请等待群主/管理员审核
+// @Tags 群组接口
+// @Accept json
+// @Produce json
+// @Param request body dto.JoinGroupRequest true "申请入群请求"
+// @Success 200 {object} dto.JoinGroupResponse
+// @Router /api/v1/auth/group/join [post]
+func (h *GroupHandler) JoinGroup(c *gin.Context) {
+	ctx := middleware.NewContextWithGin(c)
+
+	// 1. 绑定请求数据
+	var req dto.JoinGroupRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		// 参数错误由客户端输入导致,属于正常业务流程,不记录日志
+		result.Fail(c, nil, consts.CodeParamError)
+		return
+	}
+
+	// 2. 调用服务层处理业务逻辑（依赖注入）
+	resp, err := h.groupService.JoinGroup(ctx, &req)
+	if err != nil {
+		// 检查是否为业务错误
+		if consts.IsNonServerError(utils.ExtractErrorCode(err)) {
+			// 业务逻辑失败（如已经是群成员）
+			result.Fail(c, nil, utils.ExtractErrorCode(err))
+			return
+		}
+
+		// 其他内部错误
+		logger.Error(ctx, "申请入群服务内部错误",
+			logger.ErrorField("error", err),
+		)
+		result.Fail(c, nil, consts.CodeInternalError)
+		return
+	}
+
+	// 3. 返回成功响应
+	result.Success(c, resp)
+}
+
+// GetGroupApplyList 获取入群申请列表接口
+// @Summary 获取入群申请列表
+// @Description 分页获取入群申请列表，仅群主/管理员可查看
+// @Tags 群组接口
+// @Accept json
+// @Produce json
+// @Param request body dto.GetGroupApplyListRequest true "入群申请列表请求"
+// @Success 200 {object} dto.GetGroupApplyListResponse
+// @Router /api/v1/auth/group/apply/list [post]
+func (h *GroupHandler) GetGroupApplyList(c *gin.Context) {
+	ctx := middleware.NewContextWithGin(c)
+
+	// 1. 绑定请求数据
+	var req dto.GetGroupApplyListRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		// 参数错误由客户端输入导致,属于正常业务流程,不记录日志
+		result.Fail(c, nil, consts.CodeParamError)
+		return
+	}
+
+	// 2. 调用服务层处理业务逻辑（依赖注入）
+	resp, err := h.groupService.GetGroupApplyList(ctx, &req)
+	if err != nil {
+		// 检查是否为业务错误
+		if consts.IsNonServerError(utils.ExtractErrorCode(err)) {
+			// 业务逻辑失败（如没有权限）
+			result.Fail(c, nil, utils.ExtractErrorCode(err))
+			return
+		}
+
+		// 其他内部错误
+		logger.Error(ctx, "获取入群申请列表服务内部错误",
+			logger.ErrorField("error", err),
+		)
+		result.Fail(c, nil, consts.CodeInternalError)
+		return
+	}
+
+	// 3. 返回成功响应
+	result.Success(c, resp)
+}
+
+// HandleGroupApply 处理入群申请接口
+// @Summary 处理入群申请
+// @Description 同意或拒绝入群申请，仅群主/管理员可操作，处理结果实时通知申请人
+// @Tags 群组接口
+// @Accept json
+// @Produce json
+// @Param request body dto.HandleGroupApplyRequest true "处理入群申请请求"
+// @Success 200 {object} dto.HandleGroupApplyResponse
+// @Router /api/v1/auth/group/apply/handle [post]
+func (h *GroupHandler) HandleGroupApply(c *gin.Context) {
+	ctx := middleware.NewContextWithGin(c)
+
+	// 1. 绑定请求数据
+	var req dto.HandleGroupApplyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		// 参数错误由客户端输入导致,属于正常业务流程,不记录日志
+		result.Fail(c, nil, consts.CodeParamError)
+		return
+	}
+
+	// 2. 调用服务层处理业务逻辑（依赖注入）
+	_, err := h.groupService.HandleGroupApply(ctx, &req)
+	if err != nil {
+		// 检查是否为业务错误
+		if consts.IsNonServerError(utils.ExtractErrorCode(err)) {
+			// 业务逻辑失败（如申请已处理）
+			result.Fail(c, nil, utils.ExtractErrorCode(err))
+			return
+		}
+
+		// 其他内部错误
+		logger.Error(ctx, "处理入群申请服务内部错误",
+			logger.ErrorField("error", err),
+		)
+		result.Fail(c, nil, consts.CodeInternalError)
+		return
+	}
+
+	// 3. 返回成功响应
+	result.Success(c, nil)
+}
+
+// SetAdmin 设置管理员接口
+// @Summary 设置管理员
+// @Description 将普通成员设为管理员，仅群主可操作，受管理员人数上限限制
+// @Tags 群组接口
+// @Accept json
+// @Produce json
+// @Param request body dto.SetAdminRequest true "设置管理员请求"
+// @Success 200 {object} dto.SetAdminResponse
+// @Router /api/v1/auth/group/admin/set [post]
+func (h *GroupHandler) SetAdmin(c *gin.Context) {
+	ctx := middleware.NewContextWithGin(c)
+
+	// 1. 绑定请求数据
+	var req dto.SetAdminRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		// 参数错误由客户端输入导致,属于正常业务流程,不记录日志
+		result.Fail(c, nil, consts.CodeParamError)
+		return
+	}
+
+	// 2. 调用服务层处理业务逻辑（依赖注入）
+	_, err := h.groupService.SetAdmin(ctx, &req)
+	if err != nil {
+		// 检查是否为业务错误
+		if consts.IsNonServerError(utils.ExtractErrorCode(err)) {
+			// 业务逻辑失败（如管理员数量已达上限）
+			result.Fail(c, nil, utils.ExtractErrorCode(err))
+			return
+		}
+
+		// 其他内部错误
+		logger.Error(ctx, "设置管理员服务内部错误",
+			logger.ErrorField("error", err),
+		)
+		result.Fail(c, nil, consts.CodeInternalError)
+		return
+	}
+
+	// 3. 返回成功响应
+	result.Success(c, nil)
+}
+
+// UnsetAdmin 取消管理员接口
+// @Summary 取消管理员
+// @Description 将管理员降为普通成员，仅群主可操作
+// @Tags 群组接口
+// @Accept json
+// @Produce json
+// @Param request body dto.UnsetAdminRequest true "取消管理员请求"
+// @Success 200 {object} dto.UnsetAdminResponse
+// @Router /api/v1/auth/group/admin/unset [post]
+func (h *GroupHandler) UnsetAdmin(c *gin.Context) {
+	ctx := middleware.NewContextWithGin(c)
+
+	// 1. 绑定请求数据
+	var req dto.UnsetAdminRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		// 参数错误由客户端输入导致,属于正常业务流程,不记录日志
+		result.Fail(c, nil, consts.CodeParamError)
+		return
+	}
+
+	// 2. 调用服务层处理业务逻辑（依赖注入）
+	_, err := h.groupService.UnsetAdmin(ctx, &req)
+	if err != nil {
+		// 检查是否为业务错误
+		if consts.IsNonServerError(utils.ExtractErrorCode(err)) {
+			// 业务逻辑失败（如没有权限）
+			result.Fail(c, nil, utils.ExtractErrorCode(err))
+			return
+		}
+
+		// 其他内部错误
+		logger.Error(ctx, "取消管理员服务内部错误",
+			logger.ErrorField("error", err),
+		)
+		result.Fail(c, nil, consts.CodeInternalError)
+		return
+	}
+
+	// 3. 返回成功响应
+	result.Success(c, nil)
+}
+
+// KickMember 踢出群成员接口
+// @Summary 踢出群成员
+// @Description 踢出群成员：群主可踢管理员与普通成员，管理员只能踢普通成员
+// @Tags 群组接口
+// @Accept json
+// @Produce json
+// @Param request body dto.KickMemberRequest true "踢出群成员请求"
+// @Success 200 {object} dto.KickMemberResponse
+// @Router /api/v1/auth/group/kick [post]
+func (h *GroupHandler) KickMember(c *gin.Context) {
+	ctx := middleware.NewContextWithGin(c)
+
+	// 1. 绑定请求数据
+	var req dto.KickMemberRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		// 参数错误由客户端输入导致,属于正常业务流程,不记录日志
+		result.Fail(c, nil, consts.CodeParamError)
+		return
+	}
+
+	// 2. 调用服务层处理业务逻辑（依赖注入）
+	_, err := h.groupService.KickMember(ctx, &req)
+	if err != nil {
+		// 检查是否为业务错误
+		if consts.IsNonServerError(utils.ExtractErrorCode(err)) {
+			// 业务逻辑失败（如不能踢出管理员）
+			result.Fail(c, nil, utils.ExtractErrorCode(err))
+			return
+		}
+
+		// 其他内部错误
+		logger.Error(ctx, "踢出群成员服务内部错误",
+			logger.ErrorField("error", err),
+		)
+		result.Fail(c, nil, consts.CodeInternalError)
+		return
+	}
+
+	// 3. 返回成功响应
+	result.Success(c, nil)
+}
+
+// TransferOwnership 转让群主接口
+// @Summary 转让群主
+// @Description 将群主转让给其他群成员，原群主降为普通成员，仅群主可操作
+// @Tags 群组接口
+// @Accept json
+// @Produce json
+// @Param request body dto.TransferOwnershipRequest true "转让群主请求"
+// @Success 200 {object} dto.TransferOwnershipResponse
+// @Router /api/v1/auth/group/transfer [post]
+func (h *GroupHandler) TransferOwnership(c *gin.Context) {
+	ctx := middleware.NewContextWithGin(c)
+
+	// 1. 绑定请求数据
+	var req dto.TransferOwnershipRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		// 参数错误由客户端输入导致,属于正常业务流程,不记录日志
+		result.Fail(c, nil, consts.CodeParamError)
+		return
+	}
+
+	// 2. 调用服务层处理业务逻辑（依赖注入）
+	_, err := h.groupService.TransferOwnership(ctx, &req)
+	if err != nil {
+		// 检查是否为业务错误
+		if consts.IsNonServerError(utils.ExtractErrorCode(err)) {
+			// 业务逻辑失败（如群成员不存在）
+			result.Fail(c, nil, utils.ExtractErrorCode(err))
+			return
+		}
+
+		// 其他内部错误
+		logger.Error(ctx, "转让群主服务内部错误",
+			logger.ErrorField("error", err),
+		)
+		result.Fail(c, nil, consts.CodeInternalError)
+		return
+	}
+
+	// 3. 返回成功响应
+	result.Success(c, nil)
+}
+
+// MuteMember 禁言成员接口
+// @Summary 禁言成员
+// @Description 禁言或解除禁言群成员（duration 为 0 解除）：群主可禁言管理员与普通成员，管理员只能禁言普通成员
+// @Tags 群组接口
+// @Accept json
+// @Produce json
+// @Param request body dto.MuteMemberRequest true "禁言成员请求"
+// @Success 200 {object} dto.MuteMemberResponse
+// @Router /api/v1/auth/group/mute [post]
+func (h *GroupHandler) MuteMember(c *gin.Context) {
+	ctx := middleware.NewContextWithGin(c)
+
+	// 1. 绑定请求数据
+	var req dto.MuteMemberRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		// 参数错误由客户端输入导致,属于正常业务流程,不记录日志
+		result.Fail(c, nil, consts.CodeParamError)
+		return
+	}
+
+	// 2. 调用服务层处理业务逻辑（依赖注入）
+	resp, err := h.groupService.MuteMember(ctx, &req)
+	if err != nil {
+		// 检查是否为业务错误
+		if consts.IsNonServerError(utils.ExtractErrorCode(err)) {
+			// 业务逻辑失败（如没有权限）
+			result.Fail(c, nil, utils.ExtractErrorCode(err))
+			return
+		}
+
+		// 其他内部错误
+		logger.Error(ctx, "禁言成员服务内部错误",
+			logger.ErrorField("error", err),
+		)
+		result.Fail(c, nil, consts.CodeInternalError)
+		return
+	}
+
+	// 3. 返回成功响应
+	result.Success(c, resp)
+}
+
+// SetGroupMuteAll 设置全员禁言接口
+// @Summary 设置全员禁言
+// @Description 开启或关闭全员禁言，开启后仅群主与管理员可发言，群主、管理员可操作
+// @Tags 群组接口
+// @Accept json
+// @Produce json
+// @Param request body dto.SetGroupMuteAllRequest true "全员禁言请求"
+// @Success 200 {object} dto.SetGroupMuteAllResponse
+// @Router /api/v1/auth/group/mute/all [post]
+func (h *GroupHandler) SetGroupMuteAll(c *gin.Context) {
+	ctx := middleware.NewContextWithGin(c)
+
+	// 1. 绑定请求数据
+	var req dto.SetGroupMuteAllRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		// 参数错误由客户端输入导致,属于正常业务流程,不记录日志
+		result.Fail(c, nil, consts.CodeParamError)
+		return
+	}
+
+	// 2. 调用服务层处理业务逻辑（依赖注入）
+	_, err := h.groupService.SetGroupMuteAll(ctx, &req)
+	if err != nil {
+		// 检查是否为业务错误
+		if consts.IsNonServerError(utils.ExtractErrorCode(err)) {
+			// 业务逻辑失败（如没有权限）
+			result.Fail(c, nil, utils.ExtractErrorCode(err))
+			return
+		}
+
+		// 其他内部错误
+		logger.Error(ctx, "设置全员禁言服务内部错误",
+			logger.ErrorField("error", err),
+		)
+		result.Fail(c, nil, consts.CodeInternalError)
+		return
+	}
+
+	// 3. 返回成功响应
+	result.Success(c, nil)
+}
+
+// PostGroupAnnouncement 发布群公告接口
+// @Summary 发布群公告
+// @Description 发布群公告并保留历史，可置顶、可要求成员确认已读，发布后群内收到公告系统消息，群主、管理员可操作
+// @Tags 群组接口
+// @Accept json
+// @Produce json
+// @Param request body dto.PostGroupAnnouncementRequest true "发布群公告请求"
+// @Success 200 {object} dto.PostGroupAnnouncementResponse
+// @Router /api/v1/auth/group/announcement/post [post]
+func (h *GroupHandler) PostGroupAnnouncement(c *gin.Context) {
+	ctx := middleware.NewContextWithGin(c)
+
+	// 1. 绑定请求数据
+	var req dto.PostGroupAnnouncementRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		// 参数错误由客户端输入导致,属于正常业务流程,不记录日志
+		result.Fail(c, nil, consts.CodeParamError)
+		return
+	}
+
+	// 2. 调用服务层处理业务逻辑（依赖注入）
+	resp, err := h.groupService.PostGroupAnnouncement(ctx, &req)
+	if err != nil {
+		// 检查是否为业务错误
+		if consts.IsNonServerError(utils.ExtractErrorCode(err)) {
+			// 业务逻辑失败（如群公告过长）
+			result.Fail(c, nil, utils.ExtractErrorCode(err))
+			return
+		}
+
+		// 其他内部错误
+		logger.Error(ctx, "发布群公告服务内部错误",
+			logger.ErrorField("error", err),
+		)
+		result.Fail(c, nil, consts.CodeInternalError)
+		return
+	}
+
+	// 3. 返回成功响应
+	result.Success(c, resp)
+}
+
+// GetGroupAnnouncements 获取群公告历史接口
+// @Summary 获取群公告历史
+// @Description 分页获取群公告历史（置顶在前），含当前用户确认状态，仅群成员可查看
+// @Tags 群组接口
+// @Accept json
+// @Produce json
+// @Param request body dto.GetGroupAnnouncementsRequest true "获取群公告历史请求"
+// @Success 200 {object} dto.GetGroupAnnouncementsResponse
+// @Router /api/v1/auth/group/announcement/list [post]
+func (h *GroupHandler) GetGroupAnnouncements(c *gin.Context) {
+	ctx := middleware.NewContextWithGin(c)
+
+	// 1. 绑定请求数据
+	var req dto.GetGroupAnnouncementsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		// 参数错误由客户端输入导致,属于正常业务流程,不记录日志
+		result.Fail(c, nil, consts.CodeParamError)
+		return
+	}
+
+	// 2. 调用服务层处理业务逻辑（依赖注入）
+	resp, err := h.groupService.GetGroupAnnouncements(ctx, &req)
+	if err != nil {
+		// 检查是否为业务错误
+		if consts.IsNonServerError(utils.ExtractErrorCode(err)) {
+			// 业务逻辑失败（如不是群成员）
+			result.Fail(c, nil, utils.ExtractErrorCode(err))
+			return
+		}
+
+		// 其他内部错误
+		logger.Error(ctx, "获取群公告历史服务内部错误",
+			logger.ErrorField("error", err),
+		)
+		result.Fail(c, nil, consts.CodeInternalError)
+		return
+	}
+
+	// 3. 返回成功响应
+	result.Success(c, resp)
+}
+
+// PinGroupAnnouncement 置顶群公告接口
+// @Summary 置顶群公告
+// @Description 置顶或取消置顶群公告，群主、管理员可操作
+// @Tags 群组接口
+// @Accept json
+// @Produce json
+// @Param request body dto.PinGroupAnnouncementRequest true "置顶群公告请求"
+// @Success 200 {object} dto.PinGroupAnnouncementResponse
+// @Router /api/v1/auth/group/announcement/pin [post]
+func (h *GroupHandler) PinGroupAnnouncement(c *gin.Context) {
+	ctx := middleware.NewContextWithGin(c)
+
+	// 1. 绑定请求数据
+	var req dto.PinGroupAnnouncementRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		// 参数错误由客户端输入导致,属于正常业务流程,不记录日志
+		result.Fail(c, nil, consts.CodeParamError)
+		return
+	}
+
+	// 2. 调用服务层处理业务逻辑（依赖注入）
+	_, err := h.groupService.PinGroupAnnouncement(ctx, &req)
+	if err != nil {
+		// 检查是否为业务错误
+		if consts.IsNonServerError(utils.ExtractErrorCode(err)) {
+			// 业务逻辑失败（如群公告不存在）
+			result.Fail(c, nil, utils.ExtractErrorCode(err))
+			return
+		}
+
+		// 其他内部错误
+		logger.Error(ctx, "置顶群公告服务内部错误",
+			logger.ErrorField("error", err),
+		)
+		result.Fail(c, nil, consts.CodeInternalError)
+		return
+	}
+
+	// 3. 返回成功响应
+	result.Success(c, nil)
+}
+
+// AckGroupAnnouncement 确认群公告接口
+// @Summary 确认群公告
+// @Description 确认已读需确认的群公告，重复确认不重复计数
+// @Tags 群组接口
+// @Accept json
+// @Produce json
+// @Param request body dto.AckGroupAnnouncementRequest true "确认群公告请求"
+// @Success 200 {object} dto.AckGroupAnnouncementResponse
+// @Router /api/v1/auth/group/announcement/ack [post]
+func (h *GroupHandler) AckGroupAnnouncement(c *gin.Context) {
+	ctx := middleware.NewContextWithGin(c)
+
+	// 1. 绑定请求数据
+	var req dto.AckGroupAnnouncementRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		// 参数错误由客户端输入导致,属于正常业务流程,不记录日志
+		result.Fail(c, nil, consts.CodeParamError)
+		return
+	}
+
+	// 2. 调用服务层处理业务逻辑（依赖注入）
+	resp, err := h.groupService.AckGroupAnnouncement(ctx, &req)
+	if err != nil {
+		// 检查是否为业务错误
+		if consts.IsNonServerError(utils.ExtractErrorCode(err)) {
+			// 业务逻辑失败（如群公告无需确认）
+			result.Fail(c, nil, utils.ExtractErrorCode(err))
+			return
+		}
+
+		// 其他内部错误
+		logger.Error(ctx, "确认群公告服务内部错误",
+			logger.ErrorField("error", err),
+		)
+		result.Fail(c, nil, consts.CodeInternalError)
+		return
+	}
+
+	// 3. 返回成功响应
+	result.Success(c, resp)
+}
+
+// CreateGroupInvite 创建群邀请接口
+// @Summary 创建群邀请
+// @Description 创建群邀请链接/二维码，可设置有效期与最大使用次数，群主、管理员可操作
+// @Tags 群组接口
+// @Accept json
+// @Produce json
+// @Param request body dto.CreateGroupInviteRequest true "创建群邀请请求"
+// @Success 200 {object} dto.CreateGroupInviteResponse
+// @Router /api/v1/auth/group/invite/create [post]
+func (h *GroupHandler) CreateGroupInvite(c *gin.Context) {
+	ctx := middleware.NewContextWithGin(c)
+
+	// 1. 绑定请求数据
+	var req dto.CreateGroupInviteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		// 参数错误由客户端输入导致,属于正常业务流程,不记录日志
+		result.Fail(c, nil, consts.CodeParamError)
+		return
+	}
+
+	// 2. 调用服务层处理业务逻辑（依赖注入）
+	resp, err := h.groupService.CreateGroupInvite(ctx, &req)
+	if err != nil {
+		// 检查是否为业务错误
+		if consts.IsNonServerError(utils.ExtractErrorCode(err)) {
+			// 业务逻辑失败（如没有权限）
+			result.Fail(c, nil, utils.ExtractErrorCode(err))
+			return
+		}
+
+		// 其他内部错误
+		logger.Error(ctx, "创建群邀请服务内部错误",
+			logger.ErrorField("error", err),
+		)
+		result.Fail(c, nil, consts.CodeInternalError)
+		return
+	}
+
+	// 3. 返回成功响应
+	result.Success(c, resp)
+}
+
+// GetGroupInviteList 获取群邀请列表接口
+// @Summary 获取群邀请列表
+// @Description 获取群内未撤销且未过期的邀请，群主、管理员可查看
+// @Tags 群组接口
+// @Accept json
+// @Produce json
+// @Param request body dto.GetGroupInviteListRequest true "获取群邀请列表请求"
+// @Success 200 {object} dto.GetGroupInviteListResponse
+// @Router /api/v1/auth/group/invite/list [post]
+func (h *GroupHandler) GetGroupInviteList(c *gin.Context) {
+	ctx := middleware.NewContextWithGin(c)
+
+	// 1. 绑定请求数据
+	var req dto.GetGroupInviteListRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		// 参数错误由客户端输入导致,属于正常业务流程,不记录日志
+		result.Fail(c, nil, consts.CodeParamError)
+		return
+	}
+
+	// 2. 调用服务层处理业务逻辑（依赖注入）
+	resp, err := h.groupService.GetGroupInviteList(ctx, &req)
+	if err != nil {
+		// 检查是否为业务错误
+		if consts.IsNonServerError(utils.ExtractErrorCode(err)) {
+			// 业务逻辑失败（如没有权限）
+			result.Fail(c, nil, utils.ExtractErrorCode(err))
+			return
+		}
+
+		// 其他内部错误
+		logger.Error(ctx, "获取群邀请列表服务内部错误",
+			logger.ErrorField("error", err),
+		)
+		result.Fail(c, nil, consts.CodeInternalError)
+		return
+	}
+
+	// 3. 返回成功响应
+	result.Success(c, resp)
+}
+
+// RevokeGroupInvite 撤销群邀请接口
+// @Summary 撤销群邀请
+// @Description 撤销群邀请，已分发的邀请链接/二维码立即失效，群主、管理员可操作
+// @Tags 群组接口
+// @Accept json
+// @Produce json
+// @Param request body dto.RevokeGroupInviteRequest true "撤销群邀请请求"
+// @Success 200 {object} dto.RevokeGroupInviteResponse
+// @Router /api/v1/auth/group/invite/revoke [post]
+func (h *GroupHandler) RevokeGroupInvite(c *gin.Context) {
+	ctx := middleware.NewContextWithGin(c)
+
+	// 1. 绑定请求数据
+	var req dto.RevokeGroupInviteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		// 参数错误由客户端输入导致,属于正常业务流程,不记录日志
+		result.Fail(c, nil, consts.CodeParamError)
+		return
+	}
+
+	// 2. 调用服务层处理业务逻辑（依赖注入）
+	_, err := h.groupService.RevokeGroupInvite(ctx, &req)
+	if err != nil {
+		// 检查是否为业务错误
+		if consts.IsNonServerError(utils.ExtractErrorCode(err)) {
+			// 业务逻辑失败（如群邀请无效）
+			result.Fail(c, nil, utils.ExtractErrorCode(err))
+			return
+		}
+
+		// 其他内部错误
+		logger.Error(ctx, "撤销群邀请服务内部错误",
+			logger.ErrorField("error", err),
+		)
+		result.Fail(c, nil, consts.CodeInternalError)
+		return
+	}
+
+	// 3. 返回成功响应
+	result.Success(c, nil)
+}
+
+// JoinByInviteToken 通过邀请入群接口
+// @Summary 通过邀请入群
+// @Description 通过邀请链接/二维码中的令牌入群：直接加入的群立即入群，需审核的群提交入群申请
+// @Tags 群组接口
+// @Accept json
+// @Produce json
+// @Param request body dto.JoinByInviteTokenRequest true "通过邀请入群请求"
+// @Success 200 {object} dto.JoinByInviteTokenResponse
+// @Router /api/v1/auth/group/invite/join [post]
+func (h *GroupHandler) JoinByInviteToken(c *gin.Context) {
+	ctx := middleware.NewContextWithGin(c)
+
+	// 1. 绑定请求数据
+	var req dto.JoinByInviteTokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		// 参数错误由客户端输入导致,属于正常业务流程,不记录日志
+		result.Fail(c, nil, consts.CodeParamError)
+		return
+	}
+
+	// 2. 调用服务层处理业务逻辑（依赖注入）
+	resp, err := h.groupService.JoinByInviteToken(ctx, &req)
+	if err != nil {
+		// 检查是否为业务错误
+		if consts.IsNonServerError(utils.ExtractErrorCode(err)) {
+			// 业务逻辑失败（如群邀请已过期）
+			result.Fail(c, nil, utils.ExtractErrorCode(err))
+			return
+		}
+
+		// 其他内部错误
+		logger.Error(ctx, "通过邀请入群服务内部错误",
+			logger.ErrorField("error", err),
+		)
+		result.Fail(c, nil, consts.CodeInternalError)
+		return
+	}
+
+	// 3. 返回成功响应
+	result.Success(c, resp)
+}
+
+// QuitGroup 退出群组接口
+// @Summary 退出群组
+// @Description 退出群组，群主需先转让群主或解散群组
+// @Tags 群组接口
+// @Accept json
+// @Produce json
+// @Param request body dto.QuitGroupRequest true "退群请求"
+// @Success 200 {object} dto.QuitGroupResponse
+// @Router /api/v1/auth/group/quit [post]
+func (h *GroupHandler) QuitGroup(c *gin.Context) {
+	ctx := middleware.NewContextWithGin(c)
+
+	// 1. 绑定请求数据
+	var req dto.QuitGroupRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		// 参数错误由客户端输入导致,属于正常业务流程,不记录日志
+		result.Fail(c, nil, consts.CodeParamError)
+		return
+	}
+
+	// 2. 调用服务层处理业务逻辑（依赖注入）
+	_, err := h.groupService.QuitGroup(ctx, &req)
+	if err != nil {
+		// 检查是否为业务错误
+		if consts.IsNonServerError(utils.ExtractErrorCode(err)) {
+			// 业务逻辑失败（如群主不能退群）
+			result.Fail(c, nil, utils.ExtractErrorCode(err))
+			return
+		}
+
+		// 其他内部错误
+		logger.Error(ctx, "退出群组服务内部错误",
+			logger.ErrorField("error", err),
+		)
+		result.Fail(c, nil, consts.CodeInternalError)
+		return
+	}
+
+	// 3. 返回成功响应
+	result.Success(c, nil)
+}
+
+// DismissGroup 解散群组接口
+// @Summary 解散群组
+// @Description 解散群组，仅群主可操作
+// @Tags 群组接口
+// @Accept json
+// @Produce json
+// @Param request body dto.DismissGroupRequest true "解散群组请求"
+// @Success 200 {object} dto.DismissGroupResponse
+// @Router /api/v1/auth/group/dismiss [post]
+func (h *GroupHandler) DismissGroup(c *gin.Context) {
+	ctx := middleware.NewContextWithGin(c)
+
+	// 1. 绑定请求数据
+	var req dto.DismissGroupRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		// 参数错误由客户端输入导致,属于正常业务流程,不记录日志
+		result.Fail(c, nil, consts.CodeParamError)
+		return
+	}
+
+	// 2. 调用服务层处理业务逻辑（依赖注入）
+	_, err := h.groupService.DismissGroup(ctx, &req)
+	if err != nil {
+		// 检查是否为业务错误
+		if consts.IsNonServerError(utils.ExtractErrorCode(err)) {
+			// 业务逻辑失败（如无权限）
+			result.Fail(c, nil, utils.ExtractErrorCode(err))
+			return
+		}
+
+		// 其他内部错误
+		logger.Error(ctx, "解散群组服务内部错误",
+			logger.ErrorField("error", err),
+		)
+		result.Fail(c, nil, consts.CodeInternalError)
+		return
+	}
+
+	// 3. 返回成功响应
+	result.Success(c, nil)
+}
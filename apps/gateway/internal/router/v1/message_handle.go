@@ -0,0 +1,823 @@
+package v1
+
+import (
+	"ChatServer/apps/gateway/internal/dto"
+	"ChatServer/apps/gateway/internal/middleware"
+	"ChatServer/apps/gateway/internal/service"
+	"ChatServer/apps/gateway/internal/utils"
+	"ChatServer/consts"
+	"ChatServer/pkg/logger"
+	"ChatServer/pkg/result"
+
+	"github.com/gin-gonic/gin"
+)
+
+// MessageHandler 消息处理器
+type MessageHandler struct {
+	messageService service.MessageService
+}
+
+// NewMessageHandler 创建消息处理器
+// messageService: 消息服务
+func NewMessageHandler(messageService service.MessageService) *MessageHandler {
+	return &MessageHandler{
+		messageService: messageService,
+	}
+}
+
+// PullHistory 拉取历史消息接口
+// @Summary 拉取历史消息
+// @Description 从锚点序号向前（更早）或向后（更新）分页拉取会话消息
+// @Tags 消息接口
+// @Accept json
+// @Produce json
+// @Param request body dto.PullHistoryRequest true "拉取历史消息请求"
+// @Success 200 {object} dto.PullHistoryResponse
+// @Router /api/v1/auth/msg/history [post]
+func (h *MessageHandler) PullHistory(c *gin.Context) {
+	ctx := middleware.NewContextWithGin(c)
+
+	// 1. 绑定请求数据
+	var req dto.PullHistoryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		// 参数错误由客户端输入导致,属于正常业务流程,不记录日志
+		result.Fail(c, nil, consts.CodeParamError)
+		return
+	}
+
+	// 2. 调用服务层处理业务逻辑（依赖注入）
+	resp, err := h.messageService.PullHistory(ctx, &req)
+	if err != nil {
+		// 检查是否为业务错误
+		if consts.IsNonServerError(utils.ExtractErrorCode(err)) {
+			// 业务逻辑失败（如非会话成员）
+			result.Fail(c, nil, utils.ExtractErrorCode(err))
+			return
+		}
+
+		// 其他内部错误
+		logger.Error(ctx, "拉取历史消息服务内部错误",
+			logger.ErrorField("error", err),
+		)
+		result.Fail(c, nil, consts.CodeInternalError)
+		return
+	}
+
+	// 3. 返回成功响应
+	result.Success(c, resp)
+}
+
+// GetMissingMessages 按序号补拉消息接口
+// @Summary 按序号补拉消息
+// @Description 客户端发现序号空洞（如收到 10 后收到 13）时精确补拉 11、12
+// @Tags 消息接口
+// @Accept json
+// @Produce json
+// @Param request body dto.GetMissingMessagesRequest true "补拉请求"
+// @Success 200 {object} dto.GetMissingMessagesResponse
+// @Router /api/v1/auth/msg/history/missing [post]
+func (h *MessageHandler) GetMissingMessages(c *gin.Context) {
+	ctx := middleware.NewContextWithGin(c)
+
+	// 1. 绑定请求数据
+	var req dto.GetMissingMessagesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		// 参数错误由客户端输入导致,属于正常业务流程,不记录日志
+		result.Fail(c, nil, consts.CodeParamError)
+		return
+	}
+
+	// 2. 调用服务层处理业务逻辑（依赖注入）
+	resp, err := h.messageService.GetMissingMessages(ctx, &req)
+	if err != nil {
+		// 检查是否为业务错误
+		if consts.IsNonServerError(utils.ExtractErrorCode(err)) {
+			result.Fail(c, nil, utils.ExtractErrorCode(err))
+			return
+		}
+
+		// 其他内部错误
+		logger.Error(ctx, "补拉消息服务内部错误",
+			logger.ErrorField("error", err),
+		)
+		result.Fail(c, nil, consts.CodeInternalError)
+		return
+	}
+
+	// 3. 返回成功响应
+	result.Success(c, resp)
+}
+
+// RevokeMessage 撤回消息接口
+// @Summary 撤回消息
+// @Description 发送者在时限内撤回自己的消息；群主/管理员可撤回群内任意消息
+// @Tags 消息接口
+// @Accept json
+// @Produce json
+// @Param request body dto.RevokeMessageRequest true "撤回请求"
+// @Success 200 {object} dto.RevokeMessageResponse
+// @Router /api/v1/auth/msg/revoke [post]
+func (h *MessageHandler) RevokeMessage(c *gin.Context) {
+	ctx := middleware.NewContextWithGin(c)
+
+	// 1. 绑定请求数据
+	var req dto.RevokeMessageRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		// 参数错误由客户端输入导致,属于正常业务流程,不记录日志
+		result.Fail(c, nil, consts.CodeParamError)
+		return
+	}
+
+	// 2. 调用服务层处理业务逻辑（依赖注入）
+	resp, err := h.messageService.RevokeMessage(ctx, &req)
+	if err != nil {
+		// 检查是否为业务错误
+		if consts.IsNonServerError(utils.ExtractErrorCode(err)) {
+			// 业务逻辑失败（如超过撤回时限、无权撤回）
+			result.Fail(c, nil, utils.ExtractErrorCode(err))
+			return
+		}
+
+		// 其他内部错误
+		logger.Error(ctx, "撤回消息服务内部错误",
+			logger.ErrorField("error", err),
+		)
+		result.Fail(c, nil, consts.CodeInternalError)
+		return
+	}
+
+	// 3. 返回成功响应
+	result.Success(c, resp)
+}
+
+// MarkRead 上报已读接口
+// @Summary 上报已读
+// @Description 推进当前用户在会话中的已读游标并清零未读数；单聊会向对端推送已读回执
+// @Tags 消息接口
+// @Accept json
+// @Produce json
+// @Param request body dto.MarkReadRequest true "已读上报请求"
+// @Success 200 {object} dto.MarkReadResponse
+// @Router /api/v1/auth/msg/read [post]
+func (h *MessageHandler) MarkRead(c *gin.Context) {
+	ctx := middleware.NewContextWithGin(c)
+
+	// 1. 绑定请求数据
+	var req dto.MarkReadRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		// 参数错误由客户端输入导致,属于正常业务流程,不记录日志
+		result.Fail(c, nil, consts.CodeParamError)
+		return
+	}
+
+	// 2. 调用服务层处理业务逻辑（依赖注入）
+	resp, err := h.messageService.MarkRead(ctx, &req)
+	if err != nil {
+		// 检查是否为业务错误
+		if consts.IsNonServerError(utils.ExtractErrorCode(err)) {
+			// 业务逻辑失败（如无权访问该会话）
+			result.Fail(c, nil, utils.ExtractErrorCode(err))
+			return
+		}
+
+		// 其他内部错误
+		logger.Error(ctx, "上报已读服务内部错误",
+			logger.ErrorField("error", err),
+		)
+		result.Fail(c, nil, consts.CodeInternalError)
+		return
+	}
+
+	// 3. 返回成功响应
+	result.Success(c, resp)
+}
+
+// GetMessageReadCount 查询消息已读人数接口
+// @Summary 查询消息已读人数
+// @Description 查询消息的已读人数与应读人数（均不含发送者），群聊用于展示“X 人已读”
+// @Tags 消息接口
+// @Accept json
+// @Produce json
+// @Param request body dto.GetMessageReadCountRequest true "查询请求"
+// @Success 200 {object} dto.GetMessageReadCountResponse
+// @Router /api/v1/auth/msg/read/count [post]
+func (h *MessageHandler) GetMessageReadCount(c *gin.Context) {
+	ctx := middleware.NewContextWithGin(c)
+
+	// 1. 绑定请求数据
+	var req dto.GetMessageReadCountRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		// 参数错误由客户端输入导致,属于正常业务流程,不记录日志
+		result.Fail(c, nil, consts.CodeParamError)
+		return
+	}
+
+	// 2. 调用服务层处理业务逻辑（依赖注入）
+	resp, err := h.messageService.GetMessageReadCount(ctx, &req)
+	if err != nil {
+		// 检查是否为业务错误
+		if consts.IsNonServerError(utils.ExtractErrorCode(err)) {
+			// 业务逻辑失败（如消息不存在、无权访问该会话）
+			result.Fail(c, nil, utils.ExtractErrorCode(err))
+			return
+		}
+
+		// 其他内部错误
+		logger.Error(ctx, "查询已读人数服务内部错误",
+			logger.ErrorField("error", err),
+		)
+		result.Fail(c, nil, consts.CodeInternalError)
+		return
+	}
+
+	// 3. 返回成功响应
+	result.Success(c, resp)
+}
+
+// GetUnreadMentions 查询未读@消息接口
+// @Summary 查询未读@消息
+// @Description 查询群会话内已读游标之后@我（含@所有人）的消息，已撤回的消息不返回
+// @Tags 消息接口
+// @Accept json
+// @Produce json
+// @Param request body dto.GetUnreadMentionsRequest true "查询请求"
+// @Success 200 {object} dto.GetUnreadMentionsResponse
+// @Router /api/v1/auth/msg/mention/unread [post]
+func (h *MessageHandler) GetUnreadMentions(c *gin.Context) {
+	ctx := middleware.NewContextWithGin(c)
+
+	// 1. 绑定请求数据
+	var req dto.GetUnreadMentionsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		// 参数错误由客户端输入导致,属于正常业务流程,不记录日志
+		result.Fail(c, nil, consts.CodeParamError)
+		return
+	}
+
+	// 2. 调用服务层处理业务逻辑（依赖注入）
+	resp, err := h.messageService.GetUnreadMentions(ctx, &req)
+	if err != nil {
+		// 检查是否为业务错误
+		if consts.IsNonServerError(utils.ExtractErrorCode(err)) {
+			// 业务逻辑失败（如无权访问该会话）
+			result.Fail(c, nil, utils.ExtractErrorCode(err))
+			return
+		}
+
+		// 其他内部错误
+		logger.Error(ctx, "查询未读@消息服务内部错误",
+			logger.ErrorField("error", err),
+		)
+		result.Fail(c, nil, consts.CodeInternalError)
+		return
+	}
+
+	// 3. 返回成功响应
+	result.Success(c, resp)
+}
+
+// ForwardMessages 转发消息接口
+// @Summary 转发消息
+// @Description 将来源会话中的消息逐条或合并为聊天记录转发到一个或多个会话，需可读取来源会话；目标会话的黑名单/好友/群成员校验结果按目标返回
+// @Tags 消息接口
+// @Accept json
+// @Produce json
+// @Param request body dto.ForwardMessagesRequest true "转发请求"
+// @Success 200 {object} dto.ForwardMessagesResponse
+// @Router /api/v1/auth/msg/forward [post]
+func (h *MessageHandler) ForwardMessages(c *gin.Context) {
+	ctx := middleware.NewContextWithGin(c)
+
+	// 1. 绑定请求数据
+	var req dto.ForwardMessagesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		// 参数错误由客户端输入导致,属于正常业务流程,不记录日志
+		result.Fail(c, nil, consts.CodeParamError)
+		return
+	}
+
+	// 2. 调用服务层处理业务逻辑（依赖注入）
+	resp, err := h.messageService.ForwardMessages(ctx, &req)
+	if err != nil {
+		// 检查是否为业务错误
+		if consts.IsNonServerError(utils.ExtractErrorCode(err)) {
+			// 业务逻辑失败（如消息不存在、已撤回、无权访问来源会话）
+			result.Fail(c, nil, utils.ExtractErrorCode(err))
+			return
+		}
+
+		// 其他内部错误
+		logger.Error(ctx, "转发消息服务内部错误",
+			logger.ErrorField("error", err),
+		)
+		result.Fail(c, nil, consts.CodeInternalError)
+		return
+	}
+
+	// 3. 返回成功响应
+	result.Success(c, resp)
+}
+
+// AddReaction 添加表情回应接口
+// @Summary 添加表情回应
+// @Description 对可访问会话内的消息添加表情回应，同一用户对同一消息的同一表情只记一次，变更实时同步给会话参与者
+// @Tags 消息接口
+// @Accept json
+// @Produce json
+// @Param request body dto.AddReactionRequest true "添加表情回应请求"
+// @Success 200 {object} dto.AddReactionResponse
+// @Router /api/v1/auth/msg/reaction/add [post]
+func (h *MessageHandler) AddReaction(c *gin.Context) {
+	ctx := middleware.NewContextWithGin(c)
+
+	// 1. 绑定请求数据
+	var req dto.AddReactionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		// 参数错误由客户端输入导致,属于正常业务流程,不记录日志
+		result.Fail(c, nil, consts.CodeParamError)
+		return
+	}
+
+	// 2. 调用服务层处理业务逻辑（依赖注入）
+	resp, err := h.messageService.AddReaction(ctx, &req)
+	if err != nil {
+		// 检查是否为业务错误
+		if consts.IsNonServerError(utils.ExtractErrorCode(err)) {
+			// 业务逻辑失败（如消息不存在、已撤回、表情种类已达上限）
+			result.Fail(c, nil, utils.ExtractErrorCode(err))
+			return
+		}
+
+		// 其他内部错误
+		logger.Error(ctx, "添加表情回应服务内部错误",
+			logger.ErrorField("error", err),
+		)
+		result.Fail(c, nil, consts.CodeInternalError)
+		return
+	}
+
+	// 3. 返回成功响应
+	result.Success(c, resp)
+}
+
+// RemoveReaction 取消表情回应接口
+// @Summary 取消表情回应
+// @Description 取消自己对消息的表情回应，未回应过时幂等返回
+// @Tags 消息接口
+// @Accept json
+// @Produce json
+// @Param request body dto.RemoveReactionRequest true "取消表情回应请求"
+// @Success 200 {object} dto.RemoveReactionResponse
+// @Router /api/v1/auth/msg/reaction/remove [post]
+func (h *MessageHandler) RemoveReaction(c *gin.Context) {
+	ctx := middleware.NewContextWithGin(c)
+
+	// 1. 绑定请求数据
+	var req dto.RemoveReactionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		// 参数错误由客户端输入导致,属于正常业务流程,不记录日志
+		result.Fail(c, nil, consts.CodeParamError)
+		return
+	}
+
+	// 2. 调用服务层处理业务逻辑（依赖注入）
+	resp, err := h.messageService.RemoveReaction(ctx, &req)
+	if err != nil {
+		// 检查是否为业务错误
+		if consts.IsNonServerError(utils.ExtractErrorCode(err)) {
+			// 业务逻辑失败（如消息不存在、无权访问该会话）
+			result.Fail(c, nil, utils.ExtractErrorCode(err))
+			return
+		}
+
+		// 其他内部错误
+		logger.Error(ctx, "取消表情回应服务内部错误",
+			logger.ErrorField("error", err),
+		)
+		result.Fail(c, nil, consts.CodeInternalError)
+		return
+	}
+
+	// 3. 返回成功响应
+	result.Success(c, resp)
+}
+
+// GetReactionUsers 查询表情回应用户接口
+// @Summary 查询表情回应用户
+// @Description 分页查询对消息回应了某个表情的用户，按回应时间升序
+// @Tags 消息接口
+// @Accept json
+// @Produce json
+// @Param request body dto.GetReactionUsersRequest true "查询表情回应用户请求"
+// @Success 200 {object} dto.GetReactionUsersResponse
+// @Router /api/v1/auth/msg/reaction/users [post]
+func (h *MessageHandler) GetReactionUsers(c *gin.Context) {
+	ctx := middleware.NewContextWithGin(c)
+
+	// 1. 绑定请求数据
+	var req dto.GetReactionUsersRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		// 参数错误由客户端输入导致,属于正常业务流程,不记录日志
+		result.Fail(c, nil, consts.CodeParamError)
+		return
+	}
+
+	// 2. 调用服务层处理业务逻辑（依赖注入）
+	resp, err := h.messageService.GetReactionUsers(ctx, &req)
+	if err != nil {
+		// 检查是否为业务错误
+		if consts.IsNonServerError(utils.ExtractErrorCode(err)) {
+			// 业务逻辑失败（如消息不存在、无权访问该会话）
+			result.Fail(c, nil, utils.ExtractErrorCode(err))
+			return
+		}
+
+		// 其他内部错误
+		logger.Error(ctx, "查询表情回应用户服务内部错误",
+			logger.ErrorField("error", err),
+		)
+		result.Fail(c, nil, consts.CodeInternalError)
+		return
+	}
+
+	// 3. 返回成功响应
+	result.Success(c, resp)
+}
+
+// EditMessage 编辑消息接口
+// @Summary 编辑消息
+// @Description 发送者在编辑时限内修改自己发送的文本消息，会话参与者收到编辑通知并按版本号应用最新内容
+// @Tags 消息接口
+// @Accept json
+// @Produce json
+// @Param request body dto.EditMessageRequest true "编辑消息请求"
+// @Success 200 {object} dto.EditMessageResponse
+// @Router /api/v1/auth/msg/edit [post]
+func (h *MessageHandler) EditMessage(c *gin.Context) {
+	ctx := middleware.NewContextWithGin(c)
+
+	// 1. 绑定请求数据
+	var req dto.EditMessageRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		// 参数错误由客户端输入导致,属于正常业务流程,不记录日志
+		result.Fail(c, nil, consts.CodeParamError)
+		return
+	}
+
+	// 2. 调用服务层处理业务逻辑（依赖注入）
+	resp, err := h.messageService.EditMessage(ctx, &req)
+	if err != nil {
+		// 检查是否为业务错误
+		if consts.IsNonServerError(utils.ExtractErrorCode(err)) {
+			// 业务逻辑失败（如消息不存在、非本人消息、超过编辑时限、并发编辑冲突）
+			result.Fail(c, nil, utils.ExtractErrorCode(err))
+			return
+		}
+
+		// 其他内部错误
+		logger.Error(ctx, "编辑消息服务内部错误",
+			logger.ErrorField("error", err),
+		)
+		result.Fail(c, nil, consts.CodeInternalError)
+		return
+	}
+
+	// 3. 返回成功响应
+	result.Success(c, resp)
+}
+
+// PinMessage 置顶消息接口
+// @Summary 置顶消息
+// @Description 将会话内的消息置顶，单聊双方均可操作，群聊仅群主/管理员；置顶通知实时同步给会话参与者
+// @Tags 消息接口
+// @Accept json
+// @Produce json
+// @Param request body dto.PinMessageRequest true "置顶消息请求"
+// @Success 200 {object} dto.PinMessageResponse
+// @Router /api/v1/auth/msg/pin [post]
+func (h *MessageHandler) PinMessage(c *gin.Context) {
+	ctx := middleware.NewContextWithGin(c)
+
+	// 1. 绑定请求数据
+	var req dto.PinMessageRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		// 参数错误由客户端输入导致,属于正常业务流程,不记录日志
+		result.Fail(c, nil, consts.CodeParamError)
+		return
+	}
+
+	// 2. 调用服务层处理业务逻辑（依赖注入）
+	resp, err := h.messageService.PinMessage(ctx, &req)
+	if err != nil {
+		// 检查是否为业务错误
+		if consts.IsNonServerError(utils.ExtractErrorCode(err)) {
+			// 业务逻辑失败（如消息不存在、已撤回、无权置顶、置顶数量已达上限）
+			result.Fail(c, nil, utils.ExtractErrorCode(err))
+			return
+		}
+
+		// 其他内部错误
+		logger.Error(ctx, "置顶消息服务内部错误",
+			logger.ErrorField("error", err),
+		)
+		result.Fail(c, nil, consts.CodeInternalError)
+		return
+	}
+
+	// 3. 返回成功响应
+	result.Success(c, resp)
+}
+
+// UnpinMessage 取消置顶消息接口
+// @Summary 取消置顶消息
+// @Description 取消会话内消息的置顶，权限与置顶相同，未置顶时幂等返回
+// @Tags 消息接口
+// @Accept json
+// @Produce json
+// @Param request body dto.UnpinMessageRequest true "取消置顶消息请求"
+// @Success 200 {object} dto.UnpinMessageResponse
+// @Router /api/v1/auth/msg/unpin [post]
+func (h *MessageHandler) UnpinMessage(c *gin.Context) {
+	ctx := middleware.NewContextWithGin(c)
+
+	// 1. 绑定请求数据
+	var req dto.UnpinMessageRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		// 参数错误由客户端输入导致,属于正常业务流程,不记录日志
+		result.Fail(c, nil, consts.CodeParamError)
+		return
+	}
+
+	// 2. 调用服务层处理业务逻辑（依赖注入）
+	resp, err := h.messageService.UnpinMessage(ctx, &req)
+	if err != nil {
+		// 检查是否为业务错误
+		if consts.IsNonServerError(utils.ExtractErrorCode(err)) {
+			// 业务逻辑失败（如消息不存在、无权操作）
+			result.Fail(c, nil, utils.ExtractErrorCode(err))
+			return
+		}
+
+		// 其他内部错误
+		logger.Error(ctx, "取消置顶消息服务内部错误",
+			logger.ErrorField("error", err),
+		)
+		result.Fail(c, nil, consts.CodeInternalError)
+		return
+	}
+
+	// 3. 返回成功响应
+	result.Success(c, resp)
+}
+
+// ListPinnedMessages 查询置顶消息接口
+// @Summary 查询置顶消息
+// @Description 查询会话内的置顶消息及置顶人、置顶时间，最近置顶的在前
+// @Tags 消息接口
+// @Accept json
+// @Produce json
+// @Param request body dto.ListPinnedMessagesRequest true "查询置顶消息请求"
+// @Success 200 {object} dto.ListPinnedMessagesResponse
+// @Router /api/v1/auth/msg/pin/list [post]
+func (h *MessageHandler) ListPinnedMessages(c *gin.Context) {
+	ctx := middleware.NewContextWithGin(c)
+
+	// 1. 绑定请求数据
+	var req dto.ListPinnedMessagesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		// 参数错误由客户端输入导致,属于正常业务流程,不记录日志
+		result.Fail(c, nil, consts.CodeParamError)
+		return
+	}
+
+	// 2. 调用服务层处理业务逻辑（依赖注入）
+	resp, err := h.messageService.ListPinnedMessages(ctx, &req)
+	if err != nil {
+		// 检查是否为业务错误
+		if consts.IsNonServerError(utils.ExtractErrorCode(err)) {
+			// 业务逻辑失败（如无权访问该会话）
+			result.Fail(c, nil, utils.ExtractErrorCode(err))
+			return
+		}
+
+		// 其他内部错误
+		logger.Error(ctx, "查询置顶消息服务内部错误",
+			logger.ErrorField("error", err),
+		)
+		result.Fail(c, nil, consts.CodeInternalError)
+		return
+	}
+
+	// 3. 返回成功响应
+	result.Success(c, resp)
+}
+
+// ScheduleMessage 预约定时消息接口
+// @Summary 预约定时消息
+// @Description 预约在指定时间发送消息，到期后按正常发送流程发出（幂等、黑名单与禁言校验同普通发送）；按 clientMsgId 幂等
+// @Tags 消息接口
+// @Accept json
+// @Produce json
+// @Param request body dto.ScheduleMessageRequest true "预约定时消息请求"
+// @Success 200 {object} dto.ScheduleMessageResponse
+// @Router /api/v1/auth/msg/schedule [post]
+func (h *MessageHandler) ScheduleMessage(c *gin.Context) {
+	ctx := middleware.NewContextWithGin(c)
+
+	// 1. 绑定请求数据
+	var req dto.ScheduleMessageRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		// 参数错误由客户端输入导致,属于正常业务流程,不记录日志
+		result.Fail(c, nil, consts.CodeParamError)
+		return
+	}
+
+	// 2. 调用服务层处理业务逻辑（依赖注入）
+	resp, err := h.messageService.ScheduleMessage(ctx, &req)
+	if err != nil {
+		// 检查是否为业务错误
+		if consts.IsNonServerError(utils.ExtractErrorCode(err)) {
+			// 业务逻辑失败（如预约时间无效、被拉黑、非群成员、待发送数量已达上限）
+			result.Fail(c, nil, utils.ExtractErrorCode(err))
+			return
+		}
+
+		// 其他内部错误
+		logger.Error(ctx, "预约定时消息服务内部错误",
+			logger.ErrorField("error", err),
+		)
+		result.Fail(c, nil, consts.CodeInternalError)
+		return
+	}
+
+	// 3. 返回成功响应
+	result.Success(c, resp)
+}
+
+// ListScheduledMessages 查询定时消息接口
+// @Summary 查询定时消息
+// @Description 分页查询自己待发送与发送失败的定时消息，按预约发送时间升序
+// @Tags 消息接口
+// @Accept json
+// @Produce json
+// @Param request body dto.ListScheduledMessagesRequest true "查询定时消息请求"
+// @Success 200 {object} dto.ListScheduledMessagesResponse
+// @Router /api/v1/auth/msg/schedule/list [post]
+func (h *MessageHandler) ListScheduledMessages(c *gin.Context) {
+	ctx := middleware.NewContextWithGin(c)
+
+	// 1. 绑定请求数据
+	var req dto.ListScheduledMessagesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		// 参数错误由客户端输入导致,属于正常业务流程,不记录日志
+		result.Fail(c, nil, consts.CodeParamError)
+		return
+	}
+
+	// 2. 调用服务层处理业务逻辑（依赖注入）
+	resp, err := h.messageService.ListScheduledMessages(ctx, &req)
+	if err != nil {
+		// 检查是否为业务错误
+		if consts.IsNonServerError(utils.ExtractErrorCode(err)) {
+			// 业务逻辑失败（如参数不合法）
+			result.Fail(c, nil, utils.ExtractErrorCode(err))
+			return
+		}
+
+		// 其他内部错误
+		logger.Error(ctx, "查询定时消息服务内部错误",
+			logger.ErrorField("error", err),
+		)
+		result.Fail(c, nil, consts.CodeInternalError)
+		return
+	}
+
+	// 3. 返回成功响应
+	result.Success(c, resp)
+}
+
+// CancelScheduledMessage 取消定时消息接口
+// @Summary 取消定时消息
+// @Description 取消待发送的定时消息；发送失败的记录也可取消以从列表移除，发送中或已发送的不可取消
+// @Tags 消息接口
+// @Accept json
+// @Produce json
+// @Param request body dto.CancelScheduledMessageRequest true "取消定时消息请求"
+// @Success 200 {object} dto.CancelScheduledMessageResponse
+// @Router /api/v1/auth/msg/schedule/cancel [post]
+func (h *MessageHandler) CancelScheduledMessage(c *gin.Context) {
+	ctx := middleware.NewContextWithGin(c)
+
+	// 1. 绑定请求数据
+	var req dto.CancelScheduledMessageRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		// 参数错误由客户端输入导致,属于正常业务流程,不记录日志
+		result.Fail(c, nil, consts.CodeParamError)
+		return
+	}
+
+	// 2. 调用服务层处理业务逻辑（依赖注入）
+	_, err := h.messageService.CancelScheduledMessage(ctx, &req)
+	if err != nil {
+		// 检查是否为业务错误
+		if consts.IsNonServerError(utils.ExtractErrorCode(err)) {
+			// 业务逻辑失败（如定时消息不存在、已发送）
+			result.Fail(c, nil, utils.ExtractErrorCode(err))
+			return
+		}
+
+		// 其他内部错误
+		logger.Error(ctx, "取消定时消息服务内部错误",
+			logger.ErrorField("error", err),
+		)
+		result.Fail(c, nil, consts.CodeInternalError)
+		return
+	}
+
+	// 3. 返回成功响应
+	result.Success(c, nil)
+}
+
+// SetDisappearingTimer 设置会话定时销毁接口
+// @Summary 设置会话定时销毁
+// @Description 设置会话内新消息的存活时间（秒，0 表示关闭），到期后服务端删除消息并通知客户端删除本地副本；群聊仅群主/管理员可设置
+// @Tags 消息接口
+// @Accept json
+// @Produce json
+// @Param request body dto.SetDisappearingTimerRequest true "设置会话定时销毁请求"
+// @Success 200 {object} dto.SetDisappearingTimerResponse
+// @Router /api/v1/auth/msg/disappearing/set [post]
+func (h *MessageHandler) SetDisappearingTimer(c *gin.Context) {
+	ctx := middleware.NewContextWithGin(c)
+
+	// 1. 绑定请求数据
+	var req dto.SetDisappearingTimerRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		// 参数错误由客户端输入导致,属于正常业务流程,不记录日志
+		result.Fail(c, nil, consts.CodeParamError)
+		return
+	}
+
+	// 2. 调用服务层处理业务逻辑（依赖注入）
+	resp, err := h.messageService.SetDisappearingTimer(ctx, &req)
+	if err != nil {
+		// 检查是否为业务错误
+		if consts.IsNonServerError(utils.ExtractErrorCode(err)) {
+			// 业务逻辑失败（如存活时间无效、非群主/管理员）
+			result.Fail(c, nil, utils.ExtractErrorCode(err))
+			return
+		}
+
+		// 其他内部错误
+		logger.Error(ctx, "设置会话定时销毁服务内部错误",
+			logger.ErrorField("error", err),
+		)
+		result.Fail(c, nil, consts.CodeInternalError)
+		return
+	}
+
+	// 3. 返回成功响应
+	result.Success(c, resp)
+}
+
+// GetDisappearingTimer 查询会话定时销毁设置接口
+// @Summary 查询会话定时销毁设置
+// @Description 查询会话当前的消息存活时间及最后修改人，未设置过时存活时间为 0
+// @Tags 消息接口
+// @Accept json
+// @Produce json
+// @Param request body dto.GetDisappearingTimerRequest true "查询会话定时销毁设置请求"
+// @Success 200 {object} dto.GetDisappearingTimerResponse
+// @Router /api/v1/auth/msg/disappearing/get [post]
+func (h *MessageHandler) GetDisappearingTimer(c *gin.Context) {
+	ctx := middleware.NewContextWithGin(c)
+
+	// 1. 绑定请求数据
+	var req dto.GetDisappearingTimerRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		// 参数错误由客户端输入导致,属于正常业务流程,不记录日志
+		result.Fail(c, nil, consts.CodeParamError)
+		return
+	}
+
+	// 2. 调用服务层处理业务逻辑（依赖注入）
+	resp, err := h.messageService.GetDisappearingTimer(ctx, &req)
+	if err != nil {
+		// 检查是否为业务错误
+		if consts.IsNonServerError(utils.ExtractErrorCode(err)) {
+			// 业务逻辑失败（如无权访问该会话）
+			result.Fail(c, nil, utils.ExtractErrorCode(err))
+			return
+		}
+
+		// 其他内部错误
+		logger.Error(ctx, "查询会话定时销毁设置服务内部错误",
+			logger.ErrorField("error", err),
+		)
+		result.Fail(c, nil, consts.CodeInternalError)
+		return
+	}
+
+	// 3. 返回成功响应
+	result.Success(c, resp)
+}
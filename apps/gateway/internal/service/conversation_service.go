@@ -0,0 +1,174 @@
+package service
+
+import (
+	"ChatServer/apps/gateway/internal/dto"
+	"ChatServer/apps/gateway/internal/pb"
+	"ChatServer/apps/gateway/internal/utils"
+	"ChatServer/consts"
+	"ChatServer/pkg/logger"
+	"context"
+	"time"
+)
+
+// ConversationServiceImpl 会话服务实现
+type ConversationServiceImpl struct {
+	msgClient pb.MsgServiceClient
+}
+
+// NewConversationService 创建会话服务实例
+// msgClient: 消息服务 gRPC 客户端（会话服务与消息服务部署在同一进程）
+func NewConversationService(msgClient pb.MsgServiceClient) ConversationService {
+	return &ConversationServiceImpl{
+		msgClient: msgClient,
+	}
+}
+
+// GetConversationList 获取会话列表
+// ctx: 请求上下文
+// req: 会话列表请求
+// 返回: 会话列表（置顶在前，其余按更新时间倒序）
+func (s *ConversationServiceImpl) GetConversationList(ctx context.Context, req *dto.GetConversationListRequest) (*dto.GetConversationListResponse, error) {
+	startTime := time.Now()
+
+	// 1. 转换 DTO 为 Protobuf 请求
+	grpcReq := dto.ConvertToProtoGetConversationListRequest(req)
+
+	// 2. 调用会话服务获取会话列表(gRPC)
+	grpcResp, err := s.msgClient.GetConversationList(ctx, grpcReq)
+	if err != nil {
+		// gRPC 调用失败，提取业务错误码
+		code := utils.ExtractErrorCode(err)
+		// 记录错误日志
+		logger.Error(ctx, "调用会话服务 gRPC 失败",
+			logger.ErrorField("error", err),
+			logger.Int("business_code", code),
+			logger.String("business_message", consts.GetMessage(code)),
+			logger.Duration("duration", time.Since(startTime)),
+		)
+
+		// 返回业务错误（作为 Go error 返回，由 Handler 层处理）
+		return nil, err
+	}
+
+	return dto.ConvertGetConversationListResponseFromProto(grpcResp), nil
+}
+
+// SyncConversations 增量同步会话
+// ctx: 请求上下文
+// req: 增量同步请求
+// 返回: 版本号之后的会话变更
+func (s *ConversationServiceImpl) SyncConversations(ctx context.Context, req *dto.SyncConversationsRequest) (*dto.SyncConversationsResponse, error) {
+	startTime := time.Now()
+
+	// 1. 转换 DTO 为 Protobuf 请求
+	grpcReq := dto.ConvertToProtoSyncConversationsRequest(req)
+
+	// 2. 调用会话服务增量同步会话(gRPC)
+	grpcResp, err := s.msgClient.SyncConversations(ctx, grpcReq)
+	if err != nil {
+		// gRPC 调用失败，提取业务错误码
+		code := utils.ExtractErrorCode(err)
+		// 记录错误日志
+		logger.Error(ctx, "调用会话服务 gRPC 失败",
+			logger.ErrorField("error", err),
+			logger.Int("business_code", code),
+			logger.String("business_message", consts.GetMessage(code)),
+			logger.Duration("duration", time.Since(startTime)),
+		)
+
+		// 返回业务错误（作为 Go error 返回，由 Handler 层处理）
+		return nil, err
+	}
+
+	return dto.ConvertSyncConversationsResponseFromProto(grpcResp), nil
+}
+
+// SetConversationPin 置顶/取消置顶会话
+// ctx: 请求上下文
+// req: 置顶请求
+// 返回: 置顶响应
+func (s *ConversationServiceImpl) SetConversationPin(ctx context.Context, req *dto.SetConversationPinRequest) (*dto.SetConversationPinResponse, error) {
+	startTime := time.Now()
+
+	// 1. 转换 DTO 为 Protobuf 请求
+	grpcReq := dto.ConvertToProtoSetConversationPinRequest(req)
+
+	// 2. 调用会话服务置顶会话(gRPC)
+	_, err := s.msgClient.SetConversationPin(ctx, grpcReq)
+	if err != nil {
+		// gRPC 调用失败，提取业务错误码
+		code := utils.ExtractErrorCode(err)
+		// 记录错误日志
+		logger.Error(ctx, "调用会话服务 gRPC 失败",
+			logger.ErrorField("error", err),
+			logger.Int("business_code", code),
+			logger.String("business_message", consts.GetMessage(code)),
+			logger.Duration("duration", time.Since(startTime)),
+		)
+
+		// 返回业务错误（作为 Go error 返回，由 Handler 层处理）
+		return nil, err
+	}
+
+	return &dto.SetConversationPinResponse{}, nil
+}
+
+// SetConversationMute 开启/关闭会话免打扰
+// ctx: 请求上下文
+// req: 免打扰请求
+// 返回: 免打扰响应
+func (s *ConversationServiceImpl) SetConversationMute(ctx context.Context, req *dto.SetConversationMuteRequest) (*dto.SetConversationMuteResponse, error) {
+	startTime := time.Now()
+
+	// 1. 转换 DTO 为 Protobuf 请求
+	grpcReq := dto.ConvertToProtoSetConversationMuteRequest(req)
+
+	// 2. 调用会话服务设置免打扰(gRPC)
+	_, err := s.msgClient.SetConversationMute(ctx, grpcReq)
+	if err != nil {
+		// gRPC 调用失败，提取业务错误码
+		code := utils.ExtractErrorCode(err)
+		// 记录错误日志
+		logger.Error(ctx, "调用会话服务 gRPC 失败",
+			logger.ErrorField("error", err),
+			logger.Int("business_code", code),
+			logger.String("business_message", consts.GetMessage(code)),
+			logger.Duration("duration", time.Since(startTime)),
+		)
+
+		// 返回业务错误（作为 Go error 返回，由 Handler 层处理）
+		return nil, err
+	}
+
+	return &dto.SetConversationMuteResponse{}, nil
+}
+
+// DeleteConversation 删除会话
+// ctx: 请求上下文
+// req: 删除会话请求
+// 返回: 删除会话响应
+func (s *ConversationServiceImpl) DeleteConversation(ctx context.Context, req *dto.DeleteConversationRequest) (*dto.DeleteConversationResponse, error) {
+	startTime := time.Now()
+
+	// 1. 转换 DTO 为 Protobuf 请求
+	grpcReq := dto.ConvertToProtoDeleteConversationRequest(req)
+
+	// 2. 调用会话服务删除会话(gRPC)
+	_, err := s.msgClient.DeleteConversation(ctx, grpcReq)
+	if err != nil {
+		// gRPC 调用失败，提取业务错误码
+		code := utils.ExtractErrorCode(err)
+		// 记录错误日志
+		logger.Error(ctx, "调用会话服务 gRPC 失败",
+			logger.ErrorField("error", err),
+			logger.Int("business_code", code),
+			logger.String("business_message", consts.GetMessage(code)),
+			logger.Duration("duration", time.Since(startTime)),
+		)
+
+		// 返回业务错误（作为 Go error 返回，由 Handler 层处理）
+		return nil, err
+	}
+
+	return &dto.DeleteConversationResponse{}, nil
+}
@@ -0,0 +1,714 @@
+package service
+
+import (
+	"ChatServer/apps/gateway/internal/dto"
+	"ChatServer/apps/gateway/internal/pb"
+	"ChatServer/apps/gateway/internal/utils"
+	"ChatServer/consts"
+	"ChatServer/pkg/logger"
+	"context"
+	"time"
+)
+
+// GroupServiceImpl 群组服务实现
+type GroupServiceImpl struct {
+	userClient pb.UserServiceClient
+}
+
+// NewGroupService 创建群组服务实例
+// userClient: 用户服务 gRPC 客户端（群组服务与用户服务部署在同一进程）
+func NewGroupService(userClient pb.UserServiceClient) GroupService {
+	return &GroupServiceImpl{
+		userClient: userClient,
+	}
+}
+
+// CreateGroup 创建群组
+// ctx: 请求上下文
+// req: 创建群组请求
+// 返回: 创建后的群资料
+func (s *GroupServiceImpl) CreateGroup(ctx context.Context, req *dto.CreateGroupRequest) (*dto.CreateGroupResponse, error) {
+	startTime := time.Now()
+
+	// 1. 转换 DTO 为 Protobuf 请求
+	grpcReq := dto.ConvertToProtoCreateGroupRequest(req)
+
+	// 2. 调用群组服务创建群组(gRPC)
+	grpcResp, err := s.userClient.CreateGroup(ctx, grpcReq)
+	if err != nil {
+		// gRPC 调用失败，提取业务错误码
+		code := utils.ExtractErrorCode(err)
+		// 记录错误日志
+		logger.Error(ctx, "调用群组服务 gRPC 失败",
+			logger.ErrorField("error", err),
+			logger.Int("business_code", code),
+			logger.String("business_message", consts.GetMessage(code)),
+			logger.Duration("duration", time.Since(startTime)),
+		)
+
+		// 返回业务错误（作为 Go error 返回，由 Handler 层处理）
+		return nil, err
+	}
+
+	return &dto.CreateGroupResponse{Group: dto.ConvertGroupInfoFromProto(grpcResp.Group)}, nil
+}
+
+// GetGroupInfo 获取群资料
+// ctx: 请求上下文
+// req: 群资料请求
+// 返回: 群资料
+func (s *GroupServiceImpl) GetGroupInfo(ctx context.Context, req *dto.GetGroupInfoRequest) (*dto.GetGroupInfoResponse, error) {
+	startTime := time.Now()
+
+	// 1. 转换 DTO 为 Protobuf 请求
+	grpcReq := dto.ConvertToProtoGetGroupInfoRequest(req)
+
+	// 2. 调用群组服务获取群资料(gRPC)
+	grpcResp, err := s.userClient.GetGroupInfo(ctx, grpcReq)
+	if err != nil {
+		// gRPC 调用失败，提取业务错误码
+		code := utils.ExtractErrorCode(err)
+		// 记录错误日志
+		logger.Error(ctx, "调用群组服务 gRPC 失败",
+			logger.ErrorField("error", err),
+			logger.Int("business_code", code),
+			logger.String("business_message", consts.GetMessage(code)),
+			logger.Duration("duration", time.Since(startTime)),
+		)
+
+		// 返回业务错误（作为 Go error 返回，由 Handler 层处理）
+		return nil, err
+	}
+
+	return &dto.GetGroupInfoResponse{Group: dto.ConvertGroupInfoFromProto(grpcResp.Group)}, nil
+}
+
+// GetGroupMembers 获取群成员列表
+// ctx: 请求上下文
+// req: 群成员列表请求
+// 返回: 群成员列表（群主、管理员在前）
+func (s *GroupServiceImpl) GetGroupMembers(ctx context.Context, req *dto.GetGroupMembersRequest) (*dto.GetGroupMembersResponse, error) {
+	startTime := time.Now()
+
+	// 1. 转换 DTO 为 Protobuf 请求
+	grpcReq := dto.ConvertToProtoGetGroupMembersRequest(req)
+
+	// 2. 调用群组服务获取群成员列表(gRPC)
+	grpcResp, err := s.userClient.GetGroupMembers(ctx, grpcReq)
+	if err != nil {
+		// gRPC 调用失败，提取业务错误码
+		code := utils.ExtractErrorCode(err)
+		// 记录错误日志
+		logger.Error(ctx, "调用群组服务 gRPC 失败",
+			logger.ErrorField("error", err),
+			logger.Int("business_code", code),
+			logger.String("business_message", consts.GetMessage(code)),
+			logger.Duration("duration", time.Since(startTime)),
+		)
+
+		// 返回业务错误（作为 Go error 返回，由 Handler 层处理）
+		return nil, err
+	}
+
+	return dto.ConvertGetGroupMembersResponseFromProto(grpcResp), nil
+}
+
+// InviteMembers 邀请成员入群
+// ctx: 请求上下文
+// req: 邀请成员请求
+// 返回: 新加入与跳过的成员
+func (s *GroupServiceImpl) InviteMembers(ctx context.Context, req *dto.InviteMembersRequest) (*dto.InviteMembersResponse, error) {
+	startTime := time.Now()
+
+	// 1. 转换 DTO 为 Protobuf 请求
+	grpcReq := dto.ConvertToProtoInviteMembersRequest(req)
+
+	// 2. 调用群组服务邀请成员入群(gRPC)
+	grpcResp, err := s.userClient.InviteMembers(ctx, grpcReq)
+	if err != nil {
+		// gRPC 调用失败，提取业务错误码
+		code := utils.ExtractErrorCode(err)
+		// 记录错误日志
+		logger.Error(ctx, "调用群组服务 gRPC 失败",
+			logger.ErrorField("error", err),
+			logger.Int("business_code", code),
+			logger.String("business_message", consts.GetMessage(code)),
+			logger.Duration("duration", time.Since(startTime)),
+		)
+
+		// 返回业务错误（作为 Go error 返回，由 Handler 层处理）
+		return nil, err
+	}
+
+	return dto.ConvertInviteMembersResponseFromProto(grpcResp), nil
+}
+
+// JoinGroup 申请入群
+// ctx: 请求上下文
+// req: 申请入群请求
+// 返回: 是否已直接入群及申请ID
+func (s *GroupServiceImpl) JoinGroup(ctx context.Context, req *dto.JoinGroupRequest) (*dto.JoinGroupResponse, error) {
+	startTime := time.Now()
+
+	// 1. 转换 DTO 为 Protobuf 请求
+	grpcReq := dto.ConvertToProtoJoinGroupRequest(req)
+
+	// 2. 调用群组服务申请入群(gRPC)
+	grpcResp, err := s.userClient.JoinGroup(ctx, grpcReq)
+	if err != nil {
+		// gRPC 调用失败，提取业务错误码
+		code := utils.ExtractErrorCode(err)
+		// 记录错误日志
+		logger.Error(ctx, "调用群组服务 gRPC 失败",
+			logger.ErrorField("error", err),
+			logger.Int("business_code", code),
+			logger.String("business_message", consts.GetMessage(code)),
+			logger.Duration("duration", time.Since(startTime)),
+		)
+
+		// 返回业务错误（作为 Go error 返回，由 Handler 层处理）
+		return nil, err
+	}
+
+	return dto.ConvertJoinGroupResponseFromProto(grpcResp), nil
+}
+
+// GetGroupApplyList 获取入群申请列表
+// ctx: 请求上下文
+// req: 入群申请列表请求
+// 返回: 入群申请列表（按申请时间倒序）
+func (s *GroupServiceImpl) GetGroupApplyList(ctx context.Context, req *dto.GetGroupApplyListRequest) (*dto.GetGroupApplyListResponse, error) {
+	startTime := time.Now()
+
+	// 1. 转换 DTO 为 Protobuf 请求
+	grpcReq := dto.ConvertToProtoGetGroupApplyListRequest(req)
+
+	// 2. 调用群组服务获取入群申请列表(gRPC)
+	grpcResp, err := s.userClient.GetGroupApplyList(ctx, grpcReq)
+	if err != nil {
+		// gRPC 调用失败，提取业务错误码
+		code := utils.ExtractErrorCode(err)
+		// 记录错误日志
+		logger.Error(ctx, "调用群组服务 gRPC 失败",
+			logger.ErrorField("error", err),
+			logger.Int("business_code", code),
+			logger.String("business_message", consts.GetMessage(code)),
+			logger.Duration("duration", time.Since(startTime)),
+		)
+
+		// 返回业务错误（作为 Go error 返回，由 Handler 层处理）
+		return nil, err
+	}
+
+	return dto.ConvertGetGroupApplyListResponseFromProto(grpcResp), nil
+}
+
+// HandleGroupApply 处理入群申请
+// ctx: 请求上下文
+// req: 处理入群申请请求
+// 返回: 处理入群申请响应
+func (s *GroupServiceImpl) HandleGroupApply(ctx context.Context, req *dto.HandleGroupApplyRequest) (*dto.HandleGroupApplyResponse, error) {
+	startTime := time.Now()
+
+	// 1. 转换 DTO 为 Protobuf 请求
+	grpcReq := dto.ConvertToProtoHandleGroupApplyRequest(req)
+
+	// 2. 调用群组服务处理入群申请(gRPC)
+	_, err := s.userClient.HandleGroupApply(ctx, grpcReq)
+	if err != nil {
+		// gRPC 调用失败，提取业务错误码
+		code := utils.ExtractErrorCode(err)
+		// 记录错误日志
+		logger.Error(ctx, "调用群组服务 gRPC 失败",
+			logger.ErrorField("error", err),
+			logger.Int("business_code", code),
+			logger.String("business_message", consts.GetMessage(code)),
+			logger.Duration("duration", time.Since(startTime)),
+		)
+
+		// 返回业务错误（作为 Go error 返回，由 Handler 层处理）
+		return nil, err
+	}
+
+	return &dto.HandleGroupApplyResponse{}, nil
+}
+
+// SetAdmin 设置管理员
+// ctx: 请求上下文
+// req: 设置管理员请求
+// 返回: 设置管理员响应
+func (s *GroupServiceImpl) SetAdmin(ctx context.Context, req *dto.SetAdminRequest) (*dto.SetAdminResponse, error) {
+	startTime := time.Now()
+
+	// 1. 转换 DTO 为 Protobuf 请求
+	grpcReq := dto.ConvertToProtoSetAdminRequest(req)
+
+	// 2. 调用群组服务设置管理员(gRPC)
+	_, err := s.userClient.SetAdmin(ctx, grpcReq)
+	if err != nil {
+		// gRPC 调用失败，提取业务错误码
+		code := utils.ExtractErrorCode(err)
+		// 记录错误日志
+		logger.Error(ctx, "调用群组服务 gRPC 失败",
+			logger.ErrorField("error", err),
+			logger.Int("business_code", code),
+			logger.String("business_message", consts.GetMessage(code)),
+			logger.Duration("duration", time.Since(startTime)),
+		)
+
+		// 返回业务错误（作为 Go error 返回，由 Handler 层处理）
+		return nil, err
+	}
+
+	return &dto.SetAdminResponse{}, nil
+}
+
+// UnsetAdmin 取消管理员
+// ctx: 请求上下文
+// req: 取消管理员请求
+// 返回: 取消管理员响应
+func (s *GroupServiceImpl) UnsetAdmin(ctx context.Context, req *dto.UnsetAdminRequest) (*dto.UnsetAdminResponse, error) {
+	startTime := time.Now()
+
+	// 1. 转换 DTO 为 Protobuf 请求
+	grpcReq := dto.ConvertToProtoUnsetAdminRequest(req)
+
+	// 2. 调用群组服务取消管理员(gRPC)
+	_, err := s.userClient.UnsetAdmin(ctx, grpcReq)
+	if err != nil {
+		// gRPC 调用失败，提取业务错误码
+		code := utils.ExtractErrorCode(err)
+		// 记录错误日志
+		logger.Error(ctx, "调用群组服务 gRPC 失败",
+			logger.ErrorField("error", err),
+			logger.Int("business_code", code),
+			logger.String("business_message", consts.GetMessage(code)),
+			logger.Duration("duration", time.Since(startTime)),
+		)
+
+		// 返回业务错误（作为 Go error 返回，由 Handler 层处理）
+		return nil, err
+	}
+
+	return &dto.UnsetAdminResponse{}, nil
+}
+
+// KickMember 踢出群成员
+// ctx: 请求上下文
+// req: 踢出群成员请求
+// 返回: 踢出群成员响应
+func (s *GroupServiceImpl) KickMember(ctx context.Context, req *dto.KickMemberRequest) (*dto.KickMemberResponse, error) {
+	startTime := time.Now()
+
+	// 1. 转换 DTO 为 Protobuf 请求
+	grpcReq := dto.ConvertToProtoKickMemberRequest(req)
+
+	// 2. 调用群组服务踢出群成员(gRPC)
+	_, err := s.userClient.KickMember(ctx, grpcReq)
+	if err != nil {
+		// gRPC 调用失败，提取业务错误码
+		code := utils.ExtractErrorCode(err)
+		// 记录错误日志
+		logger.Error(ctx, "调用群组服务 gRPC 失败",
+			logger.ErrorField("error", err),
+			logger.Int("business_code", code),
+			logger.String("business_message", consts.GetMessage(code)),
+			logger.Duration("duration", time.Since(startTime)),
+		)
+
+		// 返回业务错误（作为 Go error 返回，由 Handler 层处理）
+		return nil, err
+	}
+
+	return &dto.KickMemberResponse{}, nil
+}
+
+// TransferOwnership 转让群主
+// ctx: 请求上下文
+// req: 转让群主请求
+// 返回: 转让群主响应
+func (s *GroupServiceImpl) TransferOwnership(ctx context.Context, req *dto.TransferOwnershipRequest) (*dto.TransferOwnershipResponse, error) {
+	startTime := time.Now()
+
+	// 1. 转换 DTO 为 Protobuf 请求
+	grpcReq := dto.ConvertToProtoTransferOwnershipRequest(req)
+
+	// 2. 调用群组服务转让群主(gRPC)
+	_, err := s.userClient.TransferOwnership(ctx, grpcReq)
+	if err != nil {
+		// gRPC 调用失败，提取业务错误码
+		code := utils.ExtractErrorCode(err)
+		// 记录错误日志
+		logger.Error(ctx, "调用群组服务 gRPC 失败",
+			logger.ErrorField("error", err),
+			logger.Int("business_code", code),
+			logger.String("business_message", consts.GetMessage(code)),
+			logger.Duration("duration", time.Since(startTime)),
+		)
+
+		// 返回业务错误（作为 Go error 返回，由 Handler 层处理）
+		return nil, err
+	}
+
+	return &dto.TransferOwnershipResponse{}, nil
+}
+
+// MuteMember 禁言成员
+// ctx: 请求上下文
+// req: 禁言成员请求
+// 返回: 禁言成员响应
+func (s *GroupServiceImpl) MuteMember(ctx context.Context, req *dto.MuteMemberRequest) (*dto.MuteMemberResponse, error) {
+	startTime := time.Now()
+
+	// 1. 转换 DTO 为 Protobuf 请求
+	grpcReq := dto.ConvertToProtoMuteMemberRequest(req)
+
+	// 2. 调用群组服务禁言成员(gRPC)
+	grpcResp, err := s.userClient.MuteMember(ctx, grpcReq)
+	if err != nil {
+		// gRPC 调用失败，提取业务错误码
+		code := utils.ExtractErrorCode(err)
+		// 记录错误日志
+		logger.Error(ctx, "调用群组服务 gRPC 失败",
+			logger.ErrorField("error", err),
+			logger.Int("business_code", code),
+			logger.String("business_message", consts.GetMessage(code)),
+			logger.Duration("duration", time.Since(startTime)),
+		)
+
+		// 返回业务错误（作为 Go error 返回，由 Handler 层处理）
+		return nil, err
+	}
+
+	return dto.ConvertMuteMemberResponseFromProto(grpcResp), nil
+}
+
+// SetGroupMuteAll 设置全员禁言
+// ctx: 请求上下文
+// req: 全员禁言请求
+// 返回: 全员禁言响应
+func (s *GroupServiceImpl) SetGroupMuteAll(ctx context.Context, req *dto.SetGroupMuteAllRequest) (*dto.SetGroupMuteAllResponse, error) {
+	startTime := time.Now()
+
+	// 1. 转换 DTO 为 Protobuf 请求
+	grpcReq := dto.ConvertToProtoSetGroupMuteAllRequest(req)
+
+	// 2. 调用群组服务设置全员禁言(gRPC)
+	_, err := s.userClient.SetGroupMuteAll(ctx, grpcReq)
+	if err != nil {
+		// gRPC 调用失败，提取业务错误码
+		code := utils.ExtractErrorCode(err)
+		// 记录错误日志
+		logger.Error(ctx, "调用群组服务 gRPC 失败",
+			logger.ErrorField("error", err),
+			logger.Int("business_code", code),
+			logger.String("business_message", consts.GetMessage(code)),
+			logger.Duration("duration", time.Since(startTime)),
+		)
+
+		// 返回业务错误（作为 Go error 返回，由 Handler 层处理）
+		return nil, err
+	}
+
+	return &dto.SetGroupMuteAllResponse{}, nil
+}
+
+// PostGroupAnnouncement 发布群公告
+// ctx: 请求上下文
+// req: 发布群公告请求
+// 返回: 发布群公告响应
+func (s *GroupServiceImpl) PostGroupAnnouncement(ctx context.Context, req *dto.PostGroupAnnouncementRequest) (*dto.PostGroupAnnouncementResponse, error) {
+	startTime := time.Now()
+
+	// 1. 转换 DTO 为 Protobuf 请求
+	grpcReq := dto.ConvertToProtoPostGroupAnnouncementRequest(req)
+
+	// 2. 调用群组服务发布群公告(gRPC)
+	grpcResp, err := s.userClient.PostGroupAnnouncement(ctx, grpcReq)
+	if err != nil {
+		// gRPC 调用失败，提取业务错误码
+		code := utils.ExtractErrorCode(err)
+		// 记录错误日志
+		logger.Error(ctx, "调用群组服务 gRPC 失败",
+			logger.ErrorField("error", err),
+			logger.Int("business_code", code),
+			logger.String("business_message", consts.GetMessage(code)),
+			logger.Duration("duration", time.Since(startTime)),
+		)
+
+		// 返回业务错误（作为 Go error 返回，由 Handler 层处理）
+		return nil, err
+	}
+
+	return dto.ConvertPostGroupAnnouncementResponseFromProto(grpcResp), nil
+}
+
+// GetGroupAnnouncements 获取群公告历史
+// ctx: 请求上下文
+// req: 获取群公告历史请求
+// 返回: 获取群公告历史响应
+func (s *GroupServiceImpl) GetGroupAnnouncements(ctx context.Context, req *dto.GetGroupAnnouncementsRequest) (*dto.GetGroupAnnouncementsResponse, error) {
+	startTime := time.Now()
+
+	// 1. 转换 DTO 为 Protobuf 请求
+	grpcReq := dto.ConvertToProtoGetGroupAnnouncementsRequest(req)
+
+	// 2. 调用群组服务获取群公告历史(gRPC)
+	grpcResp, err := s.userClient.GetGroupAnnouncements(ctx, grpcReq)
+	if err != nil {
+		// gRPC 调用失败，提取业务错误码
+		code := utils.ExtractErrorCode(err)
+		// 记录错误日志
+		logger.Error(ctx, "调用群组服务 gRPC 失败",
+			logger.ErrorField("error", err),
+			logger.Int("business_code", code),
+			logger.String("business_message", consts.GetMessage(code)),
+			logger.Duration("duration", time.Since(startTime)),
+		)
+
+		// 返回业务错误（作为 Go error 返回，由 Handler 层处理）
+		return nil, err
+	}
+
+	return dto.ConvertGetGroupAnnouncementsResponseFromProto(grpcResp), nil
+}
+
+// PinGroupAnnouncement 置顶群公告
+// ctx: 请求上下文
+// req: 置顶群公告请求
+// 返回: 置顶群公告响应
+func (s *GroupServiceImpl) PinGroupAnnouncement(ctx context.Context, req *dto.PinGroupAnnouncementRequest) (*dto.PinGroupAnnouncementResponse, error) {
+	startTime := time.Now()
+
+	// 1. 转换 DTO 为 Protobuf 请求
+	grpcReq := dto.ConvertToProtoPinGroupAnnouncementRequest(req)
+
+	// 2. 调用群组服务置顶群公告(gRPC)
+	_, err := s.userClient.PinGroupAnnouncement(ctx, grpcReq)
+	if err != nil {
+		// gRPC 调用失败，提取业务错误码
+		code := utils.ExtractErrorCode(err)
+		// 记录错误日志
+		logger.Error(ctx, "调用群组服务 gRPC 失败",
+			logger.ErrorField("error", err),
+			logger.Int("business_code", code),
+			logger.String("business_message", consts.GetMessage(code)),
+			logger.Duration("duration", time.Since(startTime)),
+		)
+
+		// 返回业务错误（作为 Go error 返回，由 Handler 层处理）
+		return nil, err
+	}
+
+	return &dto.PinGroupAnnouncementResponse{}, nil
+}
+
+// AckGroupAnnouncement 确认群公告
+// ctx: 请求上下文
+// req: 确认群公告请求
+// 返回: 确认群公告响应
+func (s *GroupServiceImpl) AckGroupAnnouncement(ctx context.Context, req *dto.AckGroupAnnouncementRequest) (*dto.AckGroupAnnouncementResponse, error) {
+	startTime := time.Now()
+
+	// 1. 转换 DTO 为 Protobuf 请求
+	grpcReq := dto.ConvertToProtoAckGroupAnnouncementRequest(req)
+
+	// 2. 调用群组服务确认群公告(gRPC)
+	grpcResp, err := s.userClient.AckGroupAnnouncement(ctx, grpcReq)
+	if err != nil {
+		// gRPC 调用失败，提取业务错误码
+		code := utils.ExtractErrorCode(err)
+		// 记录错误日志
+		logger.Error(ctx, "调用群组服务 gRPC 失败",
+			logger.ErrorField("error", err),
+			logger.Int("business_code", code),
+			logger.String("business_message", consts.GetMessage(code)),
+			logger.Duration("duration", time.Since(startTime)),
+		)
+
+		// 返回业务错误（作为 Go error 返回，由 Handler 层处理）
+		return nil, err
+	}
+
+	return dto.ConvertAckGroupAnnouncementResponseFromProto(grpcResp), nil
+}
+
+// CreateGroupInvite 创建群邀请
+// ctx: 请求上下文
+// req: 创建群邀请请求
+// 返回: 创建群邀请响应
+func (s *GroupServiceImpl) CreateGroupInvite(ctx context.Context, req *dto.CreateGroupInviteRequest) (*dto.CreateGroupInviteResponse, error) {
+	startTime := time.Now()
+
+	// 1. 转换 DTO 为 Protobuf 请求
+	grpcReq := dto.ConvertToProtoCreateGroupInviteRequest(req)
+
+	// 2. 调用群组服务创建群邀请(gRPC)
+	grpcResp, err := s.userClient.CreateGroupInvite(ctx, grpcReq)
+	if err != nil {
+		// gRPC 调用失败，提取业务错误码
+		code := utils.ExtractErrorCode(err)
+		// 记录错误日志
+		logger.Error(ctx, "调用群组服务 gRPC 失败",
+			logger.ErrorField("error", err),
+			logger.Int("business_code", code),
+			logger.String("business_message", consts.GetMessage(code)),
+			logger.Duration("duration", time.Since(startTime)),
+		)
+
+		// 返回业务错误（作为 Go error 返回，由 Handler 层处理）
+		return nil, err
+	}
+
+	return dto.ConvertCreateGroupInviteResponseFromProto(grpcResp), nil
+}
+
+// GetGroupInviteList 获取群邀请列表
+// ctx: 请求上下文
+// req: 获取群邀请列表请求
+// 返回: 获取群邀请列表响应
+func (s *GroupServiceImpl) GetGroupInviteList(ctx context.Context, req *dto.GetGroupInviteListRequest) (*dto.GetGroupInviteListResponse, error) {
+	startTime := time.Now()
+
+	// 1. 转换 DTO 为 Protobuf 请求
+	grpcReq := dto.ConvertToProtoGetGroupInviteListRequest(req)
+
+	// 2. 调用群组服务获取群邀请列表(gRPC)
+	grpcResp, err := s.userClient.GetGroupInviteList(ctx, grpcReq)
+	if err != nil {
+		// gRPC 调用失败，提取业务错误码
+		code := utils.ExtractErrorCode(err)
+		// 记录错误日志
+		logger.Error(ctx, "调用群组服务 gRPC 失败",
+			logger.ErrorField("error", err),
+			logger.Int("business_code", code),
+			logger.String("business_message", consts.GetMessage(code)),
+			logger.Duration("duration", time.Since(startTime)),
+		)
+
+		// 返回业务错误（作为 Go error 返回，由 Handler 层处理）
+		return nil, err
+	}
+
+	return dto.ConvertGetGroupInviteListResponseFromProto(grpcResp), nil
+}
+
+// RevokeGroupInvite 撤销群邀请
+// ctx: 请求上下文
+// req: 撤销群邀请请求
+// 返回: 撤销群邀请响应
+func (s *GroupServiceImpl) RevokeGroupInvite(ctx context.Context, req *dto.RevokeGroupInviteRequest) (*dto.RevokeGroupInviteResponse, error) {
+	startTime := time.Now()
+
+	// 1. 转换 DTO 为 Protobuf 请求
+	grpcReq := dto.ConvertToProtoRevokeGroupInviteRequest(req)
+
+	// 2. 调用群组服务撤销群邀请(gRPC)
+	_, err := s.userClient.RevokeGroupInvite(ctx, grpcReq)
+	if err != nil {
+		// gRPC 调用失败，提取业务错误码
+		code := utils.ExtractErrorCode(err)
+		// 记录错误日志
+		logger.Error(ctx, "调用群组服务 gRPC 失败",
+			logger.ErrorField("error", err),
+			logger.Int("business_code", code),
+			logger.String("business_message", consts.GetMessage(code)),
+			logger.Duration("duration", time.Since(startTime)),
+		)
+
+		// 返回业务错误（作为 Go error 返回，由 Handler 层处理）
+		return nil, err
+	}
+
+	return &dto.RevokeGroupInviteResponse{}, nil
+}
+
+// JoinByInviteToken 通过邀请入群
+// ctx: 请求上下文
+// req: 通过邀请入群请求
+// 返回: 通过邀请入群响应
+func (s *GroupServiceImpl) JoinByInviteToken(ctx context.Context, req *dto.JoinByInviteTokenRequest) (*dto.JoinByInviteTokenResponse, error) {
+	startTime := time.Now()
+
+	// 1. 转换 DTO 为 Protobuf 请求
+	grpcReq := dto.ConvertToProtoJoinByInviteTokenRequest(req)
+
+	// 2. 调用群组服务通过邀请入群(gRPC)
+	grpcResp, err := s.userClient.JoinByInviteToken(ctx, grpcReq)
+	if err != nil {
+		// gRPC 调用失败，提取业务错误码
+		code := utils.ExtractErrorCode(err)
+		// 记录错误日志
+		logger.Error(ctx, "调用群组服务 gRPC 失败",
+			logger.ErrorField("error", err),
+			logger.Int("business_code", code),
+			logger.String("business_message", consts.GetMessage(code)),
+			logger.Duration("duration", time.Since(startTime)),
+		)
+
+		// 返回业务错误（作为 Go error 返回，由 Handler 层处理）
+		return nil, err
+	}
+
+	return dto.ConvertJoinByInviteTokenResponseFromProto(grpcResp), nil
+}
+
+// QuitGroup 退出群组
+// ctx: 请求上下文
+// req: 退群请求
+// 返回: 退群响应
+func (s *GroupServiceImpl) QuitGroup(ctx context.Context, req *dto.QuitGroupRequest) (*dto.QuitGroupResponse, error) {
+	startTime := time.Now()
+
+	// 1. 转换 DTO 为 Protobuf 请求
+	grpcReq := dto.ConvertToProtoQuitGroupRequest(req)
+
+	// 2. 调用群组服务退出群组(gRPC)
+	_, err := s.userClient.QuitGroup(ctx, grpcReq)
+	if err != nil {
+		// gRPC 调用失败，提取业务错误码
+		code := utils.ExtractErrorCode(err)
+		// 记录错误日志
+		logger.Error(ctx, "调用群组服务 gRPC 失败",
+			logger.ErrorField("error", err),
+			logger.Int("business_code", code),
+			logger.String("business_message", consts.GetMessage(code)),
+			logger.Duration("duration", time.Since(startTime)),
+		)
+
+		// 返回业务错误（作为 Go error 返回，由 Handler 层处理）
+		return nil, err
+	}
+
+	return &dto.QuitGroupResponse{}, nil
+}
+
+// DismissGroup 解散群组
+// ctx: 请求上下文
+// req: 解散群组请求
+// 返回: 解散群组响应
+func (s *GroupServiceImpl) DismissGroup(ctx context.Context, req *dto.DismissGroupRequest) (*dto.DismissGroupResponse, error) {
+	startTime := time.Now()
+
+	// 1. 转换 DTO 为 Protobuf 请求
+	grpcReq := dto.ConvertToProtoDismissGroupRequest(req)
+
+	// 2. 调用群组服务解散群组(gRPC)
+	_, err := s.userClient.DismissGroup(ctx, grpcReq)
+	if err != nil {
+		// gRPC 调用失败，提取业务错误码
+		code := utils.ExtractErrorCode(err)
+		// 记录错误日志
+		logger.Error(ctx, "调用群组服务 gRPC 失败",
+			logger.ErrorField("error", err),
+			logger.Int("business_code", code),
+			logger.String("business_message", consts.GetMessage(code)),
+			logger.Duration("duration", time.Since(startTime)),
+		)
+
+		// 返回业务错误（作为 Go error 返回，由 Handler 层处理）
+		return nil, err
+	}
+
+	return &dto.DismissGroupResponse{}, nil
+}
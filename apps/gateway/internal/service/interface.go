@@ -58,3 +58,300 @@ type AuthService interface {
 	// 返回: 校验验证码响应
 	VerifyCode(ctx context.Context, req *dto.VerifyCodeRequest) (*dto.VerifyCodeResponse, error)
 }
+
+// MessageService 消息服务接口
+// 职责：
+//   - 调用下游消息服务拉取历史消息
+//   - 按序号补拉缺失的消息
+//   - 撤回消息
+type MessageService interface {
+	// PullHistory 拉取历史消息
+	// ctx: 请求上下文
+	// req: 拉取历史消息请求
+	// 返回: 历史消息（按 seq 升序）
+	PullHistory(ctx context.Context, req *dto.PullHistoryRequest) (*dto.PullHistoryResponse, error)
+
+	// GetMissingMessages 按序号补拉消息
+	// ctx: 请求上下文
+	// req: 补拉请求
+	// 返回: 补拉到的消息与不存在的序号
+	GetMissingMessages(ctx context.Context, req *dto.GetMissingMessagesRequest) (*dto.GetMissingMessagesResponse, error)
+
+	// RevokeMessage 撤回消息
+	// ctx: 请求上下文
+	// req: 撤回请求
+	// 返回: 撤回结果
+	RevokeMessage(ctx context.Context, req *dto.RevokeMessageRequest) (*dto.RevokeMessageResponse, error)
+
+	// MarkRead 上报已读
+	// ctx: 请求上下文
+	// req: 已读上报请求
+	// 返回: 生效后的已读序号
+	MarkRead(ctx context.Context, req *dto.MarkReadRequest) (*dto.MarkReadResponse, error)
+
+	// GetMessageReadCount 查询消息已读人数
+	// ctx: 请求上下文
+	// req: 查询请求
+	// 返回: 已读人数与应读人数
+	GetMessageReadCount(ctx context.Context, req *dto.GetMessageReadCountRequest) (*dto.GetMessageReadCountResponse, error)
+
+	// GetUnreadMentions 查询未读@消息
+	// ctx: 请求上下文
+	// req: 查询请求
+	// 返回: 未读的@消息（按 seq 升序）
+	GetUnreadMentions(ctx context.Context, req *dto.GetUnreadMentionsRequest) (*dto.GetUnreadMentionsResponse, error)
+
+	// ForwardMessages 转发消息
+	// ctx: 请求上下文
+	// req: 转发请求
+	// 返回: 各目标会话的转发结果
+	ForwardMessages(ctx context.Context, req *dto.ForwardMessagesRequest) (*dto.ForwardMessagesResponse, error)
+
+	// AddReaction 添加表情回应
+	// ctx: 请求上下文
+	// req: 添加表情回应请求
+	// 返回: 是否新增及该表情当前回应人数
+	AddReaction(ctx context.Context, req *dto.AddReactionRequest) (*dto.AddReactionResponse, error)
+
+	// RemoveReaction 取消表情回应
+	// ctx: 请求上下文
+	// req: 取消表情回应请求
+	// 返回: 是否删除及该表情当前回应人数
+	RemoveReaction(ctx context.Context, req *dto.RemoveReactionRequest) (*dto.RemoveReactionResponse, error)
+
+	// GetReactionUsers 查询表情回应用户
+	// ctx: 请求上下文
+	// req: 查询表情回应用户请求
+	// 返回: 回应用户列表及总人数
+	GetReactionUsers(ctx context.Context, req *dto.GetReactionUsersRequest) (*dto.GetReactionUsersResponse, error)
+
+	// EditMessage 编辑消息
+	// ctx: 请求上下文
+	// req: 编辑消息请求
+	// 返回: 编辑后的版本号与编辑通知序号
+	EditMessage(ctx context.Context, req *dto.EditMessageRequest) (*dto.EditMessageResponse, error)
+
+	// PinMessage 置顶消息
+	// ctx: 请求上下文
+	// req: 置顶消息请求
+	// 返回: 是否新置顶及置顶通知序号
+	PinMessage(ctx context.Context, req *dto.PinMessageRequest) (*dto.PinMessageResponse, error)
+
+	// UnpinMessage 取消置顶消息
+	// ctx: 请求上下文
+	// req: 取消置顶消息请求
+	// 返回: 是否取消及取消置顶通知序号
+	UnpinMessage(ctx context.Context, req *dto.UnpinMessageRequest) (*dto.UnpinMessageResponse, error)
+
+	// ListPinnedMessages 查询置顶消息
+	// ctx: 请求上下文
+	// req: 查询置顶消息请求
+	// 返回: 置顶消息列表
+	ListPinnedMessages(ctx context.Context, req *dto.ListPinnedMessagesRequest) (*dto.ListPinnedMessagesResponse, error)
+
+	// ScheduleMessage 预约定时消息
+	// ctx: 请求上下文
+	// req: 预约定时消息请求
+	// 返回: 定时消息
+	ScheduleMessage(ctx context.Context, req *dto.ScheduleMessageRequest) (*dto.ScheduleMessageResponse, error)
+
+	// ListScheduledMessages 查询定时消息
+	// ctx: 请求上下文
+	// req: 查询定时消息请求
+	// 返回: 待发送与发送失败的定时消息列表及总数
+	ListScheduledMessages(ctx context.Context, req *dto.ListScheduledMessagesRequest) (*dto.ListScheduledMessagesResponse, error)
+
+	// CancelScheduledMessage 取消定时消息
+	// ctx: 请求上下文
+	// req: 取消定时消息请求
+	// 返回: 取消定时消息响应
+	CancelScheduledMessage(ctx context.Context, req *dto.CancelScheduledMessageRequest) (*dto.CancelScheduledMessageResponse, error)
+
+	// SetDisappearingTimer 设置会话定时销毁
+	// ctx: 请求上下文
+	// req: 设置会话定时销毁请求
+	// 返回: 生效的存活时间及设置变更通知序号
+	SetDisappearingTimer(ctx context.Context, req *dto.SetDisappearingTimerRequest) (*dto.SetDisappearingTimerResponse, error)
+
+	// GetDisappearingTimer 查询会话定时销毁设置
+	// ctx: 请求上下文
+	// req: 查询会话定时销毁请求
+	// 返回: 当前存活时间与最后修改人
+	GetDisappearingTimer(ctx context.Context, req *dto.GetDisappearingTimerRequest) (*dto.GetDisappearingTimerResponse, error)
+}
+
+// ConversationService 会话服务接口
+// 职责：处理会话列表与会话设置相关的业务逻辑
+type ConversationService interface {
+	// GetConversationList 获取会话列表
+	// ctx: 请求上下文
+	// req: 会话列表请求
+	// 返回: 会话列表（置顶在前，其余按更新时间倒序）
+	GetConversationList(ctx context.Context, req *dto.GetConversationListRequest) (*dto.GetConversationListResponse, error)
+
+	// SyncConversations 增量同步会话
+	// ctx: 请求上下文
+	// req: 增量同步请求
+	// 返回: 版本号之后的会话变更
+	SyncConversations(ctx context.Context, req *dto.SyncConversationsRequest) (*dto.SyncConversationsResponse, error)
+
+	// SetConversationPin 置顶/取消置顶会话
+	// ctx: 请求上下文
+	// req: 置顶请求
+	// 返回: 置顶响应
+	SetConversationPin(ctx context.Context, req *dto.SetConversationPinRequest) (*dto.SetConversationPinResponse, error)
+
+	// SetConversationMute 开启/关闭会话免打扰
+	// ctx: 请求上下文
+	// req: 免打扰请求
+	// 返回: 免打扰响应
+	SetConversationMute(ctx context.Context, req *dto.SetConversationMuteRequest) (*dto.SetConversationMuteResponse, error)
+
+	// DeleteConversation 删除会话
+	// ctx: 请求上下文
+	// req: 删除会话请求
+	// 返回: 删除会话响应
+	DeleteConversation(ctx context.Context, req *dto.DeleteConversationRequest) (*dto.DeleteConversationResponse, error)
+}
+
+// GroupService 群组服务接口
+// 职责：建群、群资料、群成员列表、邀请成员、邀请链接、申请入群与审核、管理员与踢人、转让群主、禁言、群公告、退群、解散群
+type GroupService interface {
+	// CreateGroup 创建群组
+	// ctx: 请求上下文
+	// req: 创建群组请求
+	// 返回: 创建后的群资料
+	CreateGroup(ctx context.Context, req *dto.CreateGroupRequest) (*dto.CreateGroupResponse, error)
+
+	// GetGroupInfo 获取群资料
+	// ctx: 请求上下文
+	// req: 群资料请求
+	// 返回: 群资料
+	GetGroupInfo(ctx context.Context, req *dto.GetGroupInfoRequest) (*dto.GetGroupInfoResponse, error)
+
+	// GetGroupMembers 获取群成员列表
+	// ctx: 请求上下文
+	// req: 群成员列表请求
+	// 返回: 群成员列表（群主、管理员在前）
+	GetGroupMembers(ctx context.Context, req *dto.GetGroupMembersRequest) (*dto.GetGroupMembersResponse, error)
+
+	// InviteMembers 邀请成员入群
+	// ctx: 请求上下文
+	// req: 邀请成员请求
+	// 返回: 新加入与跳过的成员
+	InviteMembers(ctx context.Context, req *dto.InviteMembersRequest) (*dto.InviteMembersResponse, error)
+
+	// JoinGroup 申请入群
+	// ctx: 请求上下文
+	// req: 申请入群请求
+	// 返回: 是否已直接入群及申请ID
+	JoinGroup(ctx context.Context, req *dto.JoinGroupRequest) (*dto.JoinGroupResponse, error)
+
+	// GetGroupApplyList 获取入群申请列表
+	// ctx: 请求上下文
+	// req: 入群申请列表请求
+	// 返回: 入群申请列表（按申请时间倒序）
+	GetGroupApplyList(ctx context.Context, req *dto.GetGroupApplyListRequest) (*dto.GetGroupApplyListResponse, error)
+
+	// HandleGroupApply 处理入群申请
+	// ctx: 请求上下文
+	// req: 处理入群申请请求
+	// 返回: 处理入群申请响应
+	HandleGroupApply(ctx context.Context, req *dto.HandleGroupApplyRequest) (*dto.HandleGroupApplyResponse, error)
+
+	// SetAdmin 设置管理员
+	// ctx: 请求上下文
+	// req: 设置管理员请求
+	// 返回: 设置管理员响应
+	SetAdmin(ctx context.Context, req *dto.SetAdminRequest) (*dto.SetAdminResponse, error)
+
+	// UnsetAdmin 取消管理员
+	// ctx: 请求上下文
+	// req: 取消管理员请求
+	// 返回: 取消管理员响应
+	UnsetAdmin(ctx context.Context, req *dto.UnsetAdminRequest) (*dto.UnsetAdminResponse, error)
+
+	// KickMember 踢出群成员
+	// ctx: 请求上下文
+	// req: 踢出群成员请求
+	// 返回: 踢出群成员响应
+	KickMember(ctx context.Context, req *dto.KickMemberRequest) (*dto.KickMemberResponse, error)
+
+	// TransferOwnership 转让群主
+	// ctx: 请求上下文
+	// req: 转让群主请求
+	// 返回: 转让群主响应
+	TransferOwnership(ctx context.Context, req *dto.TransferOwnershipRequest) (*dto.TransferOwnershipResponse, error)
+
+	// MuteMember 禁言成员
+	// ctx: 请求上下文
+	// req: 禁言成员请求
+	// 返回: 禁言成员响应
+	MuteMember(ctx context.Context, req *dto.MuteMemberRequest) (*dto.MuteMemberResponse, error)
+
+	// SetGroupMuteAll 设置全员禁言
+	// ctx: 请求上下文
+	// req: 全员禁言请求
+	// 返回: 全员禁言响应
+	SetGroupMuteAll(ctx context.Context, req *dto.SetGroupMuteAllRequest) (*dto.SetGroupMuteAllResponse, error)
+
+	// PostGroupAnnouncement 发布群公告
+	// ctx: 请求上下文
+	// req: 发布群公告请求
+	// 返回: 发布群公告响应
+	PostGroupAnnouncement(ctx context.Context, req *dto.PostGroupAnnouncementRequest) (*dto.PostGroupAnnouncementResponse, error)
+
+	// GetGroupAnnouncements 获取群公告历史
+	// ctx: 请求上下文
+	// req: 获取群公告历史请求
+	// 返回: 获取群公告历史响应
+	GetGroupAnnouncements(ctx context.Context, req *dto.GetGroupAnnouncementsRequest) (*dto.GetGroupAnnouncementsResponse, error)
+
+	// PinGroupAnnouncement 置顶群公告
+	// ctx: 请求上下文
+	// req: 置顶群公告请求
+	// 返回: 置顶群公告响应
+	PinGroupAnnouncement(ctx context.Context, req *dto.PinGroupAnnouncementRequest) (*dto.PinGroupAnnouncementResponse, error)
+
+	// AckGroupAnnouncement 确认群公告
+	// ctx: 请求上下文
+	// req: 确认群公告请求
+	// 返回: 确认群公告响应
+	AckGroupAnnouncement(ctx context.Context, req *dto.AckGroupAnnouncementRequest) (*dto.AckGroupAnnouncementResponse, error)
+
+	// CreateGroupInvite 创建群邀请
+	// ctx: 请求上下文
+	// req: 创建群邀请请求
+	// 返回: 创建群邀请响应
+	CreateGroupInvite(ctx context.Context, req *dto.CreateGroupInviteRequest) (*dto.CreateGroupInviteResponse, error)
+
+	// GetGroupInviteList 获取群邀请列表
+	// ctx: 请求上下文
+	// req: 获取群邀请列表请求
+	// 返回: 获取群邀请列表响应
+	GetGroupInviteList(ctx context.Context, req *dto.GetGroupInviteListRequest) (*dto.GetGroupInviteListResponse, error)
+
+	// RevokeGroupInvite 撤销群邀请
+	// ctx: 请求上下文
+	// req: 撤销群邀请请求
+	// 返回: 撤销群邀请响应
+	RevokeGroupInvite(ctx context.Context, req *dto.RevokeGroupInviteRequest) (*dto.RevokeGroupInviteResponse, error)
+
+	// JoinByInviteToken 通过邀请入群
+	// ctx: 请求上下文
+	// req: 通过邀请入群请求
+	// 返回: 通过邀请入群响应
+	JoinByInviteToken(ctx context.Context, req *dto.JoinByInviteTokenRequest) (*dto.JoinByInviteTokenResponse, error)
+
+	// QuitGroup 退出群组
+	// ctx: 请求上下文
+	// req: 退群请求
+	// 返回: 退群响应
+	QuitGroup(ctx context.Context, req *dto.QuitGroupRequest) (*dto.QuitGroupResponse, error)
+
+	// DismissGroup 解散群组
+	// ctx: 请求上下文
+	// req: 解散群组请求
+	// 返回: 解散群组响应
+	DismissGroup(ctx context.Context, req *dto.DismissGroupRequest) (*dto.DismissGroupResponse, error)
+}
@@ -0,0 +1,594 @@
+package service
+
+import (
+	"ChatServer/apps/gateway/internal/dto"
+	"ChatServer/apps/gateway/internal/pb"
+	"ChatServer/apps/gateway/internal/utils"
+	"ChatServer/consts"
+	"ChatServer/pkg/logger"
+	"context"
+	"time"
+)
+
+// MessageServiceImpl 消息服务实现
+type MessageServiceImpl struct {
+	msgClient pb.MsgServiceClient
+}
+
+// NewMessageService 创建消息服务实例
+// msgClient: 消息服务 gRPC 客户端
+func NewMessageService(msgClient pb.MsgServiceClient) MessageService {
+	return &MessageServiceImpl{
+		msgClient: msgClient,
+	}
+}
+
+// PullHistory 拉取历史消息
+// ctx: 请求上下文
+// req: 拉取历史消息请求
+// 返回: 历史消息（按 seq 升序）
+func (s *MessageServiceImpl) PullHistory(ctx context.Context, req *dto.PullHistoryRequest) (*dto.PullHistoryResponse, error) {
+	startTime := time.Now()
+
+	// 1. 转换 DTO 为 Protobuf 请求
+	grpcReq := dto.ConvertToProtoPullMessagesRequest(req)
+
+	// 2. 调用消息服务拉取历史消息(gRPC)
+	grpcResp, err := s.msgClient.PullMessages(ctx, grpcReq)
+	if err != nil {
+		// gRPC 调用失败，提取业务错误码
+		code := utils.ExtractErrorCode(err)
+		// 记录错误日志
+		logger.Error(ctx, "调用消息服务 gRPC 失败",
+			logger.ErrorField("error", err),
+			logger.Int("business_code", code),
+			logger.String("business_message", consts.GetMessage(code)),
+			logger.Duration("duration", time.Since(startTime)),
+		)
+
+		// 返回业务错误（作为 Go error 返回，由 Handler 层处理）
+		return nil, err
+	}
+
+	return dto.ConvertPullHistoryResponseFromProto(grpcResp), nil
+}
+
+// GetMissingMessages 按序号补拉消息
+// ctx: 请求上下文
+// req: 补拉请求
+// 返回: 补拉到的消息与不存在的序号
+func (s *MessageServiceImpl) GetMissingMessages(ctx context.Context, req *dto.GetMissingMessagesRequest) (*dto.GetMissingMessagesResponse, error) {
+	startTime := time.Now()
+
+	// 1. 转换 DTO 为 Protobuf 请求
+	grpcReq := dto.ConvertToProtoGetMessagesBySeqsRequest(req)
+
+	// 2. 调用消息服务按序号拉取(gRPC)
+	grpcResp, err := s.msgClient.GetMessagesBySeqs(ctx, grpcReq)
+	if err != nil {
+		// gRPC 调用失败，提取业务错误码
+		code := utils.ExtractErrorCode(err)
+		// 记录错误日志
+		logger.Error(ctx, "调用消息服务 gRPC 失败",
+			logger.ErrorField("error", err),
+			logger.Int("business_code", code),
+			logger.String("business_message", consts.GetMessage(code)),
+			logger.Duration("duration", time.Since(startTime)),
+		)
+
+		// 返回业务错误（作为 Go error 返回，由 Handler 层处理）
+		return nil, err
+	}
+
+	return dto.ConvertGetMissingMessagesResponseFromProto(grpcResp), nil
+}
+
+// RevokeMessage 撤回消息
+// ctx: 请求上下文
+// req: 撤回请求
+// 返回: 撤回结果
+func (s *MessageServiceImpl) RevokeMessage(ctx context.Context, req *dto.RevokeMessageRequest) (*dto.RevokeMessageResponse, error) {
+	startTime := time.Now()
+
+	// 1. 转换 DTO 为 Protobuf 请求
+	grpcReq := dto.ConvertToProtoRevokeMessageRequest(req)
+
+	// 2. 调用消息服务撤回消息(gRPC)
+	grpcResp, err := s.msgClient.RevokeMessage(ctx, grpcReq)
+	if err != nil {
+		// gRPC 调用失败，提取业务错误码
+		code := utils.ExtractErrorCode(err)
+		// 记录错误日志
+		logger.Error(ctx, "调用消息服务 gRPC 失败",
+			logger.ErrorField("error", err),
+			logger.Int("business_code", code),
+			logger.String("business_message", consts.GetMessage(code)),
+			logger.Duration("duration", time.Since(startTime)),
+		)
+
+		// 返回业务错误（作为 Go error 返回，由 Handler 层处理）
+		return nil, err
+	}
+
+	return dto.ConvertRevokeMessageResponseFromProto(grpcResp), nil
+}
+
+// MarkRead 上报已读
+// ctx: 请求上下文
+// req: 已读上报请求
+// 返回: 生效后的已读序号
+func (s *MessageServiceImpl) MarkRead(ctx context.Context, req *dto.MarkReadRequest) (*dto.MarkReadResponse, error) {
+	startTime := time.Now()
+
+	// 1. 转换 DTO 为 Protobuf 请求
+	grpcReq := dto.ConvertToProtoMarkReadRequest(req)
+
+	// 2. 调用消息服务上报已读(gRPC)
+	grpcResp, err := s.msgClient.MarkRead(ctx, grpcReq)
+	if err != nil {
+		// gRPC 调用失败，提取业务错误码
+		code := utils.ExtractErrorCode(err)
+		// 记录错误日志
+		logger.Error(ctx, "调用消息服务 gRPC 失败",
+			logger.ErrorField("error", err),
+			logger.Int("business_code", code),
+			logger.String("business_message", consts.GetMessage(code)),
+			logger.Duration("duration", time.Since(startTime)),
+		)
+
+		// 返回业务错误（作为 Go error 返回，由 Handler 层处理）
+		return nil, err
+	}
+
+	return dto.ConvertMarkReadResponseFromProto(grpcResp), nil
+}
+
+// GetMessageReadCount 查询消息已读人数
+// ctx: 请求上下文
+// req: 查询请求
+// 返回: 已读人数与应读人数
+func (s *MessageServiceImpl) GetMessageReadCount(ctx context.Context, req *dto.GetMessageReadCountRequest) (*dto.GetMessageReadCountResponse, error) {
+	startTime := time.Now()
+
+	// 1. 转换 DTO 为 Protobuf 请求
+	grpcReq := dto.ConvertToProtoGetMessageReadCountRequest(req)
+
+	// 2. 调用消息服务查询已读人数(gRPC)
+	grpcResp, err := s.msgClient.GetMessageReadCount(ctx, grpcReq)
+	if err != nil {
+		// gRPC 调用失败，提取业务错误码
+		code := utils.ExtractErrorCode(err)
+		// 记录错误日志
+		logger.Error(ctx, "调用消息服务 gRPC 失败",
+			logger.ErrorField("error", err),
+			logger.Int("business_code", code),
+			logger.String("business_message", consts.GetMessage(code)),
+			logger.Duration("duration", time.Since(startTime)),
+		)
+
+		// 返回业务错误（作为 Go error 返回，由 Handler 层处理）
+		return nil, err
+	}
+
+	return dto.ConvertGetMessageReadCountResponseFromProto(grpcResp), nil
+}
+
+// GetUnreadMentions 查询未读@消息
+// ctx: 请求上下文
+// req: 查询请求
+// 返回: 未读的@消息（按 seq 升序）
+func (s *MessageServiceImpl) GetUnreadMentions(ctx context.Context, req *dto.GetUnreadMentionsRequest) (*dto.GetUnreadMentionsResponse, error) {
+	startTime := time.Now()
+
+	// 1. 转换 DTO 为 Protobuf 请求
+	grpcReq := dto.ConvertToProtoGetUnreadMentionsRequest(req)
+
+	// 2. 调用消息服务查询未读@消息(gRPC)
+	grpcResp, err := s.msgClient.GetUnreadMentions(ctx, grpcReq)
+	if err != nil {
+		// gRPC 调用失败，提取业务错误码
+		code := utils.ExtractErrorCode(err)
+		// 记录错误日志
+		logger.Error(ctx, "调用消息服务 gRPC 失败",
+			logger.ErrorField("error", err),
+			logger.Int("business_code", code),
+			logger.String("business_message", consts.GetMessage(code)),
+			logger.Duration("duration", time.Since(startTime)),
+		)
+
+		// 返回业务错误（作为 Go error 返回，由 Handler 层处理）
+		return nil, err
+	}
+
+	return dto.ConvertGetUnreadMentionsResponseFromProto(grpcResp), nil
+}
+
+// ForwardMessages 转发消息
+// ctx: 请求上下文
+// req: 转发请求
+// 返回: 各目标会话的转发结果
+func (s *MessageServiceImpl) ForwardMessages(ctx context.Context, req *dto.ForwardMessagesRequest) (*dto.ForwardMessagesResponse, error) {
+	startTime := time.Now()
+
+	// 1. 转换 DTO 为 Protobuf 请求
+	grpcReq := dto.ConvertToProtoForwardMessagesRequest(req)
+
+	// 2. 调用消息服务转发消息(gRPC)
+	grpcResp, err := s.msgClient.ForwardMessages(ctx, grpcReq)
+	if err != nil {
+		// gRPC 调用失败，提取业务错误码
+		code := utils.ExtractErrorCode(err)
+		// 记录错误日志
+		logger.Error(ctx, "调用消息服务 gRPC 失败",
+			logger.ErrorField("error", err),
+			logger.Int("business_code", code),
+			logger.String("business_message", consts.GetMessage(code)),
+			logger.Duration("duration", time.Since(startTime)),
+		)
+
+		// 返回业务错误（作为 Go error 返回，由 Handler 层处理）
+		return nil, err
+	}
+
+	return dto.ConvertForwardMessagesResponseFromProto(grpcResp), nil
+}
+
+// AddReaction 添加表情回应
+// ctx: 请求上下文
+// req: 添加表情回应请求
+// 返回: 是否新增及该表情当前回应人数
+func (s *MessageServiceImpl) AddReaction(ctx context.Context, req *dto.AddReactionRequest) (*dto.AddReactionResponse, error) {
+	startTime := time.Now()
+
+	// 1. 转换 DTO 为 Protobuf 请求
+	grpcReq := dto.ConvertToProtoAddReactionRequest(req)
+
+	// 2. 调用消息服务添加表情回应(gRPC)
+	grpcResp, err := s.msgClient.AddReaction(ctx, grpcReq)
+	if err != nil {
+		// gRPC 调用失败，提取业务错误码
+		code := utils.ExtractErrorCode(err)
+		// 记录错误日志
+		logger.Error(ctx, "调用消息服务 gRPC 失败",
+			logger.ErrorField("error", err),
+			logger.Int("business_code", code),
+			logger.String("business_message", consts.GetMessage(code)),
+			logger.Duration("duration", time.Since(startTime)),
+		)
+
+		// 返回业务错误（作为 Go error 返回，由 Handler 层处理）
+		return nil, err
+	}
+
+	return dto.ConvertAddReactionResponseFromProto(grpcResp), nil
+}
+
+// RemoveReaction 取消表情回应
+// ctx: 请求上下文
+// req: 取消表情回应请求
+// 返回: 是否删除及该表情当前回应人数
+func (s *MessageServiceImpl) RemoveReaction(ctx context.Context, req *dto.RemoveReactionRequest) (*dto.RemoveReactionResponse, error) {
+	startTime := time.Now()
+
+	// 1. 转换 DTO 为 Protobuf 请求
+	grpcReq := dto.ConvertToProtoRemoveReactionRequest(req)
+
+	// 2. 调用消息服务取消表情回应(gRPC)
+	grpcResp, err := s.msgClient.RemoveReaction(ctx, grpcReq)
+	if err != nil {
+		// gRPC 调用失败，提取业务错误码
+		code := utils.ExtractErrorCode(err)
+		// 记录错误日志
+		logger.Error(ctx, "调用消息服务 gRPC 失败",
+			logger.ErrorField("error", err),
+			logger.Int("business_code", code),
+			logger.String("business_message", consts.GetMessage(code)),
+			logger.Duration("duration", time.Since(startTime)),
+		)
+
+		// 返回业务错误（作为 Go error 返回，由 Handler 层处理）
+		return nil, err
+	}
+
+	return dto.ConvertRemoveReactionResponseFromProto(grpcResp), nil
+}
+
+// GetReactionUsers 查询表情回应用户
+// ctx: 请求上下文
+// req: 查询表情回应用户请求
+// 返回: 回应用户列表及总人数
+func (s *MessageServiceImpl) GetReactionUsers(ctx context.Context, req *dto.GetReactionUsersRequest) (*dto.GetReactionUsersResponse, error) {
+	startTime := time.Now()
+
+	// 1. 转换 DTO 为 Protobuf 请求
+	grpcReq := dto.ConvertToProtoGetReactionUsersRequest(req)
+
+	// 2. 调用消息服务查询表情回应用户(gRPC)
+	grpcResp, err := s.msgClient.GetReactionUsers(ctx, grpcReq)
+	if err != nil {
+		// gRPC 调用失败，提取业务错误码
+		code := utils.ExtractErrorCode(err)
+		// 记录错误日志
+		logger.Error(ctx, "调用消息服务 gRPC 失败",
+			logger.ErrorField("error", err),
+			logger.Int("business_code", code),
+			logger.String("business_message", consts.GetMessage(code)),
+			logger.Duration("duration", time.Since(startTime)),
+		)
+
+		// 返回业务错误（作为 Go error 返回，由 Handler 层处理）
+		return nil, err
+	}
+
+	return dto.ConvertGetReactionUsersResponseFromProto(grpcResp), nil
+}
+
+// EditMessage 编辑消息
+// ctx: 请求上下文
+// req: 编辑消息请求
+// 返回: 编辑后的版本号与编辑通知序号
+func (s *MessageServiceImpl) EditMessage(ctx context.Context, req *dto.EditMessageRequest) (*dto.EditMessageResponse, error) {
+	startTime := time.Now()
+
+	// 1. 转换 DTO 为 Protobuf 请求
+	grpcReq := dto.ConvertToProtoEditMessageRequest(req)
+
+	// 2. 调用消息服务编辑消息(gRPC)
+	grpcResp, err := s.msgClient.EditMessage(ctx, grpcReq)
+	if err != nil {
+		// gRPC 调用失败，提取业务错误码
+		code := utils.ExtractErrorCode(err)
+		// 记录错误日志
+		logger.Error(ctx, "调用消息服务 gRPC 失败",
+			logger.ErrorField("error", err),
+			logger.Int("business_code", code),
+			logger.String("business_message", consts.GetMessage(code)),
+			logger.Duration("duration", time.Since(startTime)),
+		)
+
+		// 返回业务错误（作为 Go error 返回，由 Handler 层处理）
+		return nil, err
+	}
+
+	return dto.ConvertEditMessageResponseFromProto(grpcResp), nil
+}
+
+// PinMessage 置顶消息
+// ctx: 请求上下文
+// req: 置顶消息请求
+// 返回: 是否新置顶及置顶通知序号
+func (s *MessageServiceImpl) PinMessage(ctx context.Context, req *dto.PinMessageRequest) (*dto.PinMessageResponse, error) {
+	startTime := time.Now()
+
+	// 1. 转换 DTO 为 Protobuf 请求
+	grpcReq := dto.ConvertToProtoPinMessageRequest(req)
+
+	// 2. 调用消息服务置顶消息(gRPC)
+	grpcResp, err := s.msgClient.PinMessage(ctx, grpcReq)
+	if err != nil {
+		// gRPC 调用失败，提取业务错误码
+		code := utils.ExtractErrorCode(err)
+		// 记录错误日志
+		logger.Error(ctx, "调用消息服务 gRPC 失败",
+			logger.ErrorField("error", err),
+			logger.Int("business_code", code),
+			logger.String("business_message", consts.GetMessage(code)),
+			logger.Duration("duration", time.Since(startTime)),
+		)
+
+		// 返回业务错误（作为 Go error 返回，由 Handler 层处理）
+		return nil, err
+	}
+
+	return dto.ConvertPinMessageResponseFromProto(grpcResp), nil
+}
+
+// UnpinMessage 取消置顶消息
+// ctx: 请求上下文
+// req: 取消置顶消息请求
+// 返回: 是否取消及取消置顶通知序号
+func (s *MessageServiceImpl) UnpinMessage(ctx context.Context, req *dto.UnpinMessageRequest) (*dto.UnpinMessageResponse, error) {
+	startTime := time.Now()
+
+	// 1. 转换 DTO 为 Protobuf 请求
+	grpcReq := dto.ConvertToProtoUnpinMessageRequest(req)
+
+	// 2. 调用消息服务取消置顶消息(gRPC)
+	grpcResp, err := s.msgClient.UnpinMessage(ctx, grpcReq)
+	if err != nil {
+		// gRPC 调用失败，提取业务错误码
+		code := utils.ExtractErrorCode(err)
+		// 记录错误日志
+		logger.Error(ctx, "调用消息服务 gRPC 失败",
+			logger.ErrorField("error", err),
+			logger.Int("business_code", code),
+			logger.String("business_message", consts.GetMessage(code)),
+			logger.Duration("duration", time.Since(startTime)),
+		)
+
+		// 返回业务错误（作为 Go error 返回，由 Handler 层处理）
+		return nil, err
+	}
+
+	return dto.ConvertUnpinMessageResponseFromProto(grpcResp), nil
+}
+
+// ListPinnedMessages 查询置顶消息
+// ctx: 请求上下文
+// req: 查询置顶消息请求
+// 返回: 置顶消息列表
+func (s *MessageServiceImpl) ListPinnedMessages(ctx context.Context, req *dto.ListPinnedMessagesRequest) (*dto.ListPinnedMessagesResponse, error) {
+	startTime := time.Now()
+
+	// 1. 转换 DTO 为 Protobuf 请求
+	grpcReq := dto.ConvertToProtoListPinnedMessagesRequest(req)
+
+	// 2. 调用消息服务查询置顶消息(gRPC)
+	grpcResp, err := s.msgClient.ListPinnedMessages(ctx, grpcReq)
+	if err != nil {
+		// gRPC 调用失败，提取业务错误码
+		code := utils.ExtractErrorCode(err)
+		// 记录错误日志
+		logger.Error(ctx, "调用消息服务 gRPC 失败",
+			logger.ErrorField("error", err),
+			logger.Int("business_code", code),
+			logger.String("business_message", consts.GetMessage(code)),
+			logger.Duration("duration", time.Since(startTime)),
+		)
+
+		// 返回业务错误（作为 Go error 返回，由 Handler 层处理）
+		return nil, err
+	}
+
+	return dto.ConvertListPinnedMessagesResponseFromProto(grpcResp), nil
+}
+
+// ScheduleMessage 预约定时消息
+// ctx: 请求上下文
+// req: 预约定时消息请求
+// 返回: 定时消息
+func (s *MessageServiceImpl) ScheduleMessage(ctx context.Context, req *dto.ScheduleMessageRequest) (*dto.ScheduleMessageResponse, error) {
+	startTime := time.Now()
+
+	// 1. 转换 DTO 为 Protobuf 请求
+	grpcReq := dto.ConvertToProtoScheduleMessageRequest(req)
+
+	// 2. 调用消息服务预约定时消息(gRPC)
+	grpcResp, err := s.msgClient.ScheduleMessage(ctx, grpcReq)
+	if err != nil {
+		// gRPC 调用失败，提取业务错误码
+		code := utils.ExtractErrorCode(err)
+		// 记录错误日志
+		logger.Error(ctx, "调用消息服务 gRPC 失败",
+			logger.ErrorField("error", err),
+			logger.Int("business_code", code),
+			logger.String("business_message", consts.GetMessage(code)),
+			logger.Duration("duration", time.Since(startTime)),
+		)
+
+		// 返回业务错误（作为 Go error 返回，由 Handler 层处理）
+		return nil, err
+	}
+
+	return dto.ConvertScheduleMessageResponseFromProto(grpcResp), nil
+}
+
+// ListScheduledMessages 查询定时消息
+// ctx: 请求上下文
+// req: 查询定时消息请求
+// 返回: 待发送与发送失败的定时消息列表及总数
+func (s *MessageServiceImpl) ListScheduledMessages(ctx context.Context, req *dto.ListScheduledMessagesRequest) (*dto.ListScheduledMessagesResponse, error) {
+	startTime := time.Now()
+
+	// 1. 转换 DTO 为 Protobuf 请求
+	grpcReq := dto.ConvertToProtoListScheduledMessagesRequest(req)
+
+	// 2. 调用消息服务查询定时消息(gRPC)
+	grpcResp, err := s.msgClient.ListScheduledMessages(ctx, grpcReq)
+	if err != nil {
+		// gRPC 调用失败，提取业务错误码
+		code := utils.ExtractErrorCode(err)
+		// 记录错误日志
+		logger.Error(ctx, "调用消息服务 gRPC 失败",
+			logger.ErrorField("error", err),
+			logger.Int("business_code", code),
+			logger.String("business_message", consts.GetMessage(code)),
+			logger.Duration("duration", time.Since(startTime)),
+		)
+
+		// 返回业务错误（作为 Go error 返回，由 Handler 层处理）
+		return nil, err
+	}
+
+	return dto.ConvertListScheduledMessagesResponseFromProto(grpcResp), nil
+}
+
+// CancelScheduledMessage 取消定时消息
+// ctx: 请求上下文
+// req: 取消定时消息请求
+// 返回: 取消定时消息响应
+func (s *MessageServiceImpl) CancelScheduledMessage(ctx context.Context, req *dto.CancelScheduledMessageRequest) (*dto.CancelScheduledMessageResponse, error) {
+	startTime := time.Now()
+
+	// 1. 转换 DTO 为 Protobuf 请求
+	grpcReq := dto.ConvertToProtoCancelScheduledMessageRequest(req)
+
+	// 2. 调用消息服务取消定时消息(gRPC)
+	_, err := s.msgClient.CancelScheduledMessage(ctx, grpcReq)
+	if err != nil {
+		// gRPC 调用失败，提取业务错误码
+		code := utils.ExtractErrorCode(err)
+		// 记录错误日志
+		logger.Error(ctx, "调用消息服务 gRPC 失败",
+			logger.ErrorField("error", err),
+			logger.Int("business_code", code),
+			logger.String("business_message", consts.GetMessage(code)),
+			logger.Duration("duration", time.Since(startTime)),
+		)
+
+		// 返回业务错误（作为 Go error 返回，由 Handler 层处理）
+		return nil, err
+	}
+
+	return &dto.CancelScheduledMessageResponse{}, nil
+}
+
+// SetDisappearingTimer 设置会话定时销毁
+// ctx: 请求上下文
+// req: 设置会话定时销毁请求
+// 返回: 生效的存活时间及设置变更通知序号
+func (s *MessageServiceImpl) SetDisappearingTimer(ctx context.Context, req *dto.SetDisappearingTimerRequest) (*dto.SetDisappearingTimerResponse, error) {
+	startTime := time.Now()
+
+	// 1. 转换 DTO 为 Protobuf 请求
+	grpcReq := dto.ConvertToProtoSetDisappearingTimerRequest(req)
+
+	// 2. 调用消息服务设置会话定时销毁(gRPC)
+	grpcResp, err := s.msgClient.SetDisappearingTimer(ctx, grpcReq)
+	if err != nil {
+		// gRPC 调用失败，提取业务错误码
+		code := utils.ExtractErrorCode(err)
+		// 记录错误日志
+		logger.Error(ctx, "调用消息服务 gRPC 失败",
+			logger.ErrorField("error", err),
+			logger.Int("business_code", code),
+			logger.String("business_message", consts.GetMessage(code)),
+			logger.Duration("duration", time.Since(startTime)),
+		)
+
+		// 返回业务错误（作为 Go error 返回，由 Handler 层处理）
+		return nil, err
+	}
+
+	return dto.ConvertSetDisappearingTimerResponseFromProto(grpcResp), nil
+}
+
+// GetDisappearingTimer 查询会话定时销毁设置
+// ctx: 请求上下文
+// req: 查询会话定时销毁设置请求
+// 返回: 当前存活时间与最后修改人
+func (s *MessageServiceImpl) GetDisappearingTimer(ctx context.Context, req *dto.GetDisappearingTimerRequest) (*dto.GetDisappearingTimerResponse, error) {
+	startTime := time.Now()
+
+	// 1. 转换 DTO 为 Protobuf 请求
+	grpcReq := dto.ConvertToProtoGetDisappearingTimerRequest(req)
+
+	// 2. 调用消息服务查询会话定时销毁设置(gRPC)
+	grpcResp, err := s.msgClient.GetDisappearingTimer(ctx, grpcReq)
+	if err != nil {
+		// gRPC 调用失败，提取业务错误码
+		code := utils.ExtractErrorCode(err)
+		// 记录错误日志
+		logger.Error(ctx, "调用消息服务 gRPC 失败",
+			logger.ErrorField("error", err),
+			logger.Int("business_code", code),
+			logger.String("business_message", consts.GetMessage(code)),
+			logger.Duration("duration", time.Since(startTime)),
+		)
+
+		// 返回业务错误（作为 Go error 返回，由 Handler 层处理）
+		return nil, err
+	}
+
+	return dto.ConvertGetDisappearingTimerResponseFromProto(grpcResp), nil
+}
@@ -1,11 +1,14 @@
 package utils
 
 import (
+	"strconv"
+
 	"google.golang.org/grpc/status"
 	"ChatServer/consts"
 )
 
 // 从grpc错误中提取业务错误码
+// 下游服务以 status.Error(code, strconv.Itoa(业务码)) 返回错误，业务码放在 message 中
 func ExtractErrorCode(err error) int {
 	if err == nil {
 		return 0
@@ -14,5 +17,8 @@ func ExtractErrorCode(err error) int {
 	if !ok {
 		return consts.CodeInternalError
 	}
-	return int(st.Code())
-}
\ No newline at end of file
+	if code, convErr := strconv.Atoi(st.Message()); convErr == nil {
+		return code
+	}
+	return consts.CodeInternalError
+}
@@ -0,0 +1,140 @@
+package main
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"time"
+
+	"ChatServer/apps/connect/nodeclient"
+	"ChatServer/apps/msg/internal/handler"
+	"ChatServer/apps/msg/internal/interceptors"
+	"ChatServer/apps/msg/internal/repository"
+	"ChatServer/apps/msg/internal/server"
+	"ChatServer/apps/msg/internal/service"
+	msgpb "ChatServer/apps/msg/pb"
+	"ChatServer/config"
+	"ChatServer/pkg/logger"
+	"ChatServer/pkg/mysql"
+	pkgredis "ChatServer/pkg/redis"
+	"ChatServer/pkg/registry"
+	"ChatServer/pkg/util"
+
+	"google.golang.org/grpc"
+	healthgrpc "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+func main() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// 1. 初始化日志
+	logCfg := config.DefaultLoggerConfig()
+	zl, err := logger.Build(logCfg)
+	if err != nil {
+		log.Fatalf("初始化日志失败: %v", err)
+	}
+	logger.ReplaceGlobal(zl)
+	defer zl.Sync()
+
+	// 2. 初始化MySQL
+	dbCfg := config.DefaultMySQLConfig()
+	db, err := mysql.Build(dbCfg)
+	if err != nil {
+		log.Fatalf("初始化MySQL失败: %v", err)
+	}
+	mysql.ReplaceGlobal(db)
+
+	// 3. 初始化Redis
+	redisCfg := config.DefaultRedisConfig()
+	redisCfg.ReadTimeout = 50 * time.Millisecond
+	redisCfg.WriteTimeout = 50 * time.Millisecond
+
+	redisClient, err := pkgredis.Build(redisCfg)
+	if err != nil {
+		// 会话序号由 Redis 分配，Redis 不可用时无法发送消息
+		log.Fatalf("初始化Redis失败: %v", err)
+	}
+	pkgredis.ReplaceGlobal(redisClient)
+	logger.Info(ctx, "Redis 初始化成功",
+		logger.String("addr", redisCfg.Addr),
+	)
+
+	// 4. 组装依赖 - Repository 层
+	messageRepo := repository.NewMessageRepository(db, redisClient)
+	seqRepo := repository.NewSeqRepository(db, redisClient)
+	conversationRepo := repository.NewConversationRepository(db, redisClient)
+	relationRepo := repository.NewRelationRepository(db, redisClient)
+	groupRepo := repository.NewGroupRepository(db, redisClient)
+	timelineRepo := repository.NewTimelineRepository(db, redisClient)
+	scheduleRepo := repository.NewScheduleRepository(db, redisClient)
+
+	// 连接路由（实时下发时定位 Connect 节点）
+	connectClient := nodeclient.New(registry.New(redisClient, registry.DefaultTTL))
+	defer connectClient.Close()
+
+	// 5. 组装依赖 - Service 层
+	msgCfg := config.DefaultMessageConfig()
+	messageService := service.NewMessageService(msgCfg, messageRepo, seqRepo, conversationRepo, relationRepo, groupRepo, timelineRepo, scheduleRepo, connectClient)
+	conversationService := service.NewConversationService(conversationRepo, timelineRepo)
+
+	// 6. 组装依赖 - Handler 层
+	messageHandler := handler.NewMessageHandler(messageService)
+	conversationHandler := handler.NewConversationHandler(conversationService)
+
+	// 7. 初始化小组件
+	util.InitSnowflake(2) // 雪花算法（与 User 服务使用不同的机器ID）
+
+	// 8. 启动定时消息发送任务（依赖雪花算法生成消息ID，须在其初始化之后启动）与过期消息清理任务
+	scheduleWorker := service.NewScheduleWorker(service.DefaultScheduleWorkerOptions(), scheduleRepo, messageService)
+	go scheduleWorker.Run(ctx)
+	expireWorker := service.NewExpireWorker(service.DefaultExpireWorkerOptions(), messageService)
+	go expireWorker.Run(ctx)
+
+	// 9. 启动 Metrics HTTP Server（暴露 Prometheus 指标）
+	metricsMux := http.NewServeMux()
+	metricsMux.Handle("/metrics", interceptors.GetMetricsHandler())
+
+	metricsAddr := ":9094"
+	metricsServer := &http.Server{
+		Addr:    metricsAddr,
+		Handler: metricsMux,
+	}
+
+	go func() {
+		logger.Info(ctx, "Metrics HTTP Server 启动中", logger.String("address", metricsAddr))
+		if err := metricsServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			logger.Error(ctx, "Metrics HTTP Server 启动失败", logger.ErrorField("error", err))
+		}
+	}()
+
+	// 10. 启动 gRPC Server（阻塞直到停机）
+	opts := server.Options{
+		Address:          ":9093",
+		EnableHealth:     true,
+		EnableReflection: true, // 生产环境建议关闭
+	}
+
+	logger.Info(ctx, "Msg 服务启动中",
+		logger.String("grpc_address", opts.Address),
+		logger.String("metrics_address", metricsAddr),
+	)
+
+	if err := server.Start(ctx, opts, func(s *grpc.Server, hs healthgrpc.HealthServer) {
+		// 注册消息服务
+		msgpb.RegisterMessageServiceServer(s, messageHandler)
+		// 注册会话服务
+		msgpb.RegisterConversationServiceServer(s, conversationHandler)
+
+		// 设置健康检查状态
+		if hs != nil {
+			if setter, ok := hs.(interface {
+				SetServingStatus(service string, status healthgrpc.HealthCheckResponse_ServingStatus)
+			}); ok {
+				setter.SetServingStatus("", healthgrpc.HealthCheckResponse_SERVING)
+			}
+		}
+	}); err != nil {
+		log.Fatalf("启动gRPC服务失败: %v", err)
+	}
+}
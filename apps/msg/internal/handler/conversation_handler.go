@@ -0,0 +1,51 @@
+package handler
+
+import (
+	"ChatServer/apps/msg/internal/service"
+	pb "ChatServer/apps/msg/pb"
+	"context"
+)
+
+// ConversationHandler 会话服务Handler
+type ConversationHandler struct {
+	pb.UnimplementedConversationServiceServer
+
+	conversationService service.IConversationService
+}
+
+// NewConversationHandler 创建会话Handler实例
+func NewConversationHandler(conversationService service.IConversationService) *ConversationHandler {
+	return &ConversationHandler{
+		conversationService: conversationService,
+	}
+}
+
+// GetConversationList 获取会话列表
+func (h *ConversationHandler) GetConversationList(ctx context.Context, req *pb.GetConversationListRequest) (*pb.GetConversationListResponse, error) {
+	return h.conversationService.GetConversationList(ctx, req)
+}
+
+// SyncConversations 增量同步会话
+func (h *ConversationHandler) SyncConversations(ctx context.Context, req *pb.SyncConversationsRequest) (*pb.SyncConversationsResponse, error) {
+	return h.conversationService.SyncConversations(ctx, req)
+}
+
+// SetConversationPin 置顶/取消置顶会话
+func (h *ConversationHandler) SetConversationPin(ctx context.Context, req *pb.SetConversationPinRequest) (*pb.SetConversationPinResponse, error) {
+	return h.conversationService.SetConversationPin(ctx, req)
+}
+
+// SetConversationMute 开启/关闭会话免打扰
+func (h *ConversationHandler) SetConversationMute(ctx context.Context, req *pb.SetConversationMuteRequest) (*pb.SetConversationMuteResponse, error) {
+	return h.conversationService.SetConversationMute(ctx, req)
+}
+
+// DeleteConversation 删除会话
+func (h *ConversationHandler) DeleteConversation(ctx context.Context, req *pb.DeleteConversationRequest) (*pb.DeleteConversationResponse, error) {
+	return h.conversationService.DeleteConversation(ctx, req)
+}
+
+// JoinGroupConversations 为新入群成员创建或恢复群会话
+func (h *ConversationHandler) JoinGroupConversations(ctx context.Context, req *pb.JoinGroupConversationsRequest) (*pb.JoinGroupConversationsResponse, error) {
+	return h.conversationService.JoinGroupConversations(ctx, req)
+}
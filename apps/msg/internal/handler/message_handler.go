@@ -0,0 +1,126 @@
+package handler
+
+import (
+	"ChatServer/apps/msg/internal/service"
+	pb "ChatServer/apps/msg/pb"
+	"context"
+)
+
+// MessageHandler 消息服务Handler
+type MessageHandler struct {
+	pb.UnimplementedMessageServiceServer
+
+	messageService service.IMessageService
+}
+
+// NewMessageHandler 创建消息Handler实例
+func NewMessageHandler(messageService service.IMessageService) *MessageHandler {
+	return &MessageHandler{
+		messageService: messageService,
+	}
+}
+
+// SendMessage 发送消息
+func (h *MessageHandler) SendMessage(ctx context.Context, req *pb.SendMessageRequest) (*pb.SendMessageResponse, error) {
+	return h.messageService.SendMessage(ctx, req)
+}
+
+// PullMessages 拉取历史消息
+func (h *MessageHandler) PullMessages(ctx context.Context, req *pb.PullMessagesRequest) (*pb.PullMessagesResponse, error) {
+	return h.messageService.PullMessages(ctx, req)
+}
+
+// GetMessagesBySeqs 按序号拉取消息
+func (h *MessageHandler) GetMessagesBySeqs(ctx context.Context, req *pb.GetMessagesBySeqsRequest) (*pb.GetMessagesBySeqsResponse, error) {
+	return h.messageService.GetMessagesBySeqs(ctx, req)
+}
+
+// SendSystemMessage 写入系统控制消息（内部接口）
+func (h *MessageHandler) SendSystemMessage(ctx context.Context, req *pb.SendSystemMessageRequest) (*pb.SendMessageResponse, error) {
+	return h.messageService.SendSystemMessage(ctx, req)
+}
+
+// RevokeMessage 撤回消息
+func (h *MessageHandler) RevokeMessage(ctx context.Context, req *pb.RevokeMessageRequest) (*pb.RevokeMessageResponse, error) {
+	return h.messageService.RevokeMessage(ctx, req)
+}
+
+// MarkRead 上报已读
+func (h *MessageHandler) MarkRead(ctx context.Context, req *pb.MarkReadRequest) (*pb.MarkReadResponse, error) {
+	return h.messageService.MarkRead(ctx, req)
+}
+
+// GetMessageReadCount 查询消息已读人数
+func (h *MessageHandler) GetMessageReadCount(ctx context.Context, req *pb.GetMessageReadCountRequest) (*pb.GetMessageReadCountResponse, error) {
+	return h.messageService.GetMessageReadCount(ctx, req)
+}
+
+// GetUnreadMentions 查询会话内未读的@消息
+func (h *MessageHandler) GetUnreadMentions(ctx context.Context, req *pb.GetUnreadMentionsRequest) (*pb.GetUnreadMentionsResponse, error) {
+	return h.messageService.GetUnreadMentions(ctx, req)
+}
+
+// ForwardMessages 转发消息
+func (h *MessageHandler) ForwardMessages(ctx context.Context, req *pb.ForwardMessagesRequest) (*pb.ForwardMessagesResponse, error) {
+	return h.messageService.ForwardMessages(ctx, req)
+}
+
+// AddReaction 添加表情回应
+func (h *MessageHandler) AddReaction(ctx context.Context, req *pb.AddReactionRequest) (*pb.AddReactionResponse, error) {
+	return h.messageService.AddReaction(ctx, req)
+}
+
+// RemoveReaction 取消表情回应
+func (h *MessageHandler) RemoveReaction(ctx context.Context, req *pb.RemoveReactionRequest) (*pb.RemoveReactionResponse, error) {
+	return h.messageService.RemoveReaction(ctx, req)
+}
+
+// GetReactionUsers 分页查询回应了某个表情的用户
+func (h *MessageHandler) GetReactionUsers(ctx context.Context, req *pb.GetReactionUsersRequest) (*pb.GetReactionUsersResponse, error) {
+	return h.messageService.GetReactionUsers(ctx, req)
+}
+
+// EditMessage 编辑消息
+func (h *MessageHandler) EditMessage(ctx context.Context, req *pb.EditMessageRequest) (*pb.EditMessageResponse, error) {
+	return h.messageService.EditMessage(ctx, req)
+}
+
+// PinMessage 置顶消息
+func (h *MessageHandler) PinMessage(ctx context.Context, req *pb.PinMessageRequest) (*pb.PinMessageResponse, error) {
+	return h.messageService.PinMessage(ctx, req)
+}
+
+// UnpinMessage 取消置顶消息
+func (h *MessageHandler) UnpinMessage(ctx context.Context, req *pb.UnpinMessageRequest) (*pb.UnpinMessageResponse, error) {
+	return h.messageService.UnpinMessage(ctx, req)
+}
+
+// ListPinnedMessages 查询会话内的置顶消息
+func (h *MessageHandler) ListPinnedMessages(ctx context.Context, req *pb.ListPinnedMessagesRequest) (*pb.ListPinnedMessagesResponse, error) {
+	return h.messageService.ListPinnedMessages(ctx, req)
+}
+
+// ScheduleMessage 预约定时消息
+func (h *MessageHandler) ScheduleMessage(ctx context.Context, req *pb.ScheduleMessageRequest) (*pb.ScheduleMessageResponse, error) {
+	return h.messageService.ScheduleMessage(ctx, req)
+}
+
+// ListScheduledMessages 查询自己的定时消息
+func (h *MessageHandler) ListScheduledMessages(ctx context.Context, req *pb.ListScheduledMessagesRequest) (*pb.ListScheduledMessagesResponse, error) {
+	return h.messageService.ListScheduledMessages(ctx, req)
+}
+
+// CancelScheduledMessage 取消定时消息
+func (h *MessageHandler) CancelScheduledMessage(ctx context.Context, req *pb.CancelScheduledMessageRequest) (*pb.CancelScheduledMessageResponse, error) {
+	return h.messageService.CancelScheduledMessage(ctx, req)
+}
+
+// SetDisappearingTimer 设置会话定时销毁
+func (h *MessageHandler) SetDisappearingTimer(ctx context.Context, req *pb.SetDisappearingTimerRequest) (*pb.SetDisappearingTimerResponse, error) {
+	return h.messageService.SetDisappearingTimer(ctx, req)
+}
+
+// GetDisappearingTimer 查询会话定时销毁设置
+func (h *MessageHandler) GetDisappearingTimer(ctx context.Context, req *pb.GetDisappearingTimerRequest) (*pb.GetDisappearingTimerResponse, error) {
+	return h.messageService.GetDisappearingTimer(ctx, req)
+}
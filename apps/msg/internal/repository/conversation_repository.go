@@ -0,0 +1,423 @@
+package repository
+
+import (
+	"ChatServer/consts"
+	"ChatServer/model"
+	"context"
+	"errors"
+	"sort"
+
+	"github.com/redis/go-redis/v9"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// upsertBatchSize 批量 upsert 会话时单条 SQL 的最大行数
+const upsertBatchSize = 500
+
+// 会话版本号（version）
+// 会话的任何字段变更都写入新的版本号，客户端按 version 游标增量同步。
+// 版本号取自用户维度的计数器（conversation_version），在会话变更的同一事务内加一：
+// 计数器行锁使同一用户的变更按版本号顺序提交，已同步到 version N 的客户端不会再遇到晚提交的小于 N 的版本。
+// 同一用户的版本号不重复，按 version 分页时不会出现同值截断。
+
+// ownerVersionExpr 按会话归属用户取计数器当前值，须在同一事务内先调用 bumpVersions
+var ownerVersionExpr = gorm.Expr("(SELECT conversation_version.version FROM conversation_version WHERE conversation_version.owner_uuid = conversation.owner_uuid)")
+
+// conversationRepositoryImpl 会话数据访问层实现
+type conversationRepositoryImpl struct {
+	db          *gorm.DB
+	redisClient *redis.Client
+}
+
+// NewConversationRepository 创建会话仓储实例
+func NewConversationRepository(db *gorm.DB, redisClient *redis.Client) IConversationRepository {
+	return &conversationRepositoryImpl{db: db, redisClient: redisClient}
+}
+
+// UpdatePreviewByLastMsg 更新最后一条消息为 msgID 的会话预览（单聊两条、群聊每成员一条）
+// 使用 UpdateColumns 不刷新 updated_at：撤回、过期等改写预览不是新的会话活动，不应改变会话列表顺序
+func (r *conversationRepositoryImpl) UpdatePreviewByLastMsg(ctx context.Context, convID, msgID, preview string) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var owners []string
+		err := tx.Model(&model.Conversation{}).
+			Where("conv_id = ? AND last_msg_id = ?", convID, msgID).
+			Pluck("owner_uuid", &owners).Error
+		if err != nil || len(owners) == 0 {
+			return err
+		}
+		if _, err := bumpVersions(tx, owners); err != nil {
+			return err
+		}
+		return tx.Model(&model.Conversation{}).
+			Where("conv_id = ? AND last_msg_id = ? AND owner_uuid IN ?", convID, msgID, owners).
+			UpdateColumns(map[string]interface{}{
+				"last_msg_preview": preview,
+				"version":          ownerVersionExpr,
+			}).Error
+	})
+	if err != nil {
+		return WrapDBError(err)
+	}
+	return nil
+}
+
+// MarkRead 推进已读游标并清零未读数
+// 基于唯一索引 (owner_uuid, target_uuid) 做 upsert，read_seq/read_bubble 取 GREATEST 保证多设备乱序上报时只增不减
+func (r *conversationRepositoryImpl) MarkRead(ctx context.Context, conv *model.Conversation) (int64, error) {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		versions, err := bumpVersions(tx, []string{conv.OwnerUuid})
+		if err != nil {
+			return err
+		}
+		conv.Version = versions[conv.OwnerUuid]
+		if conv.Type == 1 {
+			if conv.ReadBubble, err = readBubbleTx(tx, conv.ConvId, conv.ReadSeq); err != nil {
+				return err
+			}
+		}
+		return tx.Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "owner_uuid"}, {Name: "target_uuid"}},
+			DoUpdates: clause.Assignments(map[string]interface{}{
+				"read_seq":     gorm.Expr("GREATEST(read_seq, VALUES(read_seq))"),
+				"read_bubble":  gorm.Expr("GREATEST(read_bubble, VALUES(read_bubble))"),
+				"unread_count": 0,
+				"version":      gorm.Expr("VALUES(version)"),
+			}),
+		}).Create(conv).Error
+	})
+	if err != nil {
+		return 0, WrapDBError(err)
+	}
+	return r.GetReadSeq(ctx, conv.OwnerUuid, conv.TargetUuid)
+}
+
+// GetReadSeq 查询已读游标
+func (r *conversationRepositoryImpl) GetReadSeq(ctx context.Context, ownerUUID, targetUUID string) (int64, error) {
+	var conv model.Conversation
+	err := r.db.WithContext(ctx).
+		Select("read_seq").
+		Where("owner_uuid = ? AND target_uuid = ?", ownerUUID, targetUUID).
+		First(&conv).Error
+	if err != nil {
+		return 0, WrapDBError(err)
+	}
+	return conv.ReadSeq, nil
+}
+
+// CountGroupReaders 统计群内已读到 seq 的正常成员数（走 idx_conv_read 索引，关联成员表过滤已退群成员）
+func (r *conversationRepositoryImpl) CountGroupReaders(ctx context.Context, groupUUID string, seq int64, excludeUUID string) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&model.Conversation{}).
+		Joins("JOIN group_member ON group_member.group_uuid = conversation.conv_id AND group_member.user_uuid = conversation.owner_uuid AND group_member.status = ? AND group_member.deleted_at IS NULL", 0).
+		Where("conversation.conv_id = ? AND conversation.read_seq >= ? AND conversation.owner_uuid <> ?", groupUUID, seq, excludeUUID).
+		Count(&count).Error
+	if err != nil {
+		return 0, WrapDBError(err)
+	}
+	return count, nil
+}
+
+// UpsertLastMsg 批量刷新会话的最后消息
+// 基于唯一索引 (owner_uuid, target_uuid) 做 upsert，未读数在原值基础上累加 VALUES(unread_count)
+func (r *conversationRepositoryImpl) UpsertLastMsg(ctx context.Context, convs []*model.Conversation) error {
+	if len(convs) == 0 {
+		return nil
+	}
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		owners := make([]string, 0, len(convs))
+		for _, conv := range convs {
+			owners = append(owners, conv.OwnerUuid)
+		}
+		versions, err := bumpVersions(tx, owners)
+		if err != nil {
+			return err
+		}
+		for _, conv := range convs {
+			conv.Version = versions[conv.OwnerUuid]
+		}
+		return tx.Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "owner_uuid"}, {Name: "target_uuid"}},
+			DoUpdates: clause.Assignments(map[string]interface{}{
+				"last_msg_id":      gorm.Expr("VALUES(last_msg_id)"),
+				"last_msg_at":      gorm.Expr("VALUES(last_msg_at)"),
+				"last_msg_preview": gorm.Expr("VALUES(last_msg_preview)"),
+				"unread_count":     gorm.Expr("unread_count + VALUES(unread_count)"),
+				"mention_seq":      gorm.Expr("GREATEST(mention_seq, VALUES(mention_seq))"),
+				"status":           0,
+				"version":          gorm.Expr("VALUES(version)"),
+				"updated_at":       gorm.Expr("VALUES(updated_at)"),
+			}),
+		}).CreateInBatches(convs, upsertBatchSize).Error
+	})
+	if err != nil {
+		return WrapDBError(err)
+	}
+	return nil
+}
+
+// JoinGroup 为新入群成员创建或恢复群会话
+// 读扩散群的消息只推进群时间线、不按成员补建会话，入群时建好会话记录，群立即出现在成员的会话列表中；
+// 已读游标与 read_bubble 取时间线当前位置（无时间线时为 0），已有记录时只增不减
+func (r *conversationRepositoryImpl) JoinGroup(ctx context.Context, groupUUID string, ownerUUIDs []string) error {
+	if len(ownerUUIDs) == 0 {
+		return nil
+	}
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		versions, err := bumpVersions(tx, ownerUUIDs)
+		if err != nil {
+			return err
+		}
+		var timeline model.GroupTimeline
+		err = tx.Select("last_seq", "bubble_count").
+			Where("conv_id = ?", groupUUID).
+			Limit(1).
+			Find(&timeline).Error
+		if err != nil {
+			return err
+		}
+
+		convs := make([]*model.Conversation, 0, len(versions))
+		for owner, version := range versions {
+			convs = append(convs, &model.Conversation{
+				ConvId:     groupUUID,
+				Type:       1,
+				OwnerUuid:  owner,
+				TargetUuid: groupUUID,
+				ReadSeq:    timeline.LastSeq,
+				ReadBubble: timeline.BubbleCount,
+				Version:    version,
+			})
+		}
+		return tx.Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "owner_uuid"}, {Name: "target_uuid"}},
+			DoUpdates: clause.Assignments(map[string]interface{}{
+				"read_seq":     gorm.Expr("GREATEST(read_seq, VALUES(read_seq))"),
+				"read_bubble":  gorm.Expr("GREATEST(read_bubble, VALUES(read_bubble))"),
+				"unread_count": 0,
+				"status":       0,
+				"version":      gorm.Expr("VALUES(version)"),
+				"updated_at":   gorm.Expr("VALUES(updated_at)"),
+				"deleted_at":   nil,
+			}),
+		}).CreateInBatches(convs, upsertBatchSize).Error
+	})
+	if err != nil {
+		return WrapDBError(err)
+	}
+	return nil
+}
+
+// ListByOwner 分页查询用户的正常会话（走 idx_owner_status_update 索引）
+func (r *conversationRepositoryImpl) ListByOwner(ctx context.Context, ownerUUID string, page, pageSize int) ([]*model.Conversation, int64, error) {
+	query := r.db.WithContext(ctx).Model(&model.Conversation{}).
+		Where("owner_uuid = ? AND status = ?", ownerUUID, 0).
+		Session(&gorm.Session{})
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, WrapDBError(err)
+	}
+	if total == 0 {
+		return []*model.Conversation{}, 0, nil
+	}
+
+	var convs []*model.Conversation
+	err := query.
+		Order("pin DESC").
+		Order("updated_at DESC").
+		Offset((page - 1) * pageSize).
+		Limit(pageSize).
+		Find(&convs).Error
+	if err != nil {
+		return nil, 0, WrapDBError(err)
+	}
+	return convs, total, nil
+}
+
+// GetByOwnerAndConv 查询用户在某会话下的会话记录
+func (r *conversationRepositoryImpl) GetByOwnerAndConv(ctx context.Context, ownerUUID, convID string) (*model.Conversation, error) {
+	var conv model.Conversation
+	err := r.db.WithContext(ctx).
+		Where("owner_uuid = ? AND conv_id = ?", ownerUUID, convID).
+		First(&conv).Error
+	if err != nil {
+		return nil, WrapDBError(err)
+	}
+	return &conv, nil
+}
+
+// UpdateSettings 更新用户会话的设置字段
+// 使用 UpdateColumns 不刷新 updated_at：会话列表按 updated_at 排序，设置变更不应视为新的会话活动
+func (r *conversationRepositoryImpl) UpdateSettings(ctx context.Context, ownerUUID, convID string, updates map[string]interface{}) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		versions, err := bumpVersions(tx, []string{ownerUUID})
+		if err != nil {
+			return err
+		}
+		fields := make(map[string]interface{}, len(updates)+1)
+		for k, v := range updates {
+			fields[k] = v
+		}
+		fields["version"] = versions[ownerUUID]
+		return tx.Model(&model.Conversation{}).
+			Where("owner_uuid = ? AND conv_id = ?", ownerUUID, convID).
+			UpdateColumns(fields).Error
+	})
+	if err != nil {
+		return WrapDBError(err)
+	}
+	return nil
+}
+
+// Hide 从用户的会话列表移除会话
+func (r *conversationRepositoryImpl) Hide(ctx context.Context, ownerUUID, convID string) error {
+	return r.UpdateSettings(ctx, ownerUUID, convID, map[string]interface{}{
+		"status":       1,
+		"unread_count": 0,
+		"pin":          false,
+	})
+}
+
+// ListChangedSince 查询用户 version 之后变更的会话（含已删除），按 version 升序（走 idx_owner_version 索引）
+func (r *conversationRepositoryImpl) ListChangedSince(ctx context.Context, ownerUUID string, version int64, limit int) ([]*model.Conversation, error) {
+	var convs []*model.Conversation
+	err := r.db.WithContext(ctx).
+		Where("owner_uuid = ? AND version > ?", ownerUUID, version).
+		Order("version ASC").
+		Limit(limit).
+		Find(&convs).Error
+	if err != nil {
+		return nil, WrapDBError(err)
+	}
+	return convs, nil
+}
+
+// MaxVersion 查询用户会话的最大版本号（即版本计数器的当前值），无会话时返回 0
+func (r *conversationRepositoryImpl) MaxVersion(ctx context.Context, ownerUUID string) (int64, error) {
+	var version int64
+	err := r.db.WithContext(ctx).Model(&model.ConversationVersion{}).
+		Select("COALESCE(MAX(version), 0)").
+		Where("owner_uuid = ?", ownerUUID).
+		Scan(&version).Error
+	if err != nil {
+		return 0, WrapDBError(err)
+	}
+	return version, nil
+}
+
+// ListTimelineChangedSince 查询群时间线 version 之后有变更的用户群会话（关联 group_timeline，按用户的群会话数线性扫描）
+func (r *conversationRepositoryImpl) ListTimelineChangedSince(ctx context.Context, ownerUUID string, version int64) ([]*model.Conversation, int64, error) {
+	var rows []*timelineChange
+	err := r.db.WithContext(ctx).Model(&model.Conversation{}).
+		Select("conversation.*, group_timeline.version AS timeline_version").
+		Joins("JOIN group_timeline ON group_timeline.conv_id = conversation.conv_id").
+		Where("conversation.owner_uuid = ? AND conversation.type = ? AND conversation.status = ? AND group_timeline.version > ?",
+			ownerUUID, 1, 0, version).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, 0, WrapDBError(err)
+	}
+
+	convs := make([]*model.Conversation, 0, len(rows))
+	var maxVersion int64
+	for _, row := range rows {
+		conv := row.Conversation
+		convs = append(convs, &conv)
+		if row.TimelineVersion > maxVersion {
+			maxVersion = row.TimelineVersion
+		}
+	}
+	return convs, maxVersion, nil
+}
+
+// timelineChange 群会话及其时间线版本号
+type timelineChange struct {
+	model.Conversation
+	TimelineVersion int64 `gorm:"column:timeline_version"`
+}
+
+// MarkMentioned 推进群成员会话的 mention_seq（只增不减）
+func (r *conversationRepositoryImpl) MarkMentioned(ctx context.Context, convID string, ownerUUIDs []string, seq int64) error {
+	if len(ownerUUIDs) == 0 {
+		return nil
+	}
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if _, err := bumpVersions(tx, ownerUUIDs); err != nil {
+			return err
+		}
+		return tx.Model(&model.Conversation{}).
+			Where("conv_id = ? AND owner_uuid IN ? AND mention_seq < ?", convID, ownerUUIDs, seq).
+			Updates(map[string]interface{}{
+				"mention_seq": seq,
+				"version":     ownerVersionExpr,
+			}).Error
+	})
+	if err != nil {
+		return WrapDBError(err)
+	}
+	return nil
+}
+
+// bumpVersions 在事务内将用户的会话版本计数器加一，返回各用户的新版本号
+// 计数器行锁持有到事务提交；用户按 uuid 排序后加锁，多用户的批量变更并发时不会互相死锁
+func bumpVersions(tx *gorm.DB, ownerUUIDs []string) (map[string]int64, error) {
+	owners := make([]string, 0, len(ownerUUIDs))
+	seen := make(map[string]struct{}, len(ownerUUIDs))
+	for _, owner := range ownerUUIDs {
+		if _, ok := seen[owner]; ok {
+			continue
+		}
+		seen[owner] = struct{}{}
+		owners = append(owners, owner)
+	}
+	sort.Strings(owners)
+
+	rows := make([]*model.ConversationVersion, 0, len(owners))
+	for _, owner := range owners {
+		rows = append(rows, &model.ConversationVersion{OwnerUuid: owner, Version: 1})
+	}
+	err := tx.Clauses(clause.OnConflict{
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"version": gorm.Expr("version + 1"),
+		}),
+	}).CreateInBatches(rows, upsertBatchSize).Error
+	if err != nil {
+		return nil, err
+	}
+
+	var current []*model.ConversationVersion
+	if err := tx.Where("owner_uuid IN ?", owners).Find(&current).Error; err != nil {
+		return nil, err
+	}
+	versions := make(map[string]int64, len(current))
+	for _, row := range current {
+		versions[row.OwnerUuid] = row.Version
+	}
+	return versions, nil
+}
+
+// readBubbleTx 计算已读到 readSeq 时对应的群时间线气泡数：时间线气泡数减去 readSeq 之后仍存在的气泡消息数
+// 群没有时间线时返回 0；只扫描已读游标之后的消息，通常为 0 条
+func readBubbleTx(tx *gorm.DB, convID string, readSeq int64) (int64, error) {
+	var timeline model.GroupTimeline
+	err := tx.Select("bubble_count").Where("conv_id = ?", convID).Take(&timeline).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	var unread int64
+	err = tx.Model(&model.Message{}).
+		Where("conv_id = ? AND seq > ? AND msg_type < ?", convID, readSeq, consts.MsgTypeControlBase).
+		Count(&unread).Error
+	if err != nil {
+		return 0, err
+	}
+	if unread >= timeline.BubbleCount {
+		return 0, nil
+	}
+	return timeline.BubbleCount - unread, nil
+}
@@ -0,0 +1,46 @@
+package repository
+
+import (
+	"ChatServer/model"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/glebarez/sqlite"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// newTestDB 基于内存 SQLite 创建数据库并迁移会话相关表
+func newTestDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{Logger: logger.Discard})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&model.Conversation{}, &model.ConversationVersion{}))
+	return db
+}
+
+// TestUpdatePreviewByLastMsg_KeepsListOrder 测试撤回最后一条消息改写预览时只写入新版本号，不改变会话列表顺序
+func TestUpdatePreviewByLastMsg_KeepsListOrder(t *testing.T) {
+	ctx := context.Background()
+	db := newTestDB(t)
+	repo := NewConversationRepository(db, nil)
+
+	base := time.Now().Add(-time.Hour)
+	convs := []*model.Conversation{
+		{ConvId: "u1_u2", OwnerUuid: "u1", TargetUuid: "u2", LastMsgId: "m1", LastMsgPrev: "hi", UpdatedAt: base},
+		{ConvId: "u1_u3", OwnerUuid: "u1", TargetUuid: "u3", LastMsgId: "m2", LastMsgPrev: "hello", UpdatedAt: base.Add(time.Minute)},
+	}
+	require.NoError(t, db.Create(convs).Error)
+
+	require.NoError(t, repo.UpdatePreviewByLastMsg(ctx, "u1_u2", "m1", "[消息已撤回]"))
+
+	list, total, err := repo.ListByOwner(ctx, "u1", 1, 10)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), total)
+	assert.Equal(t, []string{"u1_u3", "u1_u2"}, []string{list[0].ConvId, list[1].ConvId})
+	assert.Equal(t, "[消息已撤回]", list[1].LastMsgPrev)
+	assert.Equal(t, int64(1), list[1].Version)
+	assert.True(t, list[1].UpdatedAt.Equal(base), "改写预览不应刷新 updated_at")
+}
@@ -0,0 +1,106 @@
+package repository
+
+import (
+	"ChatServer/model"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"gorm.io/gorm"
+)
+
+// memberCountCacheTTL 群人数缓存时长
+// 只用于选择扩散模式，短时过期即可：群人数跨过阈值后的短时间内两种模式都能正确投递
+const memberCountCacheTTL = time.Minute
+
+// groupRepositoryImpl 群组只读数据访问层实现
+type groupRepositoryImpl struct {
+	db          *gorm.DB
+	redisClient *redis.Client
+}
+
+// NewGroupRepository 创建群组仓储实例
+func NewGroupRepository(db *gorm.DB, redisClient *redis.Client) IGroupRepository {
+	return &groupRepositoryImpl{db: db, redisClient: redisClient}
+}
+
+// GetGroup 查询群信息
+func (r *groupRepositoryImpl) GetGroup(ctx context.Context, groupUUID string) (*model.GroupInfo, error) {
+	var group model.GroupInfo
+	err := r.db.WithContext(ctx).
+		Where("uuid = ?", groupUUID).
+		First(&group).Error
+	if err != nil {
+		return nil, WrapDBError(err)
+	}
+	return &group, nil
+}
+
+// GetMember 查询群成员
+func (r *groupRepositoryImpl) GetMember(ctx context.Context, groupUUID, userUUID string) (*model.GroupMember, error) {
+	var member model.GroupMember
+	err := r.db.WithContext(ctx).
+		Where("group_uuid = ? AND user_uuid = ?", groupUUID, userUUID).
+		First(&member).Error
+	if err != nil {
+		return nil, WrapDBError(err)
+	}
+	return &member, nil
+}
+
+// ListMemberUUIDs 查询群内全部正常成员的 uuid
+func (r *groupRepositoryImpl) ListMemberUUIDs(ctx context.Context, groupUUID string) ([]string, error) {
+	var uuids []string
+	err := r.db.WithContext(ctx).Model(&model.GroupMember{}).
+		Where("group_uuid = ? AND status = ?", groupUUID, 0).
+		Pluck("user_uuid", &uuids).Error
+	if err != nil {
+		return nil, WrapDBError(err)
+	}
+	return uuids, nil
+}
+
+// CountMembers 统计群内正常成员数（不含 excludeUUID）
+func (r *groupRepositoryImpl) CountMembers(ctx context.Context, groupUUID, excludeUUID string) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&model.GroupMember{}).
+		Where("group_uuid = ? AND status = ? AND user_uuid <> ?", groupUUID, 0, excludeUUID).
+		Count(&count).Error
+	if err != nil {
+		return 0, WrapDBError(err)
+	}
+	return count, nil
+}
+
+// GetMemberCount 查询群人数
+// 先读 Redis 缓存，未命中时读取 group_info.member_cnt（单行主键查询，不统计成员表）并写回缓存
+func (r *groupRepositoryImpl) GetMemberCount(ctx context.Context, groupUUID string) (int64, error) {
+	key := r.memberCountKey(groupUUID)
+	count, err := r.redisClient.Get(ctx, key).Int64()
+	if err == nil {
+		return count, nil
+	}
+	if !errors.Is(err, redis.Nil) {
+		return 0, WrapRedisError(err)
+	}
+
+	var group model.GroupInfo
+	err = r.db.WithContext(ctx).
+		Select("member_cnt").
+		Where("uuid = ?", groupUUID).
+		First(&group).Error
+	if err != nil {
+		return 0, WrapDBError(err)
+	}
+	// 写回缓存失败不影响本次结果，下次查询再回源
+	count = int64(group.MemberCnt)
+	r.redisClient.Set(ctx, key, count, memberCountCacheTTL)
+	return count, nil
+}
+
+// memberCountKey 群人数缓存 Key
+func (r *groupRepositoryImpl) memberCountKey(groupUUID string) string {
+	return fmt.Sprintf("msg:group:member_cnt:%s", groupUUID)
+}
@@ -0,0 +1,251 @@
+package repository
+
+import (
+	"ChatServer/model"
+	"context"
+	"time"
+)
+
+// ==================== 消息 Repository ====================
+
+// IMessageRepository 消息数据访问接口
+type IMessageRepository interface {
+	// Create 写入消息（msg.Seq 需预先由 ISeqRepository 分配）
+	// (from_uuid, client_msg_id) 或 (conv_id, seq) 冲突时返回 ErrDuplicateKey
+	Create(ctx context.Context, msg *model.Message) error
+
+	// GetByClientMsgId 按 (发送者, 客户端幂等ID) 查询消息
+	GetByClientMsgId(ctx context.Context, fromUUID, clientMsgID string) (*model.Message, error)
+
+	// ListAfterSeq 查询序号大于 afterSeq 的消息，按 seq 升序，最多 limit 条
+	ListAfterSeq(ctx context.Context, convID string, afterSeq int64, limit int) ([]*model.Message, error)
+
+	// ListBeforeSeq 查询序号小于 beforeSeq 的消息（beforeSeq <= 0 表示不限），按 seq 降序，最多 limit 条
+	ListBeforeSeq(ctx context.Context, convID string, beforeSeq int64, limit int) ([]*model.Message, error)
+
+	// ListBySeqs 按序号列表查询消息，按 seq 升序
+	ListBySeqs(ctx context.Context, convID string, seqs []int64) ([]*model.Message, error)
+
+	// GetByMsgId 按全局消息ID查询消息，不存在（含已过期）返回 ErrRecordNotFound
+	GetByMsgId(ctx context.Context, msgID string) (*model.Message, error)
+
+	// ListByMsgIds 查询会话内指定消息ID的消息（不存在或不属于该会话的ID不返回），按 seq 升序
+	ListByMsgIds(ctx context.Context, convID string, msgIDs []string) ([]*model.Message, error)
+
+	// Revoke 在同一事务中将消息标记为撤回并写入撤回通知（notify.Seq 需预先分配）
+	// 消息不存在或已撤回时返回 ErrRecordNotFound
+	Revoke(ctx context.Context, msgID string, notify *model.Message) error
+
+	// Edit 在同一事务中将消息更新为 revision 的内容与版本号、写入修订记录并写入编辑通知（notify.Seq 需预先分配）
+	// 仅当消息正常且当前版本为 revision.Version-1 时更新，否则返回 ErrRecordNotFound；
+	// original 非 nil 时同时保存原始版本（重复写入忽略）
+	Edit(ctx context.Context, original, revision *model.MessageRevision, notify *model.Message) error
+
+	// Pin 在同一事务中写入置顶记录与置顶通知（notify.Seq 需预先分配）
+	// 消息已置顶时不写入通知并返回 false；会话置顶数已达 maxPins 时返回 ErrLimitExceeded，被置顶消息已不存在时返回 ErrRecordNotFound
+	Pin(ctx context.Context, pin *model.PinnedMessage, maxPins int64, notify *model.Message) (bool, error)
+
+	// Unpin 在同一事务中删除置顶记录并写入取消置顶通知（notify.Seq 需预先分配）
+	// 消息未置顶时不写入通知并返回 false
+	Unpin(ctx context.Context, convID, msgID string, notify *model.Message) (bool, error)
+
+	// ListPins 查询会话内的置顶记录，最近置顶的在前
+	ListPins(ctx context.Context, convID string) ([]*model.PinnedMessage, error)
+
+	// SetDisappearingTimer 在同一事务中更新会话定时销毁设置并写入设置变更通知（notify.Seq 需预先分配）
+	// 设置未发生变化（含从未开启时关闭）时不写入通知并返回 false
+	SetDisappearingTimer(ctx context.Context, timer *model.DisappearingTimer, notify *model.Message) (bool, error)
+
+	// GetDisappearingTimer 查询会话定时销毁设置，未设置过返回 ErrRecordNotFound
+	GetDisappearingTimer(ctx context.Context, convID string) (*model.DisappearingTimer, error)
+
+	// PurgeExpired 物理删除最多 limit 条 expire_at <= now 的消息及其修订、表情回应、@提及、置顶记录，
+	// 返回被删除的消息（仅含 id、conv_id、seq、msg_id、expire_at）
+	PurgeExpired(ctx context.Context, now time.Time, limit int) ([]*model.Message, error)
+
+	// CreateMentions 批量写入消息的 @提及记录（重复写入忽略）
+	CreateMentions(ctx context.Context, mentions []*model.MessageMention) error
+
+	// ListMentionSeqs 查询会话内 seq 大于 afterSeq 的 @userUUID 或 @所有人 的消息序号，按 seq 升序，最多 limit 条
+	ListMentionSeqs(ctx context.Context, convID, userUUID string, afterSeq int64, limit int) ([]int64, error)
+
+	// AddReaction 写入表情回应，(msg_id, user_uuid, emoji) 已存在时忽略，返回是否新增
+	// 新表情需消息已有表情种类数小于 maxKinds，否则返回 ErrLimitExceeded；消息不存在返回 ErrRecordNotFound
+	AddReaction(ctx context.Context, reaction *model.MessageReaction, maxKinds int64) (bool, error)
+
+	// RemoveReaction 删除用户对消息的表情回应，返回是否删除
+	RemoveReaction(ctx context.Context, msgID, userUUID, emoji string) (bool, error)
+
+	// CountReaction 统计消息某个表情的回应人数
+	CountReaction(ctx context.Context, msgID, emoji string) (int64, error)
+
+	// ListReactionCounts 批量聚合消息的表情回应人数，同一消息内按首次回应时间升序
+	ListReactionCounts(ctx context.Context, msgIDs []string) ([]*ReactionCount, error)
+
+	// ListUserReactions 查询用户在这些消息上的表情回应
+	ListUserReactions(ctx context.Context, msgIDs []string, userUUID string) ([]*model.MessageReaction, error)
+
+	// ListReactionUsers 分页查询回应了某个表情的用户 uuid（按回应时间升序），同时返回总人数
+	ListReactionUsers(ctx context.Context, msgID, emoji string, page, pageSize int) ([]string, int64, error)
+}
+
+// ReactionCount 单条消息单个表情的回应人数
+type ReactionCount struct {
+	MsgId string
+	Emoji string
+	Count int64
+}
+
+// ==================== 会话 Repository ====================
+
+// IConversationRepository 会话数据访问接口
+// 所有写操作都会为受影响的会话写入新的 version，供客户端增量同步
+type IConversationRepository interface {
+	// UpdatePreviewByLastMsg 将最后一条消息为 msgID 的会话预览更新为 preview（撤回后刷新会话列表）
+	UpdatePreviewByLastMsg(ctx context.Context, convID, msgID, preview string) error
+
+	// MarkRead 推进 (owner, target) 会话的已读游标（只增不减）并清零未读数，会话不存在时创建
+	// 群会话同时按群时间线推进 read_bubble（时间线气泡数减去已读游标之后的气泡消息数）
+	// 返回生效后的已读游标
+	MarkRead(ctx context.Context, conv *model.Conversation) (int64, error)
+
+	// GetReadSeq 查询 (owner, target) 会话的已读游标，不存在返回 ErrRecordNotFound
+	GetReadSeq(ctx context.Context, ownerUUID, targetUUID string) (int64, error)
+
+	// CountGroupReaders 统计群内已读到 seq 的正常成员数（不含 excludeUUID）
+	CountGroupReaders(ctx context.Context, groupUUID string, seq int64, excludeUUID string) (int64, error)
+
+	// UpsertLastMsg 批量刷新会话的最后消息（每个参与者一条），不存在时创建
+	// 未读数按 conv.UnreadCount 累加（发送者传 0，其他参与者传 1），被删除的会话重新出现
+	// conv.MentionSeq 非 0 表示该参与者被@，mention_seq 只增不减
+	UpsertLastMsg(ctx context.Context, convs []*model.Conversation) error
+
+	// ListByOwner 分页查询用户的正常会话（置顶在前，其余按 updated_at 倒序）
+	ListByOwner(ctx context.Context, ownerUUID string, page, pageSize int) ([]*model.Conversation, int64, error)
+
+	// GetByOwnerAndConv 查询用户在某会话下的会话记录，不存在返回 ErrRecordNotFound
+	GetByOwnerAndConv(ctx context.Context, ownerUUID, convID string) (*model.Conversation, error)
+
+	// UpdateSettings 更新用户会话的设置字段（pin/mute 等）
+	UpdateSettings(ctx context.Context, ownerUUID, convID string, updates map[string]interface{}) error
+
+	// Hide 从用户的会话列表移除会话（status=1，同时清零未读、取消置顶）
+	Hide(ctx context.Context, ownerUUID, convID string) error
+
+	// ListChangedSince 查询用户 version 之后变更的会话（含已删除），按 version 升序
+	ListChangedSince(ctx context.Context, ownerUUID string, version int64, limit int) ([]*model.Conversation, error)
+
+	// MaxVersion 查询用户会话的最大版本号（全量拉取会话列表后作为增量同步的起点）
+	MaxVersion(ctx context.Context, ownerUUID string) (int64, error)
+
+	// ListTimelineChangedSince 查询用户的正常群会话中群时间线 version 之后有变更的会话，返回会话与其中最大的时间线版本号
+	// 读扩散群的新消息只推进时间线，不写成员会话的 version，增量同步时据此补发
+	ListTimelineChangedSince(ctx context.Context, ownerUUID string, version int64) ([]*model.Conversation, int64, error)
+
+	// JoinGroup 为新入群成员创建群会话，曾退群的成员复用原记录（重新显示并清零未读）
+	// 已读位置从群时间线的当前位置开始，入群前的消息不计未读
+	JoinGroup(ctx context.Context, groupUUID string, ownerUUIDs []string) error
+
+	// MarkMentioned 将群内 ownerUUIDs 的会话 mention_seq 推进到 seq（只增不减，读扩散群单独@成员时调用）
+	MarkMentioned(ctx context.Context, convID string, ownerUUIDs []string, seq int64) error
+}
+
+// ==================== 群时间线 Repository ====================
+
+// ITimelineRepository 读扩散群时间线数据访问接口
+// 大群每条消息只推进一行时间线，成员会话的最后消息与未读数在读取时由时间线计算
+type ITimelineRepository interface {
+	// Advance 推进群时间线的最后消息，timeline.LastSeq 不大于已有序号时忽略，时间线不存在时创建
+	// 气泡消息数累加 bubbles（气泡消息传 1，控制类消息传 0），timeline.AtAllSeq 非 0 时同时记录最近一条@所有人消息
+	Advance(ctx context.Context, timeline *model.GroupTimeline, bubbles int64) error
+
+	// BatchGet 批量查询群时间线，返回 conv_id -> 时间线（无时间线的群不在结果中）
+	BatchGet(ctx context.Context, convIDs []string) (map[string]*model.GroupTimeline, error)
+
+	// UpdatePreviewByLastMsg 将最后一条消息为 msgID 的群时间线预览更新为 preview（撤回后刷新会话列表）
+	UpdatePreviewByLastMsg(ctx context.Context, convID, msgID, preview string) error
+
+	// RemoveBubbles 扣减到期销毁的气泡消息（seqs 为被销毁气泡消息的序号），同步修正已读过这些消息的成员的 read_bubble
+	// 群没有时间线时忽略
+	RemoveBubbles(ctx context.Context, convID string, seqs []int64) error
+}
+
+// ==================== 定时消息 Repository ====================
+
+// IScheduleRepository 定时消息数据访问接口
+// 发送任务通过 ClaimDue 认领到期记录（多实例并发认领互不重复），认领到期未完成的记录可被重新认领
+type IScheduleRepository interface {
+	// Create 写入定时消息，(from_uuid, client_msg_id) 冲突时返回 ErrDuplicateKey
+	Create(ctx context.Context, scheduled *model.ScheduledMessage) error
+
+	// GetByClientMsgId 按 (发送者, 客户端幂等ID) 查询定时消息，不存在返回 ErrRecordNotFound
+	GetByClientMsgId(ctx context.Context, fromUUID, clientMsgID string) (*model.ScheduledMessage, error)
+
+	// GetById 查询用户的定时消息，不存在或不属于该用户返回 ErrRecordNotFound
+	GetById(ctx context.Context, id int64, fromUUID string) (*model.ScheduledMessage, error)
+
+	// CountPending 统计用户待发送（含发送中）的定时消息数
+	CountPending(ctx context.Context, fromUUID string) (int64, error)
+
+	// ListByOwner 分页查询用户指定状态的定时消息（按预约发送时间升序），同时返回总数
+	ListByOwner(ctx context.Context, fromUUID string, statuses []int8, page, pageSize int) ([]*model.ScheduledMessage, int64, error)
+
+	// Cancel 将用户待发送或发送失败的定时消息标记为已取消，返回是否取消
+	Cancel(ctx context.Context, id int64, fromUUID string) (bool, error)
+
+	// ClaimDue 认领最多 limit 条到期的待发送记录及认领已过期的发送中记录：
+	// 置为发送中、认领到期时间设为 leaseUntil、尝试次数 +1，返回认领到的记录（按预约发送时间升序）
+	ClaimDue(ctx context.Context, now, leaseUntil time.Time, limit int) ([]*model.ScheduledMessage, error)
+
+	// MarkSent 将发送中的记录标记为已发送并回填消息ID与序号
+	MarkSent(ctx context.Context, id int64, msgID string, seq int64, sentAt time.Time) error
+
+	// MarkFailed 将发送中的记录标记为发送失败并记录业务错误码
+	MarkFailed(ctx context.Context, id int64, failCode int) error
+}
+
+// ==================== 会话序号 Repository ====================
+
+// ISeqRepository 会话内序号分配接口
+// 基于 Redis INCRBY 保证同一会话的序号严格递增、不重复；Redis Key 丢失时从 MySQL max(seq) 恢复。
+type ISeqRepository interface {
+	// Allocate 为会话分配 n 个连续序号，返回区间 [first, last]
+	Allocate(ctx context.Context, convID string, n int64) (first int64, last int64, err error)
+
+	// BatchAllocate 为多个会话各分配一个序号，返回 conv_id -> seq
+	BatchAllocate(ctx context.Context, convIDs []string) (map[string]int64, error)
+
+	// Resync 将序号抬升到不小于 MySQL 中的最大序号（写入发现序号冲突后调用）
+	Resync(ctx context.Context, convID string) error
+
+	// Current 查询会话当前已分配的最大序号（不分配新序号）
+	Current(ctx context.Context, convID string) (int64, error)
+}
+
+// ==================== 关系链 Repository（只读） ====================
+
+// IRelationRepository 用户关系只读访问接口（关系链由 User 服务维护，消息服务只做发送前校验）
+type IRelationRepository interface {
+	// GetRelation 查询 user -> peer 的单向关系，不存在返回 ErrRecordNotFound
+	GetRelation(ctx context.Context, userUUID, peerUUID string) (*model.UserRelation, error)
+}
+
+// ==================== 群组 Repository（只读） ====================
+
+// IGroupRepository 群组只读访问接口（群资料与成员由 User 服务维护，消息服务只做发送前校验）
+type IGroupRepository interface {
+	// GetGroup 查询群信息，不存在返回 ErrRecordNotFound
+	GetGroup(ctx context.Context, groupUUID string) (*model.GroupInfo, error)
+
+	// GetMember 查询群成员，不存在返回 ErrRecordNotFound
+	GetMember(ctx context.Context, groupUUID, userUUID string) (*model.GroupMember, error)
+
+	// ListMemberUUIDs 查询群内全部正常成员的 uuid
+	ListMemberUUIDs(ctx context.Context, groupUUID string) ([]string, error)
+
+	// CountMembers 统计群内正常成员数（不含 excludeUUID）
+	CountMembers(ctx context.Context, groupUUID, excludeUUID string) (int64, error)
+
+	// GetMemberCount 查询群人数（group_info.member_cnt，Redis 短时缓存），用于选择扩散模式
+	GetMemberCount(ctx context.Context, groupUUID string) (int64, error)
+}
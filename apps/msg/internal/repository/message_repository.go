@@ -0,0 +1,531 @@
+package repository
+
+import (
+	"ChatServer/consts"
+	"ChatServer/model"
+	"context"
+	"slices"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// messageRepositoryImpl 消息数据访问层实现
+type messageRepositoryImpl struct {
+	db          *gorm.DB
+	redisClient *redis.Client
+}
+
+// NewMessageRepository 创建消息仓储实例
+func NewMessageRepository(db *gorm.DB, redisClient *redis.Client) IMessageRepository {
+	return &messageRepositoryImpl{db: db, redisClient: redisClient}
+}
+
+// Create 写入消息
+func (r *messageRepositoryImpl) Create(ctx context.Context, msg *model.Message) error {
+	err := r.db.WithContext(ctx).Create(msg).Error
+	if err != nil {
+		return WrapDBError(err)
+	}
+	return nil
+}
+
+// GetByClientMsgId 按 (发送者, 客户端幂等ID) 查询消息
+func (r *messageRepositoryImpl) GetByClientMsgId(ctx context.Context, fromUUID, clientMsgID string) (*model.Message, error) {
+	var msg model.Message
+	err := r.db.WithContext(ctx).
+		Where("from_uuid = ? AND client_msg_id = ?", fromUUID, clientMsgID).
+		First(&msg).Error
+	if err != nil {
+		return nil, WrapDBError(err)
+	}
+	return &msg, nil
+}
+
+// ListAfterSeq 查询序号大于 afterSeq 的消息（走 idx_conv_seq 索引）
+func (r *messageRepositoryImpl) ListAfterSeq(ctx context.Context, convID string, afterSeq int64, limit int) ([]*model.Message, error) {
+	var msgs []*model.Message
+	err := r.db.WithContext(ctx).Scopes(notExpired).
+		Where("conv_id = ? AND seq > ?", convID, afterSeq).
+		Order("seq ASC").
+		Limit(limit).
+		Find(&msgs).Error
+	if err != nil {
+		return nil, WrapDBError(err)
+	}
+	return msgs, nil
+}
+
+// ListBeforeSeq 查询序号小于 beforeSeq 的消息（走 idx_conv_seq 索引）
+func (r *messageRepositoryImpl) ListBeforeSeq(ctx context.Context, convID string, beforeSeq int64, limit int) ([]*model.Message, error) {
+	query := r.db.WithContext(ctx).Scopes(notExpired).Where("conv_id = ?", convID)
+	if beforeSeq > 0 {
+		query = query.Where("seq < ?", beforeSeq)
+	}
+
+	var msgs []*model.Message
+	err := query.Order("seq DESC").Limit(limit).Find(&msgs).Error
+	if err != nil {
+		return nil, WrapDBError(err)
+	}
+	return msgs, nil
+}
+
+// ListBySeqs 按序号列表查询消息
+func (r *messageRepositoryImpl) ListBySeqs(ctx context.Context, convID string, seqs []int64) ([]*model.Message, error) {
+	if len(seqs) == 0 {
+		return []*model.Message{}, nil
+	}
+
+	var msgs []*model.Message
+	err := r.db.WithContext(ctx).Scopes(notExpired).
+		Where("conv_id = ? AND seq IN ?", convID, seqs).
+		Order("seq ASC").
+		Find(&msgs).Error
+	if err != nil {
+		return nil, WrapDBError(err)
+	}
+	return msgs, nil
+}
+
+// GetByMsgId 按全局消息ID查询消息
+func (r *messageRepositoryImpl) GetByMsgId(ctx context.Context, msgID string) (*model.Message, error) {
+	var msg model.Message
+	err := r.db.WithContext(ctx).Scopes(notExpired).
+		Where("msg_id = ?", msgID).
+		First(&msg).Error
+	if err != nil {
+		return nil, WrapDBError(err)
+	}
+	return &msg, nil
+}
+
+// ListByMsgIds 查询会话内指定消息ID的消息
+func (r *messageRepositoryImpl) ListByMsgIds(ctx context.Context, convID string, msgIDs []string) ([]*model.Message, error) {
+	if len(msgIDs) == 0 {
+		return []*model.Message{}, nil
+	}
+
+	var msgs []*model.Message
+	err := r.db.WithContext(ctx).Scopes(notExpired).
+		Where("conv_id = ? AND msg_id IN ?", convID, msgIDs).
+		Order("seq ASC").
+		Find(&msgs).Error
+	if err != nil {
+		return nil, WrapDBError(err)
+	}
+	return msgs, nil
+}
+
+// notExpired 过滤已过期但尚未被清理任务删除的消息
+func notExpired(db *gorm.DB) *gorm.DB {
+	return db.Where("(expire_at IS NULL OR expire_at > ?)", time.Now())
+}
+
+// Revoke 标记撤回并写入撤回通知
+// 撤回与通知同事务提交，避免消息已撤回但离线设备拉不到通知
+func (r *messageRepositoryImpl) Revoke(ctx context.Context, msgID string, notify *model.Message) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&model.Message{}).
+			Where("msg_id = ? AND status = ?", msgID, 0).
+			Update("status", 1)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return tx.Create(notify).Error
+	})
+	if err != nil {
+		return WrapDBError(err)
+	}
+	return nil
+}
+
+// Edit 编辑消息
+// 以 version 作为乐观锁：并发编辑时只有一个请求能将版本号从 N 推进到 N+1
+func (r *messageRepositoryImpl) Edit(ctx context.Context, original, revision *model.MessageRevision, notify *model.Message) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&model.Message{}).
+			Where("msg_id = ? AND status = ? AND version = ?", revision.MsgId, 0, revision.Version-1).
+			Updates(map[string]interface{}{
+				"content":   revision.Content,
+				"version":   revision.Version,
+				"edited_at": revision.RevisedAt,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		if original != nil {
+			if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(original).Error; err != nil {
+				return err
+			}
+		}
+		if err := tx.Create(revision).Error; err != nil {
+			return err
+		}
+		return tx.Create(notify).Error
+	})
+	if err != nil {
+		return WrapDBError(err)
+	}
+	return nil
+}
+
+// Pin 置顶消息
+// 先锁定会话内序号最小的消息与被置顶消息，再校验是否已置顶与数量上限，未新增置顶记录时不写入通知
+func (r *messageRepositoryImpl) Pin(ctx context.Context, pin *model.PinnedMessage, maxPins int64, notify *model.Message) (bool, error) {
+	var pinned bool
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// 会话尚无置顶记录时锁定置顶表只加间隙锁，并发的首次置顶会在插入时互相死锁；
+		// 改为锁定会话内序号最小的消息（被置顶消息存在，该行必然存在），同会话置顶串行执行
+		var ids []int64
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Model(&model.Message{}).
+			Where("conv_id = ?", pin.ConvId).
+			Order("seq").
+			Limit(1).
+			Pluck("id", &ids).Error
+		if err != nil {
+			return err
+		}
+		// 锁定被置顶消息，并复核其未被到期清理
+		ids = nil
+		err = tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Model(&model.Message{}).
+			Where("msg_id = ?", pin.MsgId).
+			Pluck("id", &ids).Error
+		if err != nil {
+			return err
+		}
+		if len(ids) == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		var msgIDs []string
+		err = tx.Model(&model.PinnedMessage{}).
+			Where("conv_id = ?", pin.ConvId).
+			Pluck("msg_id", &msgIDs).Error
+		if err != nil {
+			return err
+		}
+		if slices.Contains(msgIDs, pin.MsgId) {
+			return nil
+		}
+		if int64(len(msgIDs)) >= maxPins {
+			return ErrLimitExceeded
+		}
+
+		if err := tx.Create(pin).Error; err != nil {
+			return err
+		}
+		pinned = true
+		return tx.Create(notify).Error
+	})
+	if err != nil {
+		return false, WrapDBError(err)
+	}
+	return pinned, nil
+}
+
+// Unpin 取消置顶消息
+func (r *messageRepositoryImpl) Unpin(ctx context.Context, convID, msgID string, notify *model.Message) (bool, error) {
+	var unpinned bool
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Where("conv_id = ? AND msg_id = ?", convID, msgID).Delete(&model.PinnedMessage{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+		unpinned = true
+		return tx.Create(notify).Error
+	})
+	if err != nil {
+		return false, WrapDBError(err)
+	}
+	return unpinned, nil
+}
+
+// ListPins 查询会话内的置顶记录
+func (r *messageRepositoryImpl) ListPins(ctx context.Context, convID string) ([]*model.PinnedMessage, error) {
+	var pins []*model.PinnedMessage
+	err := r.db.WithContext(ctx).
+		Where("conv_id = ?", convID).
+		Order("pinned_at DESC").
+		Order("id DESC").
+		Find(&pins).Error
+	if err != nil {
+		return nil, WrapDBError(err)
+	}
+	return pins, nil
+}
+
+// SetDisappearingTimer 更新会话定时销毁设置并写入设置变更通知
+// 以 conv_id 唯一索引 upsert，锁定当前设置比较后再写入，避免并发修改时重复写入通知
+func (r *messageRepositoryImpl) SetDisappearingTimer(ctx context.Context, timer *model.DisappearingTimer, notify *model.Message) (bool, error) {
+	var changed bool
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var current model.DisappearingTimer
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("conv_id = ?", timer.ConvId).
+			Limit(1).
+			Find(&current).Error
+		if err != nil {
+			return err
+		}
+		if current.Id == 0 && timer.Ttl == 0 {
+			return nil
+		}
+		if current.Id != 0 && current.Ttl == timer.Ttl {
+			return nil
+		}
+
+		err = tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "conv_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"ttl", "operator_uuid", "updated_at"}),
+		}).Create(timer).Error
+		if err != nil {
+			return err
+		}
+		changed = true
+		return tx.Create(notify).Error
+	})
+	if err != nil {
+		return false, WrapDBError(err)
+	}
+	return changed, nil
+}
+
+// GetDisappearingTimer 查询会话定时销毁设置
+func (r *messageRepositoryImpl) GetDisappearingTimer(ctx context.Context, convID string) (*model.DisappearingTimer, error) {
+	var timer model.DisappearingTimer
+	err := r.db.WithContext(ctx).
+		Where("conv_id = ?", convID).
+		First(&timer).Error
+	if err != nil {
+		return nil, WrapDBError(err)
+	}
+	return &timer, nil
+}
+
+// PurgeExpired 物理删除一批已过期的消息及其关联数据（走 idx_expire_at 索引）
+// 选取时跳过其他实例已锁定的行，多实例并发清理互不阻塞；同一事务内删除修订、表情回应、@提及、置顶记录与消息本身
+func (r *messageRepositoryImpl) PurgeExpired(ctx context.Context, now time.Time, limit int) ([]*model.Message, error) {
+	var msgs []*model.Message
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Unscoped().
+			Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Select("id", "conv_id", "seq", "msg_id", "expire_at").
+			Where("expire_at <= ?", now).
+			Order("expire_at ASC").
+			Limit(limit).
+			Find(&msgs).Error
+		if err != nil || len(msgs) == 0 {
+			return err
+		}
+
+		ids := make([]int64, 0, len(msgs))
+		msgIDs := make([]string, 0, len(msgs))
+		seqsByConv := make(map[string][]int64)
+		msgIDsByConv := make(map[string][]string)
+		for _, msg := range msgs {
+			ids = append(ids, msg.Id)
+			msgIDs = append(msgIDs, msg.MsgId)
+			seqsByConv[msg.ConvId] = append(seqsByConv[msg.ConvId], msg.Seq)
+			msgIDsByConv[msg.ConvId] = append(msgIDsByConv[msg.ConvId], msg.MsgId)
+		}
+
+		if err := tx.Where("msg_id IN ?", msgIDs).Delete(&model.MessageRevision{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("msg_id IN ?", msgIDs).Delete(&model.MessageReaction{}).Error; err != nil {
+			return err
+		}
+		for convID, seqs := range seqsByConv {
+			if err := tx.Where("conv_id = ? AND seq IN ?", convID, seqs).Delete(&model.MessageMention{}).Error; err != nil {
+				return err
+			}
+			if err := tx.Where("conv_id = ? AND msg_id IN ?", convID, msgIDsByConv[convID]).Delete(&model.PinnedMessage{}).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Unscoped().Where("id IN ?", ids).Delete(&model.Message{}).Error
+	})
+	if err != nil {
+		return nil, WrapDBError(err)
+	}
+	return msgs, nil
+}
+
+// CreateMentions 批量写入 @提及记录
+// 基于唯一索引 (conv_id, user_uuid, seq) 忽略重复写入
+func (r *messageRepositoryImpl) CreateMentions(ctx context.Context, mentions []*model.MessageMention) error {
+	if len(mentions) == 0 {
+		return nil
+	}
+	err := r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&mentions).Error
+	if err != nil {
+		return WrapDBError(err)
+	}
+	return nil
+}
+
+// ListMentionSeqs 查询 @userUUID 或 @所有人 的消息序号（走 uidx_conv_user_seq 索引）
+func (r *messageRepositoryImpl) ListMentionSeqs(ctx context.Context, convID, userUUID string, afterSeq int64, limit int) ([]int64, error) {
+	var seqs []int64
+	err := r.db.WithContext(ctx).Model(&model.MessageMention{}).
+		Where("conv_id = ? AND user_uuid IN ? AND seq > ?", convID, []string{userUUID, consts.MentionAllUUID}, afterSeq).
+		Order("seq ASC").
+		Limit(limit).
+		Distinct().
+		Pluck("seq", &seqs).Error
+	if err != nil {
+		return nil, WrapDBError(err)
+	}
+	return seqs, nil
+}
+
+// AddReaction 写入表情回应
+// 锁定被回应的消息行，同一消息的回应串行执行，种类数校验与写入之间不会被并发的新表情插入；
+// 基于唯一索引 (msg_id, user_uuid, emoji) 保证幂等，RowsAffected 为 0 表示已回应过
+func (r *messageRepositoryImpl) AddReaction(ctx context.Context, reaction *model.MessageReaction, maxKinds int64) (bool, error) {
+	var added bool
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var ids []int64
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Model(&model.Message{}).
+			Where("msg_id = ?", reaction.MsgId).
+			Pluck("id", &ids).Error
+		if err != nil {
+			return err
+		}
+		if len(ids) == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		// 已有人回应过的表情直接叠加，新表情校验种类上限
+		var exists int64
+		err = tx.Model(&model.MessageReaction{}).
+			Where("msg_id = ? AND emoji = ?", reaction.MsgId, reaction.Emoji).
+			Count(&exists).Error
+		if err != nil {
+			return err
+		}
+		if exists == 0 {
+			var kinds int64
+			err = tx.Model(&model.MessageReaction{}).
+				Where("msg_id = ?", reaction.MsgId).
+				Distinct("emoji").
+				Count(&kinds).Error
+			if err != nil {
+				return err
+			}
+			if kinds >= maxKinds {
+				return ErrLimitExceeded
+			}
+		}
+
+		result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(reaction)
+		if result.Error != nil {
+			return result.Error
+		}
+		added = result.RowsAffected > 0
+		return nil
+	})
+	if err != nil {
+		return false, WrapDBError(err)
+	}
+	return added, nil
+}
+
+// RemoveReaction 删除表情回应
+func (r *messageRepositoryImpl) RemoveReaction(ctx context.Context, msgID, userUUID, emoji string) (bool, error) {
+	result := r.db.WithContext(ctx).
+		Where("msg_id = ? AND user_uuid = ? AND emoji = ?", msgID, userUUID, emoji).
+		Delete(&model.MessageReaction{})
+	if result.Error != nil {
+		return false, WrapDBError(result.Error)
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// CountReaction 统计消息某个表情的回应人数
+func (r *messageRepositoryImpl) CountReaction(ctx context.Context, msgID, emoji string) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&model.MessageReaction{}).
+		Where("msg_id = ? AND emoji = ?", msgID, emoji).
+		Count(&count).Error
+	if err != nil {
+		return 0, WrapDBError(err)
+	}
+	return count, nil
+}
+
+// ListReactionCounts 按 (msg_id, emoji) 聚合回应人数，以最早一条回应的自增 id 排序
+func (r *messageRepositoryImpl) ListReactionCounts(ctx context.Context, msgIDs []string) ([]*ReactionCount, error) {
+	counts := make([]*ReactionCount, 0)
+	if len(msgIDs) == 0 {
+		return counts, nil
+	}
+	err := r.db.WithContext(ctx).Model(&model.MessageReaction{}).
+		Select("msg_id, emoji, COUNT(*) AS count").
+		Where("msg_id IN ?", msgIDs).
+		Group("msg_id, emoji").
+		Order("MIN(id) ASC").
+		Scan(&counts).Error
+	if err != nil {
+		return nil, WrapDBError(err)
+	}
+	return counts, nil
+}
+
+// ListUserReactions 查询用户在这些消息上的表情回应
+func (r *messageRepositoryImpl) ListUserReactions(ctx context.Context, msgIDs []string, userUUID string) ([]*model.MessageReaction, error) {
+	var reactions []*model.MessageReaction
+	if len(msgIDs) == 0 {
+		return reactions, nil
+	}
+	err := r.db.WithContext(ctx).
+		Where("msg_id IN ? AND user_uuid = ?", msgIDs, userUUID).
+		Find(&reactions).Error
+	if err != nil {
+		return nil, WrapDBError(err)
+	}
+	return reactions, nil
+}
+
+// ListReactionUsers 分页查询回应了某个表情的用户
+func (r *messageRepositoryImpl) ListReactionUsers(ctx context.Context, msgID, emoji string, page, pageSize int) ([]string, int64, error) {
+	query := r.db.WithContext(ctx).Model(&model.MessageReaction{}).
+		Where("msg_id = ? AND emoji = ?", msgID, emoji).
+		Session(&gorm.Session{})
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, WrapDBError(err)
+	}
+	if total == 0 {
+		return []string{}, 0, nil
+	}
+
+	var userUUIDs []string
+	err := query.
+		Order("id ASC").
+		Offset((page-1)*pageSize).
+		Limit(pageSize).
+		Pluck("user_uuid", &userUUIDs).Error
+	if err != nil {
+		return nil, 0, WrapDBError(err)
+	}
+	return userUUIDs, total, nil
+}
@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"ChatServer/model"
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestPin 测试置顶复核：重复置顶不写通知、达到上限返回 ErrLimitExceeded、被置顶消息已清理返回 ErrRecordNotFound
+func TestPin(t *testing.T) {
+	ctx := context.Background()
+	db := newTestDB(t)
+	require.NoError(t, db.AutoMigrate(&model.Message{}, &model.PinnedMessage{}))
+	repo := NewMessageRepository(db, nil)
+
+	now := time.Now()
+	for i := 1; i <= 3; i++ {
+		require.NoError(t, db.Create(&model.Message{
+			ConvId:      "u1_u2",
+			MsgId:       "m" + strconv.Itoa(i),
+			ClientMsgId: "c" + strconv.Itoa(i),
+			Seq:         int64(i),
+			FromUuid:    "u1",
+			SendTime:    now,
+		}).Error)
+	}
+	nextSeq := int64(3)
+	pin := func(msgID string) (bool, error) {
+		nextSeq++
+		notifyID := "n" + strconv.FormatInt(nextSeq, 10)
+		return repo.Pin(ctx, &model.PinnedMessage{
+			ConvId:   "u1_u2",
+			MsgId:    msgID,
+			PinnedBy: "u1",
+			PinnedAt: now,
+		}, 2, &model.Message{
+			ConvId:      "u1_u2",
+			MsgId:       notifyID,
+			ClientMsgId: "pin_" + notifyID,
+			Seq:         nextSeq,
+			FromUuid:    "u1",
+			SendTime:    now,
+		})
+	}
+
+	pinned, err := pin("m2")
+	require.NoError(t, err)
+	assert.True(t, pinned)
+
+	pinned, err = pin("m2")
+	require.NoError(t, err)
+	assert.False(t, pinned, "已置顶的消息不应重复写入")
+
+	pinned, err = pin("m1")
+	require.NoError(t, err)
+	assert.True(t, pinned)
+
+	_, err = pin("m3")
+	assert.ErrorIs(t, err, ErrLimitExceeded)
+
+	_, err = pin("m404")
+	assert.ErrorIs(t, err, ErrRecordNotFound)
+
+	var notifies int64
+	require.NoError(t, db.Model(&model.Message{}).Where("client_msg_id LIKE ?", "pin_%").Count(&notifies).Error)
+	assert.Equal(t, int64(2), notifies)
+}
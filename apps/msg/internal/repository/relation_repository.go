@@ -0,0 +1,32 @@
+package repository
+
+import (
+	"ChatServer/model"
+	"context"
+
+	"github.com/redis/go-redis/v9"
+	"gorm.io/gorm"
+)
+
+// relationRepositoryImpl 用户关系只读数据访问层实现
+type relationRepositoryImpl struct {
+	db          *gorm.DB
+	redisClient *redis.Client
+}
+
+// NewRelationRepository 创建用户关系仓储实例
+func NewRelationRepository(db *gorm.DB, redisClient *redis.Client) IRelationRepository {
+	return &relationRepositoryImpl{db: db, redisClient: redisClient}
+}
+
+// GetRelation 查询 user -> peer 的单向关系
+func (r *relationRepositoryImpl) GetRelation(ctx context.Context, userUUID, peerUUID string) (*model.UserRelation, error) {
+	var relation model.UserRelation
+	err := r.db.WithContext(ctx).
+		Where("user_uuid = ? AND peer_uuid = ?", userUUID, peerUUID).
+		First(&relation).Error
+	if err != nil {
+		return nil, WrapDBError(err)
+	}
+	return &relation, nil
+}
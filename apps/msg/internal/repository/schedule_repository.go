@@ -0,0 +1,181 @@
+package repository
+
+import (
+	"ChatServer/consts"
+	"ChatServer/model"
+	"context"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// scheduleRepositoryImpl 定时消息数据访问层实现
+type scheduleRepositoryImpl struct {
+	db          *gorm.DB
+	redisClient *redis.Client
+}
+
+// NewScheduleRepository 创建定时消息仓储实例
+func NewScheduleRepository(db *gorm.DB, redisClient *redis.Client) IScheduleRepository {
+	return &scheduleRepositoryImpl{db: db, redisClient: redisClient}
+}
+
+// Create 写入定时消息
+func (r *scheduleRepositoryImpl) Create(ctx context.Context, scheduled *model.ScheduledMessage) error {
+	err := r.db.WithContext(ctx).Create(scheduled).Error
+	if err != nil {
+		return WrapDBError(err)
+	}
+	return nil
+}
+
+// GetByClientMsgId 按 (发送者, 客户端幂等ID) 查询定时消息
+func (r *scheduleRepositoryImpl) GetByClientMsgId(ctx context.Context, fromUUID, clientMsgID string) (*model.ScheduledMessage, error) {
+	var scheduled model.ScheduledMessage
+	err := r.db.WithContext(ctx).
+		Where("from_uuid = ? AND client_msg_id = ?", fromUUID, clientMsgID).
+		First(&scheduled).Error
+	if err != nil {
+		return nil, WrapDBError(err)
+	}
+	return &scheduled, nil
+}
+
+// GetById 查询用户的定时消息
+func (r *scheduleRepositoryImpl) GetById(ctx context.Context, id int64, fromUUID string) (*model.ScheduledMessage, error) {
+	var scheduled model.ScheduledMessage
+	err := r.db.WithContext(ctx).
+		Where("id = ? AND from_uuid = ?", id, fromUUID).
+		First(&scheduled).Error
+	if err != nil {
+		return nil, WrapDBError(err)
+	}
+	return &scheduled, nil
+}
+
+// CountPending 统计用户待发送（含发送中）的定时消息数
+func (r *scheduleRepositoryImpl) CountPending(ctx context.Context, fromUUID string) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&model.ScheduledMessage{}).
+		Where("from_uuid = ? AND status IN ?", fromUUID,
+			[]int8{consts.ScheduledStatusPending, consts.ScheduledStatusDispatching}).
+		Count(&count).Error
+	if err != nil {
+		return 0, WrapDBError(err)
+	}
+	return count, nil
+}
+
+// ListByOwner 分页查询用户指定状态的定时消息
+func (r *scheduleRepositoryImpl) ListByOwner(ctx context.Context, fromUUID string, statuses []int8, page, pageSize int) ([]*model.ScheduledMessage, int64, error) {
+	query := r.db.WithContext(ctx).Model(&model.ScheduledMessage{}).
+		Where("from_uuid = ? AND status IN ?", fromUUID, statuses).
+		Session(&gorm.Session{})
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, WrapDBError(err)
+	}
+	if total == 0 {
+		return []*model.ScheduledMessage{}, 0, nil
+	}
+
+	var list []*model.ScheduledMessage
+	err := query.
+		Order("send_at ASC").
+		Order("id ASC").
+		Offset((page - 1) * pageSize).
+		Limit(pageSize).
+		Find(&list).Error
+	if err != nil {
+		return nil, 0, WrapDBError(err)
+	}
+	return list, total, nil
+}
+
+// Cancel 取消定时消息
+// 仅待发送与发送失败的记录可取消，已被发送任务认领的记录不受影响
+func (r *scheduleRepositoryImpl) Cancel(ctx context.Context, id int64, fromUUID string) (bool, error) {
+	result := r.db.WithContext(ctx).Model(&model.ScheduledMessage{}).
+		Where("id = ? AND from_uuid = ? AND status IN ?", id, fromUUID,
+			[]int8{consts.ScheduledStatusPending, consts.ScheduledStatusFailed}).
+		Update("status", consts.ScheduledStatusCanceled)
+	if result.Error != nil {
+		return false, WrapDBError(result.Error)
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// ClaimDue 认领到期的定时消息
+// 在事务内以 SELECT ... FOR UPDATE SKIP LOCKED 锁定候选记录，多个发送任务并发扫描时各自认领不同的记录；
+// 进程在认领后崩溃时，记录停留在发送中状态，认领到期后由下一轮扫描重新认领
+func (r *scheduleRepositoryImpl) ClaimDue(ctx context.Context, now, leaseUntil time.Time, limit int) ([]*model.ScheduledMessage, error) {
+	var claimed []*model.ScheduledMessage
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("(status = ? AND send_at <= ?) OR (status = ? AND lease_until <= ?)",
+				consts.ScheduledStatusPending, now, consts.ScheduledStatusDispatching, now).
+			Order("send_at ASC").
+			Limit(limit).
+			Find(&claimed).Error
+		if err != nil {
+			return err
+		}
+		if len(claimed) == 0 {
+			return nil
+		}
+
+		ids := make([]int64, 0, len(claimed))
+		for _, scheduled := range claimed {
+			ids = append(ids, scheduled.Id)
+			scheduled.Status = consts.ScheduledStatusDispatching
+			scheduled.LeaseUntil = &leaseUntil
+			scheduled.Attempts++
+		}
+		return tx.Model(&model.ScheduledMessage{}).
+			Where("id IN ?", ids).
+			Updates(map[string]interface{}{
+				"status":      consts.ScheduledStatusDispatching,
+				"lease_until": leaseUntil,
+				"attempts":    gorm.Expr("attempts + 1"),
+			}).Error
+	})
+	if err != nil {
+		return nil, WrapDBError(err)
+	}
+	return claimed, nil
+}
+
+// MarkSent 标记为已发送
+func (r *scheduleRepositoryImpl) MarkSent(ctx context.Context, id int64, msgID string, seq int64, sentAt time.Time) error {
+	err := r.db.WithContext(ctx).Model(&model.ScheduledMessage{}).
+		Where("id = ? AND status = ?", id, consts.ScheduledStatusDispatching).
+		Updates(map[string]interface{}{
+			"status":      consts.ScheduledStatusSent,
+			"msg_id":      msgID,
+			"seq":         seq,
+			"sent_at":     sentAt,
+			"lease_until": nil,
+		}).Error
+	if err != nil {
+		return WrapDBError(err)
+	}
+	return nil
+}
+
+// MarkFailed 标记为发送失败
+func (r *scheduleRepositoryImpl) MarkFailed(ctx context.Context, id int64, failCode int) error {
+	err := r.db.WithContext(ctx).Model(&model.ScheduledMessage{}).
+		Where("id = ? AND status = ?", id, consts.ScheduledStatusDispatching).
+		Updates(map[string]interface{}{
+			"status":      consts.ScheduledStatusFailed,
+			"fail_code":   failCode,
+			"lease_until": nil,
+		}).Error
+	if err != nil {
+		return WrapDBError(err)
+	}
+	return nil
+}
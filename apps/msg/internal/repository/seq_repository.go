@@ -0,0 +1,167 @@
+package repository
+
+import (
+	"ChatServer/model"
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/redis/go-redis/v9"
+	"gorm.io/gorm"
+)
+
+// allocateSeqScript 为会话分配 n 个连续序号，返回分配区间的最大值
+// Key 不存在时返回 -1，由调用方从 MySQL 恢复后重试（不在脚本里默认从 0 开始，避免 Redis 丢数据后序号回退）
+var allocateSeqScript = redis.NewScript(`
+if redis.call("EXISTS", KEYS[1]) == 0 then
+	return -1
+end
+return redis.call("INCRBY", KEYS[1], ARGV[1])
+`)
+
+// raiseSeqScript 将序号抬升到不小于指定值（只增不减）
+var raiseSeqScript = redis.NewScript(`
+local current = tonumber(redis.call("GET", KEYS[1]) or "0")
+local target = tonumber(ARGV[1])
+if target > current then
+	redis.call("SET", KEYS[1], target)
+	return target
+end
+return current
+`)
+
+// seqRepositoryImpl 会话序号分配器实现（Redis INCRBY + MySQL 恢复）
+type seqRepositoryImpl struct {
+	redisClient *redis.Client
+	// loadMaxSeq 从持久化存储读取会话当前最大序号（Redis Key 丢失时用于恢复）
+	loadMaxSeq func(ctx context.Context, convID string) (int64, error)
+}
+
+// NewSeqRepository 创建会话序号分配器实例
+func NewSeqRepository(db *gorm.DB, redisClient *redis.Client) ISeqRepository {
+	return &seqRepositoryImpl{
+		redisClient: redisClient,
+		loadMaxSeq: func(ctx context.Context, convID string) (int64, error) {
+			var maxSeq int64
+			err := db.WithContext(ctx).Model(&model.Message{}).
+				Unscoped(). // 软删除的消息同样占用序号
+				Where("conv_id = ?", convID).
+				Select("COALESCE(MAX(seq), 0)").
+				Scan(&maxSeq).Error
+			return maxSeq, err
+		},
+	}
+}
+
+// seqKey 会话序号 Key（不设过期时间）
+func (r *seqRepositoryImpl) seqKey(convID string) string {
+	return fmt.Sprintf("msg:seq:%s", convID)
+}
+
+// Allocate 为会话分配 n 个连续序号
+func (r *seqRepositoryImpl) Allocate(ctx context.Context, convID string, n int64) (int64, int64, error) {
+	if n <= 0 {
+		n = 1
+	}
+	key := r.seqKey(convID)
+
+	// Key 丢失时恢复一次后重试；恢复使用 SET NX，并发恢复的多个请求只有一个生效
+	for attempt := 0; attempt < 2; attempt++ {
+		last, err := allocateSeqScript.Run(ctx, r.redisClient, []string{key}, n).Int64()
+		if err != nil {
+			return 0, 0, WrapRedisError(err)
+		}
+		if last >= 0 {
+			return last - n + 1, last, nil
+		}
+		if err := r.recover(ctx, convID); err != nil {
+			return 0, 0, err
+		}
+	}
+	return 0, 0, fmt.Errorf("%w: allocate seq for %s: key missing after recover", ErrRedis, convID)
+}
+
+// BatchAllocate 为多个会话各分配一个序号（一次 Pipeline 往返）
+func (r *seqRepositoryImpl) BatchAllocate(ctx context.Context, convIDs []string) (map[string]int64, error) {
+	result := make(map[string]int64, len(convIDs))
+	pending := convIDs
+
+	for attempt := 0; attempt < 2 && len(pending) > 0; attempt++ {
+		if err := allocateSeqScript.Load(ctx, r.redisClient).Err(); err != nil {
+			return nil, WrapRedisError(err)
+		}
+		pipe := r.redisClient.Pipeline()
+		cmds := make([]*redis.Cmd, len(pending))
+		for i, convID := range pending {
+			cmds[i] = allocateSeqScript.EvalSha(ctx, pipe, []string{r.seqKey(convID)}, 1)
+		}
+		if _, err := pipe.Exec(ctx); err != nil {
+			return nil, WrapRedisError(err)
+		}
+
+		var missing []string
+		for i, cmd := range cmds {
+			seq, err := cmd.Int64()
+			if err != nil {
+				return nil, WrapRedisError(err)
+			}
+			if seq < 0 {
+				missing = append(missing, pending[i])
+				continue
+			}
+			result[pending[i]] = seq
+		}
+		for _, convID := range missing {
+			if err := r.recover(ctx, convID); err != nil {
+				return nil, err
+			}
+		}
+		pending = missing
+	}
+	if len(pending) > 0 {
+		return nil, fmt.Errorf("%w: batch allocate seq: %d keys missing after recover", ErrRedis, len(pending))
+	}
+	return result, nil
+}
+
+// Resync 将 Redis 中的序号抬升到不小于 MySQL 中的最大序号
+// 用于写入时发现序号冲突（Redis 丢失了尚未落库的分配记录）后的修复
+func (r *seqRepositoryImpl) Resync(ctx context.Context, convID string) error {
+	maxSeq, err := r.loadMaxSeq(ctx, convID)
+	if err != nil {
+		return WrapDBError(err)
+	}
+	if err := raiseSeqScript.Run(ctx, r.redisClient, []string{r.seqKey(convID)}, maxSeq).Err(); err != nil {
+		return WrapRedisError(err)
+	}
+	return nil
+}
+
+// Current 查询会话当前已分配的最大序号
+// Redis Key 不存在时直接读 MySQL 最大序号（只读，不初始化 Key）
+func (r *seqRepositoryImpl) Current(ctx context.Context, convID string) (int64, error) {
+	seq, err := r.redisClient.Get(ctx, r.seqKey(convID)).Int64()
+	if err == nil {
+		return seq, nil
+	}
+	if !errors.Is(err, redis.Nil) {
+		return 0, WrapRedisError(err)
+	}
+	maxSeq, err := r.loadMaxSeq(ctx, convID)
+	if err != nil {
+		return 0, WrapDBError(err)
+	}
+	return maxSeq, nil
+}
+
+// recover 从 MySQL 最大序号初始化 Redis Key（Key 已存在时不覆盖）
+func (r *seqRepositoryImpl) recover(ctx context.Context, convID string) error {
+	maxSeq, err := r.loadMaxSeq(ctx, convID)
+	if err != nil {
+		return WrapDBError(err)
+	}
+	if err := r.redisClient.SetNX(ctx, r.seqKey(convID), maxSeq, 0).Err(); err != nil {
+		return WrapRedisError(err)
+	}
+	return nil
+}
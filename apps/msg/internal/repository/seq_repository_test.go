@@ -0,0 +1,172 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestSeqRepository 基于 miniredis 创建序号分配器，maxSeq 模拟 MySQL 中各会话的最大序号
+func newTestSeqRepository(t *testing.T, maxSeq map[string]int64) (*seqRepositoryImpl, *miniredis.Miniredis, *int32) {
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+
+	var loads int32
+	var mu sync.Mutex
+	repo := &seqRepositoryImpl{
+		redisClient: client,
+		loadMaxSeq: func(ctx context.Context, convID string) (int64, error) {
+			atomic.AddInt32(&loads, 1)
+			mu.Lock()
+			defer mu.Unlock()
+			return maxSeq[convID], nil
+		},
+	}
+	return repo, mr, &loads
+}
+
+// TestSeqRepository_ConcurrentAllocate 测试并发分配序号：无空洞、无重复、同一发送者内严格递增
+func TestSeqRepository_ConcurrentAllocate(t *testing.T) {
+	ctx := context.Background()
+	repo, _, _ := newTestSeqRepository(t, nil)
+
+	const (
+		senders   = 32
+		perSender = 50
+	)
+
+	var (
+		mu   sync.Mutex
+		seqs []int64
+		wg   sync.WaitGroup
+	)
+	for i := 0; i < senders; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			var local []int64
+			var prev int64
+			for j := 0; j < perSender; j++ {
+				// 混合单条与批量分配
+				n := int64(1)
+				if (i+j)%3 == 0 {
+					n = 3
+				}
+				first, last, err := repo.Allocate(ctx, "conv-1", n)
+				if !assert.NoError(t, err) {
+					return
+				}
+				assert.Equal(t, n, last-first+1)
+				assert.Greater(t, first, prev, "同一发送者的序号应该严格递增")
+				prev = last
+				for seq := first; seq <= last; seq++ {
+					local = append(local, seq)
+				}
+			}
+			mu.Lock()
+			seqs = append(seqs, local...)
+			mu.Unlock()
+		}(i)
+	}
+	wg.Wait()
+
+	sort.Slice(seqs, func(i, j int) bool { return seqs[i] < seqs[j] })
+	for i, seq := range seqs {
+		require.Equal(t, int64(i+1), seq, "序号应该从 1 开始连续且不重复")
+	}
+}
+
+// TestSeqRepository_RecoverFromMySQL 测试 Redis Key 丢失后从 MySQL 最大序号恢复
+func TestSeqRepository_RecoverFromMySQL(t *testing.T) {
+	ctx := context.Background()
+	repo, mr, loads := newTestSeqRepository(t, map[string]int64{"conv-1": 100})
+
+	first, last, err := repo.Allocate(ctx, "conv-1", 1)
+	require.NoError(t, err)
+	assert.Equal(t, int64(101), first)
+	assert.Equal(t, int64(101), last)
+
+	// Key 存在时不再访问 MySQL
+	_, _, err = repo.Allocate(ctx, "conv-1", 2)
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(loads))
+
+	// Redis 数据丢失：重新从 MySQL 恢复，不会回退到 1
+	mr.FlushAll()
+	first, _, err = repo.Allocate(ctx, "conv-1", 1)
+	require.NoError(t, err)
+	assert.Equal(t, int64(101), first)
+}
+
+// TestSeqRepository_BatchAllocate 测试批量为多个会话分配序号
+func TestSeqRepository_BatchAllocate(t *testing.T) {
+	ctx := context.Background()
+	repo, _, _ := newTestSeqRepository(t, map[string]int64{"conv-b": 7})
+
+	_, _, err := repo.Allocate(ctx, "conv-a", 5)
+	require.NoError(t, err)
+
+	result, err := repo.BatchAllocate(ctx, []string{"conv-a", "conv-b", "conv-c"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int64{
+		"conv-a": 6,
+		"conv-b": 8,
+		"conv-c": 1,
+	}, result)
+
+	result, err = repo.BatchAllocate(ctx, []string{"conv-a", "conv-b"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int64{"conv-a": 7, "conv-b": 9}, result)
+}
+
+// TestSeqRepository_Resync 测试序号冲突后抬升序号（只增不减）
+func TestSeqRepository_Resync(t *testing.T) {
+	ctx := context.Background()
+	maxSeq := map[string]int64{"conv-1": 0}
+	repo, _, _ := newTestSeqRepository(t, maxSeq)
+
+	_, _, err := repo.Allocate(ctx, "conv-1", 10)
+	require.NoError(t, err)
+
+	// MySQL 中的最大序号比 Redis 小：不回退
+	maxSeq["conv-1"] = 5
+	require.NoError(t, repo.Resync(ctx, "conv-1"))
+	first, _, err := repo.Allocate(ctx, "conv-1", 1)
+	require.NoError(t, err)
+	assert.Equal(t, int64(11), first)
+
+	// MySQL 中的最大序号比 Redis 大：抬升
+	maxSeq["conv-1"] = 50
+	require.NoError(t, repo.Resync(ctx, "conv-1"))
+	first, _, err = repo.Allocate(ctx, "conv-1", 1)
+	require.NoError(t, err)
+	assert.Equal(t, int64(51), first)
+}
+
+// TestSeqRepository_Current 测试查询会话当前最大序号：Key 丢失时回退 MySQL，且不分配新序号
+func TestSeqRepository_Current(t *testing.T) {
+	ctx := context.Background()
+	repo, mr, _ := newTestSeqRepository(t, map[string]int64{"conv-1": 30})
+
+	current, err := repo.Current(ctx, "conv-1")
+	require.NoError(t, err)
+	assert.Equal(t, int64(30), current)
+
+	mr.Set("msg:seq:conv-1", "42")
+	current, err = repo.Current(ctx, "conv-1")
+	require.NoError(t, err)
+	assert.Equal(t, int64(42), current)
+
+	// 查询不应推进序号
+	first, _, err := repo.Allocate(ctx, "conv-1", 1)
+	require.NoError(t, err)
+	assert.Equal(t, int64(43), first)
+}
@@ -0,0 +1,145 @@
+package repository
+
+import (
+	"ChatServer/model"
+	"ChatServer/pkg/util"
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/redis/go-redis/v9"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// 群时间线版本号（version）
+// 时间线的任何变更都写入新的雪花ID，SyncConversations 据此下发读扩散群的最后消息与未读数变化。
+// 雪花ID在事务提交前生成，晚提交的较小版本号由同步时的回看窗口兜底（见 ListTimelineChangedSince）。
+
+// timelineRepositoryImpl 读扩散群时间线数据访问层实现
+type timelineRepositoryImpl struct {
+	db          *gorm.DB
+	redisClient *redis.Client
+}
+
+// NewTimelineRepository 创建群时间线仓储实例
+func NewTimelineRepository(db *gorm.DB, redisClient *redis.Client) ITimelineRepository {
+	return &timelineRepositoryImpl{db: db, redisClient: redisClient}
+}
+
+// Advance 推进群时间线
+// 同一事务内先累加气泡消息数（时间线不存在时创建），再按 last_seq < 新序号条件覆盖最后消息：
+// 气泡计数与消息到达顺序无关，最后消息只增不减
+func (r *timelineRepositoryImpl) Advance(ctx context.Context, timeline *model.GroupTimeline, bubbles int64) error {
+	timeline.BubbleCount = bubbles
+	timeline.Version = util.GenID()
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "conv_id"}},
+			DoUpdates: clause.Assignments(map[string]interface{}{
+				"bubble_count": gorm.Expr("bubble_count + VALUES(bubble_count)"),
+				"version":      gorm.Expr("VALUES(version)"),
+			}),
+		}).Create(timeline).Error
+		if err != nil {
+			return err
+		}
+		return advanceIfNewer(tx, timeline)
+	})
+	if err != nil {
+		return WrapDBError(err)
+	}
+	return nil
+}
+
+// advanceIfNewer 时间线已有序号小于新序号时覆盖最后消息
+func advanceIfNewer(tx *gorm.DB, timeline *model.GroupTimeline) error {
+	updates := map[string]interface{}{
+		"last_seq":         timeline.LastSeq,
+		"last_msg_id":      timeline.LastMsgId,
+		"last_msg_at":      timeline.LastMsgAt,
+		"last_msg_preview": timeline.LastMsgPrev,
+	}
+	if timeline.AtAllSeq > 0 {
+		updates["at_all_seq"] = timeline.AtAllSeq
+	}
+	return tx.Model(&model.GroupTimeline{}).
+		Where("conv_id = ? AND last_seq < ?", timeline.ConvId, timeline.LastSeq).
+		Updates(updates).Error
+}
+
+// BatchGet 批量查询群时间线
+func (r *timelineRepositoryImpl) BatchGet(ctx context.Context, convIDs []string) (map[string]*model.GroupTimeline, error) {
+	result := make(map[string]*model.GroupTimeline, len(convIDs))
+	if len(convIDs) == 0 {
+		return result, nil
+	}
+
+	var timelines []*model.GroupTimeline
+	err := r.db.WithContext(ctx).
+		Where("conv_id IN ?", convIDs).
+		Find(&timelines).Error
+	if err != nil {
+		return nil, WrapDBError(err)
+	}
+	for _, timeline := range timelines {
+		result[timeline.ConvId] = timeline
+	}
+	return result, nil
+}
+
+// UpdatePreviewByLastMsg 更新最后一条消息为 msgID 的群时间线预览
+// 与会话预览相同使用 UpdateColumns，撤回、过期改写预览不刷新 updated_at
+func (r *timelineRepositoryImpl) UpdatePreviewByLastMsg(ctx context.Context, convID, msgID, preview string) error {
+	err := r.db.WithContext(ctx).Model(&model.GroupTimeline{}).
+		Where("conv_id = ? AND last_msg_id = ?", convID, msgID).
+		UpdateColumns(map[string]interface{}{
+			"last_msg_preview": preview,
+			"version":          util.GenID(),
+		}).Error
+	if err != nil {
+		return WrapDBError(err)
+	}
+	return nil
+}
+
+// RemoveBubbles 扣减到期销毁的气泡消息
+// 时间线 bubble_count 减去销毁条数；已读游标不小于被销毁序号的成员同步扣减 read_bubble（每人扣减其已读范围内的销毁条数），
+// 使 bubble_count - read_bubble 仍等于成员未读的存活气泡数。已读过的成员未读数不变，其会话记录不写入新的 version。
+func (r *timelineRepositoryImpl) RemoveBubbles(ctx context.Context, convID string, seqs []int64) error {
+	if len(seqs) == 0 {
+		return nil
+	}
+	sorted := append([]int64(nil), seqs...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&model.GroupTimeline{}).
+			Where("conv_id = ?", convID).
+			Updates(map[string]interface{}{
+				"bubble_count": gorm.Expr("GREATEST(bubble_count - ?, 0)", len(sorted)),
+				"version":      util.GenID(),
+			})
+		if result.Error != nil || result.RowsAffected == 0 {
+			// 未使用读扩散的群没有时间线，无需扣减
+			return result.Error
+		}
+
+		// 按已读游标落在哪个区间决定扣减条数：read_seq >= 第 i 小的销毁序号时扣减 i 条
+		var expr strings.Builder
+		args := make([]interface{}, 0, len(sorted)*2)
+		expr.WriteString("GREATEST(read_bubble - CASE")
+		for i := len(sorted) - 1; i >= 0; i-- {
+			expr.WriteString(" WHEN read_seq >= ? THEN ?")
+			args = append(args, sorted[i], i+1)
+		}
+		expr.WriteString(" END, 0)")
+		return tx.Model(&model.Conversation{}).
+			Where("conv_id = ? AND read_seq >= ?", convID, sorted[0]).
+			UpdateColumn("read_bubble", gorm.Expr(expr.String(), args...)).Error
+	})
+	if err != nil {
+		return WrapDBError(err)
+	}
+	return nil
+}
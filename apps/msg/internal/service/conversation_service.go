@@ -0,0 +1,367 @@
+package service
+
+import (
+	"ChatServer/apps/msg/internal/repository"
+	pb "ChatServer/apps/msg/pb"
+	"ChatServer/consts"
+	"ChatServer/model"
+	"ChatServer/pkg/logger"
+	"ChatServer/pkg/util"
+	"context"
+	"errors"
+	"strconv"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// defaultSyncLimit 增量同步默认单次变更数
+	defaultSyncLimit = 100
+	// maxSyncLimit 增量同步单次最大变更数
+	maxSyncLimit = 500
+	// timelineSyncLookback 群时间线增量同步的回看窗口（10 秒，雪花ID时间戳位于低 22 位之上）
+	// 时间线版本号在事务提交前生成，回看窗口内的变更每次同步都会重复下发，覆盖晚提交的较小版本号
+	timelineSyncLookback = int64(10*1000) << 22
+)
+
+// conversationServiceImpl 会话服务实现
+type conversationServiceImpl struct {
+	conversationRepo repository.IConversationRepository
+	timelineRepo     repository.ITimelineRepository
+}
+
+// NewConversationService 创建会话服务实例
+func NewConversationService(conversationRepo repository.IConversationRepository, timelineRepo repository.ITimelineRepository) ConversationService {
+	return &conversationServiceImpl{
+		conversationRepo: conversationRepo,
+		timelineRepo:     timelineRepo,
+	}
+}
+
+// GetConversationList 获取会话列表
+// 置顶会话在前，其余按 updated_at 倒序；已删除（status=1）的会话不返回。
+// 读扩散大群的最后消息与未读数由群时间线覆盖（排序仍按会话记录，大群新消息不改变其排序位置）。
+// 返回的 version/timeline_version 在查询列表之前读取，分页期间发生的变更会在后续增量同步中再次下发。
+//
+// 错误码映射：
+//   - codes.InvalidArgument: 参数错误
+//   - codes.Internal: 系统内部错误
+func (s *conversationServiceImpl) GetConversationList(ctx context.Context, req *pb.GetConversationListRequest) (*pb.GetConversationListResponse, error) {
+	userUUID := util.GetUserUUIDFromContext(ctx)
+	if userUUID == "" {
+		logger.Error(ctx, "从 context 中获取 user_uuid 失败")
+		return nil, status.Error(codes.Internal, strconv.Itoa(consts.CodeInternalError))
+	}
+	if req.Page < 1 || req.PageSize < 1 || req.PageSize > 100 {
+		return nil, status.Error(codes.InvalidArgument, strconv.Itoa(consts.CodeParamError))
+	}
+
+	version, err := s.conversationRepo.MaxVersion(ctx, userUUID)
+	if err != nil {
+		logger.Error(ctx, "查询会话版本号失败",
+			logger.String("user_uuid", userUUID),
+			logger.ErrorField("error", err),
+		)
+		return nil, status.Error(codes.Internal, strconv.Itoa(consts.CodeInternalError))
+	}
+
+	timelineVersion := util.GenID()
+
+	convs, total, err := s.conversationRepo.ListByOwner(ctx, userUUID, int(req.Page), int(req.PageSize))
+	if err != nil {
+		logger.Error(ctx, "查询会话列表失败",
+			logger.String("user_uuid", userUUID),
+			logger.ErrorField("error", err),
+		)
+		return nil, status.Error(codes.Internal, strconv.Itoa(consts.CodeInternalError))
+	}
+
+	items := s.buildConversationItems(ctx, convs)
+
+	totalPages := int32((total + int64(req.PageSize) - 1) / int64(req.PageSize))
+	return &pb.GetConversationListResponse{
+		Items: items,
+		Pagination: &pb.PaginationInfo{
+			Page:       req.Page,
+			PageSize:   req.PageSize,
+			Total:      total,
+			TotalPages: totalPages,
+		},
+		Version:         version,
+		TimelineVersion: timelineVersion,
+	}, nil
+}
+
+// SyncConversations 按版本号增量同步会话
+// 业务流程：
+//  1. limit 缺省为 100，最大 500
+//  2. 查询 version 之后变更的会话（按 version 升序，多查一条判断 has_more）
+//  3. status=0 的会话作为新增/更新下发，status=1 的会话作为删除下发
+//  4. 返回本批最后一条变更的 version，无变更时原样返回请求的 version
+//  5. 读扩散大群的新消息只推进群时间线、不产生会话变更：另按 timeline_version 查询时间线有变更的群会话一并下发，
+//     并返回其中最大的时间线版本号（回看 timelineSyncLookback，窗口内的变更会重复下发，客户端按 conv_id 覆盖）
+//
+// 下发的群会话条目均由群时间线覆盖最后消息与未读数。
+//
+// 错误码映射：
+//   - codes.InvalidArgument: 参数错误
+//   - codes.Internal: 系统内部错误
+func (s *conversationServiceImpl) SyncConversations(ctx context.Context, req *pb.SyncConversationsRequest) (*pb.SyncConversationsResponse, error) {
+	userUUID := util.GetUserUUIDFromContext(ctx)
+	if userUUID == "" {
+		logger.Error(ctx, "从 context 中获取 user_uuid 失败")
+		return nil, status.Error(codes.Internal, strconv.Itoa(consts.CodeInternalError))
+	}
+	if req.Version < 0 || req.Limit < 0 || req.Limit > maxSyncLimit {
+		return nil, status.Error(codes.InvalidArgument, strconv.Itoa(consts.CodeParamError))
+	}
+	limit := int(req.Limit)
+	if limit == 0 {
+		limit = defaultSyncLimit
+	}
+
+	convs, err := s.conversationRepo.ListChangedSince(ctx, userUUID, req.Version, limit+1)
+	if err != nil {
+		logger.Error(ctx, "查询会话变更失败",
+			logger.String("user_uuid", userUUID),
+			logger.Int64("version", req.Version),
+			logger.ErrorField("error", err),
+		)
+		return nil, status.Error(codes.Internal, strconv.Itoa(consts.CodeInternalError))
+	}
+
+	hasMore := len(convs) > limit
+	if hasMore {
+		convs = convs[:limit]
+	}
+
+	since := req.TimelineVersion - timelineSyncLookback
+	if since < 0 {
+		since = 0
+	}
+	timelineConvs, timelineVersion, err := s.conversationRepo.ListTimelineChangedSince(ctx, userUUID, since)
+	if err != nil {
+		logger.Error(ctx, "查询群时间线变更失败",
+			logger.String("user_uuid", userUUID),
+			logger.Int64("timeline_version", req.TimelineVersion),
+			logger.ErrorField("error", err),
+		)
+		return nil, status.Error(codes.Internal, strconv.Itoa(consts.CodeInternalError))
+	}
+
+	resp := &pb.SyncConversationsResponse{
+		Upserts:         make([]*pb.ConversationItem, 0, len(convs)),
+		DeletedConvIds:  make([]string, 0),
+		HasMore:         hasMore,
+		Version:         req.Version,
+		TimelineVersion: req.TimelineVersion,
+	}
+	if timelineVersion > resp.TimelineVersion {
+		resp.TimelineVersion = timelineVersion
+	}
+	upserts := make([]*model.Conversation, 0, len(convs)+len(timelineConvs))
+	seen := make(map[string]struct{}, len(convs))
+	for _, conv := range convs {
+		seen[conv.ConvId] = struct{}{}
+		if conv.Status == 0 {
+			upserts = append(upserts, conv)
+		} else {
+			resp.DeletedConvIds = append(resp.DeletedConvIds, conv.ConvId)
+		}
+		resp.Version = conv.Version
+	}
+	for _, conv := range timelineConvs {
+		if _, ok := seen[conv.ConvId]; !ok {
+			upserts = append(upserts, conv)
+		}
+	}
+	resp.Upserts = s.buildConversationItems(ctx, upserts)
+	return resp, nil
+}
+
+// SetConversationPin 置顶/取消置顶会话
+//
+// 错误码映射：
+//   - codes.InvalidArgument: 参数错误
+//   - codes.NotFound: 会话不存在
+//   - codes.Internal: 系统内部错误
+func (s *conversationServiceImpl) SetConversationPin(ctx context.Context, req *pb.SetConversationPinRequest) (*pb.SetConversationPinResponse, error) {
+	if err := s.updateSettings(ctx, req.ConvId, map[string]interface{}{"pin": req.Pin}); err != nil {
+		return nil, err
+	}
+	return &pb.SetConversationPinResponse{}, nil
+}
+
+// SetConversationMute 开启/关闭会话免打扰
+// 免打扰只影响客户端提醒方式，未读数照常累加
+//
+// 错误码映射：
+//   - codes.InvalidArgument: 参数错误
+//   - codes.NotFound: 会话不存在
+//   - codes.Internal: 系统内部错误
+func (s *conversationServiceImpl) SetConversationMute(ctx context.Context, req *pb.SetConversationMuteRequest) (*pb.SetConversationMuteResponse, error) {
+	if err := s.updateSettings(ctx, req.ConvId, map[string]interface{}{"mute": req.Mute}); err != nil {
+		return nil, err
+	}
+	return &pb.SetConversationMuteResponse{}, nil
+}
+
+// DeleteConversation 删除会话
+// 只从本人的会话列表移除（清零未读、取消置顶），不删除消息；会话收到新消息后重新出现
+//
+// 错误码映射：
+//   - codes.InvalidArgument: 参数错误
+//   - codes.NotFound: 会话不存在
+//   - codes.Internal: 系统内部错误
+func (s *conversationServiceImpl) DeleteConversation(ctx context.Context, req *pb.DeleteConversationRequest) (*pb.DeleteConversationResponse, error) {
+	userUUID, err := s.getOwnConversation(ctx, req.ConvId)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.conversationRepo.Hide(ctx, userUUID, req.ConvId); err != nil {
+		logger.Error(ctx, "删除会话失败",
+			logger.String("conv_id", req.ConvId),
+			logger.ErrorField("error", err),
+		)
+		return nil, status.Error(codes.Internal, strconv.Itoa(consts.CodeInternalError))
+	}
+	return &pb.DeleteConversationResponse{}, nil
+}
+
+// JoinGroupConversations 为新入群成员创建或恢复群会话（内部接口）
+// 由用户服务在成员入群事务提交后调用，会话表只由消息服务维护
+//
+// 错误码映射：
+//   - codes.InvalidArgument: 参数错误
+//   - codes.Internal: 系统内部错误
+func (s *conversationServiceImpl) JoinGroupConversations(ctx context.Context, req *pb.JoinGroupConversationsRequest) (*pb.JoinGroupConversationsResponse, error) {
+	if req.GroupUuid == "" || len(req.MemberUuids) == 0 {
+		return nil, status.Error(codes.InvalidArgument, strconv.Itoa(consts.CodeParamError))
+	}
+	for _, memberUUID := range req.MemberUuids {
+		if memberUUID == "" {
+			return nil, status.Error(codes.InvalidArgument, strconv.Itoa(consts.CodeParamError))
+		}
+	}
+
+	if err := s.conversationRepo.JoinGroup(ctx, req.GroupUuid, req.MemberUuids); err != nil {
+		logger.Error(ctx, "创建入群会话失败",
+			logger.String("group_uuid", req.GroupUuid),
+			logger.Int("member_count", len(req.MemberUuids)),
+			logger.ErrorField("error", err),
+		)
+		return nil, status.Error(codes.Internal, strconv.Itoa(consts.CodeInternalError))
+	}
+	return &pb.JoinGroupConversationsResponse{}, nil
+}
+
+// updateSettings 更新当前用户的会话设置
+func (s *conversationServiceImpl) updateSettings(ctx context.Context, convID string, updates map[string]interface{}) error {
+	userUUID, err := s.getOwnConversation(ctx, convID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.conversationRepo.UpdateSettings(ctx, userUUID, convID, updates); err != nil {
+		logger.Error(ctx, "更新会话设置失败",
+			logger.String("conv_id", convID),
+			logger.ErrorField("error", err),
+		)
+		return status.Error(codes.Internal, strconv.Itoa(consts.CodeInternalError))
+	}
+	return nil
+}
+
+// getOwnConversation 校验当前用户存在该会话记录，返回当前用户 uuid
+func (s *conversationServiceImpl) getOwnConversation(ctx context.Context, convID string) (string, error) {
+	userUUID := util.GetUserUUIDFromContext(ctx)
+	if userUUID == "" {
+		logger.Error(ctx, "从 context 中获取 user_uuid 失败")
+		return "", status.Error(codes.Internal, strconv.Itoa(consts.CodeInternalError))
+	}
+	if convID == "" {
+		return "", status.Error(codes.InvalidArgument, strconv.Itoa(consts.CodeParamError))
+	}
+
+	if _, err := s.conversationRepo.GetByOwnerAndConv(ctx, userUUID, convID); err != nil {
+		if errors.Is(err, repository.ErrRecordNotFound) {
+			return "", status.Error(codes.NotFound, strconv.Itoa(consts.CodeConversationNotFound))
+		}
+		logger.Error(ctx, "查询会话失败",
+			logger.String("conv_id", convID),
+			logger.ErrorField("error", err),
+		)
+		return "", status.Error(codes.Internal, strconv.Itoa(consts.CodeInternalError))
+	}
+	return userUUID, nil
+}
+
+// buildConversationItems 转换会话列表，群会话存在更新的群时间线时以时间线覆盖最后消息与未读数
+// 查询时间线失败时退化为会话记录本身的数据
+func (s *conversationServiceImpl) buildConversationItems(ctx context.Context, convs []*model.Conversation) []*pb.ConversationItem {
+	groupIDs := make([]string, 0, len(convs))
+	for _, conv := range convs {
+		if conv.Type == consts.ConvTypeGroup {
+			groupIDs = append(groupIDs, conv.ConvId)
+		}
+	}
+	timelines, err := s.timelineRepo.BatchGet(ctx, groupIDs)
+	if err != nil {
+		logger.Warn(ctx, "查询群时间线失败", logger.ErrorField("error", err))
+		timelines = nil
+	}
+
+	items := make([]*pb.ConversationItem, 0, len(convs))
+	for _, conv := range convs {
+		item := buildConversationItem(conv)
+		if timeline, ok := timelines[conv.ConvId]; ok {
+			applyTimeline(item, conv, timeline)
+			if timeline.AtAllSeq > conv.ReadSeq {
+				item.Mentioned = true
+			}
+		}
+		items = append(items, item)
+	}
+	return items
+}
+
+// applyTimeline 以群时间线覆盖会话条目
+// 群从写扩散切换为读扩散前的消息记录在会话本身，只有时间线的最后消息更新时才覆盖
+// 未读数只计气泡消息：控制类通知与已销毁的消息不占未读
+func applyTimeline(item *pb.ConversationItem, conv *model.Conversation, timeline *model.GroupTimeline) {
+	if timeline.LastMsgAt == nil || (conv.LastMsgAt != nil && !timeline.LastMsgAt.After(*conv.LastMsgAt)) {
+		return
+	}
+	item.LastMsgId = timeline.LastMsgId
+	item.LastMsgPreview = timeline.LastMsgPrev
+	item.LastMsgTime = timeline.LastMsgAt.UnixMilli()
+	item.UnreadCount = 0
+	if timeline.BubbleCount > conv.ReadBubble {
+		item.UnreadCount = int32(timeline.BubbleCount - conv.ReadBubble)
+	}
+}
+
+// buildConversationItem 转换会话
+func buildConversationItem(conv *model.Conversation) *pb.ConversationItem {
+	var lastMsgTime int64
+	if conv.LastMsgAt != nil {
+		lastMsgTime = conv.LastMsgAt.UnixMilli()
+	}
+	return &pb.ConversationItem{
+		ConvId:         conv.ConvId,
+		Type:           int32(conv.Type),
+		TargetUuid:     conv.TargetUuid,
+		LastMsgId:      conv.LastMsgId,
+		LastMsgPreview: conv.LastMsgPrev,
+		LastMsgTime:    lastMsgTime,
+		UnreadCount:    int32(conv.UnreadCount),
+		ReadSeq:        conv.ReadSeq,
+		Mute:           conv.Mute,
+		Pin:            conv.Pin,
+		UpdatedAt:      conv.UpdatedAt.UnixMilli(),
+		Version:        conv.Version,
+		Mentioned:      conv.MentionSeq > conv.ReadSeq,
+	}
+}
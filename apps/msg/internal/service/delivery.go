@@ -0,0 +1,98 @@
+package service
+
+import (
+	"ChatServer/model"
+	"ChatServer/pkg/logger"
+	"ChatServer/pkg/msgcontent"
+	"context"
+)
+
+// 消息扩散模式
+// 写扩散：每条消息为每个参与者刷新会话记录（最后消息、未读数 +1），会话列表直接读取，适合单聊与小群。
+// 读扩散：成员数达到 ReadDiffusionThreshold 的群每条消息只推进一行群时间线，成员会话只维护已读游标，
+// 会话列表读取时由时间线覆盖最后消息，未读数按 bubble_count - read_bubble 计算（只计气泡消息），避免大群每条消息写 N 行。
+// 成员的群会话记录在入群时由群组服务调用 JoinGroupConversations 创建，投递时不再按成员补建。
+// 两种模式都会向参与者的在线设备实时下发，离线设备上线后按 seq 补拉。
+
+// deliver 按会话规模选择扩散模式投递消息，并实时下发给在线设备
+// 消息已落库，投递失败只记录日志，不影响发送结果
+func (s *messageServiceImpl) deliver(ctx context.Context, msg *model.Message) {
+	s.saveMentions(ctx, msg)
+	if s.useReadDiffusion(ctx, msg.ConvId) {
+		s.appendTimeline(ctx, msg)
+	} else {
+		s.refreshConversations(ctx, msg)
+	}
+	s.pushToParticipants(ctx, msg)
+}
+
+// useReadDiffusion 判断会话是否使用读扩散：仅成员数达到阈值的群聊
+// 群人数取自带短时缓存的 group_info.member_cnt，查询失败时按写扩散处理
+func (s *messageServiceImpl) useReadDiffusion(ctx context.Context, convID string) bool {
+	if s.cfg.ReadDiffusionThreshold <= 0 {
+		return false
+	}
+	if _, _, ok := splitP2PConvID(convID); ok {
+		return false
+	}
+
+	count, err := s.groupRepo.GetMemberCount(ctx, convID)
+	if err != nil {
+		logger.Warn(ctx, "查询群人数失败，按写扩散投递",
+			logger.String("conv_id", convID),
+			logger.ErrorField("error", err),
+		)
+		return false
+	}
+	return count >= s.cfg.ReadDiffusionThreshold
+}
+
+// appendTimeline 读扩散投递：推进群时间线，标记被@的成员，并推进发送者本人的已读游标
+func (s *messageServiceImpl) appendTimeline(ctx context.Context, msg *model.Message) {
+	timeline := &model.GroupTimeline{
+		ConvId:      msg.ConvId,
+		LastSeq:     msg.Seq,
+		LastMsgId:   msg.MsgId,
+		LastMsgAt:   &msg.SendTime,
+		LastMsgPrev: msgcontent.Preview(int32(msg.MsgType), msg.Content),
+	}
+	if msg.AtAll {
+		timeline.AtAllSeq = msg.Seq
+	}
+	var bubbles int64
+	if !msgcontent.IsControl(int32(msg.MsgType)) {
+		bubbles = 1
+	}
+	if err := s.timelineRepo.Advance(ctx, timeline, bubbles); err != nil {
+		logger.Error(ctx, "推进群时间线失败",
+			logger.String("conv_id", msg.ConvId),
+			logger.Int64("seq", msg.Seq),
+			logger.ErrorField("error", err),
+		)
+		return
+	}
+
+	// @所有人记录在时间线上，单独@的成员逐个推进 mention_seq
+	if err := s.conversationRepo.MarkMentioned(ctx, msg.ConvId, msg.AtUuids, msg.Seq); err != nil {
+		logger.Error(ctx, "标记被@成员失败",
+			logger.String("conv_id", msg.ConvId),
+			logger.ErrorField("error", err),
+		)
+	}
+
+	// 发送者自己的消息不计未读
+	convType, targetUUID := convTarget(msg.FromUuid, msg.ConvId)
+	_, err := s.conversationRepo.MarkRead(ctx, &model.Conversation{
+		ConvId:     msg.ConvId,
+		Type:       convType,
+		OwnerUuid:  msg.FromUuid,
+		TargetUuid: targetUUID,
+		ReadSeq:    msg.Seq,
+	})
+	if err != nil {
+		logger.Warn(ctx, "推进发送者已读游标失败",
+			logger.String("conv_id", msg.ConvId),
+			logger.ErrorField("error", err),
+		)
+	}
+}
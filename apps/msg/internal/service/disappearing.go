@@ -0,0 +1,293 @@
+package service
+
+import (
+	"ChatServer/apps/msg/internal/repository"
+	pb "ChatServer/apps/msg/pb"
+	"ChatServer/consts"
+	"ChatServer/model"
+	"ChatServer/pkg/logger"
+	"ChatServer/pkg/msgcontent"
+	"ChatServer/pkg/util"
+	"context"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// expiredPreview 最后一条消息到期销毁后的会话预览
+const expiredPreview = "[消息已过期]"
+
+// SetDisappearingTimer 设置会话的消息定时销毁时长
+// 业务流程：
+//  1. 校验存活时间：0 表示关闭，否则须在 [MinMessageTTL, MaxMessageTTL] 秒之间
+//  2. 校验管理权限：单聊双方均可设置；群聊仅群主/管理员
+//  3. 与当前设置比较，未变化时幂等返回（不分配通知序号）；
+//     否则分配设置变更通知的序号，同一事务内锁定设置复核后更新并写入通知（MsgTypeDisappearTimer）；
+//     复核时设置已被并发请求改为相同值则不写入通知，已分配的序号在会话内留下空洞，按序号补拉时通过 absent_seqs 返回
+//  4. 向会话全部参与者的在线设备下发通知，离线设备按 seq 拉取时收到
+//
+// 设置只影响之后发送的消息，已发送消息的过期时间不变。
+//
+// 错误码映射：
+//   - codes.InvalidArgument: 参数错误、存活时间无效
+//   - codes.PermissionDenied: 无权访问该会话、非群主/管理员
+//   - codes.Internal: 系统内部错误
+func (s *messageServiceImpl) SetDisappearingTimer(ctx context.Context, req *pb.SetDisappearingTimerRequest) (*pb.SetDisappearingTimerResponse, error) {
+	operatorUUID := util.GetUserUUIDFromContext(ctx)
+	if operatorUUID == "" {
+		logger.Error(ctx, "从 context 中获取 user_uuid 失败")
+		return nil, status.Error(codes.Internal, strconv.Itoa(consts.CodeInternalError))
+	}
+
+	// 1. 校验参数
+	if req.ConvId == "" {
+		return nil, status.Error(codes.InvalidArgument, strconv.Itoa(consts.CodeParamError))
+	}
+	if req.Ttl != 0 && !validTTL(req.Ttl) {
+		return nil, status.Error(codes.InvalidArgument, strconv.Itoa(consts.CodeMessageTTLInvalid))
+	}
+
+	// 2. 校验管理权限
+	if err := s.checkManagePermission(ctx, operatorUUID, req.ConvId); err != nil {
+		return nil, err
+	}
+
+	// 3. 与当前设置比较，再更新设置并写入设置变更通知
+	current, err := s.conversationTTL(ctx, req.ConvId)
+	if err != nil {
+		return nil, err
+	}
+	if current == req.Ttl {
+		return &pb.SetDisappearingTimerResponse{ConvId: req.ConvId, Ttl: req.Ttl, Changed: false}, nil
+	}
+
+	now := time.Now()
+	content, _ := json.Marshal(msgcontent.DisappearTimerContent{
+		Ttl:          int64(req.Ttl),
+		OperatorUuid: operatorUUID,
+	})
+	notifyID := util.GenIDString()
+	notify := &model.Message{
+		ConvId:      req.ConvId,
+		MsgId:       notifyID,
+		ClientMsgId: "timer_" + notifyID,
+		FromUuid:    operatorUUID,
+		MsgType:     consts.MsgTypeDisappearTimer,
+		Content:     string(content),
+		Status:      0,
+		SendTime:    now,
+	}
+	// 通知按变更后的设置过期：开启后随之后的消息一起销毁，关闭后永久保存
+	setExpireAt(notify, req.Ttl)
+	seq, _, err := s.seqRepo.Allocate(ctx, req.ConvId, 1)
+	if err != nil {
+		logger.Error(ctx, "分配消息序号失败",
+			logger.String("conv_id", req.ConvId),
+			logger.ErrorField("error", err),
+		)
+		return nil, status.Error(codes.Internal, strconv.Itoa(consts.CodeInternalError))
+	}
+	notify.Seq = seq
+
+	changed, err := s.messageRepo.SetDisappearingTimer(ctx, &model.DisappearingTimer{
+		ConvId:       req.ConvId,
+		Ttl:          req.Ttl,
+		OperatorUuid: operatorUUID,
+	}, notify)
+	if err != nil {
+		s.handleNotifyWriteError(ctx, req.ConvId, err)
+		logger.Error(ctx, "设置会话定时销毁失败",
+			logger.String("conv_id", req.ConvId),
+			logger.ErrorField("error", err),
+		)
+		return nil, status.Error(codes.Internal, strconv.Itoa(consts.CodeInternalError))
+	}
+	if !changed {
+		// 并发请求已写入相同设置：不重复写入通知，notify.Seq 成为空洞（与落库失败留下的空洞相同，客户端按 absent_seqs 停止补拉）
+		return &pb.SetDisappearingTimerResponse{ConvId: req.ConvId, Ttl: req.Ttl, Changed: false}, nil
+	}
+
+	// 4. 实时下发设置变更通知
+	s.pushToParticipants(ctx, notify)
+
+	logger.Info(ctx, "会话定时销毁设置成功",
+		logger.String("conv_id", req.ConvId),
+		logger.Int("ttl", int(req.Ttl)),
+		logger.String("operator_uuid", operatorUUID),
+	)
+	return &pb.SetDisappearingTimerResponse{
+		ConvId:    req.ConvId,
+		Ttl:       req.Ttl,
+		Changed:   true,
+		NotifySeq: notify.Seq,
+	}, nil
+}
+
+// GetDisappearingTimer 查询会话的消息定时销毁设置，未设置过返回 ttl 为 0
+//
+// 错误码映射：
+//   - codes.InvalidArgument: 参数错误
+//   - codes.PermissionDenied: 无权访问该会话
+//   - codes.Internal: 系统内部错误
+func (s *messageServiceImpl) GetDisappearingTimer(ctx context.Context, req *pb.GetDisappearingTimerRequest) (*pb.GetDisappearingTimerResponse, error) {
+	userUUID := util.GetUserUUIDFromContext(ctx)
+	if userUUID == "" {
+		logger.Error(ctx, "从 context 中获取 user_uuid 失败")
+		return nil, status.Error(codes.Internal, strconv.Itoa(consts.CodeInternalError))
+	}
+	if req.ConvId == "" {
+		return nil, status.Error(codes.InvalidArgument, strconv.Itoa(consts.CodeParamError))
+	}
+	if err := s.checkConvAccess(ctx, userUUID, req.ConvId); err != nil {
+		return nil, err
+	}
+
+	timer, err := s.messageRepo.GetDisappearingTimer(ctx, req.ConvId)
+	if err != nil {
+		if errors.Is(err, repository.ErrRecordNotFound) {
+			return &pb.GetDisappearingTimerResponse{}, nil
+		}
+		logger.Error(ctx, "查询会话定时销毁设置失败",
+			logger.String("conv_id", req.ConvId),
+			logger.ErrorField("error", err),
+		)
+		return nil, status.Error(codes.Internal, strconv.Itoa(consts.CodeInternalError))
+	}
+	return &pb.GetDisappearingTimerResponse{
+		Ttl:          timer.Ttl,
+		OperatorUuid: timer.OperatorUuid,
+		UpdateTime:   timer.UpdatedAt.UnixMilli(),
+	}, nil
+}
+
+// PurgeExpiredMessages 物理删除一批已到期的消息，返回删除条数
+// 删除后刷新以被删消息为最后一条消息的会话预览，并按会话向参与者的在线设备下发销毁通知，
+// 离线设备按 seq 补拉时这些序号作为 absent_seqs 返回。
+func (s *messageServiceImpl) PurgeExpiredMessages(ctx context.Context, now time.Time, limit int) (int, error) {
+	msgs, err := s.messageRepo.PurgeExpired(ctx, now, limit)
+	if err != nil {
+		return 0, err
+	}
+
+	// 按会话分组，保持首次出现的顺序
+	convIDs := make([]string, 0)
+	byConv := make(map[string][]*model.Message)
+	for _, msg := range msgs {
+		if _, ok := byConv[msg.ConvId]; !ok {
+			convIDs = append(convIDs, msg.ConvId)
+		}
+		byConv[msg.ConvId] = append(byConv[msg.ConvId], msg)
+	}
+
+	for _, convID := range convIDs {
+		expired := byConv[convID]
+		event := &pb.MessagesExpired{
+			ConvId: convID,
+			MsgIds: make([]string, 0, len(expired)),
+			Seqs:   make([]int64, 0, len(expired)),
+		}
+		latest := expired[0]
+		bubbleSeqs := make([]int64, 0, len(expired))
+		for _, msg := range expired {
+			event.MsgIds = append(event.MsgIds, msg.MsgId)
+			event.Seqs = append(event.Seqs, msg.Seq)
+			if msg.Seq > latest.Seq {
+				latest = msg
+			}
+			if !msgcontent.IsControl(int32(msg.MsgType)) {
+				bubbleSeqs = append(bubbleSeqs, msg.Seq)
+			}
+		}
+
+		// 会话的最后一条消息只可能是本批中序号最大的一条
+		s.refreshExpiredPreview(ctx, latest)
+		s.removeExpiredBubbles(ctx, convID, bubbleSeqs)
+
+		targetConvID := convID
+		s.pushAsync(ctx, &pb.PushEnvelope{
+			Payload: &pb.PushEnvelope_Expired{Expired: event},
+		}, func(ctx context.Context) ([]string, error) {
+			return s.participants(ctx, targetConvID)
+		})
+	}
+	return len(msgs), nil
+}
+
+// refreshExpiredPreview 刷新以过期消息为最后一条消息的会话预览（写扩散的成员会话与读扩散的群时间线）
+// 失败只记录日志，预览在会话的下一条消息到达时覆盖
+func (s *messageServiceImpl) refreshExpiredPreview(ctx context.Context, msg *model.Message) {
+	if err := s.conversationRepo.UpdatePreviewByLastMsg(ctx, msg.ConvId, msg.MsgId, expiredPreview); err != nil {
+		logger.Warn(ctx, "刷新会话预览失败",
+			logger.String("conv_id", msg.ConvId),
+			logger.ErrorField("error", err),
+		)
+	}
+	if _, _, ok := splitP2PConvID(msg.ConvId); !ok {
+		if err := s.timelineRepo.UpdatePreviewByLastMsg(ctx, msg.ConvId, msg.MsgId, expiredPreview); err != nil {
+			logger.Warn(ctx, "刷新群时间线预览失败",
+				logger.String("conv_id", msg.ConvId),
+				logger.ErrorField("error", err),
+			)
+		}
+	}
+}
+
+// removeExpiredBubbles 从读扩散群的时间线扣减已销毁的气泡消息，使成员未读数不再计入
+// 失败只记录日志：未扣减时成员未读数偏大，成员上报已读后按存活消息重新计算
+func (s *messageServiceImpl) removeExpiredBubbles(ctx context.Context, convID string, seqs []int64) {
+	if len(seqs) == 0 {
+		return
+	}
+	if _, _, ok := splitP2PConvID(convID); ok {
+		return
+	}
+	if err := s.timelineRepo.RemoveBubbles(ctx, convID, seqs); err != nil {
+		logger.Warn(ctx, "扣减群时间线气泡消息数失败",
+			logger.String("conv_id", convID),
+			logger.ErrorField("error", err),
+		)
+	}
+}
+
+// conversationTTL 查询会话的定时销毁时长（秒），未开启返回 0
+func (s *messageServiceImpl) conversationTTL(ctx context.Context, convID string) (int32, error) {
+	timer, err := s.messageRepo.GetDisappearingTimer(ctx, convID)
+	if err != nil {
+		if errors.Is(err, repository.ErrRecordNotFound) {
+			return 0, nil
+		}
+		logger.Error(ctx, "查询会话定时销毁设置失败",
+			logger.String("conv_id", convID),
+			logger.ErrorField("error", err),
+		)
+		return 0, status.Error(codes.Internal, strconv.Itoa(consts.CodeInternalError))
+	}
+	return timer.Ttl, nil
+}
+
+// setExpireAt 按存活时间（秒）计算消息的过期时间，ttl 为 0 表示永久保存
+func setExpireAt(msg *model.Message, ttl int32) {
+	if ttl <= 0 {
+		return
+	}
+	expireAt := msg.SendTime.Add(time.Duration(ttl) * time.Second)
+	msg.ExpireAt = &expireAt
+}
+
+// setNotifyExpireAt 按会话定时销毁设置计算控制类通知的过期时间
+// 通知指向的消息先过期时随之销毁（消息已不存在，通知不再有意义）
+func setNotifyExpireAt(notify *model.Message, ttl int32, target *model.Message) {
+	setExpireAt(notify, ttl)
+	if target.ExpireAt != nil && (notify.ExpireAt == nil || target.ExpireAt.Before(*notify.ExpireAt)) {
+		notify.ExpireAt = target.ExpireAt
+	}
+}
+
+// validTTL 校验存活时间是否在允许范围内
+func validTTL(ttl int32) bool {
+	return ttl >= consts.MinMessageTTL && ttl <= consts.MaxMessageTTL
+}
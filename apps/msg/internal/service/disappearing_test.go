@@ -0,0 +1,68 @@
+package service
+
+import (
+	"ChatServer/apps/msg/internal/repository"
+	pb "ChatServer/apps/msg/pb"
+	"ChatServer/consts"
+	"ChatServer/model"
+	"ChatServer/pkg/util"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeTimerRepo 模拟并发设置：服务端比较时尚未开启，事务内复核时已被另一请求改为相同值
+type fakeTimerRepo struct {
+	repository.IMessageRepository
+
+	msgs []*model.Message
+}
+
+func (r *fakeTimerRepo) GetDisappearingTimer(ctx context.Context, convID string) (*model.DisappearingTimer, error) {
+	return nil, repository.ErrRecordNotFound
+}
+
+func (r *fakeTimerRepo) SetDisappearingTimer(ctx context.Context, timer *model.DisappearingTimer, notify *model.Message) (bool, error) {
+	return false, nil
+}
+
+func (r *fakeTimerRepo) ListBySeqs(ctx context.Context, convID string, seqs []int64) ([]*model.Message, error) {
+	return r.msgs, nil
+}
+
+func (r *fakeTimerRepo) ListReactionCounts(ctx context.Context, msgIDs []string) ([]*repository.ReactionCount, error) {
+	return nil, nil
+}
+
+// fakeSeqRepo 顺序分配会话内序号
+type fakeSeqRepo struct {
+	repository.ISeqRepository
+
+	last int64
+}
+
+func (r *fakeSeqRepo) Allocate(ctx context.Context, convID string, n int64) (int64, int64, error) {
+	r.last += n
+	return r.last - n + 1, r.last, nil
+}
+
+// TestSetDisappearingTimer_UnchangedLeavesAbsentSeq 测试复核发现设置未变化时不写入通知，已分配的序号按 absent_seqs 返回
+func TestSetDisappearingTimer_UnchangedLeavesAbsentSeq(t *testing.T) {
+	ctx := context.WithValue(context.Background(), util.ContextKeyUserUUID, "u1")
+	messageRepo := &fakeTimerRepo{msgs: []*model.Message{{ConvId: "u1_u2", MsgId: "m1", Seq: 1}}}
+	seqRepo := &fakeSeqRepo{last: 1}
+	svc := &messageServiceImpl{messageRepo: messageRepo, seqRepo: seqRepo}
+
+	resp, err := svc.SetDisappearingTimer(ctx, &pb.SetDisappearingTimerRequest{ConvId: "u1_u2", Ttl: consts.MinMessageTTL})
+	require.NoError(t, err)
+	assert.False(t, resp.Changed)
+	assert.Zero(t, resp.NotifySeq)
+	require.Equal(t, int64(2), seqRepo.last, "序号已在复核前分配")
+
+	pulled, err := svc.GetMessagesBySeqs(ctx, &pb.GetMessagesBySeqsRequest{ConvId: "u1_u2", Seqs: []int64{1, 2}})
+	require.NoError(t, err)
+	require.Len(t, pulled.Messages, 1)
+	assert.Equal(t, []int64{2}, pulled.AbsentSeqs)
+}
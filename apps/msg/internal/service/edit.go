@@ -0,0 +1,188 @@
+package service
+
+import (
+	"ChatServer/apps/msg/internal/repository"
+	pb "ChatServer/apps/msg/pb"
+	"ChatServer/consts"
+	"ChatServer/model"
+	"ChatServer/pkg/logger"
+	"ChatServer/pkg/msgcontent"
+	"ChatServer/pkg/util"
+	"context"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// EditMessage 编辑消息
+// 业务流程：
+//  1. 查询被编辑的消息：仅文本消息可编辑，已撤回/已删除直接拒绝
+//  2. 校验编辑权限：仅发送者本人、仍可访问该会话且在编辑时限内
+//  3. 按原消息类型校验新内容
+//  4. 分配编辑通知的序号，同一事务内以版本号为乐观锁更新消息、写入修订记录与编辑通知（MsgTypeEdit）；
+//     首次编辑时同时保存原始版本；编辑通知携带新内容，按会话定时销毁设置过期，且不晚于原消息销毁
+//  5. 刷新以该消息为最后一条消息的会话预览
+//  6. 向会话全部参与者的在线设备下发编辑通知，离线设备按 seq 拉取时收到，按 version 只应用最新版本
+//
+// 错误码映射：
+//   - codes.InvalidArgument: 参数错误、消息类型不可编辑、内容校验失败
+//   - codes.NotFound: 消息不存在
+//   - codes.PermissionDenied: 非发送者、无权访问该会话
+//   - codes.FailedPrecondition: 已撤回、已删除、超过编辑时限
+//   - codes.Aborted: 并发编辑冲突
+//   - codes.Internal: 系统内部错误
+func (s *messageServiceImpl) EditMessage(ctx context.Context, req *pb.EditMessageRequest) (*pb.EditMessageResponse, error) {
+	operatorUUID := util.GetUserUUIDFromContext(ctx)
+	if operatorUUID == "" {
+		logger.Error(ctx, "从 context 中获取 user_uuid 失败")
+		return nil, status.Error(codes.Internal, strconv.Itoa(consts.CodeInternalError))
+	}
+	if req.MsgId == "" {
+		return nil, status.Error(codes.InvalidArgument, strconv.Itoa(consts.CodeParamError))
+	}
+
+	// 1. 查询被编辑的消息
+	msg, err := s.messageRepo.GetByMsgId(ctx, req.MsgId)
+	if err != nil {
+		if errors.Is(err, repository.ErrRecordNotFound) {
+			return nil, status.Error(codes.NotFound, strconv.Itoa(consts.CodeMessageNotFound))
+		}
+		logger.Error(ctx, "查询消息失败",
+			logger.String("msg_id", req.MsgId),
+			logger.ErrorField("error", err),
+		)
+		return nil, status.Error(codes.Internal, strconv.Itoa(consts.CodeInternalError))
+	}
+	if msg.MsgType != consts.MsgTypeText {
+		return nil, status.Error(codes.InvalidArgument, strconv.Itoa(consts.CodeMessageTypeNotSupport))
+	}
+	if err := checkMessageAvailable(msg); err != nil {
+		return nil, err
+	}
+
+	// 2. 校验编辑权限
+	if operatorUUID != msg.FromUuid {
+		return nil, status.Error(codes.PermissionDenied, strconv.Itoa(consts.CodeNoPermission))
+	}
+	if err := s.checkConvAccess(ctx, operatorUUID, msg.ConvId); err != nil {
+		return nil, err
+	}
+	if time.Since(msg.SendTime) > s.cfg.EditWindow {
+		return nil, status.Error(codes.FailedPrecondition, strconv.Itoa(consts.CodeMessageEditTimeout))
+	}
+
+	// 3. 校验新内容
+	if len(req.Content) > maxContentLen {
+		return nil, status.Error(codes.InvalidArgument, strconv.Itoa(consts.CodeMessageTooLong))
+	}
+	if _, err := msgcontent.Parse(int32(msg.MsgType), req.Content); err != nil {
+		return nil, contentError(err)
+	}
+
+	// 4. 更新消息并写入编辑通知
+	ttl, err := s.conversationTTL(ctx, msg.ConvId)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	version := msg.Version + 1
+	revision := &model.MessageRevision{
+		MsgId:      msg.MsgId,
+		Version:    version,
+		ConvId:     msg.ConvId,
+		Content:    req.Content,
+		EditorUuid: operatorUUID,
+		RevisedAt:  now,
+	}
+	var original *model.MessageRevision
+	if msg.Version == 0 {
+		original = &model.MessageRevision{
+			MsgId:      msg.MsgId,
+			Version:    0,
+			ConvId:     msg.ConvId,
+			Content:    msg.Content,
+			EditorUuid: msg.FromUuid,
+			RevisedAt:  msg.SendTime,
+		}
+	}
+	content, _ := json.Marshal(msgcontent.EditContent{
+		MsgId:   msg.MsgId,
+		Seq:     msg.Seq,
+		Version: version,
+		Content: json.RawMessage(req.Content),
+	})
+	notify := &model.Message{
+		ConvId:      msg.ConvId,
+		MsgId:       util.GenIDString(),
+		ClientMsgId: "edit_" + msg.MsgId + "_" + strconv.Itoa(int(version)),
+		FromUuid:    operatorUUID,
+		MsgType:     consts.MsgTypeEdit,
+		Content:     string(content),
+		Status:      0,
+		SendTime:    now,
+	}
+	setNotifyExpireAt(notify, ttl, msg)
+	seq, _, err := s.seqRepo.Allocate(ctx, msg.ConvId, 1)
+	if err != nil {
+		logger.Error(ctx, "分配消息序号失败",
+			logger.String("conv_id", msg.ConvId),
+			logger.ErrorField("error", err),
+		)
+		return nil, status.Error(codes.Internal, strconv.Itoa(consts.CodeInternalError))
+	}
+	notify.Seq = seq
+
+	if err := s.messageRepo.Edit(ctx, original, revision, notify); err != nil {
+		// 并发编辑或撤回：版本号已变化
+		if errors.Is(err, repository.ErrRecordNotFound) {
+			return nil, status.Error(codes.Aborted, strconv.Itoa(consts.CodeMessageEditConflict))
+		}
+		// 序号冲突或同一版本的编辑通知已存在：抬升序号后由客户端重试
+		if errors.Is(err, repository.ErrDuplicateKey) {
+			if syncErr := s.seqRepo.Resync(ctx, msg.ConvId); syncErr != nil {
+				logger.Error(ctx, "同步消息序号失败", logger.ErrorField("error", syncErr))
+			}
+		}
+		logger.Error(ctx, "编辑消息失败",
+			logger.String("msg_id", msg.MsgId),
+			logger.ErrorField("error", err),
+		)
+		return nil, status.Error(codes.Internal, strconv.Itoa(consts.CodeInternalError))
+	}
+
+	// 5. 刷新会话预览（写扩散的成员会话与读扩散的群时间线，失败不影响编辑结果）
+	preview := msgcontent.Preview(int32(msg.MsgType), req.Content)
+	if err := s.conversationRepo.UpdatePreviewByLastMsg(ctx, msg.ConvId, msg.MsgId, preview); err != nil {
+		logger.Warn(ctx, "刷新会话预览失败",
+			logger.String("conv_id", msg.ConvId),
+			logger.ErrorField("error", err),
+		)
+	}
+	if _, _, ok := splitP2PConvID(msg.ConvId); !ok {
+		if err := s.timelineRepo.UpdatePreviewByLastMsg(ctx, msg.ConvId, msg.MsgId, preview); err != nil {
+			logger.Warn(ctx, "刷新群时间线预览失败",
+				logger.String("conv_id", msg.ConvId),
+				logger.ErrorField("error", err),
+			)
+		}
+	}
+
+	// 6. 实时下发编辑通知
+	s.pushToParticipants(ctx, notify)
+
+	logger.Info(ctx, "消息编辑成功",
+		logger.String("conv_id", msg.ConvId),
+		logger.String("msg_id", msg.MsgId),
+		logger.Int("version", int(version)),
+	)
+	return &pb.EditMessageResponse{
+		ConvId:    msg.ConvId,
+		Version:   version,
+		NotifySeq: notify.Seq,
+		EditTime:  now.UnixMilli(),
+	}, nil
+}
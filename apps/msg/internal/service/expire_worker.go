@@ -0,0 +1,76 @@
+package service
+
+import (
+	"ChatServer/pkg/logger"
+	"context"
+	"time"
+)
+
+// ExpireWorkerOptions 过期消息清理任务参数
+type ExpireWorkerOptions struct {
+	Interval  time.Duration // 扫描到期消息的间隔
+	BatchSize int           // 每批最多删除的消息数（控制单个事务的大小）
+}
+
+// DefaultExpireWorkerOptions 返回默认清理任务参数：每秒扫描一次，每批 500 条
+func DefaultExpireWorkerOptions() ExpireWorkerOptions {
+	return ExpireWorkerOptions{
+		Interval:  time.Second,
+		BatchSize: 500,
+	}
+}
+
+// ExpireWorker 过期消息清理任务
+// 按 expire_at 索引分批物理删除到期消息，一批删满时立即继续下一批，直到没有到期消息。
+// 多实例部署时各实例跳过其他实例已锁定的行，互不重复删除；删除前的短暂窗口内，查询接口已按过期时间过滤。
+type ExpireWorker struct {
+	opts           ExpireWorkerOptions
+	messageService MessageService
+}
+
+// NewExpireWorker 创建过期消息清理任务
+func NewExpireWorker(opts ExpireWorkerOptions, messageService MessageService) *ExpireWorker {
+	defaults := DefaultExpireWorkerOptions()
+	if opts.Interval <= 0 {
+		opts.Interval = defaults.Interval
+	}
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = defaults.BatchSize
+	}
+	return &ExpireWorker{
+		opts:           opts,
+		messageService: messageService,
+	}
+}
+
+// Run 定时清理到期消息，直到 ctx 取消
+func (w *ExpireWorker) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.opts.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			w.purgeDue(ctx)
+		}
+	}
+}
+
+// purgeDue 分批删除到期消息，一批删满时继续删除下一批
+func (w *ExpireWorker) purgeDue(ctx context.Context) {
+	for ctx.Err() == nil {
+		purged, err := w.messageService.PurgeExpiredMessages(ctx, time.Now(), w.opts.BatchSize)
+		if err != nil {
+			logger.Error(ctx, "清理过期消息失败", logger.ErrorField("error", err))
+			return
+		}
+		if purged > 0 {
+			logger.Info(ctx, "过期消息清理完成", logger.Int("count", purged))
+		}
+		if purged < w.opts.BatchSize {
+			return
+		}
+	}
+}
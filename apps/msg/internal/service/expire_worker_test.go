@@ -0,0 +1,168 @@
+package service
+
+import (
+	"ChatServer/apps/msg/internal/repository"
+	"ChatServer/consts"
+	"ChatServer/model"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/glebarez/sqlite"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+)
+
+// fakePurger 模拟 PurgeExpiredMessages：每次最多删除 limit 条，记录每批的大小
+type fakePurger struct {
+	MessageService
+
+	remaining int
+	batches   []int
+	err       error
+}
+
+func (f *fakePurger) PurgeExpiredMessages(ctx context.Context, now time.Time, limit int) (int, error) {
+	if f.err != nil {
+		return 0, f.err
+	}
+	n := min(f.remaining, limit)
+	f.remaining -= n
+	f.batches = append(f.batches, n)
+	return n, nil
+}
+
+// TestExpireWorker_PurgeDue 测试一批删满时继续删除，直到不足一批
+func TestExpireWorker_PurgeDue(t *testing.T) {
+	ctx := context.Background()
+	purger := &fakePurger{remaining: 25}
+	NewExpireWorker(ExpireWorkerOptions{BatchSize: 10}, purger).purgeDue(ctx)
+	assert.Equal(t, []int{10, 10, 5}, purger.batches)
+	assert.Equal(t, 0, purger.remaining)
+
+	// 恰好整批时多查一次空批后停止
+	purger = &fakePurger{remaining: 10}
+	NewExpireWorker(ExpireWorkerOptions{BatchSize: 10}, purger).purgeDue(ctx)
+	assert.Equal(t, []int{10, 0}, purger.batches)
+
+	purger = &fakePurger{remaining: 10, err: errors.New("db down")}
+	NewExpireWorker(ExpireWorkerOptions{BatchSize: 10}, purger).purgeDue(ctx)
+	assert.Empty(t, purger.batches)
+}
+
+// fakeExpireMessageRepo 返回预置的一批过期消息
+type fakeExpireMessageRepo struct {
+	repository.IMessageRepository
+
+	expired []*model.Message
+}
+
+func (r *fakeExpireMessageRepo) PurgeExpired(ctx context.Context, now time.Time, limit int) ([]*model.Message, error) {
+	return r.expired, nil
+}
+
+// fakePreviewRepo 记录按最后一条消息刷新预览的调用
+type fakePreviewRepo struct {
+	repository.IConversationRepository
+	repository.ITimelineRepository
+
+	refreshed map[string]string  // conv_id -> msg_id
+	removed   map[string][]int64 // conv_id -> 扣减的气泡消息序号
+}
+
+func (r *fakePreviewRepo) UpdatePreviewByLastMsg(ctx context.Context, convID, msgID, preview string) error {
+	r.refreshed[convID] = msgID
+	return nil
+}
+
+func (r *fakePreviewRepo) RemoveBubbles(ctx context.Context, convID string, seqs []int64) error {
+	r.removed[convID] = append(r.removed[convID], seqs...)
+	return nil
+}
+
+// TestPurgeExpiredMessages_RefreshPreview 测试每个会话只按本批序号最大的过期消息刷新预览，
+// 群会话只从时间线扣减气泡消息
+func TestPurgeExpiredMessages_RefreshPreview(t *testing.T) {
+	messageRepo := &fakeExpireMessageRepo{expired: []*model.Message{
+		{ConvId: "u1_u2", MsgId: "m2", Seq: 2},
+		{ConvId: "g1", MsgId: "m7", Seq: 7},
+		{ConvId: "g1", MsgId: "m6", Seq: 6, MsgType: consts.MsgTypeEdit},
+		{ConvId: "u1_u2", MsgId: "m5", Seq: 5},
+		{ConvId: "u1_u2", MsgId: "m3", Seq: 3},
+	}}
+	convRepo := &fakePreviewRepo{refreshed: map[string]string{}}
+	timelineRepo := &fakePreviewRepo{refreshed: map[string]string{}, removed: map[string][]int64{}}
+	svc := &messageServiceImpl{
+		messageRepo:      messageRepo,
+		conversationRepo: convRepo,
+		timelineRepo:     timelineRepo,
+	}
+
+	purged, err := svc.PurgeExpiredMessages(context.Background(), time.Now(), 100)
+	require.NoError(t, err)
+	assert.Equal(t, 5, purged)
+	assert.Equal(t, map[string]string{"u1_u2": "m5", "g1": "m7"}, convRepo.refreshed)
+	assert.Equal(t, map[string]string{"g1": "m7"}, timelineRepo.refreshed, "单聊会话不刷新群时间线")
+	assert.Equal(t, map[string][]int64{"g1": {7}}, timelineRepo.removed, "控制类通知不计入气泡消息数")
+}
+
+// TestPurgeExpiredMessages_KeepsListOrder 测试最后一条消息到期改写预览后，会话列表顺序不变
+func TestPurgeExpiredMessages_KeepsListOrder(t *testing.T) {
+	ctx := context.Background()
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{Logger: gormlogger.Discard})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&model.Conversation{}, &model.ConversationVersion{}))
+
+	base := time.Now().Add(-time.Hour)
+	require.NoError(t, db.Create([]*model.Conversation{
+		{ConvId: "u1_u2", OwnerUuid: "u1", TargetUuid: "u2", LastMsgId: "m5", LastMsgPrev: "hi", UpdatedAt: base},
+		{ConvId: "u1_u3", OwnerUuid: "u1", TargetUuid: "u3", LastMsgId: "m9", LastMsgPrev: "hello", UpdatedAt: base.Add(time.Minute)},
+	}).Error)
+	convRepo := repository.NewConversationRepository(db, nil)
+	svc := &messageServiceImpl{
+		messageRepo: &fakeExpireMessageRepo{expired: []*model.Message{
+			{ConvId: "u1_u2", MsgId: "m5", Seq: 5},
+		}},
+		conversationRepo: convRepo,
+	}
+
+	_, err = svc.PurgeExpiredMessages(ctx, time.Now(), 100)
+	require.NoError(t, err)
+
+	list, _, err := convRepo.ListByOwner(ctx, "u1", 1, 10)
+	require.NoError(t, err)
+	require.Len(t, list, 2)
+	assert.Equal(t, []string{"u1_u3", "u1_u2"}, []string{list[0].ConvId, list[1].ConvId})
+	assert.Equal(t, expiredPreview, list[1].LastMsgPrev)
+}
+
+// TestSetNotifyExpireAt 测试控制类通知按会话定时销毁设置过期，且不晚于指向的消息
+func TestSetNotifyExpireAt(t *testing.T) {
+	now := time.Now()
+	soon := now.Add(time.Minute)
+	later := now.Add(2 * time.Hour)
+
+	notify := &model.Message{SendTime: now}
+	setNotifyExpireAt(notify, 3600, &model.Message{})
+	require.NotNil(t, notify.ExpireAt)
+	assert.True(t, notify.ExpireAt.Equal(now.Add(time.Hour)))
+
+	notify = &model.Message{SendTime: now}
+	setNotifyExpireAt(notify, 3600, &model.Message{ExpireAt: &soon})
+	assert.True(t, notify.ExpireAt.Equal(soon), "指向的消息先过期时随之过期")
+
+	notify = &model.Message{SendTime: now}
+	setNotifyExpireAt(notify, 3600, &model.Message{ExpireAt: &later})
+	assert.True(t, notify.ExpireAt.Equal(now.Add(time.Hour)))
+
+	notify = &model.Message{SendTime: now}
+	setNotifyExpireAt(notify, 0, &model.Message{ExpireAt: &later})
+	assert.True(t, notify.ExpireAt.Equal(later), "会话未开启定时销毁时沿用指向消息的过期时间")
+
+	notify = &model.Message{SendTime: now}
+	setNotifyExpireAt(notify, 0, &model.Message{})
+	assert.Nil(t, notify.ExpireAt)
+}
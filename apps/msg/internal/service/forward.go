@@ -0,0 +1,320 @@
+package service
+
+import (
+	"ChatServer/apps/msg/internal/repository"
+	pb "ChatServer/apps/msg/pb"
+	"ChatServer/consts"
+	"ChatServer/model"
+	"ChatServer/pkg/logger"
+	"ChatServer/pkg/msgcontent"
+	"ChatServer/pkg/util"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+	"unicode/utf8"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// forwardModeEach 逐条转发
+	forwardModeEach = 0
+	// forwardModeMerge 合并转发为一条聊天记录
+	forwardModeMerge = 1
+
+	// maxForwardTargets 单次转发最多目标会话数
+	maxForwardTargets = 9
+	// maxForwardTitleRunes 合并转发标题最大字符数
+	maxForwardTitleRunes = 64
+	// defaultForwardTitle 合并转发默认标题
+	defaultForwardTitle = "聊天记录"
+)
+
+// buildQuoteContent 为引用回复写入被引用消息的快照
+// 被引用消息须属于同一会话、未撤回且不是控制类消息；快照写入新消息的 content，原消息之后撤回也不影响
+func (s *messageServiceImpl) buildQuoteContent(ctx context.Context, convID, raw string) (string, error) {
+	var quote msgcontent.QuoteContent
+	if err := json.Unmarshal([]byte(raw), &quote); err != nil {
+		return "", status.Error(codes.InvalidArgument, strconv.Itoa(consts.CodeMessageContentInvalid))
+	}
+
+	quoted, err := s.messageRepo.GetByMsgId(ctx, quote.QuoteMsgId)
+	if err != nil {
+		if errors.Is(err, repository.ErrRecordNotFound) {
+			return "", status.Error(codes.NotFound, strconv.Itoa(consts.CodeMessageNotFound))
+		}
+		logger.Error(ctx, "查询被引用消息失败",
+			logger.String("quote_msg_id", quote.QuoteMsgId),
+			logger.ErrorField("error", err),
+		)
+		return "", status.Error(codes.Internal, strconv.Itoa(consts.CodeInternalError))
+	}
+	if quoted.ConvId != convID || msgcontent.IsControl(int32(quoted.MsgType)) {
+		return "", status.Error(codes.NotFound, strconv.Itoa(consts.CodeMessageNotFound))
+	}
+	if err := checkMessageAvailable(quoted); err != nil {
+		return "", err
+	}
+
+	quote.QuoteSeq = quoted.Seq
+	quote.QuoteFromUuid = quoted.FromUuid
+	quote.QuoteMsgType = int32(quoted.MsgType)
+	quote.QuotePreview = msgcontent.Preview(int32(quoted.MsgType), quoted.Content)
+	content, _ := json.Marshal(quote)
+	return string(content), nil
+}
+
+// checkMessageAvailable 校验消息未撤回、未删除
+func checkMessageAvailable(msg *model.Message) error {
+	switch msg.Status {
+	case 0:
+		return nil
+	case 1:
+		return status.Error(codes.FailedPrecondition, strconv.Itoa(consts.CodeMessageRevoked))
+	default:
+		return status.Error(codes.FailedPrecondition, strconv.Itoa(consts.CodeMessageDeleted))
+	}
+}
+
+// ForwardMessages 转发消息
+// 业务流程：
+//  1. 校验参数，校验当前用户可读取来源会话
+//  2. 查询被转发的消息：须全部属于来源会话、未撤回且不是控制类消息
+//  3. 逐条转发：每条消息原样复制到目标会话（不保留@提及）；
+//     合并转发：将消息快照按 seq 升序打包为一条聊天记录消息（MsgTypeMergeForward）
+//  4. 逐个目标会话校验发送权限（单聊：黑名单/好友；群聊：成员身份/禁言），不通过的目标记录错误码并跳过
+//  5. 为每条转发消息派生幂等ID（client_msg_id_目标序号_消息序号），按目标会话的定时销毁设置计算过期时间，
+//     落库并投递，客户端重试不会重复转发
+//
+// 错误码映射（目标会话的权限错误通过 results[].code 返回，不影响其他目标）：
+//   - codes.InvalidArgument: 参数错误、转发控制类消息、合并后内容过长
+//   - codes.PermissionDenied: 无权访问来源会话
+//   - codes.NotFound: 消息不存在
+//   - codes.FailedPrecondition: 消息已撤回、已删除
+//   - codes.Internal: 系统内部错误
+func (s *messageServiceImpl) ForwardMessages(ctx context.Context, req *pb.ForwardMessagesRequest) (*pb.ForwardMessagesResponse, error) {
+	// 1. 校验参数
+	userUUID := util.GetUserUUIDFromContext(ctx)
+	if userUUID == "" {
+		logger.Error(ctx, "从 context 中获取 user_uuid 失败")
+		return nil, status.Error(codes.Internal, strconv.Itoa(consts.CodeInternalError))
+	}
+	msgIDs, err := validateForwardRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkConvAccess(ctx, userUUID, req.ConvId); err != nil {
+		return nil, err
+	}
+
+	// 2. 查询被转发的消息
+	msgs, err := s.messageRepo.ListByMsgIds(ctx, req.ConvId, msgIDs)
+	if err != nil {
+		logger.Error(ctx, "查询被转发消息失败",
+			logger.String("conv_id", req.ConvId),
+			logger.ErrorField("error", err),
+		)
+		return nil, status.Error(codes.Internal, strconv.Itoa(consts.CodeInternalError))
+	}
+	if len(msgs) != len(msgIDs) {
+		return nil, status.Error(codes.NotFound, strconv.Itoa(consts.CodeMessageNotFound))
+	}
+	for _, msg := range msgs {
+		if msgcontent.IsControl(int32(msg.MsgType)) {
+			return nil, status.Error(codes.InvalidArgument, strconv.Itoa(consts.CodeMessageTypeNotSupport))
+		}
+		if err := checkMessageAvailable(msg); err != nil {
+			return nil, err
+		}
+	}
+
+	// 3. 生成转发内容
+	payloads, err := buildForwardPayloads(req, msgs)
+	if err != nil {
+		if errors.Is(err, errMergeForwardTooLong) {
+			return nil, status.Error(codes.InvalidArgument, strconv.Itoa(consts.CodeMessageTooLong))
+		}
+		logger.Error(ctx, "生成转发内容失败", logger.ErrorField("error", err))
+		return nil, status.Error(codes.Internal, strconv.Itoa(consts.CodeInternalError))
+	}
+
+	// 4-5. 逐个目标会话转发
+	resp := &pb.ForwardMessagesResponse{Results: make([]*pb.ForwardResult, 0, len(req.Targets))}
+	for i, target := range req.Targets {
+		resp.Results = append(resp.Results, s.forwardTo(ctx, userUUID, target, payloads, fmt.Sprintf("%s_%d", req.ClientMsgId, i)))
+	}
+
+	logger.Info(ctx, "消息转发完成",
+		logger.String("conv_id", req.ConvId),
+		logger.Int("msg_count", len(msgs)),
+		logger.Int("target_count", len(req.Targets)),
+		logger.Int("mode", int(req.Mode)),
+	)
+	return resp, nil
+}
+
+// errMergeForwardTooLong 合并后的聊天记录超过单条消息内容上限
+var errMergeForwardTooLong = errors.New("merge forward content too long")
+
+// forwardPayload 一条待写入目标会话的转发消息
+type forwardPayload struct {
+	msgType int16
+	content string
+}
+
+// validateForwardRequest 校验转发参数，返回去重后的消息ID
+func validateForwardRequest(req *pb.ForwardMessagesRequest) ([]string, error) {
+	if req.ConvId == "" || req.ClientMsgId == "" || len(req.ClientMsgId) > 40 || isReservedClientMsgID(req.ClientMsgId) {
+		return nil, status.Error(codes.InvalidArgument, strconv.Itoa(consts.CodeParamError))
+	}
+	if req.Mode != forwardModeEach && req.Mode != forwardModeMerge {
+		return nil, status.Error(codes.InvalidArgument, strconv.Itoa(consts.CodeParamError))
+	}
+	if len(req.Targets) == 0 || len(req.Targets) > maxForwardTargets {
+		return nil, status.Error(codes.InvalidArgument, strconv.Itoa(consts.CodeParamError))
+	}
+	if utf8.RuneCountInString(req.Title) > maxForwardTitleRunes {
+		return nil, status.Error(codes.InvalidArgument, strconv.Itoa(consts.CodeParamError))
+	}
+	if len(req.MsgIds) == 0 || len(req.MsgIds) > msgcontent.MaxForwardItems {
+		return nil, status.Error(codes.InvalidArgument, strconv.Itoa(consts.CodeParamError))
+	}
+
+	msgIDs := make([]string, 0, len(req.MsgIds))
+	seen := make(map[string]struct{}, len(req.MsgIds))
+	for _, msgID := range req.MsgIds {
+		if msgID == "" {
+			return nil, status.Error(codes.InvalidArgument, strconv.Itoa(consts.CodeParamError))
+		}
+		if _, ok := seen[msgID]; ok {
+			continue
+		}
+		seen[msgID] = struct{}{}
+		msgIDs = append(msgIDs, msgID)
+	}
+	return msgIDs, nil
+}
+
+// buildForwardPayloads 按转发模式生成待写入的消息：逐条转发为原消息副本，合并转发为一条聊天记录
+// 合并后的内容与普通消息同受 maxContentLen 限制，超出时返回 errMergeForwardTooLong
+func buildForwardPayloads(req *pb.ForwardMessagesRequest, msgs []*model.Message) ([]forwardPayload, error) {
+	if req.Mode == forwardModeEach {
+		payloads := make([]forwardPayload, 0, len(msgs))
+		for _, msg := range msgs {
+			payloads = append(payloads, forwardPayload{msgType: msg.MsgType, content: msg.Content})
+		}
+		return payloads, nil
+	}
+
+	title := req.Title
+	if title == "" {
+		title = defaultForwardTitle
+	}
+	merged := msgcontent.MergeForwardContent{
+		Title:     title,
+		SrcConvId: req.ConvId,
+		Items:     make([]msgcontent.MergeForwardItem, 0, len(msgs)),
+	}
+	for _, msg := range msgs {
+		merged.Items = append(merged.Items, msgcontent.MergeForwardItem{
+			MsgId:    msg.MsgId,
+			FromUuid: msg.FromUuid,
+			MsgType:  int32(msg.MsgType),
+			Content:  json.RawMessage(msg.Content),
+			SendTime: msg.SendTime.UnixMilli(),
+		})
+	}
+	content, err := json.Marshal(merged)
+	if err != nil {
+		return nil, err
+	}
+	if len(content) > maxContentLen {
+		return nil, errMergeForwardTooLong
+	}
+	return []forwardPayload{{msgType: consts.MsgTypeMergeForward, content: string(content)}}, nil
+}
+
+// forwardTo 向单个目标会话写入转发消息，权限不通过或写入失败时在结果中返回错误码
+func (s *messageServiceImpl) forwardTo(ctx context.Context, fromUUID string, target *pb.ForwardTarget, payloads []forwardPayload, clientMsgPrefix string) *pb.ForwardResult {
+	result := &pb.ForwardResult{ConvType: target.ConvType, TargetUuid: target.TargetUuid}
+
+	if (target.ConvType != consts.ConvTypeP2P && target.ConvType != consts.ConvTypeGroup) ||
+		target.TargetUuid == "" ||
+		(target.ConvType == consts.ConvTypeP2P && target.TargetUuid == fromUUID) {
+		result.Code = consts.CodeParamError
+		return result
+	}
+	sendReq := &pb.SendMessageRequest{ConvType: target.ConvType, TargetUuid: target.TargetUuid}
+	if err := s.checkSendPermission(ctx, fromUUID, sendReq); err != nil {
+		result.Code = int32(extractBusinessCode(err))
+		return result
+	}
+
+	convID := buildConvID(target.ConvType, fromUUID, target.TargetUuid)
+	ttl, err := s.conversationTTL(ctx, convID)
+	if err != nil {
+		result.Code = int32(extractBusinessCode(err))
+		return result
+	}
+	for i, payload := range payloads {
+		msg := &model.Message{
+			ConvId:      convID,
+			MsgId:       util.GenIDString(),
+			ClientMsgId: fmt.Sprintf("%s_%d", clientMsgPrefix, i),
+			FromUuid:    fromUUID,
+			MsgType:     payload.msgType,
+			Content:     payload.content,
+			Status:      0,
+			SendTime:    time.Now(),
+		}
+		setExpireAt(msg, ttl)
+		stored, duplicated, err := s.sendDerived(ctx, msg)
+		if err != nil {
+			result.Code = int32(extractBusinessCode(err))
+			return result
+		}
+		result.Messages = append(result.Messages, buildSendResponse(stored, duplicated))
+	}
+	return result
+}
+
+// sendDerived 写入服务端派生的消息（按 client_msg_id 幂等）并投递
+func (s *messageServiceImpl) sendDerived(ctx context.Context, msg *model.Message) (*model.Message, bool, error) {
+	existing, err := s.messageRepo.GetByClientMsgId(ctx, msg.FromUuid, msg.ClientMsgId)
+	if err == nil {
+		return existing, true, nil
+	}
+	if !errors.Is(err, repository.ErrRecordNotFound) {
+		logger.Error(ctx, "查询幂等消息失败",
+			logger.String("client_msg_id", msg.ClientMsgId),
+			logger.ErrorField("error", err),
+		)
+		return nil, false, status.Error(codes.Internal, strconv.Itoa(consts.CodeInternalError))
+	}
+
+	stored, duplicated, err := s.saveMessage(ctx, msg)
+	if err != nil {
+		return nil, false, err
+	}
+	if !duplicated {
+		s.deliver(ctx, stored)
+	}
+	return stored, duplicated, nil
+}
+
+// extractBusinessCode 从 gRPC status 中提取业务错误码
+func extractBusinessCode(err error) int {
+	st, ok := status.FromError(err)
+	if !ok || st.Code() == codes.Unknown {
+		return consts.CodeInternalError
+	}
+	code, convErr := strconv.Atoi(st.Message())
+	if convErr != nil {
+		return consts.CodeInternalError
+	}
+	return code
+}
@@ -0,0 +1,111 @@
+package service
+
+import (
+	pb "ChatServer/apps/msg/pb"
+	"context"
+	"time"
+)
+
+// ==================== 消息服务接口 ====================
+
+// IMessageService 消息服务接口
+// 职责：消息发送（权限校验、幂等、序号分配、落库）、定时发送、定时销毁、引用回复、转发、按序号拉取历史消息、撤回、已读回执、@提及
+type IMessageService interface {
+	// SendMessage 发送消息
+	SendMessage(ctx context.Context, req *pb.SendMessageRequest) (*pb.SendMessageResponse, error)
+
+	// PullMessages 按锚点序号分页拉取历史消息
+	PullMessages(ctx context.Context, req *pb.PullMessagesRequest) (*pb.PullMessagesResponse, error)
+
+	// GetMessagesBySeqs 按序号精确拉取消息（补拉序号空洞）
+	GetMessagesBySeqs(ctx context.Context, req *pb.GetMessagesBySeqsRequest) (*pb.GetMessagesBySeqsResponse, error)
+
+	// SendSystemMessage 写入系统控制消息（内部接口）
+	SendSystemMessage(ctx context.Context, req *pb.SendSystemMessageRequest) (*pb.SendMessageResponse, error)
+
+	// ForwardMessages 转发消息（逐条或合并）到一个或多个会话
+	ForwardMessages(ctx context.Context, req *pb.ForwardMessagesRequest) (*pb.ForwardMessagesResponse, error)
+
+	// RevokeMessage 撤回消息
+	RevokeMessage(ctx context.Context, req *pb.RevokeMessageRequest) (*pb.RevokeMessageResponse, error)
+
+	// EditMessage 编辑自己发送的文本消息
+	EditMessage(ctx context.Context, req *pb.EditMessageRequest) (*pb.EditMessageResponse, error)
+
+	// PinMessage 置顶消息
+	PinMessage(ctx context.Context, req *pb.PinMessageRequest) (*pb.PinMessageResponse, error)
+
+	// UnpinMessage 取消置顶消息
+	UnpinMessage(ctx context.Context, req *pb.UnpinMessageRequest) (*pb.UnpinMessageResponse, error)
+
+	// ListPinnedMessages 查询会话内的置顶消息
+	ListPinnedMessages(ctx context.Context, req *pb.ListPinnedMessagesRequest) (*pb.ListPinnedMessagesResponse, error)
+
+	// ScheduleMessage 预约定时消息
+	ScheduleMessage(ctx context.Context, req *pb.ScheduleMessageRequest) (*pb.ScheduleMessageResponse, error)
+
+	// ListScheduledMessages 查询自己的定时消息
+	ListScheduledMessages(ctx context.Context, req *pb.ListScheduledMessagesRequest) (*pb.ListScheduledMessagesResponse, error)
+
+	// CancelScheduledMessage 取消定时消息
+	CancelScheduledMessage(ctx context.Context, req *pb.CancelScheduledMessageRequest) (*pb.CancelScheduledMessageResponse, error)
+
+	// SetDisappearingTimer 设置会话的消息定时销毁时长
+	SetDisappearingTimer(ctx context.Context, req *pb.SetDisappearingTimerRequest) (*pb.SetDisappearingTimerResponse, error)
+
+	// GetDisappearingTimer 查询会话的消息定时销毁设置
+	GetDisappearingTimer(ctx context.Context, req *pb.GetDisappearingTimerRequest) (*pb.GetDisappearingTimerResponse, error)
+
+	// PurgeExpiredMessages 删除一批已到期的消息并通知客户端（内部方法，由 ExpireWorker 调用）
+	PurgeExpiredMessages(ctx context.Context, now time.Time, limit int) (int, error)
+
+	// MarkRead 上报已读游标
+	MarkRead(ctx context.Context, req *pb.MarkReadRequest) (*pb.MarkReadResponse, error)
+
+	// GetMessageReadCount 查询消息已读人数
+	GetMessageReadCount(ctx context.Context, req *pb.GetMessageReadCountRequest) (*pb.GetMessageReadCountResponse, error)
+
+	// GetUnreadMentions 查询会话内未读的@消息
+	GetUnreadMentions(ctx context.Context, req *pb.GetUnreadMentionsRequest) (*pb.GetUnreadMentionsResponse, error)
+
+	// AddReaction 添加表情回应
+	AddReaction(ctx context.Context, req *pb.AddReactionRequest) (*pb.AddReactionResponse, error)
+
+	// RemoveReaction 取消表情回应
+	RemoveReaction(ctx context.Context, req *pb.RemoveReactionRequest) (*pb.RemoveReactionResponse, error)
+
+	// GetReactionUsers 分页查询回应了某个表情的用户
+	GetReactionUsers(ctx context.Context, req *pb.GetReactionUsersRequest) (*pb.GetReactionUsersResponse, error)
+}
+
+// ==================== 会话服务接口 ====================
+
+// IConversationService 会话服务接口
+// 职责：会话列表查询、增量同步、置顶、免打扰、删除会话
+type IConversationService interface {
+	// GetConversationList 分页获取会话列表
+	GetConversationList(ctx context.Context, req *pb.GetConversationListRequest) (*pb.GetConversationListResponse, error)
+
+	// SyncConversations 按版本号增量同步会话变更
+	SyncConversations(ctx context.Context, req *pb.SyncConversationsRequest) (*pb.SyncConversationsResponse, error)
+
+	// SetConversationPin 置顶/取消置顶会话
+	SetConversationPin(ctx context.Context, req *pb.SetConversationPinRequest) (*pb.SetConversationPinResponse, error)
+
+	// SetConversationMute 开启/关闭会话免打扰
+	SetConversationMute(ctx context.Context, req *pb.SetConversationMuteRequest) (*pb.SetConversationMuteResponse, error)
+
+	// DeleteConversation 从会话列表移除会话
+	DeleteConversation(ctx context.Context, req *pb.DeleteConversationRequest) (*pb.DeleteConversationResponse, error)
+
+	// JoinGroupConversations 为新入群成员创建或恢复群会话（内部接口）
+	JoinGroupConversations(ctx context.Context, req *pb.JoinGroupConversationsRequest) (*pb.JoinGroupConversationsResponse, error)
+}
+
+// ==================== 别名类型定义 ====================
+
+// MessageService 别名 IMessageService
+type MessageService = IMessageService
+
+// ConversationService 别名 IConversationService
+type ConversationService = IConversationService
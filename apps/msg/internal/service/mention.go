@@ -0,0 +1,159 @@
+package service
+
+import (
+	"ChatServer/apps/msg/internal/repository"
+	pb "ChatServer/apps/msg/pb"
+	"ChatServer/consts"
+	"ChatServer/model"
+	"ChatServer/pkg/logger"
+	"ChatServer/pkg/util"
+	"context"
+	"errors"
+	"strconv"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// @提及
+// 发送时 at_uuids 指定被@的成员，at_all 表示@所有人（仅群聊，仅群主/管理员）。
+// 每条消息按被@的成员写入 message_mention（@所有人只写一条保留 uuid），用于查询未读@消息；
+// 同时推进被@成员会话的 mention_seq（读扩散群的@所有人记录在群时间线的 at_all_seq），
+// mention_seq 大于 read_seq 即会话有未读@，与免打扰无关，免打扰的群同样提醒。
+
+// normalizeMentions 去重并剔除空值与发送者本人
+func normalizeMentions(fromUUID string, atUUIDs []string) []string {
+	if len(atUUIDs) == 0 {
+		return nil
+	}
+	result := make([]string, 0, len(atUUIDs))
+	seen := make(map[string]struct{}, len(atUUIDs))
+	for _, userUUID := range atUUIDs {
+		if userUUID == "" || userUUID == fromUUID {
+			continue
+		}
+		if _, ok := seen[userUUID]; ok {
+			continue
+		}
+		seen[userUUID] = struct{}{}
+		result = append(result, userUUID)
+	}
+	return result
+}
+
+// isMentioned 判断消息是否@了 userUUID（发送者本人不算）
+func isMentioned(msg *model.Message, userUUID string) bool {
+	if userUUID == msg.FromUuid {
+		return false
+	}
+	if msg.AtAll {
+		return true
+	}
+	for _, atUUID := range msg.AtUuids {
+		if atUUID == userUUID {
+			return true
+		}
+	}
+	return false
+}
+
+// saveMentions 写入消息的 @提及记录，失败只记录日志
+func (s *messageServiceImpl) saveMentions(ctx context.Context, msg *model.Message) {
+	mentions := make([]*model.MessageMention, 0, len(msg.AtUuids)+1)
+	if msg.AtAll {
+		mentions = append(mentions, &model.MessageMention{
+			ConvId:   msg.ConvId,
+			UserUuid: consts.MentionAllUUID,
+			Seq:      msg.Seq,
+			MsgId:    msg.MsgId,
+		})
+	}
+	for _, userUUID := range msg.AtUuids {
+		mentions = append(mentions, &model.MessageMention{
+			ConvId:   msg.ConvId,
+			UserUuid: userUUID,
+			Seq:      msg.Seq,
+			MsgId:    msg.MsgId,
+		})
+	}
+	if len(mentions) == 0 {
+		return
+	}
+
+	if err := s.messageRepo.CreateMentions(ctx, mentions); err != nil {
+		logger.Error(ctx, "写入@提及记录失败",
+			logger.String("conv_id", msg.ConvId),
+			logger.String("msg_id", msg.MsgId),
+			logger.ErrorField("error", err),
+		)
+	}
+}
+
+// GetUnreadMentions 查询会话内未读的@消息
+// 业务流程：
+//  1. 校验当前用户可访问该会话
+//  2. 以本人已读游标为起点，查询@本人或@所有人的消息序号
+//  3. 按序号查询消息，过滤已撤回/已删除的消息与本人发送的@所有人消息
+//
+// 错误码映射：
+//   - codes.InvalidArgument: 参数错误
+//   - codes.PermissionDenied: 无权访问该会话
+//   - codes.Internal: 系统内部错误
+func (s *messageServiceImpl) GetUnreadMentions(ctx context.Context, req *pb.GetUnreadMentionsRequest) (*pb.GetUnreadMentionsResponse, error) {
+	userUUID := util.GetUserUUIDFromContext(ctx)
+	if userUUID == "" {
+		logger.Error(ctx, "从 context 中获取 user_uuid 失败")
+		return nil, status.Error(codes.Internal, strconv.Itoa(consts.CodeInternalError))
+	}
+	if req.ConvId == "" || req.Limit < 0 || req.Limit > maxPullLimit {
+		return nil, status.Error(codes.InvalidArgument, strconv.Itoa(consts.CodeParamError))
+	}
+	limit := int(req.Limit)
+	if limit == 0 {
+		limit = defaultPullLimit
+	}
+
+	// 1. 校验会话访问权限
+	if err := s.checkConvAccess(ctx, userUUID, req.ConvId); err != nil {
+		return nil, err
+	}
+
+	// 2. 查询已读游标之后的@序号（单聊不支持@；会话记录不存在视为从未读过）
+	convType, targetUUID := convTarget(userUUID, req.ConvId)
+	if convType != consts.ConvTypeGroup {
+		return &pb.GetUnreadMentionsResponse{Messages: []*pb.MessageItem{}}, nil
+	}
+	readSeq, err := s.conversationRepo.GetReadSeq(ctx, userUUID, targetUUID)
+	if err != nil && !errors.Is(err, repository.ErrRecordNotFound) {
+		logger.Error(ctx, "查询已读游标失败",
+			logger.String("conv_id", req.ConvId),
+			logger.ErrorField("error", err),
+		)
+		return nil, status.Error(codes.Internal, strconv.Itoa(consts.CodeInternalError))
+	}
+	seqs, err := s.messageRepo.ListMentionSeqs(ctx, req.ConvId, userUUID, readSeq, limit)
+	if err != nil {
+		logger.Error(ctx, "查询@提及失败",
+			logger.String("conv_id", req.ConvId),
+			logger.ErrorField("error", err),
+		)
+		return nil, status.Error(codes.Internal, strconv.Itoa(consts.CodeInternalError))
+	}
+
+	// 3. 查询消息并过滤
+	msgs, err := s.messageRepo.ListBySeqs(ctx, req.ConvId, seqs)
+	if err != nil {
+		logger.Error(ctx, "按序号查询消息失败",
+			logger.String("conv_id", req.ConvId),
+			logger.ErrorField("error", err),
+		)
+		return nil, status.Error(codes.Internal, strconv.Itoa(consts.CodeInternalError))
+	}
+	visible := make([]*model.Message, 0, len(msgs))
+	for _, msg := range msgs {
+		if msg.Status == 0 && msg.FromUuid != userUUID {
+			visible = append(visible, msg)
+		}
+	}
+	return &pb.GetUnreadMentionsResponse{Messages: buildMessageItems(visible)}, nil
+}
@@ -0,0 +1,1109 @@
+package service
+
+import (
+	"ChatServer/apps/connect/nodeclient"
+	"ChatServer/apps/msg/internal/repository"
+	pb "ChatServer/apps/msg/pb"
+	"ChatServer/config"
+	"ChatServer/consts"
+	"ChatServer/model"
+	"ChatServer/pkg/logger"
+	"ChatServer/pkg/msgcontent"
+	"ChatServer/pkg/util"
+	"context"
+	"encoding/json"
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// maxContentLen 消息内容最大字节数
+	maxContentLen = 16 * 1024
+
+	// defaultPullLimit 拉取历史消息默认每页条数
+	defaultPullLimit = 20
+	// maxPullLimit 拉取历史消息每页最大条数
+	maxPullLimit = 100
+	// maxSeqsPerRequest 按序号补拉时单次最多序号数
+	maxSeqsPerRequest = 100
+
+	// pullDirectionBackward 向前拉取（更早的消息）
+	pullDirectionBackward = 0
+	// pullDirectionForward 向后拉取（更新的消息）
+	pullDirectionForward = 1
+
+	// scheduledClientMsgIDPrefix 定时消息到期发送时的幂等ID前缀（见 scheduledClientMsgID）
+	scheduledClientMsgIDPrefix = "sched_"
+
+	// revokedPreview 撤回后的会话预览
+	revokedPreview = "[消息已撤回]"
+
+	// pushTimeout 单次实时下发的超时时间（下发失败由客户端按 seq 补拉兜底）
+	pushTimeout = 3 * time.Second
+)
+
+// messageServiceImpl 消息服务实现
+type messageServiceImpl struct {
+	cfg              config.MessageConfig
+	messageRepo      repository.IMessageRepository
+	seqRepo          repository.ISeqRepository
+	conversationRepo repository.IConversationRepository
+	relationRepo     repository.IRelationRepository
+	groupRepo        repository.IGroupRepository
+	timelineRepo     repository.ITimelineRepository
+	scheduleRepo     repository.IScheduleRepository
+	connectClient    *nodeclient.Client // 可为 nil（不做实时下发）
+}
+
+// NewMessageService 创建消息服务实例
+func NewMessageService(
+	cfg config.MessageConfig,
+	messageRepo repository.IMessageRepository,
+	seqRepo repository.ISeqRepository,
+	conversationRepo repository.IConversationRepository,
+	relationRepo repository.IRelationRepository,
+	groupRepo repository.IGroupRepository,
+	timelineRepo repository.ITimelineRepository,
+	scheduleRepo repository.IScheduleRepository,
+	connectClient *nodeclient.Client,
+) MessageService {
+	return &messageServiceImpl{
+		cfg:              cfg,
+		messageRepo:      messageRepo,
+		seqRepo:          seqRepo,
+		conversationRepo: conversationRepo,
+		relationRepo:     relationRepo,
+		groupRepo:        groupRepo,
+		timelineRepo:     timelineRepo,
+		scheduleRepo:     scheduleRepo,
+		connectClient:    connectClient,
+	}
+}
+
+// SendMessage 发送消息
+// 业务流程：
+//  1. 从 context 中获取发送者 user_uuid，校验消息类型与内容；client_msg_id 不能使用服务端保留前缀（sched_、revoke_ 等）
+//  2. 按 (发送者, client_msg_id) 查询，命中说明是客户端重试，直接返回首次发送的结果
+//  3. 校验发送权限（单聊：双方关系/黑名单；群聊：群状态/成员身份/禁言，@所有人仅限群主/管理员）
+//  4. 引用回复校验被引用消息（同会话、未撤回）并写入其快照；按 ttl 或会话定时销毁设置计算过期时间；
+//     生成 MsgId，从 Redis 分配会话内序号并落库
+//  5. 唯一键冲突：client_msg_id 冲突说明是并发重试，回查首次写入的消息返回；
+//     否则为序号冲突（Redis 序号丢失后回退），抬升序号后重新分配并重试一次
+//  6. 投递：单聊与小群写扩散（刷新各参与者会话的最后消息预览，接收方未读数 +1，已删除的会话重新出现），
+//     大群读扩散（只推进群时间线），并实时下发给参与者的在线设备
+//
+// 注意：序号分配后落库失败会在会话内留下空洞，客户端按序号拉取时需容忍空洞。
+//
+// 错误码映射：
+//   - codes.InvalidArgument: 消息类型不支持、内容为空、内容过长、内容格式错误、存活时间无效
+//   - codes.PermissionDenied: 被拉黑、非好友、非群成员、被禁言、无权@所有人
+//   - codes.NotFound: 群组不存在、被引用的消息不存在
+//   - codes.FailedPrecondition: 群组已解散、被引用的消息已撤回
+//   - codes.Internal: 系统内部错误
+func (s *messageServiceImpl) SendMessage(ctx context.Context, req *pb.SendMessageRequest) (*pb.SendMessageResponse, error) {
+	// 1. 获取发送者并校验参数
+	fromUUID := util.GetUserUUIDFromContext(ctx)
+	if fromUUID == "" {
+		logger.Error(ctx, "从 context 中获取 user_uuid 失败")
+		return nil, status.Error(codes.Internal, strconv.Itoa(consts.CodeInternalError))
+	}
+	if err := validateSendRequest(fromUUID, req); err != nil {
+		return nil, err
+	}
+	if isReservedClientMsgID(req.ClientMsgId) && !(isScheduleDispatch(ctx) && strings.HasPrefix(req.ClientMsgId, scheduledClientMsgIDPrefix)) {
+		return nil, status.Error(codes.InvalidArgument, strconv.Itoa(consts.CodeParamError))
+	}
+
+	// 2. 幂等：客户端重试直接返回首次发送的结果
+	existing, err := s.messageRepo.GetByClientMsgId(ctx, fromUUID, req.ClientMsgId)
+	if err == nil {
+		return buildSendResponse(existing, true), nil
+	}
+	if !errors.Is(err, repository.ErrRecordNotFound) {
+		logger.Error(ctx, "查询幂等消息失败",
+			logger.String("client_msg_id", req.ClientMsgId),
+			logger.ErrorField("error", err),
+		)
+		return nil, status.Error(codes.Internal, strconv.Itoa(consts.CodeInternalError))
+	}
+
+	// 3. 校验发送权限
+	if err := s.checkSendPermission(ctx, fromUUID, req); err != nil {
+		return nil, err
+	}
+
+	// 4. 落库（引用回复写入被引用消息的快照；未指定存活时间时沿用会话的定时销毁设置）
+	convID := buildConvID(req.ConvType, fromUUID, req.TargetUuid)
+	content := req.Content
+	if req.MsgType == consts.MsgTypeQuote {
+		if content, err = s.buildQuoteContent(ctx, convID, req.Content); err != nil {
+			return nil, err
+		}
+	}
+	ttl := req.Ttl
+	if ttl == 0 {
+		if ttl, err = s.conversationTTL(ctx, convID); err != nil {
+			return nil, err
+		}
+	}
+	msg := &model.Message{
+		ConvId:      convID,
+		MsgId:       util.GenIDString(),
+		ClientMsgId: req.ClientMsgId,
+		FromUuid:    fromUUID,
+		MsgType:     int16(req.MsgType),
+		Content:     content,
+		Status:      0,
+		SendTime:    time.Now(),
+		AtUuids:     normalizeMentions(fromUUID, req.AtUuids),
+		AtAll:       req.AtAll,
+	}
+	setExpireAt(msg, ttl)
+	stored, duplicated, err := s.saveMessage(ctx, msg)
+	if err != nil {
+		return nil, err
+	}
+	if duplicated {
+		return buildSendResponse(stored, true), nil
+	}
+
+	// 5. 投递（刷新会话列表并实时下发）
+	// 消息已落库，投递失败不影响发送结果，客户端拉取历史消息时可自行修正
+	s.deliver(ctx, msg)
+
+	logger.Info(ctx, "消息发送成功",
+		logger.String("conv_id", msg.ConvId),
+		logger.String("msg_id", msg.MsgId),
+		logger.Int64("seq", msg.Seq),
+	)
+	return buildSendResponse(msg, false), nil
+}
+
+// SendSystemMessage 写入系统控制消息（内部接口，由其他服务在业务操作完成后调用）
+// 业务流程：
+//  1. 校验消息类型为已登记的控制类消息（>= MsgTypeControlBase），content 符合该类型的结构
+//  2. 按 (from_uuid, client_msg_id) 幂等，调用方重试直接返回首次写入的结果
+//  3. 按会话的定时销毁设置计算过期时间，分配会话内序号并落库，不做发送权限校验（由调用方保证操作合法）
+//  4. 按会话规模写扩散/读扩散投递，并向在线设备实时下发
+//
+// 错误码映射：
+//   - codes.InvalidArgument: 参数错误、非控制类消息、内容不符合类型结构
+//   - codes.Internal: 系统内部错误
+func (s *messageServiceImpl) SendSystemMessage(ctx context.Context, req *pb.SendSystemMessageRequest) (*pb.SendMessageResponse, error) {
+	// 1. 校验参数
+	if !msgcontent.IsControl(req.MsgType) {
+		return nil, status.Error(codes.InvalidArgument, strconv.Itoa(consts.CodeMessageTypeNotSupport))
+	}
+	if req.ConvType != consts.ConvTypeP2P && req.ConvType != consts.ConvTypeGroup {
+		return nil, status.Error(codes.InvalidArgument, strconv.Itoa(consts.CodeParamError))
+	}
+	if req.FromUuid == "" || req.TargetUuid == "" || req.ClientMsgId == "" {
+		return nil, status.Error(codes.InvalidArgument, strconv.Itoa(consts.CodeParamError))
+	}
+	if _, err := msgcontent.Parse(req.MsgType, req.Content); err != nil {
+		return nil, contentError(err)
+	}
+
+	// 2. 幂等
+	existing, err := s.messageRepo.GetByClientMsgId(ctx, req.FromUuid, req.ClientMsgId)
+	if err == nil {
+		return buildSendResponse(existing, true), nil
+	}
+	if !errors.Is(err, repository.ErrRecordNotFound) {
+		logger.Error(ctx, "查询幂等消息失败",
+			logger.String("client_msg_id", req.ClientMsgId),
+			logger.ErrorField("error", err),
+		)
+		return nil, status.Error(codes.Internal, strconv.Itoa(consts.CodeInternalError))
+	}
+
+	// 3. 落库
+	convID := buildConvID(req.ConvType, req.FromUuid, req.TargetUuid)
+	ttl, err := s.conversationTTL(ctx, convID)
+	if err != nil {
+		return nil, err
+	}
+	msg := &model.Message{
+		ConvId:      convID,
+		MsgId:       util.GenIDString(),
+		ClientMsgId: req.ClientMsgId,
+		FromUuid:    req.FromUuid,
+		MsgType:     int16(req.MsgType),
+		Content:     req.Content,
+		Status:      0,
+		SendTime:    time.Now(),
+	}
+	setExpireAt(msg, ttl)
+	stored, duplicated, err := s.saveMessage(ctx, msg)
+	if err != nil {
+		return nil, err
+	}
+	if duplicated {
+		return buildSendResponse(stored, true), nil
+	}
+
+	// 4. 投递并实时下发
+	s.deliver(ctx, msg)
+
+	logger.Info(ctx, "系统消息写入成功",
+		logger.String("conv_id", msg.ConvId),
+		logger.String("msg_id", msg.MsgId),
+		logger.Int("msg_type", int(msg.MsgType)),
+	)
+	return buildSendResponse(msg, false), nil
+}
+
+// saveMessage 分配会话内序号并落库
+// client_msg_id 冲突说明是并发重试，返回首次写入的消息（duplicated=true）；
+// 序号冲突（Redis 序号丢失后回退）时抬升序号后重新分配并重试一次
+func (s *messageServiceImpl) saveMessage(ctx context.Context, msg *model.Message) (*model.Message, bool, error) {
+	for attempt := 0; ; attempt++ {
+		seq, _, err := s.seqRepo.Allocate(ctx, msg.ConvId, 1)
+		if err != nil {
+			logger.Error(ctx, "分配消息序号失败",
+				logger.String("conv_id", msg.ConvId),
+				logger.ErrorField("error", err),
+			)
+			return nil, false, status.Error(codes.Internal, strconv.Itoa(consts.CodeMessageSendFail))
+		}
+		msg.Seq = seq
+
+		err = s.messageRepo.Create(ctx, msg)
+		if err == nil {
+			return msg, false, nil
+		}
+		if !errors.Is(err, repository.ErrDuplicateKey) {
+			logger.Error(ctx, "消息落库失败",
+				logger.String("conv_id", msg.ConvId),
+				logger.ErrorField("error", err),
+			)
+			return nil, false, status.Error(codes.Internal, strconv.Itoa(consts.CodeMessageSendFail))
+		}
+
+		// 并发重试：另一请求已写入，回查首次写入的消息
+		existing, getErr := s.messageRepo.GetByClientMsgId(ctx, msg.FromUuid, msg.ClientMsgId)
+		if getErr == nil {
+			return existing, true, nil
+		}
+		if !errors.Is(getErr, repository.ErrRecordNotFound) {
+			logger.Error(ctx, "回查幂等消息失败",
+				logger.String("client_msg_id", msg.ClientMsgId),
+				logger.ErrorField("error", getErr),
+			)
+			return nil, false, status.Error(codes.Internal, strconv.Itoa(consts.CodeInternalError))
+		}
+
+		// 序号冲突：抬升 Redis 序号后重试一次
+		logger.Warn(ctx, "消息序号冲突，重新同步序号",
+			logger.String("conv_id", msg.ConvId),
+			logger.Int64("seq", msg.Seq),
+		)
+		if attempt >= 1 {
+			return nil, false, status.Error(codes.Internal, strconv.Itoa(consts.CodeMessageSendFail))
+		}
+		if err := s.seqRepo.Resync(ctx, msg.ConvId); err != nil {
+			logger.Error(ctx, "同步消息序号失败",
+				logger.String("conv_id", msg.ConvId),
+				logger.ErrorField("error", err),
+			)
+			return nil, false, status.Error(codes.Internal, strconv.Itoa(consts.CodeMessageSendFail))
+		}
+	}
+}
+
+// PullMessages 拉取历史消息
+// 业务流程：
+//  1. 校验参数，limit 缺省为 20，最大 100
+//  2. 校验当前用户可访问该会话（单聊为会话双方之一；群聊为正常群成员）
+//  3. 向前：seq < anchor_seq（anchor_seq 为 0 时从最新消息开始）；向后：seq > anchor_seq
+//  4. 多查一条判断是否还有更多，结果统一按 seq 升序返回
+//  5. 填充各消息的表情回应汇总
+//
+// 错误码映射：
+//   - codes.InvalidArgument: 参数错误
+//   - codes.PermissionDenied: 无权访问该会话
+//   - codes.NotFound: 群组不存在
+//   - codes.Internal: 系统内部错误
+func (s *messageServiceImpl) PullMessages(ctx context.Context, req *pb.PullMessagesRequest) (*pb.PullMessagesResponse, error) {
+	// 1. 校验参数
+	userUUID := util.GetUserUUIDFromContext(ctx)
+	if userUUID == "" {
+		logger.Error(ctx, "从 context 中获取 user_uuid 失败")
+		return nil, status.Error(codes.Internal, strconv.Itoa(consts.CodeInternalError))
+	}
+	if req.ConvId == "" || req.AnchorSeq < 0 || req.Limit < 0 || req.Limit > maxPullLimit {
+		return nil, status.Error(codes.InvalidArgument, strconv.Itoa(consts.CodeParamError))
+	}
+	if req.Direction != pullDirectionBackward && req.Direction != pullDirectionForward {
+		return nil, status.Error(codes.InvalidArgument, strconv.Itoa(consts.CodeParamError))
+	}
+	limit := int(req.Limit)
+	if limit == 0 {
+		limit = defaultPullLimit
+	}
+
+	// 2. 校验会话访问权限
+	if err := s.checkConvAccess(ctx, userUUID, req.ConvId); err != nil {
+		return nil, err
+	}
+
+	// 3. 查询（多查一条用于判断 has_more）
+	var (
+		msgs []*model.Message
+		err  error
+	)
+	if req.Direction == pullDirectionForward {
+		msgs, err = s.messageRepo.ListAfterSeq(ctx, req.ConvId, req.AnchorSeq, limit+1)
+	} else {
+		msgs, err = s.messageRepo.ListBeforeSeq(ctx, req.ConvId, req.AnchorSeq, limit+1)
+	}
+	if err != nil {
+		logger.Error(ctx, "查询历史消息失败",
+			logger.String("conv_id", req.ConvId),
+			logger.Int64("anchor_seq", req.AnchorSeq),
+			logger.ErrorField("error", err),
+		)
+		return nil, status.Error(codes.Internal, strconv.Itoa(consts.CodeInternalError))
+	}
+
+	// 4. 截断并统一为升序
+	hasMore := len(msgs) > limit
+	if hasMore {
+		msgs = msgs[:limit]
+	}
+	if req.Direction == pullDirectionBackward {
+		for i, j := 0, len(msgs)-1; i < j; i, j = i+1, j-1 {
+			msgs[i], msgs[j] = msgs[j], msgs[i]
+		}
+	}
+
+	items := buildMessageItems(msgs)
+	s.attachReactions(ctx, userUUID, items)
+	return &pb.PullMessagesResponse{
+		Messages: items,
+		HasMore:  hasMore,
+	}, nil
+}
+
+// GetMessagesBySeqs 按序号拉取消息
+// 客户端收到 seq 10 后又收到 13，可用 [11, 12] 精确补拉。
+// 查不到的序号（分配后落库失败留下的空洞、已删除的消息）通过 absent_seqs 返回，客户端据此停止补拉。
+//
+// 错误码映射：
+//   - codes.InvalidArgument: 参数错误（序号为空、超过 100 个、序号非正数）
+//   - codes.PermissionDenied: 无权访问该会话
+//   - codes.NotFound: 群组不存在
+//   - codes.Internal: 系统内部错误
+func (s *messageServiceImpl) GetMessagesBySeqs(ctx context.Context, req *pb.GetMessagesBySeqsRequest) (*pb.GetMessagesBySeqsResponse, error) {
+	// 1. 校验参数并去重
+	userUUID := util.GetUserUUIDFromContext(ctx)
+	if userUUID == "" {
+		logger.Error(ctx, "从 context 中获取 user_uuid 失败")
+		return nil, status.Error(codes.Internal, strconv.Itoa(consts.CodeInternalError))
+	}
+	if req.ConvId == "" || len(req.Seqs) == 0 || len(req.Seqs) > maxSeqsPerRequest {
+		return nil, status.Error(codes.InvalidArgument, strconv.Itoa(consts.CodeParamError))
+	}
+	seqs := make([]int64, 0, len(req.Seqs))
+	seen := make(map[int64]struct{}, len(req.Seqs))
+	for _, seq := range req.Seqs {
+		if seq <= 0 {
+			return nil, status.Error(codes.InvalidArgument, strconv.Itoa(consts.CodeParamError))
+		}
+		if _, ok := seen[seq]; ok {
+			continue
+		}
+		seen[seq] = struct{}{}
+		seqs = append(seqs, seq)
+	}
+
+	// 2. 校验会话访问权限
+	if err := s.checkConvAccess(ctx, userUUID, req.ConvId); err != nil {
+		return nil, err
+	}
+
+	// 3. 查询并计算缺失的序号
+	msgs, err := s.messageRepo.ListBySeqs(ctx, req.ConvId, seqs)
+	if err != nil {
+		logger.Error(ctx, "按序号查询消息失败",
+			logger.String("conv_id", req.ConvId),
+			logger.ErrorField("error", err),
+		)
+		return nil, status.Error(codes.Internal, strconv.Itoa(consts.CodeInternalError))
+	}
+	for _, msg := range msgs {
+		delete(seen, msg.Seq)
+	}
+	absent := make([]int64, 0, len(seen))
+	for seq := range seen {
+		absent = append(absent, seq)
+	}
+	sort.Slice(absent, func(i, j int) bool { return absent[i] < absent[j] })
+
+	items := buildMessageItems(msgs)
+	s.attachReactions(ctx, userUUID, items)
+	return &pb.GetMessagesBySeqsResponse{
+		Messages:   items,
+		AbsentSeqs: absent,
+	}, nil
+}
+
+// checkConvAccess 校验用户是否可以读取会话消息
+// 单聊会话ID为 "uuidA_uuidB"，用户必须是其中一方；否则视为群会话，用户必须是正常群成员。
+func (s *messageServiceImpl) checkConvAccess(ctx context.Context, userUUID, convID string) error {
+	if left, right, ok := splitP2PConvID(convID); ok {
+		if userUUID != left && userUUID != right {
+			return status.Error(codes.PermissionDenied, strconv.Itoa(consts.CodeNoPermission))
+		}
+		return nil
+	}
+
+	member, err := s.groupRepo.GetMember(ctx, convID, userUUID)
+	if err != nil {
+		if errors.Is(err, repository.ErrRecordNotFound) {
+			return status.Error(codes.PermissionDenied, strconv.Itoa(consts.CodeNotGroupMember))
+		}
+		logger.Error(ctx, "查询群成员失败", logger.ErrorField("error", err))
+		return status.Error(codes.Internal, strconv.Itoa(consts.CodeInternalError))
+	}
+	if member.Status != 0 {
+		return status.Error(codes.PermissionDenied, strconv.Itoa(consts.CodeNotGroupMember))
+	}
+	return nil
+}
+
+// RevokeMessage 撤回消息
+// 业务流程：
+//  1. 查询被撤回的消息，控制类消息不可撤回，已撤回/已删除直接拒绝
+//  2. 校验撤回权限：发送者本人需在撤回时限内；群聊中群主/管理员可撤回任意消息且不受时限
+//  3. 分配撤回通知的序号（按会话定时销毁设置计算过期时间），同一事务内标记撤回并写入撤回通知（MsgTypeRevoke）
+//  4. 刷新以该消息为最后一条消息的会话预览
+//  5. 向会话全部参与者的在线设备下发撤回通知，离线设备按 seq 拉取时收到
+//
+// 错误码映射：
+//   - codes.InvalidArgument: 参数错误、控制类消息
+//   - codes.NotFound: 消息不存在
+//   - codes.FailedPrecondition: 已撤回、已删除、超过撤回时限
+//   - codes.PermissionDenied: 无权撤回
+//   - codes.Internal: 系统内部错误
+func (s *messageServiceImpl) RevokeMessage(ctx context.Context, req *pb.RevokeMessageRequest) (*pb.RevokeMessageResponse, error) {
+	operatorUUID := util.GetUserUUIDFromContext(ctx)
+	if operatorUUID == "" {
+		logger.Error(ctx, "从 context 中获取 user_uuid 失败")
+		return nil, status.Error(codes.Internal, strconv.Itoa(consts.CodeInternalError))
+	}
+	if req.MsgId == "" {
+		return nil, status.Error(codes.InvalidArgument, strconv.Itoa(consts.CodeParamError))
+	}
+
+	// 1. 查询被撤回的消息
+	msg, err := s.messageRepo.GetByMsgId(ctx, req.MsgId)
+	if err != nil {
+		if errors.Is(err, repository.ErrRecordNotFound) {
+			return nil, status.Error(codes.NotFound, strconv.Itoa(consts.CodeMessageNotFound))
+		}
+		logger.Error(ctx, "查询消息失败",
+			logger.String("msg_id", req.MsgId),
+			logger.ErrorField("error", err),
+		)
+		return nil, status.Error(codes.Internal, strconv.Itoa(consts.CodeInternalError))
+	}
+	if msg.MsgType >= consts.MsgTypeControlBase {
+		return nil, status.Error(codes.InvalidArgument, strconv.Itoa(consts.CodeMessageTypeNotSupport))
+	}
+	switch msg.Status {
+	case 0:
+	case 1:
+		return nil, status.Error(codes.FailedPrecondition, strconv.Itoa(consts.CodeMessageRevoked))
+	default:
+		return nil, status.Error(codes.FailedPrecondition, strconv.Itoa(consts.CodeMessageDeleted))
+	}
+
+	// 2. 校验撤回权限
+	if err := s.checkRevokePermission(ctx, operatorUUID, msg); err != nil {
+		return nil, err
+	}
+
+	// 3. 标记撤回并写入撤回通知
+	ttl, err := s.conversationTTL(ctx, msg.ConvId)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	content, _ := json.Marshal(msgcontent.RevokeContent{
+		MsgId:        msg.MsgId,
+		Seq:          msg.Seq,
+		OperatorUuid: operatorUUID,
+	})
+	notify := &model.Message{
+		ConvId:      msg.ConvId,
+		MsgId:       util.GenIDString(),
+		ClientMsgId: "revoke_" + msg.MsgId,
+		FromUuid:    operatorUUID,
+		MsgType:     consts.MsgTypeRevoke,
+		Content:     string(content),
+		Status:      0,
+		SendTime:    now,
+	}
+	setNotifyExpireAt(notify, ttl, msg)
+	seq, _, err := s.seqRepo.Allocate(ctx, msg.ConvId, 1)
+	if err != nil {
+		logger.Error(ctx, "分配消息序号失败",
+			logger.String("conv_id", msg.ConvId),
+			logger.ErrorField("error", err),
+		)
+		return nil, status.Error(codes.Internal, strconv.Itoa(consts.CodeInternalError))
+	}
+	notify.Seq = seq
+
+	if err := s.messageRepo.Revoke(ctx, msg.MsgId, notify); err != nil {
+		// 并发撤回：另一请求已完成撤回
+		if errors.Is(err, repository.ErrRecordNotFound) {
+			return nil, status.Error(codes.FailedPrecondition, strconv.Itoa(consts.CodeMessageRevoked))
+		}
+		// 序号冲突：抬升序号后由客户端重试
+		if errors.Is(err, repository.ErrDuplicateKey) {
+			if syncErr := s.seqRepo.Resync(ctx, msg.ConvId); syncErr != nil {
+				logger.Error(ctx, "同步消息序号失败", logger.ErrorField("error", syncErr))
+			}
+		}
+		logger.Error(ctx, "撤回消息失败",
+			logger.String("msg_id", msg.MsgId),
+			logger.ErrorField("error", err),
+		)
+		return nil, status.Error(codes.Internal, strconv.Itoa(consts.CodeInternalError))
+	}
+
+	// 4. 刷新会话预览（写扩散的成员会话与读扩散的群时间线，失败不影响撤回结果）
+	if err := s.conversationRepo.UpdatePreviewByLastMsg(ctx, msg.ConvId, msg.MsgId, revokedPreview); err != nil {
+		logger.Warn(ctx, "刷新会话预览失败",
+			logger.String("conv_id", msg.ConvId),
+			logger.ErrorField("error", err),
+		)
+	}
+	if _, _, ok := splitP2PConvID(msg.ConvId); !ok {
+		if err := s.timelineRepo.UpdatePreviewByLastMsg(ctx, msg.ConvId, msg.MsgId, revokedPreview); err != nil {
+			logger.Warn(ctx, "刷新群时间线预览失败",
+				logger.String("conv_id", msg.ConvId),
+				logger.ErrorField("error", err),
+			)
+		}
+	}
+
+	// 5. 实时下发撤回通知
+	s.pushToParticipants(ctx, notify)
+
+	logger.Info(ctx, "消息撤回成功",
+		logger.String("conv_id", msg.ConvId),
+		logger.String("msg_id", msg.MsgId),
+		logger.String("operator_uuid", operatorUUID),
+	)
+	return &pb.RevokeMessageResponse{
+		ConvId:     msg.ConvId,
+		NotifySeq:  notify.Seq,
+		RevokeTime: now.UnixMilli(),
+	}, nil
+}
+
+// checkRevokePermission 校验撤回权限
+// 发送者本人：单聊需在撤回时限内；群聊需仍为正常成员，且普通成员受撤回时限约束。
+// 非发送者：仅群主/管理员可撤回群内消息。
+func (s *messageServiceImpl) checkRevokePermission(ctx context.Context, operatorUUID string, msg *model.Message) error {
+	_, _, isP2P := splitP2PConvID(msg.ConvId)
+	if isP2P {
+		if operatorUUID != msg.FromUuid {
+			return status.Error(codes.PermissionDenied, strconv.Itoa(consts.CodeNoPermission))
+		}
+		return s.checkRevokeWindow(msg)
+	}
+
+	member, err := s.groupRepo.GetMember(ctx, msg.ConvId, operatorUUID)
+	if err != nil {
+		if errors.Is(err, repository.ErrRecordNotFound) {
+			return status.Error(codes.PermissionDenied, strconv.Itoa(consts.CodeNotGroupMember))
+		}
+		logger.Error(ctx, "查询群成员失败", logger.ErrorField("error", err))
+		return status.Error(codes.Internal, strconv.Itoa(consts.CodeInternalError))
+	}
+	if member.Status != consts.GroupMemberStatusNormal {
+		return status.Error(codes.PermissionDenied, strconv.Itoa(consts.CodeNotGroupMember))
+	}
+	// 群主/管理员不受撤回时限约束
+	if member.Role >= consts.GroupRoleAdmin {
+		return nil
+	}
+	if operatorUUID != msg.FromUuid {
+		return status.Error(codes.PermissionDenied, strconv.Itoa(consts.CodeNoPermission))
+	}
+	return s.checkRevokeWindow(msg)
+}
+
+// checkRevokeWindow 校验是否在撤回时限内
+func (s *messageServiceImpl) checkRevokeWindow(msg *model.Message) error {
+	if time.Since(msg.SendTime) > s.cfg.RevokeWindow {
+		return status.Error(codes.FailedPrecondition, strconv.Itoa(consts.CodeMessageRevokeTimeout))
+	}
+	return nil
+}
+
+// participants 查询会话参与者：单聊为双方，群聊为全部正常成员
+func (s *messageServiceImpl) participants(ctx context.Context, convID string) ([]string, error) {
+	if left, right, ok := splitP2PConvID(convID); ok {
+		return []string{left, right}, nil
+	}
+	return s.groupRepo.ListMemberUUIDs(ctx, convID)
+}
+
+// refreshConversations 写扩散投递：为会话全部参与者刷新最后消息（发送者本人不累加未读）
+func (s *messageServiceImpl) refreshConversations(ctx context.Context, msg *model.Message) {
+	userUUIDs, err := s.participants(ctx, msg.ConvId)
+	if err != nil {
+		logger.Error(ctx, "查询会话参与者失败",
+			logger.String("conv_id", msg.ConvId),
+			logger.ErrorField("error", err),
+		)
+		return
+	}
+
+	preview := msgcontent.Preview(int32(msg.MsgType), msg.Content)
+	convs := make([]*model.Conversation, 0, len(userUUIDs))
+	for _, userUUID := range userUUIDs {
+		convType, targetUUID := convTarget(userUUID, msg.ConvId)
+		unread := 1
+		if userUUID == msg.FromUuid {
+			unread = 0
+		}
+		var mentionSeq int64
+		if isMentioned(msg, userUUID) {
+			mentionSeq = msg.Seq
+		}
+		convs = append(convs, &model.Conversation{
+			ConvId:      msg.ConvId,
+			Type:        convType,
+			OwnerUuid:   userUUID,
+			TargetUuid:  targetUUID,
+			LastMsgId:   msg.MsgId,
+			LastMsgAt:   &msg.SendTime,
+			LastMsgPrev: preview,
+			UnreadCount: unread,
+			MentionSeq:  mentionSeq,
+		})
+	}
+
+	if err := s.conversationRepo.UpsertLastMsg(ctx, convs); err != nil {
+		logger.Error(ctx, "刷新会话最后消息失败",
+			logger.String("conv_id", msg.ConvId),
+			logger.ErrorField("error", err),
+		)
+	}
+}
+
+// pushToParticipants 异步向会话全部参与者的在线设备下发消息
+func (s *messageServiceImpl) pushToParticipants(ctx context.Context, msg *model.Message) {
+	envelope := &pb.PushEnvelope{
+		Payload: &pb.PushEnvelope_Message{Message: buildMessageItem(msg)},
+	}
+	s.pushAsync(ctx, envelope, func(ctx context.Context) ([]string, error) {
+		return s.participants(ctx, msg.ConvId)
+	})
+}
+
+// pushAsync 异步向目标用户的在线设备下发 Push 帧
+// 尽力而为：下发失败只记录日志，客户端按 seq 拉取兜底。targets 在下发协程中解析，避免阻塞请求。
+func (s *messageServiceImpl) pushAsync(ctx context.Context, envelope *pb.PushEnvelope, targets func(ctx context.Context) ([]string, error)) {
+	if s.connectClient == nil {
+		return
+	}
+	body, err := proto.Marshal(envelope)
+	if err != nil {
+		logger.Error(ctx, "编码下发消息失败", logger.ErrorField("error", err))
+		return
+	}
+
+	pushCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), pushTimeout)
+	go func() {
+		defer cancel()
+
+		userUUIDs, err := targets(pushCtx)
+		if err != nil {
+			logger.Error(pushCtx, "查询下发目标失败", logger.ErrorField("error", err))
+			return
+		}
+		if _, err := s.connectClient.Push(pushCtx, userUUIDs, body); err != nil {
+			logger.Warn(pushCtx, "实时下发失败", logger.ErrorField("error", err))
+		}
+	}()
+}
+
+// MarkRead 上报已读
+// 业务流程：
+//  1. 校验当前用户可访问该会话
+//  2. read_seq 超过会话当前最大序号时按最大序号处理（防止客户端上报未来序号导致后续消息被误判已读）
+//  3. 推进本人会话的已读游标（只增不减）并清零未读数，会话记录不存在时创建
+//  4. 下发已读回执：单聊推送给对端与本人其他设备；群聊只同步本人其他设备
+//
+// 错误码映射：
+//   - codes.InvalidArgument: 参数错误
+//   - codes.PermissionDenied: 无权访问该会话
+//   - codes.Internal: 系统内部错误
+func (s *messageServiceImpl) MarkRead(ctx context.Context, req *pb.MarkReadRequest) (*pb.MarkReadResponse, error) {
+	userUUID := util.GetUserUUIDFromContext(ctx)
+	if userUUID == "" {
+		logger.Error(ctx, "从 context 中获取 user_uuid 失败")
+		return nil, status.Error(codes.Internal, strconv.Itoa(consts.CodeInternalError))
+	}
+	if req.ConvId == "" || req.ReadSeq <= 0 {
+		return nil, status.Error(codes.InvalidArgument, strconv.Itoa(consts.CodeParamError))
+	}
+
+	// 1. 校验会话访问权限
+	if err := s.checkConvAccess(ctx, userUUID, req.ConvId); err != nil {
+		return nil, err
+	}
+
+	// 2. 按会话当前最大序号截断
+	currentSeq, err := s.seqRepo.Current(ctx, req.ConvId)
+	if err != nil {
+		logger.Error(ctx, "查询会话序号失败",
+			logger.String("conv_id", req.ConvId),
+			logger.ErrorField("error", err),
+		)
+		return nil, status.Error(codes.Internal, strconv.Itoa(consts.CodeInternalError))
+	}
+	readSeq := req.ReadSeq
+	if readSeq > currentSeq {
+		readSeq = currentSeq
+	}
+	if readSeq <= 0 {
+		return &pb.MarkReadResponse{ReadSeq: 0}, nil
+	}
+
+	// 3. 推进已读游标
+	convType, targetUUID := convTarget(userUUID, req.ConvId)
+	readSeq, err = s.conversationRepo.MarkRead(ctx, &model.Conversation{
+		ConvId:     req.ConvId,
+		Type:       convType,
+		OwnerUuid:  userUUID,
+		TargetUuid: targetUUID,
+		ReadSeq:    readSeq,
+	})
+	if err != nil {
+		logger.Error(ctx, "更新已读游标失败",
+			logger.String("conv_id", req.ConvId),
+			logger.ErrorField("error", err),
+		)
+		return nil, status.Error(codes.Internal, strconv.Itoa(consts.CodeInternalError))
+	}
+
+	// 4. 下发已读回执
+	receiptTargets := []string{userUUID}
+	if convType == consts.ConvTypeP2P {
+		receiptTargets = append(receiptTargets, targetUUID)
+	}
+	s.pushAsync(ctx, &pb.PushEnvelope{
+		Payload: &pb.PushEnvelope_ReadReceipt{ReadReceipt: &pb.ReadReceipt{
+			ConvId:   req.ConvId,
+			UserUuid: userUUID,
+			ReadSeq:  readSeq,
+			ReadTime: time.Now().UnixMilli(),
+		}},
+	}, func(context.Context) ([]string, error) {
+		return receiptTargets, nil
+	})
+
+	return &pb.MarkReadResponse{ReadSeq: readSeq}, nil
+}
+
+// GetMessageReadCount 查询消息已读人数
+// 单聊：对端已读游标 >= 消息序号即为已读，应读人数为 1；
+// 群聊：统计已读游标 >= 消息序号的正常成员数，应读人数为当前正常成员数，均不含发送者。
+//
+// 错误码映射：
+//   - codes.NotFound: 消息不存在
+//   - codes.PermissionDenied: 无权访问该会话
+//   - codes.Internal: 系统内部错误
+func (s *messageServiceImpl) GetMessageReadCount(ctx context.Context, req *pb.GetMessageReadCountRequest) (*pb.GetMessageReadCountResponse, error) {
+	userUUID := util.GetUserUUIDFromContext(ctx)
+	if userUUID == "" {
+		logger.Error(ctx, "从 context 中获取 user_uuid 失败")
+		return nil, status.Error(codes.Internal, strconv.Itoa(consts.CodeInternalError))
+	}
+	if req.MsgId == "" {
+		return nil, status.Error(codes.InvalidArgument, strconv.Itoa(consts.CodeParamError))
+	}
+
+	msg, err := s.messageRepo.GetByMsgId(ctx, req.MsgId)
+	if err != nil {
+		if errors.Is(err, repository.ErrRecordNotFound) {
+			return nil, status.Error(codes.NotFound, strconv.Itoa(consts.CodeMessageNotFound))
+		}
+		logger.Error(ctx, "查询消息失败",
+			logger.String("msg_id", req.MsgId),
+			logger.ErrorField("error", err),
+		)
+		return nil, status.Error(codes.Internal, strconv.Itoa(consts.CodeInternalError))
+	}
+	if err := s.checkConvAccess(ctx, userUUID, msg.ConvId); err != nil {
+		return nil, err
+	}
+
+	// 单聊：查询对端的已读游标
+	if _, peerUUID := convTarget(msg.FromUuid, msg.ConvId); peerUUID != msg.ConvId {
+		readSeq, err := s.conversationRepo.GetReadSeq(ctx, peerUUID, msg.FromUuid)
+		if err != nil && !errors.Is(err, repository.ErrRecordNotFound) {
+			logger.Error(ctx, "查询已读游标失败", logger.ErrorField("error", err))
+			return nil, status.Error(codes.Internal, strconv.Itoa(consts.CodeInternalError))
+		}
+		var readCount int64
+		if readSeq >= msg.Seq {
+			readCount = 1
+		}
+		return &pb.GetMessageReadCountResponse{ReadCount: readCount, TotalCount: 1}, nil
+	}
+
+	// 群聊：统计已读成员数
+	total, err := s.groupRepo.CountMembers(ctx, msg.ConvId, msg.FromUuid)
+	if err != nil {
+		logger.Error(ctx, "统计群成员数失败", logger.ErrorField("error", err))
+		return nil, status.Error(codes.Internal, strconv.Itoa(consts.CodeInternalError))
+	}
+	readCount, err := s.conversationRepo.CountGroupReaders(ctx, msg.ConvId, msg.Seq, msg.FromUuid)
+	if err != nil {
+		logger.Error(ctx, "统计群已读人数失败", logger.ErrorField("error", err))
+		return nil, status.Error(codes.Internal, strconv.Itoa(consts.CodeInternalError))
+	}
+	return &pb.GetMessageReadCountResponse{ReadCount: readCount, TotalCount: total}, nil
+}
+
+// checkSendPermission 校验发送权限
+func (s *messageServiceImpl) checkSendPermission(ctx context.Context, fromUUID string, req *pb.SendMessageRequest) error {
+	if req.ConvType == consts.ConvTypeGroup {
+		return s.checkGroupSendPermission(ctx, fromUUID, req.TargetUuid, req.AtAll)
+	}
+	return s.checkP2PSendPermission(ctx, fromUUID, req.TargetUuid)
+}
+
+// checkP2PSendPermission 单聊：对方未拉黑自己、自己未拉黑对方、双方为好友
+func (s *messageServiceImpl) checkP2PSendPermission(ctx context.Context, fromUUID, targetUUID string) error {
+	// 对方 -> 自己：被拉黑直接拒绝
+	peerRelation, err := s.relationRepo.GetRelation(ctx, targetUUID, fromUUID)
+	if err != nil && !errors.Is(err, repository.ErrRecordNotFound) {
+		logger.Error(ctx, "查询用户关系失败", logger.ErrorField("error", err))
+		return status.Error(codes.Internal, strconv.Itoa(consts.CodeInternalError))
+	}
+	if peerRelation != nil && peerRelation.Status == 1 {
+		return status.Error(codes.PermissionDenied, strconv.Itoa(consts.CodePeerBlacklistYou))
+	}
+
+	// 自己 -> 对方：拉黑了对方或已不是好友
+	selfRelation, err := s.relationRepo.GetRelation(ctx, fromUUID, targetUUID)
+	if err != nil {
+		if errors.Is(err, repository.ErrRecordNotFound) {
+			return status.Error(codes.PermissionDenied, strconv.Itoa(consts.CodeNotFriend))
+		}
+		logger.Error(ctx, "查询用户关系失败", logger.ErrorField("error", err))
+		return status.Error(codes.Internal, strconv.Itoa(consts.CodeInternalError))
+	}
+	switch selfRelation.Status {
+	case 0:
+		return nil
+	case 1:
+		return status.Error(codes.PermissionDenied, strconv.Itoa(consts.CodeYouBlacklistPeer))
+	default:
+		return status.Error(codes.PermissionDenied, strconv.Itoa(consts.CodeNotFriend))
+	}
+}
+
+// checkGroupSendPermission 群聊：群正常、发送者为正常成员且未被禁言（全员禁言时仅群主、管理员可发言）
+// atAll 为 true 时发送者须为群主或管理员
+func (s *messageServiceImpl) checkGroupSendPermission(ctx context.Context, fromUUID, groupUUID string, atAll bool) error {
+	group, err := s.groupRepo.GetGroup(ctx, groupUUID)
+	if err != nil {
+		if errors.Is(err, repository.ErrRecordNotFound) {
+			return status.Error(codes.NotFound, strconv.Itoa(consts.CodeGroupNotFound))
+		}
+		logger.Error(ctx, "查询群信息失败", logger.ErrorField("error", err))
+		return status.Error(codes.Internal, strconv.Itoa(consts.CodeInternalError))
+	}
+	if group.Status == 2 {
+		return status.Error(codes.FailedPrecondition, strconv.Itoa(consts.CodeGroupAlreadyDismiss))
+	}
+	if group.Status != 0 {
+		return status.Error(codes.PermissionDenied, strconv.Itoa(consts.CodeNoPermission))
+	}
+
+	member, err := s.groupRepo.GetMember(ctx, groupUUID, fromUUID)
+	if err != nil {
+		if errors.Is(err, repository.ErrRecordNotFound) {
+			return status.Error(codes.PermissionDenied, strconv.Itoa(consts.CodeNotGroupMember))
+		}
+		logger.Error(ctx, "查询群成员失败", logger.ErrorField("error", err))
+		return status.Error(codes.Internal, strconv.Itoa(consts.CodeInternalError))
+	}
+	if member.Status != 0 {
+		return status.Error(codes.PermissionDenied, strconv.Itoa(consts.CodeNotGroupMember))
+	}
+
+	// 禁言到期即自动失效，无需额外解除
+	if member.MuteUntil != nil && time.Now().Before(*member.MuteUntil) {
+		return status.Error(codes.PermissionDenied, strconv.Itoa(consts.CodeGroupMemberMuted))
+	}
+	if group.MuteAll && member.Role < consts.GroupRoleAdmin {
+		return status.Error(codes.PermissionDenied, strconv.Itoa(consts.CodeGroupAllMuted))
+	}
+	if atAll && member.Role < consts.GroupRoleAdmin {
+		return status.Error(codes.PermissionDenied, strconv.Itoa(consts.CodeMessageAtAllDenied))
+	}
+	return nil
+}
+
+// validateSendRequest 校验发送参数
+func validateSendRequest(fromUUID string, req *pb.SendMessageRequest) error {
+	if req.ConvType != consts.ConvTypeP2P && req.ConvType != consts.ConvTypeGroup {
+		return status.Error(codes.InvalidArgument, strconv.Itoa(consts.CodeParamError))
+	}
+	if req.TargetUuid == "" || req.ClientMsgId == "" {
+		return status.Error(codes.InvalidArgument, strconv.Itoa(consts.CodeParamError))
+	}
+	if req.ConvType == consts.ConvTypeP2P && req.TargetUuid == fromUUID {
+		return status.Error(codes.InvalidArgument, strconv.Itoa(consts.CodeParamError))
+	}
+	// 控制类消息与合并转发消息只能由服务端生成
+	if msgcontent.IsControl(req.MsgType) || req.MsgType == consts.MsgTypeMergeForward {
+		return status.Error(codes.InvalidArgument, strconv.Itoa(consts.CodeMessageTypeNotSupport))
+	}
+	if len(req.Content) > maxContentLen {
+		return status.Error(codes.InvalidArgument, strconv.Itoa(consts.CodeMessageTooLong))
+	}
+	// 按消息类型校验 content 结构（未登记的类型、空内容、超长字段）
+	if _, err := msgcontent.Parse(req.MsgType, req.Content); err != nil {
+		return contentError(err)
+	}
+	// @提及仅支持群聊
+	if (len(req.AtUuids) > 0 || req.AtAll) && req.ConvType != consts.ConvTypeGroup {
+		return status.Error(codes.InvalidArgument, strconv.Itoa(consts.CodeParamError))
+	}
+	if len(req.AtUuids) > consts.MaxMentions {
+		return status.Error(codes.InvalidArgument, strconv.Itoa(consts.CodeParamError))
+	}
+	if req.Ttl != 0 && !validTTL(req.Ttl) {
+		return status.Error(codes.InvalidArgument, strconv.Itoa(consts.CodeMessageTTLInvalid))
+	}
+	return nil
+}
+
+// reservedClientMsgIDPrefixes 服务端生成消息使用的幂等ID前缀，客户端不可使用，避免与服务端消息的幂等ID冲突
+var reservedClientMsgIDPrefixes = []string{
+	scheduledClientMsgIDPrefix, "revoke_", "edit_", "pin_", "timer_", "announcement_",
+}
+
+// isReservedClientMsgID 判断 client_msg_id 是否使用了服务端保留前缀
+func isReservedClientMsgID(clientMsgID string) bool {
+	for _, prefix := range reservedClientMsgIDPrefixes {
+		if strings.HasPrefix(clientMsgID, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// buildConvID 生成会话ID
+// 单聊：两个 uuid 按字典序拼接（双方得到同一个会话ID）；群聊：群 uuid
+func buildConvID(convType int32, fromUUID, targetUUID string) string {
+	if convType == consts.ConvTypeGroup {
+		return targetUUID
+	}
+	if fromUUID > targetUUID {
+		fromUUID, targetUUID = targetUUID, fromUUID
+	}
+	return fromUUID + "_" + targetUUID
+}
+
+// buildSendResponse 构造发送响应
+func buildSendResponse(msg *model.Message, duplicated bool) *pb.SendMessageResponse {
+	return &pb.SendMessageResponse{
+		MsgId:      msg.MsgId,
+		ConvId:     msg.ConvId,
+		Seq:        msg.Seq,
+		SendTime:   msg.SendTime.UnixMilli(),
+		Duplicated: duplicated,
+	}
+}
+
+// buildMessageItems 转换消息列表
+func buildMessageItems(msgs []*model.Message) []*pb.MessageItem {
+	items := make([]*pb.MessageItem, 0, len(msgs))
+	for _, msg := range msgs {
+		items = append(items, buildMessageItem(msg))
+	}
+	return items
+}
+
+// buildMessageItem 转换消息（已撤回的消息不再下发内容）
+func buildMessageItem(msg *model.Message) *pb.MessageItem {
+	content := msg.Content
+	if msg.Status == 1 {
+		content = ""
+	}
+	var editedAt, expireAt int64
+	if msg.EditedAt != nil {
+		editedAt = msg.EditedAt.UnixMilli()
+	}
+	if msg.ExpireAt != nil {
+		expireAt = msg.ExpireAt.UnixMilli()
+	}
+	return &pb.MessageItem{
+		MsgId:       msg.MsgId,
+		ConvId:      msg.ConvId,
+		Seq:         msg.Seq,
+		ClientMsgId: msg.ClientMsgId,
+		FromUuid:    msg.FromUuid,
+		MsgType:     int32(msg.MsgType),
+		Content:     content,
+		Status:      int32(msg.Status),
+		SendTime:    msg.SendTime.UnixMilli(),
+		AtUuids:     msg.AtUuids,
+		AtAll:       msg.AtAll,
+		Version:     msg.Version,
+		EditedAt:    editedAt,
+		ExpireAt:    expireAt,
+	}
+}
+
+// contentError 将 content 校验错误映射为业务错误码
+func contentError(err error) error {
+	switch {
+	case errors.Is(err, msgcontent.ErrTypeNotSupport):
+		return status.Error(codes.InvalidArgument, strconv.Itoa(consts.CodeMessageTypeNotSupport))
+	case errors.Is(err, msgcontent.ErrContentEmpty):
+		return status.Error(codes.InvalidArgument, strconv.Itoa(consts.CodeMessageContentEmpty))
+	case errors.Is(err, msgcontent.ErrContentTooLong):
+		return status.Error(codes.InvalidArgument, strconv.Itoa(consts.CodeMessageTooLong))
+	default:
+		return status.Error(codes.InvalidArgument, strconv.Itoa(consts.CodeMessageContentInvalid))
+	}
+}
+
+// convTarget 计算用户在会话中的会话类型与目标（单聊为对端 uuid，群聊为群 uuid）
+func convTarget(userUUID, convID string) (int8, string) {
+	left, right, ok := splitP2PConvID(convID)
+	if !ok {
+		return consts.ConvTypeGroup, convID
+	}
+	if left == userUUID {
+		return consts.ConvTypeP2P, right
+	}
+	return consts.ConvTypeP2P, left
+}
+
+// splitP2PConvID 解析单聊会话ID "uuidA_uuidB"；群会话ID（群 uuid）返回 ok=false
+func splitP2PConvID(convID string) (string, string, bool) {
+	return strings.Cut(convID, "_")
+}
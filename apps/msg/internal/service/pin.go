@@ -0,0 +1,357 @@
+package service
+
+import (
+	"ChatServer/apps/msg/internal/repository"
+	pb "ChatServer/apps/msg/pb"
+	"ChatServer/consts"
+	"ChatServer/model"
+	"ChatServer/pkg/logger"
+	"ChatServer/pkg/msgcontent"
+	"ChatServer/pkg/util"
+	"context"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// PinMessage 置顶消息
+// 业务流程：
+//  1. 查询被置顶的消息：控制类消息不可置顶，已撤回/已删除直接拒绝
+//  2. 校验置顶权限：单聊双方均可置顶；群聊仅群主/管理员
+//  3. 校验消息未置顶、会话置顶数量未达上限，已置顶时幂等返回（不分配通知序号）
+//  4. 分配置顶通知的序号，同一事务内锁定会话与被置顶消息、复核状态与数量后写入置顶记录与置顶通知（MsgTypePin）
+//  5. 向会话全部参与者的在线设备下发置顶通知，离线设备按 seq 拉取时收到
+//
+// 错误码映射：
+//   - codes.InvalidArgument: 参数错误、控制类消息
+//   - codes.NotFound: 消息不存在
+//   - codes.PermissionDenied: 无权访问该会话、非群主/管理员
+//   - codes.FailedPrecondition: 已撤回、已删除
+//   - codes.ResourceExhausted: 置顶数量已达上限
+//   - codes.Internal: 系统内部错误
+func (s *messageServiceImpl) PinMessage(ctx context.Context, req *pb.PinMessageRequest) (*pb.PinMessageResponse, error) {
+	operatorUUID := util.GetUserUUIDFromContext(ctx)
+	if operatorUUID == "" {
+		logger.Error(ctx, "从 context 中获取 user_uuid 失败")
+		return nil, status.Error(codes.Internal, strconv.Itoa(consts.CodeInternalError))
+	}
+	if req.MsgId == "" {
+		return nil, status.Error(codes.InvalidArgument, strconv.Itoa(consts.CodeParamError))
+	}
+
+	// 1. 查询被置顶的消息
+	msg, err := s.getPinTarget(ctx, req.MsgId)
+	if err != nil {
+		return nil, err
+	}
+	if msgcontent.IsControl(int32(msg.MsgType)) {
+		return nil, status.Error(codes.InvalidArgument, strconv.Itoa(consts.CodeMessageTypeNotSupport))
+	}
+	if err := checkMessageAvailable(msg); err != nil {
+		return nil, err
+	}
+
+	// 2. 校验置顶权限
+	if err := s.checkManagePermission(ctx, operatorUUID, msg.ConvId); err != nil {
+		return nil, err
+	}
+
+	// 3. 校验置顶状态与数量
+	now := time.Now()
+	pins, err := s.listPins(ctx, msg.ConvId)
+	if err != nil {
+		return nil, err
+	}
+	if isPinned(pins, msg.MsgId) {
+		return &pb.PinMessageResponse{ConvId: msg.ConvId, Pinned: false, PinTime: now.UnixMilli()}, nil
+	}
+	if len(pins) >= consts.MaxPinnedMessages {
+		return nil, status.Error(codes.ResourceExhausted, strconv.Itoa(consts.CodePinLimitExceeded))
+	}
+
+	// 4. 写入置顶记录与置顶通知
+	notify, err := s.buildPinNotify(ctx, msg, operatorUUID, consts.MsgTypePin, now)
+	if err != nil {
+		return nil, err
+	}
+	pinned, err := s.messageRepo.Pin(ctx, &model.PinnedMessage{
+		ConvId:   msg.ConvId,
+		MsgId:    msg.MsgId,
+		Seq:      msg.Seq,
+		PinnedBy: operatorUUID,
+		PinnedAt: now,
+	}, consts.MaxPinnedMessages, notify)
+	if err != nil {
+		if errors.Is(err, repository.ErrLimitExceeded) {
+			return nil, status.Error(codes.ResourceExhausted, strconv.Itoa(consts.CodePinLimitExceeded))
+		}
+		if errors.Is(err, repository.ErrRecordNotFound) {
+			return nil, status.Error(codes.NotFound, strconv.Itoa(consts.CodeMessageNotFound))
+		}
+		s.handleNotifyWriteError(ctx, msg.ConvId, err)
+		logger.Error(ctx, "置顶消息失败",
+			logger.String("msg_id", msg.MsgId),
+			logger.ErrorField("error", err),
+		)
+		return nil, status.Error(codes.Internal, strconv.Itoa(consts.CodeInternalError))
+	}
+	if !pinned {
+		return &pb.PinMessageResponse{ConvId: msg.ConvId, Pinned: false, PinTime: now.UnixMilli()}, nil
+	}
+
+	// 5. 实时下发置顶通知
+	s.pushToParticipants(ctx, notify)
+
+	logger.Info(ctx, "消息置顶成功",
+		logger.String("conv_id", msg.ConvId),
+		logger.String("msg_id", msg.MsgId),
+		logger.String("operator_uuid", operatorUUID),
+	)
+	return &pb.PinMessageResponse{
+		ConvId:    msg.ConvId,
+		Pinned:    true,
+		NotifySeq: notify.Seq,
+		PinTime:   now.UnixMilli(),
+	}, nil
+}
+
+// UnpinMessage 取消置顶消息
+// 权限与置顶相同；已撤回/已删除的消息也可取消置顶，未置顶时幂等返回（不分配通知序号）。
+//
+// 错误码映射：
+//   - codes.InvalidArgument: 参数错误
+//   - codes.NotFound: 消息不存在
+//   - codes.PermissionDenied: 无权访问该会话、非群主/管理员
+//   - codes.Internal: 系统内部错误
+func (s *messageServiceImpl) UnpinMessage(ctx context.Context, req *pb.UnpinMessageRequest) (*pb.UnpinMessageResponse, error) {
+	operatorUUID := util.GetUserUUIDFromContext(ctx)
+	if operatorUUID == "" {
+		logger.Error(ctx, "从 context 中获取 user_uuid 失败")
+		return nil, status.Error(codes.Internal, strconv.Itoa(consts.CodeInternalError))
+	}
+	if req.MsgId == "" {
+		return nil, status.Error(codes.InvalidArgument, strconv.Itoa(consts.CodeParamError))
+	}
+
+	msg, err := s.getPinTarget(ctx, req.MsgId)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkManagePermission(ctx, operatorUUID, msg.ConvId); err != nil {
+		return nil, err
+	}
+
+	pins, err := s.listPins(ctx, msg.ConvId)
+	if err != nil {
+		return nil, err
+	}
+	if !isPinned(pins, msg.MsgId) {
+		return &pb.UnpinMessageResponse{ConvId: msg.ConvId, Unpinned: false}, nil
+	}
+
+	notify, err := s.buildPinNotify(ctx, msg, operatorUUID, consts.MsgTypeUnpin, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	unpinned, err := s.messageRepo.Unpin(ctx, msg.ConvId, msg.MsgId, notify)
+	if err != nil {
+		s.handleNotifyWriteError(ctx, msg.ConvId, err)
+		logger.Error(ctx, "取消置顶消息失败",
+			logger.String("msg_id", msg.MsgId),
+			logger.ErrorField("error", err),
+		)
+		return nil, status.Error(codes.Internal, strconv.Itoa(consts.CodeInternalError))
+	}
+	if !unpinned {
+		return &pb.UnpinMessageResponse{ConvId: msg.ConvId, Unpinned: false}, nil
+	}
+
+	s.pushToParticipants(ctx, notify)
+
+	logger.Info(ctx, "取消置顶成功",
+		logger.String("conv_id", msg.ConvId),
+		logger.String("msg_id", msg.MsgId),
+		logger.String("operator_uuid", operatorUUID),
+	)
+	return &pb.UnpinMessageResponse{
+		ConvId:    msg.ConvId,
+		Unpinned:  true,
+		NotifySeq: notify.Seq,
+	}, nil
+}
+
+// ListPinnedMessages 查询会话内的置顶消息
+// 置顶后被撤回/删除的消息不返回（置顶记录保留，由群主/管理员取消置顶）。
+//
+// 错误码映射：
+//   - codes.InvalidArgument: 参数错误
+//   - codes.PermissionDenied: 无权访问该会话
+//   - codes.Internal: 系统内部错误
+func (s *messageServiceImpl) ListPinnedMessages(ctx context.Context, req *pb.ListPinnedMessagesRequest) (*pb.ListPinnedMessagesResponse, error) {
+	userUUID := util.GetUserUUIDFromContext(ctx)
+	if userUUID == "" {
+		logger.Error(ctx, "从 context 中获取 user_uuid 失败")
+		return nil, status.Error(codes.Internal, strconv.Itoa(consts.CodeInternalError))
+	}
+	if req.ConvId == "" {
+		return nil, status.Error(codes.InvalidArgument, strconv.Itoa(consts.CodeParamError))
+	}
+
+	if err := s.checkConvAccess(ctx, userUUID, req.ConvId); err != nil {
+		return nil, err
+	}
+
+	pins, err := s.listPins(ctx, req.ConvId)
+	if err != nil {
+		return nil, err
+	}
+	if len(pins) == 0 {
+		return &pb.ListPinnedMessagesResponse{Items: []*pb.PinnedMessageItem{}}, nil
+	}
+
+	msgIDs := make([]string, 0, len(pins))
+	for _, pin := range pins {
+		msgIDs = append(msgIDs, pin.MsgId)
+	}
+	msgs, err := s.messageRepo.ListByMsgIds(ctx, req.ConvId, msgIDs)
+	if err != nil {
+		logger.Error(ctx, "查询置顶消息内容失败",
+			logger.String("conv_id", req.ConvId),
+			logger.ErrorField("error", err),
+		)
+		return nil, status.Error(codes.Internal, strconv.Itoa(consts.CodeInternalError))
+	}
+	byID := make(map[string]*model.Message, len(msgs))
+	for _, msg := range msgs {
+		if msg.Status == 0 {
+			byID[msg.MsgId] = msg
+		}
+	}
+
+	items := make([]*pb.PinnedMessageItem, 0, len(pins))
+	for _, pin := range pins {
+		msg, ok := byID[pin.MsgId]
+		if !ok {
+			continue
+		}
+		items = append(items, &pb.PinnedMessageItem{
+			Message:  buildMessageItem(msg),
+			PinnedBy: pin.PinnedBy,
+			PinnedAt: pin.PinnedAt.UnixMilli(),
+		})
+	}
+	return &pb.ListPinnedMessagesResponse{Items: items}, nil
+}
+
+// getPinTarget 查询被置顶/取消置顶的消息
+func (s *messageServiceImpl) getPinTarget(ctx context.Context, msgID string) (*model.Message, error) {
+	msg, err := s.messageRepo.GetByMsgId(ctx, msgID)
+	if err != nil {
+		if errors.Is(err, repository.ErrRecordNotFound) {
+			return nil, status.Error(codes.NotFound, strconv.Itoa(consts.CodeMessageNotFound))
+		}
+		logger.Error(ctx, "查询消息失败",
+			logger.String("msg_id", msgID),
+			logger.ErrorField("error", err),
+		)
+		return nil, status.Error(codes.Internal, strconv.Itoa(consts.CodeInternalError))
+	}
+	return msg, nil
+}
+
+// listPins 查询会话内的置顶记录
+func (s *messageServiceImpl) listPins(ctx context.Context, convID string) ([]*model.PinnedMessage, error) {
+	pins, err := s.messageRepo.ListPins(ctx, convID)
+	if err != nil {
+		logger.Error(ctx, "查询置顶消息失败",
+			logger.String("conv_id", convID),
+			logger.ErrorField("error", err),
+		)
+		return nil, status.Error(codes.Internal, strconv.Itoa(consts.CodeInternalError))
+	}
+	return pins, nil
+}
+
+// isPinned 判断消息是否在置顶记录中
+func isPinned(pins []*model.PinnedMessage, msgID string) bool {
+	for _, pin := range pins {
+		if pin.MsgId == msgID {
+			return true
+		}
+	}
+	return false
+}
+
+// checkManagePermission 校验会话管理权限（置顶消息、定时销毁设置）
+// 单聊：会话双方均可；群聊：仅正常状态的群主/管理员。
+func (s *messageServiceImpl) checkManagePermission(ctx context.Context, operatorUUID, convID string) error {
+	if _, _, ok := splitP2PConvID(convID); ok {
+		return s.checkConvAccess(ctx, operatorUUID, convID)
+	}
+
+	member, err := s.groupRepo.GetMember(ctx, convID, operatorUUID)
+	if err != nil {
+		if errors.Is(err, repository.ErrRecordNotFound) {
+			return status.Error(codes.PermissionDenied, strconv.Itoa(consts.CodeNotGroupMember))
+		}
+		logger.Error(ctx, "查询群成员失败", logger.ErrorField("error", err))
+		return status.Error(codes.Internal, strconv.Itoa(consts.CodeInternalError))
+	}
+	if member.Status != consts.GroupMemberStatusNormal {
+		return status.Error(codes.PermissionDenied, strconv.Itoa(consts.CodeNotGroupMember))
+	}
+	if member.Role < consts.GroupRoleAdmin {
+		return status.Error(codes.PermissionDenied, strconv.Itoa(consts.CodeNoPermission))
+	}
+	return nil
+}
+
+// buildPinNotify 构造置顶/取消置顶通知并分配序号，通知按会话定时销毁设置过期
+// 每次操作使用独立的幂等ID（同一消息可反复置顶/取消置顶）
+func (s *messageServiceImpl) buildPinNotify(ctx context.Context, msg *model.Message, operatorUUID string, msgType int16, now time.Time) (*model.Message, error) {
+	ttl, err := s.conversationTTL(ctx, msg.ConvId)
+	if err != nil {
+		return nil, err
+	}
+	content, _ := json.Marshal(msgcontent.PinContent{
+		MsgId:        msg.MsgId,
+		Seq:          msg.Seq,
+		OperatorUuid: operatorUUID,
+	})
+	notifyID := util.GenIDString()
+	notify := &model.Message{
+		ConvId:      msg.ConvId,
+		MsgId:       notifyID,
+		ClientMsgId: "pin_" + notifyID,
+		FromUuid:    operatorUUID,
+		MsgType:     msgType,
+		Content:     string(content),
+		Status:      0,
+		SendTime:    now,
+	}
+	setNotifyExpireAt(notify, ttl, msg)
+	seq, _, err := s.seqRepo.Allocate(ctx, msg.ConvId, 1)
+	if err != nil {
+		logger.Error(ctx, "分配消息序号失败",
+			logger.String("conv_id", msg.ConvId),
+			logger.ErrorField("error", err),
+		)
+		return nil, status.Error(codes.Internal, strconv.Itoa(consts.CodeInternalError))
+	}
+	notify.Seq = seq
+	return notify, nil
+}
+
+// handleNotifyWriteError 写入通知发生序号冲突时抬升序号，由客户端重试
+func (s *messageServiceImpl) handleNotifyWriteError(ctx context.Context, convID string, err error) {
+	if !errors.Is(err, repository.ErrDuplicateKey) {
+		return
+	}
+	if syncErr := s.seqRepo.Resync(ctx, convID); syncErr != nil {
+		logger.Error(ctx, "同步消息序号失败", logger.ErrorField("error", syncErr))
+	}
+}
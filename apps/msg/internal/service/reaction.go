@@ -0,0 +1,273 @@
+package service
+
+import (
+	"ChatServer/apps/msg/internal/repository"
+	pb "ChatServer/apps/msg/pb"
+	"ChatServer/consts"
+	"ChatServer/model"
+	"ChatServer/pkg/logger"
+	"ChatServer/pkg/msgcontent"
+	"ChatServer/pkg/util"
+	"context"
+	"errors"
+	"strconv"
+	"time"
+	"unicode/utf8"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// maxEmojiLen 表情最大字节数
+	maxEmojiLen = 32
+
+	// defaultReactionPageSize 查询回应用户默认每页条数
+	defaultReactionPageSize = 20
+	// maxReactionPageSize 查询回应用户每页最大条数
+	maxReactionPageSize = 100
+)
+
+// AddReaction 添加表情回应
+// 业务流程：
+//  1. 查询消息并校验会话访问权限，控制类消息、已撤回/已删除的消息不可回应
+//  2. 锁定消息行写入回应记录：新表情需消息的表情种类数未达上限（已有表情直接叠加），
+//     (msg_id, user_uuid, emoji) 已存在时幂等返回
+//  3. 新增时向会话参与者下发 ReactionEvent（不写入消息、不占用会话序号）
+//
+// 错误码映射：
+//   - codes.InvalidArgument: 参数错误、控制类消息
+//   - codes.NotFound: 消息不存在
+//   - codes.PermissionDenied: 无权访问该会话
+//   - codes.FailedPrecondition: 已撤回、已删除
+//   - codes.ResourceExhausted: 表情种类已达上限
+//   - codes.Internal: 系统内部错误
+func (s *messageServiceImpl) AddReaction(ctx context.Context, req *pb.AddReactionRequest) (*pb.AddReactionResponse, error) {
+	userUUID := util.GetUserUUIDFromContext(ctx)
+	if userUUID == "" {
+		logger.Error(ctx, "从 context 中获取 user_uuid 失败")
+		return nil, status.Error(codes.Internal, strconv.Itoa(consts.CodeInternalError))
+	}
+	if req.MsgId == "" || !validEmoji(req.Emoji) {
+		return nil, status.Error(codes.InvalidArgument, strconv.Itoa(consts.CodeParamError))
+	}
+
+	// 1. 查询消息并校验
+	msg, err := s.getReactionTarget(ctx, userUUID, req.MsgId)
+	if err != nil {
+		return nil, err
+	}
+	if msgcontent.IsControl(int32(msg.MsgType)) {
+		return nil, status.Error(codes.InvalidArgument, strconv.Itoa(consts.CodeMessageTypeNotSupport))
+	}
+	if err := checkMessageAvailable(msg); err != nil {
+		return nil, err
+	}
+
+	// 2. 写入回应记录
+	added, err := s.messageRepo.AddReaction(ctx, &model.MessageReaction{
+		MsgId:    msg.MsgId,
+		UserUuid: userUUID,
+		Emoji:    req.Emoji,
+		ConvId:   msg.ConvId,
+	}, consts.MaxReactionKinds)
+	if err != nil {
+		if errors.Is(err, repository.ErrLimitExceeded) {
+			return nil, status.Error(codes.ResourceExhausted, strconv.Itoa(consts.CodeReactionLimitExceeded))
+		}
+		if errors.Is(err, repository.ErrRecordNotFound) {
+			return nil, status.Error(codes.NotFound, strconv.Itoa(consts.CodeMessageNotFound))
+		}
+		logger.Error(ctx, "写入表情回应失败",
+			logger.String("msg_id", msg.MsgId),
+			logger.ErrorField("error", err),
+		)
+		return nil, status.Error(codes.Internal, strconv.Itoa(consts.CodeInternalError))
+	}
+	count, err := s.messageRepo.CountReaction(ctx, msg.MsgId, req.Emoji)
+	if err != nil {
+		logger.Error(ctx, "统计表情回应人数失败",
+			logger.String("msg_id", msg.MsgId),
+			logger.ErrorField("error", err),
+		)
+		return nil, status.Error(codes.Internal, strconv.Itoa(consts.CodeInternalError))
+	}
+
+	// 3. 下发回应变更
+	if added {
+		s.pushReactionEvent(ctx, msg, userUUID, req.Emoji, false, count)
+	}
+	return &pb.AddReactionResponse{Added: added, Count: count}, nil
+}
+
+// RemoveReaction 取消表情回应
+// 只能取消自己的回应；消息撤回后仍可取消。未回应过时幂等返回。
+//
+// 错误码映射：
+//   - codes.InvalidArgument: 参数错误
+//   - codes.NotFound: 消息不存在
+//   - codes.PermissionDenied: 无权访问该会话
+//   - codes.Internal: 系统内部错误
+func (s *messageServiceImpl) RemoveReaction(ctx context.Context, req *pb.RemoveReactionRequest) (*pb.RemoveReactionResponse, error) {
+	userUUID := util.GetUserUUIDFromContext(ctx)
+	if userUUID == "" {
+		logger.Error(ctx, "从 context 中获取 user_uuid 失败")
+		return nil, status.Error(codes.Internal, strconv.Itoa(consts.CodeInternalError))
+	}
+	if req.MsgId == "" || !validEmoji(req.Emoji) {
+		return nil, status.Error(codes.InvalidArgument, strconv.Itoa(consts.CodeParamError))
+	}
+
+	msg, err := s.getReactionTarget(ctx, userUUID, req.MsgId)
+	if err != nil {
+		return nil, err
+	}
+
+	removed, err := s.messageRepo.RemoveReaction(ctx, msg.MsgId, userUUID, req.Emoji)
+	if err != nil {
+		logger.Error(ctx, "删除表情回应失败",
+			logger.String("msg_id", msg.MsgId),
+			logger.ErrorField("error", err),
+		)
+		return nil, status.Error(codes.Internal, strconv.Itoa(consts.CodeInternalError))
+	}
+	count, err := s.messageRepo.CountReaction(ctx, msg.MsgId, req.Emoji)
+	if err != nil {
+		logger.Error(ctx, "统计表情回应人数失败",
+			logger.String("msg_id", msg.MsgId),
+			logger.ErrorField("error", err),
+		)
+		return nil, status.Error(codes.Internal, strconv.Itoa(consts.CodeInternalError))
+	}
+
+	if removed {
+		s.pushReactionEvent(ctx, msg, userUUID, req.Emoji, true, count)
+	}
+	return &pb.RemoveReactionResponse{Removed: removed, Count: count}, nil
+}
+
+// GetReactionUsers 分页查询回应了某个表情的用户
+//
+// 错误码映射：
+//   - codes.InvalidArgument: 参数错误
+//   - codes.NotFound: 消息不存在
+//   - codes.PermissionDenied: 无权访问该会话
+//   - codes.Internal: 系统内部错误
+func (s *messageServiceImpl) GetReactionUsers(ctx context.Context, req *pb.GetReactionUsersRequest) (*pb.GetReactionUsersResponse, error) {
+	userUUID := util.GetUserUUIDFromContext(ctx)
+	if userUUID == "" {
+		logger.Error(ctx, "从 context 中获取 user_uuid 失败")
+		return nil, status.Error(codes.Internal, strconv.Itoa(consts.CodeInternalError))
+	}
+	if req.MsgId == "" || !validEmoji(req.Emoji) || req.Page < 0 || req.PageSize < 0 || req.PageSize > maxReactionPageSize {
+		return nil, status.Error(codes.InvalidArgument, strconv.Itoa(consts.CodeParamError))
+	}
+	page := int(req.Page)
+	if page == 0 {
+		page = 1
+	}
+	pageSize := int(req.PageSize)
+	if pageSize == 0 {
+		pageSize = defaultReactionPageSize
+	}
+
+	msg, err := s.getReactionTarget(ctx, userUUID, req.MsgId)
+	if err != nil {
+		return nil, err
+	}
+
+	userUUIDs, total, err := s.messageRepo.ListReactionUsers(ctx, msg.MsgId, req.Emoji, page, pageSize)
+	if err != nil {
+		logger.Error(ctx, "查询表情回应用户失败",
+			logger.String("msg_id", msg.MsgId),
+			logger.ErrorField("error", err),
+		)
+		return nil, status.Error(codes.Internal, strconv.Itoa(consts.CodeInternalError))
+	}
+	return &pb.GetReactionUsersResponse{UserUuids: userUUIDs, Total: total}, nil
+}
+
+// getReactionTarget 查询被回应的消息并校验会话访问权限
+func (s *messageServiceImpl) getReactionTarget(ctx context.Context, userUUID, msgID string) (*model.Message, error) {
+	msg, err := s.messageRepo.GetByMsgId(ctx, msgID)
+	if err != nil {
+		if errors.Is(err, repository.ErrRecordNotFound) {
+			return nil, status.Error(codes.NotFound, strconv.Itoa(consts.CodeMessageNotFound))
+		}
+		logger.Error(ctx, "查询消息失败",
+			logger.String("msg_id", msgID),
+			logger.ErrorField("error", err),
+		)
+		return nil, status.Error(codes.Internal, strconv.Itoa(consts.CodeInternalError))
+	}
+	if err := s.checkConvAccess(ctx, userUUID, msg.ConvId); err != nil {
+		return nil, err
+	}
+	return msg, nil
+}
+
+// pushReactionEvent 异步向会话全部参与者下发表情回应变更
+func (s *messageServiceImpl) pushReactionEvent(ctx context.Context, msg *model.Message, userUUID, emoji string, removed bool, count int64) {
+	s.pushAsync(ctx, &pb.PushEnvelope{
+		Payload: &pb.PushEnvelope_Reaction{Reaction: &pb.ReactionEvent{
+			ConvId:    msg.ConvId,
+			MsgId:     msg.MsgId,
+			Seq:       msg.Seq,
+			UserUuid:  userUUID,
+			Emoji:     emoji,
+			Removed:   removed,
+			Count:     count,
+			EventTime: time.Now().UnixMilli(),
+		}},
+	}, func(ctx context.Context) ([]string, error) {
+		return s.participants(ctx, msg.ConvId)
+	})
+}
+
+// attachReactions 为消息列表填充表情回应汇总（reacted 相对 userUUID）
+// 尽力而为：查询失败只记录日志，消息照常返回
+func (s *messageServiceImpl) attachReactions(ctx context.Context, userUUID string, items []*pb.MessageItem) {
+	if len(items) == 0 {
+		return
+	}
+	msgIDs := make([]string, 0, len(items))
+	for _, item := range items {
+		msgIDs = append(msgIDs, item.MsgId)
+	}
+
+	counts, err := s.messageRepo.ListReactionCounts(ctx, msgIDs)
+	if err != nil {
+		logger.Error(ctx, "聚合表情回应失败", logger.ErrorField("error", err))
+		return
+	}
+	if len(counts) == 0 {
+		return
+	}
+	mine, err := s.messageRepo.ListUserReactions(ctx, msgIDs, userUUID)
+	if err != nil {
+		logger.Error(ctx, "查询本人表情回应失败", logger.ErrorField("error", err))
+		return
+	}
+	reacted := make(map[string]struct{}, len(mine))
+	for _, r := range mine {
+		reacted[r.MsgId+"\x00"+r.Emoji] = struct{}{}
+	}
+
+	summaries := make(map[string][]*pb.ReactionSummary, len(items))
+	for _, c := range counts {
+		_, ok := reacted[c.MsgId+"\x00"+c.Emoji]
+		summaries[c.MsgId] = append(summaries[c.MsgId], &pb.ReactionSummary{
+			Emoji:   c.Emoji,
+			Count:   c.Count,
+			Reacted: ok,
+		})
+	}
+	for _, item := range items {
+		item.Reactions = summaries[item.MsgId]
+	}
+}
+
+// validEmoji 校验表情：非空、合法 UTF-8、不超过 maxEmojiLen 字节
+func validEmoji(emoji string) bool {
+	return emoji != "" && len(emoji) <= maxEmojiLen && utf8.ValidString(emoji)
+}
@@ -0,0 +1,242 @@
+package service
+
+import (
+	"ChatServer/apps/msg/internal/repository"
+	pb "ChatServer/apps/msg/pb"
+	"ChatServer/consts"
+	"ChatServer/model"
+	"ChatServer/pkg/logger"
+	"ChatServer/pkg/util"
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// defaultSchedulePageSize 查询定时消息默认每页条数
+	defaultSchedulePageSize = 20
+	// maxSchedulePageSize 查询定时消息每页最大条数
+	maxSchedulePageSize = 100
+)
+
+// listedScheduleStatuses 定时消息列表展示的状态：待发送、发送中、发送失败（已发送与已取消的不再展示）
+var listedScheduleStatuses = []int8{
+	consts.ScheduledStatusPending,
+	consts.ScheduledStatusDispatching,
+	consts.ScheduledStatusFailed,
+}
+
+// ScheduleMessage 预约定时消息
+// 业务流程：
+//  1. 按 (发送者, client_msg_id) 查询，命中说明是客户端重试，直接返回首次预约的结果
+//  2. 按发送消息的规则校验参数与发送权限（到期发送时会再次校验），校验预约时间在 (当前时间, 当前时间+最远预约时长] 内
+//  3. 校验用户待发送的定时消息数未达上限
+//  4. 写入定时消息，唯一键冲突说明是并发重试，回查首次预约的结果返回
+//
+// client_msg_id 仅用于预约去重；到期后由 ScheduleWorker 以定时消息ID派生的幂等ID调用 SendMessage 发出。
+//
+// 错误码映射：
+//   - codes.InvalidArgument: 参数错误、预约时间无效、消息类型不支持、内容格式错误
+//   - codes.PermissionDenied: 被拉黑、非好友、非群成员、被禁言、无权@所有人
+//   - codes.NotFound: 群组不存在
+//   - codes.FailedPrecondition: 群组已解散
+//   - codes.ResourceExhausted: 待发送的定时消息数已达上限
+//   - codes.Internal: 系统内部错误
+func (s *messageServiceImpl) ScheduleMessage(ctx context.Context, req *pb.ScheduleMessageRequest) (*pb.ScheduleMessageResponse, error) {
+	fromUUID := util.GetUserUUIDFromContext(ctx)
+	if fromUUID == "" {
+		logger.Error(ctx, "从 context 中获取 user_uuid 失败")
+		return nil, status.Error(codes.Internal, strconv.Itoa(consts.CodeInternalError))
+	}
+	if req.ClientMsgId == "" {
+		return nil, status.Error(codes.InvalidArgument, strconv.Itoa(consts.CodeParamError))
+	}
+
+	// 1. 幂等：客户端重试直接返回首次预约的结果
+	existing, err := s.scheduleRepo.GetByClientMsgId(ctx, fromUUID, req.ClientMsgId)
+	if err == nil {
+		return &pb.ScheduleMessageResponse{Scheduled: buildScheduledItem(existing), Duplicated: true}, nil
+	}
+	if !errors.Is(err, repository.ErrRecordNotFound) {
+		logger.Error(ctx, "查询幂等定时消息失败",
+			logger.String("client_msg_id", req.ClientMsgId),
+			logger.ErrorField("error", err),
+		)
+		return nil, status.Error(codes.Internal, strconv.Itoa(consts.CodeInternalError))
+	}
+
+	// 2. 校验参数、预约时间与发送权限
+	sendReq := &pb.SendMessageRequest{
+		ConvType:    req.ConvType,
+		TargetUuid:  req.TargetUuid,
+		ClientMsgId: req.ClientMsgId,
+		MsgType:     req.MsgType,
+		Content:     req.Content,
+		AtUuids:     req.AtUuids,
+		AtAll:       req.AtAll,
+	}
+	if err := validateSendRequest(fromUUID, sendReq); err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	sendAt := time.UnixMilli(req.SendAt)
+	if !sendAt.After(now) || (s.cfg.ScheduleMaxDelay > 0 && sendAt.Sub(now) > s.cfg.ScheduleMaxDelay) {
+		return nil, status.Error(codes.InvalidArgument, strconv.Itoa(consts.CodeScheduleTimeInvalid))
+	}
+	if err := s.checkSendPermission(ctx, fromUUID, sendReq); err != nil {
+		return nil, err
+	}
+
+	// 3. 校验待发送数量
+	count, err := s.scheduleRepo.CountPending(ctx, fromUUID)
+	if err != nil {
+		logger.Error(ctx, "统计待发送定时消息失败", logger.ErrorField("error", err))
+		return nil, status.Error(codes.Internal, strconv.Itoa(consts.CodeInternalError))
+	}
+	if count >= consts.MaxPendingScheduled {
+		return nil, status.Error(codes.ResourceExhausted, strconv.Itoa(consts.CodeScheduleLimitExceeded))
+	}
+
+	// 4. 写入定时消息
+	scheduled := &model.ScheduledMessage{
+		FromUuid:    fromUUID,
+		ClientMsgId: req.ClientMsgId,
+		ConvType:    int8(req.ConvType),
+		TargetUuid:  req.TargetUuid,
+		MsgType:     int16(req.MsgType),
+		Content:     req.Content,
+		AtUuids:     req.AtUuids,
+		AtAll:       req.AtAll,
+		SendAt:      sendAt,
+		Status:      consts.ScheduledStatusPending,
+	}
+	if err := s.scheduleRepo.Create(ctx, scheduled); err != nil {
+		if errors.Is(err, repository.ErrDuplicateKey) {
+			if existing, getErr := s.scheduleRepo.GetByClientMsgId(ctx, fromUUID, req.ClientMsgId); getErr == nil {
+				return &pb.ScheduleMessageResponse{Scheduled: buildScheduledItem(existing), Duplicated: true}, nil
+			}
+		}
+		logger.Error(ctx, "写入定时消息失败",
+			logger.String("client_msg_id", req.ClientMsgId),
+			logger.ErrorField("error", err),
+		)
+		return nil, status.Error(codes.Internal, strconv.Itoa(consts.CodeInternalError))
+	}
+
+	logger.Info(ctx, "定时消息预约成功",
+		logger.Int64("schedule_id", scheduled.Id),
+		logger.String("target_uuid", scheduled.TargetUuid),
+		logger.Int64("send_at", req.SendAt),
+	)
+	return &pb.ScheduleMessageResponse{Scheduled: buildScheduledItem(scheduled)}, nil
+}
+
+// ListScheduledMessages 分页查询自己的定时消息
+// 返回待发送（含发送中）与发送失败的记录，按预约发送时间升序；已发送的消息在会话中查看。
+//
+// 错误码映射：
+//   - codes.InvalidArgument: 参数错误
+//   - codes.Internal: 系统内部错误
+func (s *messageServiceImpl) ListScheduledMessages(ctx context.Context, req *pb.ListScheduledMessagesRequest) (*pb.ListScheduledMessagesResponse, error) {
+	userUUID := util.GetUserUUIDFromContext(ctx)
+	if userUUID == "" {
+		logger.Error(ctx, "从 context 中获取 user_uuid 失败")
+		return nil, status.Error(codes.Internal, strconv.Itoa(consts.CodeInternalError))
+	}
+	if req.Page < 0 || req.PageSize < 0 || req.PageSize > maxSchedulePageSize {
+		return nil, status.Error(codes.InvalidArgument, strconv.Itoa(consts.CodeParamError))
+	}
+	page := int(req.Page)
+	if page == 0 {
+		page = 1
+	}
+	pageSize := int(req.PageSize)
+	if pageSize == 0 {
+		pageSize = defaultSchedulePageSize
+	}
+
+	list, total, err := s.scheduleRepo.ListByOwner(ctx, userUUID, listedScheduleStatuses, page, pageSize)
+	if err != nil {
+		logger.Error(ctx, "查询定时消息失败", logger.ErrorField("error", err))
+		return nil, status.Error(codes.Internal, strconv.Itoa(consts.CodeInternalError))
+	}
+
+	items := make([]*pb.ScheduledMessageItem, 0, len(list))
+	for _, scheduled := range list {
+		items = append(items, buildScheduledItem(scheduled))
+	}
+	return &pb.ListScheduledMessagesResponse{Items: items, Total: total}, nil
+}
+
+// CancelScheduledMessage 取消定时消息
+// 待发送与发送失败的记录可取消；已被发送任务认领（发送中）或已发送的不可取消，已取消的幂等返回。
+//
+// 错误码映射：
+//   - codes.InvalidArgument: 参数错误
+//   - codes.NotFound: 定时消息不存在
+//   - codes.FailedPrecondition: 发送中或已发送
+//   - codes.Internal: 系统内部错误
+func (s *messageServiceImpl) CancelScheduledMessage(ctx context.Context, req *pb.CancelScheduledMessageRequest) (*pb.CancelScheduledMessageResponse, error) {
+	userUUID := util.GetUserUUIDFromContext(ctx)
+	if userUUID == "" {
+		logger.Error(ctx, "从 context 中获取 user_uuid 失败")
+		return nil, status.Error(codes.Internal, strconv.Itoa(consts.CodeInternalError))
+	}
+	if req.ScheduleId <= 0 {
+		return nil, status.Error(codes.InvalidArgument, strconv.Itoa(consts.CodeParamError))
+	}
+
+	canceled, err := s.scheduleRepo.Cancel(ctx, req.ScheduleId, userUUID)
+	if err != nil {
+		logger.Error(ctx, "取消定时消息失败",
+			logger.Int64("schedule_id", req.ScheduleId),
+			logger.ErrorField("error", err),
+		)
+		return nil, status.Error(codes.Internal, strconv.Itoa(consts.CodeInternalError))
+	}
+	if canceled {
+		logger.Info(ctx, "定时消息已取消", logger.Int64("schedule_id", req.ScheduleId))
+		return &pb.CancelScheduledMessageResponse{}, nil
+	}
+
+	// 未取消：区分不存在、已取消与不可取消
+	scheduled, err := s.scheduleRepo.GetById(ctx, req.ScheduleId, userUUID)
+	if err != nil {
+		if errors.Is(err, repository.ErrRecordNotFound) {
+			return nil, status.Error(codes.NotFound, strconv.Itoa(consts.CodeScheduleNotFound))
+		}
+		logger.Error(ctx, "查询定时消息失败",
+			logger.Int64("schedule_id", req.ScheduleId),
+			logger.ErrorField("error", err),
+		)
+		return nil, status.Error(codes.Internal, strconv.Itoa(consts.CodeInternalError))
+	}
+	if scheduled.Status == consts.ScheduledStatusCanceled {
+		return &pb.CancelScheduledMessageResponse{}, nil
+	}
+	return nil, status.Error(codes.FailedPrecondition, strconv.Itoa(consts.CodeScheduleNotCancelable))
+}
+
+// buildScheduledItem 转换定时消息
+func buildScheduledItem(scheduled *model.ScheduledMessage) *pb.ScheduledMessageItem {
+	return &pb.ScheduledMessageItem{
+		ScheduleId:  scheduled.Id,
+		ConvType:    int32(scheduled.ConvType),
+		TargetUuid:  scheduled.TargetUuid,
+		ClientMsgId: scheduled.ClientMsgId,
+		MsgType:     int32(scheduled.MsgType),
+		Content:     scheduled.Content,
+		AtUuids:     scheduled.AtUuids,
+		AtAll:       scheduled.AtAll,
+		SendAt:      scheduled.SendAt.UnixMilli(),
+		Status:      int32(scheduled.Status),
+		FailCode:    int32(scheduled.FailCode),
+		MsgId:       scheduled.MsgId,
+		Seq:         scheduled.Seq,
+		CreateTime:  scheduled.CreatedAt.UnixMilli(),
+	}
+}
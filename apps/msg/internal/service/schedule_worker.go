@@ -0,0 +1,186 @@
+package service
+
+import (
+	"ChatServer/apps/msg/internal/repository"
+	pb "ChatServer/apps/msg/pb"
+	"ChatServer/consts"
+	"ChatServer/model"
+	"ChatServer/pkg/logger"
+	"ChatServer/pkg/util"
+	"context"
+	"strconv"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ScheduleWorkerOptions 定时消息发送任务参数
+type ScheduleWorkerOptions struct {
+	Interval    time.Duration // 扫描到期定时消息的间隔
+	BatchSize   int           // 每次最多认领的记录数
+	Lease       time.Duration // 认领有效期，到期仍未完成的记录由下一轮扫描重新认领（应明显大于单条发送耗时）
+	MaxAttempts int           // 系统错误时的最大尝试次数，达到后标记为发送失败
+}
+
+// DefaultScheduleWorkerOptions 返回默认发送任务参数：每秒扫描一次，每批 100 条，认领 1 分钟有效，最多尝试 5 次
+func DefaultScheduleWorkerOptions() ScheduleWorkerOptions {
+	return ScheduleWorkerOptions{
+		Interval:    time.Second,
+		BatchSize:   100,
+		Lease:       time.Minute,
+		MaxAttempts: 5,
+	}
+}
+
+// ScheduleWorker 定时消息发送任务
+// 到期的定时消息以发送者身份、沿用预约时的 client_msg_id 调用 SendMessage，幂等、黑名单/禁言校验与序号分配均与普通发送一致。
+// 可靠性：
+//   - 不丢失：记录先认领（发送中 + 认领到期时间）再发送，进程在任意时刻退出，认领到期后都会被重新认领发送
+//   - 不重复：重新发送命中消息表 (from_uuid, client_msg_id) 幂等，返回首次写入的消息，只回填结果不再投递
+//   - 多实例：认领时跳过其他实例已锁定的记录，同一记录同一时刻只由一个实例发送
+type ScheduleWorker struct {
+	opts           ScheduleWorkerOptions
+	scheduleRepo   repository.IScheduleRepository
+	messageService MessageService
+}
+
+// NewScheduleWorker 创建定时消息发送任务
+func NewScheduleWorker(opts ScheduleWorkerOptions, scheduleRepo repository.IScheduleRepository, messageService MessageService) *ScheduleWorker {
+	defaults := DefaultScheduleWorkerOptions()
+	if opts.Interval <= 0 {
+		opts.Interval = defaults.Interval
+	}
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = defaults.BatchSize
+	}
+	if opts.Lease <= 0 {
+		opts.Lease = defaults.Lease
+	}
+	if opts.MaxAttempts <= 0 {
+		opts.MaxAttempts = defaults.MaxAttempts
+	}
+	return &ScheduleWorker{
+		opts:           opts,
+		scheduleRepo:   scheduleRepo,
+		messageService: messageService,
+	}
+}
+
+// Run 定时扫描并发送到期的定时消息，直到 ctx 取消
+func (w *ScheduleWorker) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.opts.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			w.dispatchDue(ctx)
+		}
+	}
+}
+
+// dispatchDue 认领并发送到期的定时消息，一批认领满时继续认领下一批
+func (w *ScheduleWorker) dispatchDue(ctx context.Context) {
+	for ctx.Err() == nil {
+		now := time.Now()
+		claimed, err := w.scheduleRepo.ClaimDue(ctx, now, now.Add(w.opts.Lease), w.opts.BatchSize)
+		if err != nil {
+			logger.Error(ctx, "认领到期定时消息失败", logger.ErrorField("error", err))
+			return
+		}
+		for _, scheduled := range claimed {
+			w.dispatch(ctx, scheduled)
+		}
+		if len(claimed) < w.opts.BatchSize {
+			return
+		}
+	}
+}
+
+// dispatch 发送一条定时消息并回填结果
+// 以定时消息ID派生 client_msg_id，不与用户直接发送的消息共用幂等ID，同一条定时消息重复发送仍命中幂等；
+// 业务错误（如已被拉黑、已退群）标记为发送失败；系统错误保持发送中，认领到期后重试，尝试次数用尽后标记为发送失败
+func (w *ScheduleWorker) dispatch(ctx context.Context, scheduled *model.ScheduledMessage) {
+	sendCtx := context.WithValue(ctx, util.ContextKeyUserUUID, scheduled.FromUuid)
+	sendCtx = context.WithValue(sendCtx, scheduleDispatchKey{}, true)
+	resp, err := w.messageService.SendMessage(sendCtx, &pb.SendMessageRequest{
+		ConvType:    int32(scheduled.ConvType),
+		TargetUuid:  scheduled.TargetUuid,
+		ClientMsgId: scheduledClientMsgID(scheduled.Id),
+		MsgType:     int32(scheduled.MsgType),
+		Content:     scheduled.Content,
+		AtUuids:     scheduled.AtUuids,
+		AtAll:       scheduled.AtAll,
+	})
+	if err != nil {
+		failCode, retryable := classifySendError(err)
+		if retryable && scheduled.Attempts < w.opts.MaxAttempts {
+			logger.Warn(ctx, "定时消息发送失败，等待重试",
+				logger.Int64("schedule_id", scheduled.Id),
+				logger.Int("attempts", scheduled.Attempts),
+				logger.ErrorField("error", err),
+			)
+			return
+		}
+		if markErr := w.scheduleRepo.MarkFailed(ctx, scheduled.Id, failCode); markErr != nil {
+			logger.Error(ctx, "标记定时消息发送失败出错",
+				logger.Int64("schedule_id", scheduled.Id),
+				logger.ErrorField("error", markErr),
+			)
+		}
+		logger.Warn(ctx, "定时消息发送失败",
+			logger.Int64("schedule_id", scheduled.Id),
+			logger.Int("fail_code", failCode),
+			logger.Int("attempts", scheduled.Attempts),
+		)
+		return
+	}
+
+	// 回填失败时记录停留在发送中，认领到期后重新发送命中幂等，再次回填
+	if err := w.scheduleRepo.MarkSent(ctx, scheduled.Id, resp.MsgId, resp.Seq, time.UnixMilli(resp.SendTime)); err != nil {
+		logger.Error(ctx, "回填定时消息发送结果失败",
+			logger.Int64("schedule_id", scheduled.Id),
+			logger.String("msg_id", resp.MsgId),
+			logger.ErrorField("error", err),
+		)
+		return
+	}
+	logger.Info(ctx, "定时消息发送成功",
+		logger.Int64("schedule_id", scheduled.Id),
+		logger.String("conv_id", resp.ConvId),
+		logger.String("msg_id", resp.MsgId),
+		logger.Bool("duplicated", resp.Duplicated),
+	)
+}
+
+// scheduleDispatchKey 标记由 ScheduleWorker 发起的发送，只有此时 SendMessage 接受定时消息前缀的幂等ID
+type scheduleDispatchKey struct{}
+
+// isScheduleDispatch 判断发送是否由 ScheduleWorker 发起（该标记只能在进程内设置，客户端请求无法携带）
+func isScheduleDispatch(ctx context.Context) bool {
+	dispatch, _ := ctx.Value(scheduleDispatchKey{}).(bool)
+	return dispatch
+}
+
+// scheduledClientMsgID 定时消息发送时使用的幂等ID
+// 使用保留前缀，不会与客户端直接发送的消息冲突
+func scheduledClientMsgID(scheduleID int64) string {
+	return scheduledClientMsgIDPrefix + strconv.FormatInt(scheduleID, 10)
+}
+
+// classifySendError 解析 SendMessage 返回的错误：业务错误返回其错误码且不可重试，系统错误可重试
+func classifySendError(err error) (int, bool) {
+	st, ok := status.FromError(err)
+	if !ok || st.Code() == codes.Internal || st.Code() == codes.Unavailable ||
+		st.Code() == codes.DeadlineExceeded || st.Code() == codes.Canceled {
+		return consts.CodeMessageSendFail, true
+	}
+	code, convErr := strconv.Atoi(st.Message())
+	if convErr != nil {
+		return consts.CodeMessageSendFail, true
+	}
+	return code, false
+}
@@ -0,0 +1,298 @@
+package service
+
+import (
+	"ChatServer/apps/msg/internal/repository"
+	pb "ChatServer/apps/msg/pb"
+	"ChatServer/consts"
+	"ChatServer/model"
+	"ChatServer/pkg/logger"
+	"ChatServer/pkg/util"
+	"context"
+	"errors"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// init 初始化 logger（测试模式，不输出日志）
+func init() {
+	logger.ReplaceGlobal(zap.NewNop())
+}
+
+// fakeScheduleRepo 内存版定时消息仓储，按 IScheduleRepository 的状态流转语义实现
+type fakeScheduleRepo struct {
+	repository.IScheduleRepository
+
+	mu          sync.Mutex
+	records     map[int64]*model.ScheduledMessage
+	markSentErr error
+}
+
+func newFakeScheduleRepo(records ...*model.ScheduledMessage) *fakeScheduleRepo {
+	repo := &fakeScheduleRepo{records: make(map[int64]*model.ScheduledMessage)}
+	for _, r := range records {
+		repo.records[r.Id] = r
+	}
+	return repo
+}
+
+func (r *fakeScheduleRepo) ClaimDue(ctx context.Context, now, leaseUntil time.Time, limit int) ([]*model.ScheduledMessage, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var claimed []*model.ScheduledMessage
+	for _, rec := range r.records {
+		if len(claimed) >= limit {
+			break
+		}
+		due := rec.Status == consts.ScheduledStatusPending && !rec.SendAt.After(now)
+		expired := rec.Status == consts.ScheduledStatusDispatching && rec.LeaseUntil != nil && !rec.LeaseUntil.After(now)
+		if !due && !expired {
+			continue
+		}
+		lease := leaseUntil
+		rec.Status = consts.ScheduledStatusDispatching
+		rec.LeaseUntil = &lease
+		rec.Attempts++
+		copied := *rec
+		claimed = append(claimed, &copied)
+	}
+	return claimed, nil
+}
+
+func (r *fakeScheduleRepo) MarkSent(ctx context.Context, id int64, msgID string, seq int64, sentAt time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.markSentErr != nil {
+		return r.markSentErr
+	}
+	rec := r.records[id]
+	if rec.Status == consts.ScheduledStatusDispatching {
+		rec.Status, rec.MsgId, rec.Seq, rec.LeaseUntil = consts.ScheduledStatusSent, msgID, seq, nil
+	}
+	return nil
+}
+
+func (r *fakeScheduleRepo) MarkFailed(ctx context.Context, id int64, failCode int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	rec := r.records[id]
+	if rec.Status == consts.ScheduledStatusDispatching {
+		rec.Status, rec.FailCode, rec.LeaseUntil = consts.ScheduledStatusFailed, failCode, nil
+	}
+	return nil
+}
+
+func (r *fakeScheduleRepo) get(id int64) model.ScheduledMessage {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return *r.records[id]
+}
+
+// expireLeases 模拟认领到期（发送任务重启后的下一轮扫描）
+func (r *fakeScheduleRepo) expireLeases() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	past := time.Now().Add(-time.Second)
+	for _, rec := range r.records {
+		if rec.LeaseUntil != nil {
+			rec.LeaseUntil = &past
+		}
+	}
+}
+
+// fakeSender 模拟 SendMessage：按 (发送者, client_msg_id) 幂等，记录实际写入的消息数；
+// 与 SendMessage 相同，非 ScheduleWorker 发起的发送拒绝保留前缀
+type fakeSender struct {
+	MessageService
+
+	mu      sync.Mutex
+	sent    map[string]*pb.SendMessageResponse
+	written int
+	err     error
+}
+
+func (f *fakeSender) SendMessage(ctx context.Context, req *pb.SendMessageRequest) (*pb.SendMessageResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.err != nil {
+		return nil, f.err
+	}
+	if isReservedClientMsgID(req.ClientMsgId) && !isScheduleDispatch(ctx) {
+		return nil, status.Error(codes.InvalidArgument, strconv.Itoa(consts.CodeParamError))
+	}
+	key := util.GetUserUUIDFromContext(ctx) + "/" + req.ClientMsgId
+	if resp, ok := f.sent[key]; ok {
+		dup := proto.Clone(resp).(*pb.SendMessageResponse)
+		dup.Duplicated = true
+		return dup, nil
+	}
+	f.written++
+	resp := &pb.SendMessageResponse{
+		MsgId:    "m" + strconv.Itoa(f.written),
+		ConvId:   req.TargetUuid,
+		Seq:      int64(f.written),
+		SendTime: time.Now().UnixMilli(),
+	}
+	f.sent[key] = resp
+	return resp, nil
+}
+
+func newDueRecord(id int64) *model.ScheduledMessage {
+	return &model.ScheduledMessage{
+		Id:          id,
+		FromUuid:    "u1",
+		ClientMsgId: "c" + strconv.FormatInt(id, 10),
+		ConvType:    consts.ConvTypeGroup,
+		TargetUuid:  "g1",
+		MsgType:     consts.MsgTypeText,
+		Content:     `{"text":"hi"}`,
+		SendAt:      time.Now().Add(-time.Second),
+		Status:      consts.ScheduledStatusPending,
+	}
+}
+
+// TestScheduleWorker_Dispatch 测试到期发送：成功回填、未到期不发送、业务错误直接失败
+func TestScheduleWorker_Dispatch(t *testing.T) {
+	ctx := context.Background()
+
+	future := newDueRecord(2)
+	future.SendAt = time.Now().Add(time.Hour)
+	repo := newFakeScheduleRepo(newDueRecord(1), future)
+	sender := &fakeSender{sent: map[string]*pb.SendMessageResponse{}}
+	NewScheduleWorker(DefaultScheduleWorkerOptions(), repo, sender).dispatchDue(ctx)
+
+	sent := repo.get(1)
+	assert.Equal(t, int8(consts.ScheduledStatusSent), sent.Status)
+	assert.Equal(t, "m1", sent.MsgId)
+	assert.Equal(t, int8(consts.ScheduledStatusPending), repo.get(2).Status)
+
+	repo = newFakeScheduleRepo(newDueRecord(3))
+	sender = &fakeSender{
+		sent: map[string]*pb.SendMessageResponse{},
+		err:  status.Error(codes.PermissionDenied, strconv.Itoa(consts.CodeNotGroupMember)),
+	}
+	NewScheduleWorker(DefaultScheduleWorkerOptions(), repo, sender).dispatchDue(ctx)
+
+	failed := repo.get(3)
+	assert.Equal(t, int8(consts.ScheduledStatusFailed), failed.Status)
+	assert.Equal(t, consts.CodeNotGroupMember, failed.FailCode)
+}
+
+// TestScheduleWorker_RetryAfterCrash 测试发送成功但回填失败（模拟发送后进程退出）：认领到期后重新发送命中幂等，不重复写入
+func TestScheduleWorker_RetryAfterCrash(t *testing.T) {
+	ctx := context.Background()
+	repo := newFakeScheduleRepo(newDueRecord(1))
+	repo.markSentErr = errors.New("connection reset")
+	sender := &fakeSender{sent: map[string]*pb.SendMessageResponse{}}
+	worker := NewScheduleWorker(DefaultScheduleWorkerOptions(), repo, sender)
+
+	worker.dispatchDue(ctx)
+	require.Equal(t, int8(consts.ScheduledStatusDispatching), repo.get(1).Status)
+
+	// 认领未到期时不会被重复认领
+	worker.dispatchDue(ctx)
+	assert.Equal(t, 1, repo.get(1).Attempts)
+
+	repo.markSentErr = nil
+	repo.expireLeases()
+	worker.dispatchDue(ctx)
+
+	rec := repo.get(1)
+	assert.Equal(t, int8(consts.ScheduledStatusSent), rec.Status)
+	assert.Equal(t, "m1", rec.MsgId)
+	assert.Equal(t, 1, sender.written, "重新发送不应重复写入消息")
+}
+
+// TestScheduleWorker_ClientMsgIdReused 测试预约沿用了用户直接发送过的 client_msg_id：定时消息仍会发出，不命中那条消息的幂等
+func TestScheduleWorker_ClientMsgIdReused(t *testing.T) {
+	ctx := context.Background()
+	repo := newFakeScheduleRepo(newDueRecord(1))
+	sender := &fakeSender{sent: map[string]*pb.SendMessageResponse{}}
+	sendCtx := context.WithValue(ctx, util.ContextKeyUserUUID, "u1")
+	_, err := sender.SendMessage(sendCtx, &pb.SendMessageRequest{ClientMsgId: "c1", TargetUuid: "g1"})
+	require.NoError(t, err)
+
+	NewScheduleWorker(DefaultScheduleWorkerOptions(), repo, sender).dispatchDue(ctx)
+
+	rec := repo.get(1)
+	assert.Equal(t, int8(consts.ScheduledStatusSent), rec.Status)
+	assert.Equal(t, "m2", rec.MsgId)
+	assert.Equal(t, 2, sender.written)
+}
+
+// TestScheduleWorker_InternalErrorRetry 测试系统错误保持发送中等待重试，尝试次数用尽后标记失败
+func TestScheduleWorker_InternalErrorRetry(t *testing.T) {
+	ctx := context.Background()
+	repo := newFakeScheduleRepo(newDueRecord(1))
+	sender := &fakeSender{
+		sent: map[string]*pb.SendMessageResponse{},
+		err:  status.Error(codes.Internal, strconv.Itoa(consts.CodeInternalError)),
+	}
+	opts := DefaultScheduleWorkerOptions()
+	opts.MaxAttempts = 2
+	worker := NewScheduleWorker(opts, repo, sender)
+
+	worker.dispatchDue(ctx)
+	assert.Equal(t, int8(consts.ScheduledStatusDispatching), repo.get(1).Status)
+
+	repo.expireLeases()
+	worker.dispatchDue(ctx)
+
+	rec := repo.get(1)
+	assert.Equal(t, int8(consts.ScheduledStatusFailed), rec.Status)
+	assert.Equal(t, consts.CodeMessageSendFail, rec.FailCode)
+	assert.Equal(t, 2, rec.Attempts)
+}
+
+// fakeIdempotentRepo 按 (发送者, client_msg_id) 返回已写入的消息，模拟 SendMessage 命中幂等
+type fakeIdempotentRepo struct {
+	repository.IMessageRepository
+
+	msgs map[string]*model.Message
+}
+
+func (f *fakeIdempotentRepo) GetByClientMsgId(ctx context.Context, fromUUID, clientMsgID string) (*model.Message, error) {
+	if msg, ok := f.msgs[fromUUID+"/"+clientMsgID]; ok {
+		return msg, nil
+	}
+	return nil, repository.ErrRecordNotFound
+}
+
+// TestSendMessage_ReservedClientMsgID 测试客户端不能使用服务端保留前缀的 client_msg_id，定时消息前缀只对 ScheduleWorker 放行
+func TestSendMessage_ReservedClientMsgID(t *testing.T) {
+	svc := &messageServiceImpl{messageRepo: &fakeIdempotentRepo{msgs: map[string]*model.Message{
+		"u1/sched_1": {MsgId: "m1", ConvId: "g1", Seq: 1, SendTime: time.Now()},
+	}}}
+	userCtx := context.WithValue(context.Background(), util.ContextKeyUserUUID, "u1")
+	newReq := func(clientMsgID string) *pb.SendMessageRequest {
+		return &pb.SendMessageRequest{
+			ConvType:    consts.ConvTypeGroup,
+			TargetUuid:  "g1",
+			ClientMsgId: clientMsgID,
+			MsgType:     consts.MsgTypeText,
+			Content:     `{"text":"hi"}`,
+		}
+	}
+
+	for _, clientMsgID := range []string{"sched_1", "revoke_1", "edit_1_2", "pin_1", "timer_1", "announcement_1"} {
+		_, err := svc.SendMessage(userCtx, newReq(clientMsgID))
+		assert.Equal(t, codes.InvalidArgument, status.Code(err), clientMsgID)
+	}
+
+	dispatchCtx := context.WithValue(userCtx, scheduleDispatchKey{}, true)
+	_, err := svc.SendMessage(dispatchCtx, newReq("revoke_1"))
+	assert.Equal(t, codes.InvalidArgument, status.Code(err), "定时发送也不能使用其他保留前缀")
+
+	resp, err := svc.SendMessage(dispatchCtx, newReq(scheduledClientMsgID(1)))
+	require.NoError(t, err)
+	assert.True(t, resp.Duplicated)
+	assert.Equal(t, "m1", resp.MsgId)
+}
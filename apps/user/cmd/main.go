@@ -6,6 +6,8 @@ import (
 	"net/http"
 	"time"
 
+	"ChatServer/apps/connect/nodeclient"
+	msgpb "ChatServer/apps/msg/pb"
 	"ChatServer/apps/user/internal/handler"
 	"ChatServer/apps/user/internal/interceptors"
 	"ChatServer/apps/user/internal/repository"
@@ -16,9 +18,11 @@ import (
 	"ChatServer/pkg/logger"
 	"ChatServer/pkg/mysql"
 	pkgredis "ChatServer/pkg/redis"
+	"ChatServer/pkg/registry"
 	"ChatServer/pkg/util"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
 	healthgrpc "google.golang.org/grpc/health/grpc_health_v1"
 )
 
@@ -70,13 +74,33 @@ func main() {
 	applyRepo := repository.NewApplyRepository(db, redisClient)
 	blacklistRepo := repository.NewBlacklistRepository(db, redisClient)
 	deviceRepo := repository.NewDeviceRepository(db, redisClient)
+	groupRepo := repository.NewGroupRepository(db, redisClient)
+
+	// 连接路由（踢设备、下发群事件时定位 Connect 节点），依赖 Redis
+	var connectClient *nodeclient.Client
+	if redisClient != nil {
+		connectClient = nodeclient.New(registry.New(redisClient, registry.DefaultTTL))
+		defer connectClient.Close()
+	}
+
+	// 消息服务客户端（写入群公告等系统消息、创建入群会话）
+	// TODO: 从配置文件读取msg服务地址
+	msgServiceAddr := "localhost:9093"
+	msgServiceConn, err := grpc.NewClient(msgServiceAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		log.Fatalf("创建消息服务连接失败: %v", err)
+	}
+	defer msgServiceConn.Close()
+	msgClient := msgpb.NewMessageServiceClient(msgServiceConn)
+	convClient := msgpb.NewConversationServiceClient(msgServiceConn)
 
 	// 5. 组装依赖 - Service 层
 	authService := service.NewAuthService(authRepo, deviceRepo)
 	userService := service.NewUserService(userRepo)
 	friendService := service.NewFriendService(userRepo, friendRepo, applyRepo)
 	blacklistService := service.NewBlacklistService(blacklistRepo)
-	deviceService := service.NewDeviceService(deviceRepo)
+	deviceService := service.NewDeviceService(deviceRepo, connectClient)
+	groupService := service.NewGroupService(config.DefaultGroupConfig(), groupRepo, userRepo, connectClient, msgClient, convClient)
 
 	// 6. 组装依赖 - Handler 层
 	authHandler := handler.NewAuthHandler(authService)
@@ -84,6 +108,7 @@ func main() {
 	friendHandler := handler.NewFriendHandler(friendService)
 	blacklistHandler := handler.NewBlacklistHandler(blacklistService)
 	deviceHandler := handler.NewDeviceHandler(deviceService)
+	groupHandler := handler.NewGroupHandler(groupService)
 
 	// 7.初始化小组件
 	util.InitSnowflake(1)//雪花算法
@@ -108,6 +133,8 @@ func main() {
 		userpb.RegisterBlacklistServiceServer(s, blacklistHandler)
 		// 注册设备服务
 		userpb.RegisterDeviceServiceServer(s, deviceHandler)
+		// 注册群组服务
+		userpb.RegisterGroupServiceServer(s, groupHandler)
 
 		// 设置健康检查状态
 		if hs != nil {
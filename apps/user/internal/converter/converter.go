@@ -290,8 +290,135 @@ func ModelsToProtoDeviceItemList(sessions []*model.DeviceSession, currentDeviceI
 	return result
 }
 
+// ==================== Group 相关转换函数 ====================
+
+// ModelToProtoGroupInfo 将 GroupInfo Model 转换为 Proto
+func ModelToProtoGroupInfo(group *model.GroupInfo) *pb.GroupInfo {
+	if group == nil {
+		return nil
+	}
+	return &pb.GroupInfo{
+		Uuid:      group.Uuid,
+		Name:      group.Name,
+		Notice:    group.Notice,
+		Avatar:    group.Avatar,
+		OwnerUuid: group.OwnerUuid,
+		MemberCnt: int32(group.MemberCnt),
+		AddMode:   int32(group.AddMode),
+		Status:    int32(group.Status),
+		CreatedAt: TimeToMillis(group.CreatedAt),
+		MuteAll:   group.MuteAll,
+	}
+}
+
+// ModelToProtoGroupMemberItem 将 GroupMember Model 转换为 Proto
+func ModelToProtoGroupMemberItem(member *model.GroupMember) *pb.GroupMemberItem {
+	if member == nil {
+		return nil
+	}
+	return &pb.GroupMemberItem{
+		UserUuid:    member.UserUuid,
+		Role:        int32(member.Role),
+		Remark:      member.Remark,
+		MuteUntil:   TimePointerToMillis(member.MuteUntil),
+		InviterUuid: member.Inviter,
+		JoinedAt:    TimeToMillis(member.JoinedAt),
+	}
+}
+
+// ModelToProtoGroupAnnouncement 将 GroupAnnouncement Model 转换为 Proto（acked 为当前用户是否已确认）
+func ModelToProtoGroupAnnouncement(announcement *model.GroupAnnouncement, acked bool) *pb.GroupAnnouncement {
+	if announcement == nil {
+		return nil
+	}
+	return &pb.GroupAnnouncement{
+		Id:            announcement.Id,
+		GroupUuid:     announcement.GroupUuid,
+		PublisherUuid: announcement.PublisherUuid,
+		Content:       announcement.Content,
+		Pinned:        announcement.Pinned,
+		RequireAck:    announcement.RequireAck,
+		AckCount:      int32(announcement.AckCount),
+		Acked:         acked,
+		CreatedAt:     TimeToMillis(announcement.CreatedAt),
+	}
+}
+
+// ModelToProtoGroupInvite 将 GroupInvite Model 转换为 Proto（token、qrcode 由服务层签发）
+func ModelToProtoGroupInvite(invite *model.GroupInvite, token, qrcode string) *pb.GroupInvite {
+	if invite == nil {
+		return nil
+	}
+	return &pb.GroupInvite{
+		Id:          invite.Id,
+		GroupUuid:   invite.GroupUuid,
+		CreatorUuid: invite.CreatorUuid,
+		Token:       token,
+		Qrcode:      qrcode,
+		MaxUses:     int32(invite.MaxUses),
+		UsedCount:   int32(invite.UsedCount),
+		ExpireAt:    TimeToMillis(invite.ExpireAt),
+		CreatedAt:   TimeToMillis(invite.CreatedAt),
+	}
+}
+
+// ModelsToProtoGroupMemberItemList 批量转换 GroupMemberItem
+func ModelsToProtoGroupMemberItemList(members []*model.GroupMember) []*pb.GroupMemberItem {
+	if members == nil {
+		return []*pb.GroupMemberItem{}
+	}
+
+	result := make([]*pb.GroupMemberItem, 0, len(members))
+	for _, member := range members {
+		result = append(result, ModelToProtoGroupMemberItem(member))
+	}
+	return result
+}
+
 // ==================== Proto to Model 转换函数 ====================
 
+// ModelToProtoGroupApplyItem 将入群申请 ApplyRequest Model 和申请人 UserInfo Model 转换为 GroupApplyItem Proto
+func ModelToProtoGroupApplyItem(apply *model.ApplyRequest, applicant *model.UserInfo) *pb.GroupApplyItem {
+	if apply == nil {
+		return nil
+	}
+
+	applicantInfo := &pb.SimpleUserInfo{Uuid: apply.ApplicantUuid}
+	if applicant != nil {
+		applicantInfo.Nickname = applicant.Nickname
+		applicantInfo.Avatar = applicant.Avatar
+	}
+
+	return &pb.GroupApplyItem{
+		ApplyId:        apply.Id,
+		GroupUuid:      apply.TargetUuid,
+		ApplicantInfo:  applicantInfo,
+		Reason:         apply.Reason,
+		Status:         int32(apply.Status),
+		HandleUserUuid: apply.HandleUserUuid,
+		HandleRemark:   apply.HandleRemark,
+		CreatedAt:      TimeToMillis(apply.UpdatedAt),
+	}
+}
+
+// ModelsToProtoGroupApplyItemList 批量转换 GroupApplyItem
+func ModelsToProtoGroupApplyItemList(applies []*model.ApplyRequest, users []*model.UserInfo) []*pb.GroupApplyItem {
+	if applies == nil {
+		return []*pb.GroupApplyItem{}
+	}
+
+	userMap := make(map[string]*model.UserInfo, len(users))
+	for _, user := range users {
+		userMap[user.Uuid] = user
+	}
+
+	result := make([]*pb.GroupApplyItem, 0, len(applies))
+	for _, apply := range applies {
+		result = append(result, ModelToProtoGroupApplyItem(apply, userMap[apply.ApplicantUuid]))
+	}
+	return result
+}
+
 // ProtoToModelDeviceInfo 将 DeviceInfo Proto 转换为创建 DeviceSession Model 所需的字段
 func ProtoToModelDeviceInfo(deviceInfo *pb.DeviceInfo) (deviceName, platform, osVersion, appVersion string) {
 	if deviceInfo == nil {
@@ -0,0 +1,136 @@
+package handler
+
+import (
+	"ChatServer/apps/user/internal/service"
+	pb "ChatServer/apps/user/pb"
+	"context"
+)
+
+// GroupHandler 群组服务Handler
+type GroupHandler struct {
+	pb.UnimplementedGroupServiceServer
+
+	groupService service.IGroupService
+}
+
+// NewGroupHandler 创建群组Handler实例
+func NewGroupHandler(groupService service.IGroupService) *GroupHandler {
+	return &GroupHandler{
+		groupService: groupService,
+	}
+}
+
+// CreateGroup 创建群组
+func (h *GroupHandler) CreateGroup(ctx context.Context, req *pb.CreateGroupRequest) (*pb.CreateGroupResponse, error) {
+	return h.groupService.CreateGroup(ctx, req)
+}
+
+// GetGroupInfo 获取群资料
+func (h *GroupHandler) GetGroupInfo(ctx context.Context, req *pb.GetGroupInfoRequest) (*pb.GetGroupInfoResponse, error) {
+	return h.groupService.GetGroupInfo(ctx, req)
+}
+
+// GetGroupMembers 获取群成员列表
+func (h *GroupHandler) GetGroupMembers(ctx context.Context, req *pb.GetGroupMembersRequest) (*pb.GetGroupMembersResponse, error) {
+	return h.groupService.GetGroupMembers(ctx, req)
+}
+
+// InviteMembers 邀请成员入群
+func (h *GroupHandler) InviteMembers(ctx context.Context, req *pb.InviteMembersRequest) (*pb.InviteMembersResponse, error) {
+	return h.groupService.InviteMembers(ctx, req)
+}
+
+// JoinGroup 申请入群
+func (h *GroupHandler) JoinGroup(ctx context.Context, req *pb.JoinGroupRequest) (*pb.JoinGroupResponse, error) {
+	return h.groupService.JoinGroup(ctx, req)
+}
+
+// GetGroupApplyList 获取入群申请列表
+func (h *GroupHandler) GetGroupApplyList(ctx context.Context, req *pb.GetGroupApplyListRequest) (*pb.GetGroupApplyListResponse, error) {
+	return h.groupService.GetGroupApplyList(ctx, req)
+}
+
+// HandleGroupApply 处理入群申请
+func (h *GroupHandler) HandleGroupApply(ctx context.Context, req *pb.HandleGroupApplyRequest) (*pb.HandleGroupApplyResponse, error) {
+	return &pb.HandleGroupApplyResponse{}, h.groupService.HandleGroupApply(ctx, req)
+}
+
+// SetAdmin 设置管理员
+func (h *GroupHandler) SetAdmin(ctx context.Context, req *pb.SetAdminRequest) (*pb.SetAdminResponse, error) {
+	return &pb.SetAdminResponse{}, h.groupService.SetAdmin(ctx, req)
+}
+
+// UnsetAdmin 取消管理员
+func (h *GroupHandler) UnsetAdmin(ctx context.Context, req *pb.UnsetAdminRequest) (*pb.UnsetAdminResponse, error) {
+	return &pb.UnsetAdminResponse{}, h.groupService.UnsetAdmin(ctx, req)
+}
+
+// KickMember 踢出群成员
+func (h *GroupHandler) KickMember(ctx context.Context, req *pb.KickMemberRequest) (*pb.KickMemberResponse, error) {
+	return &pb.KickMemberResponse{}, h.groupService.KickMember(ctx, req)
+}
+
+// TransferOwnership 转让群主
+func (h *GroupHandler) TransferOwnership(ctx context.Context, req *pb.TransferOwnershipRequest) (*pb.TransferOwnershipResponse, error) {
+	return &pb.TransferOwnershipResponse{}, h.groupService.TransferOwnership(ctx, req)
+}
+
+// MuteMember 禁言/解除禁言成员
+func (h *GroupHandler) MuteMember(ctx context.Context, req *pb.MuteMemberRequest) (*pb.MuteMemberResponse, error) {
+	return h.groupService.MuteMember(ctx, req)
+}
+
+// SetGroupMuteAll 开启/关闭全员禁言
+func (h *GroupHandler) SetGroupMuteAll(ctx context.Context, req *pb.SetGroupMuteAllRequest) (*pb.SetGroupMuteAllResponse, error) {
+	return &pb.SetGroupMuteAllResponse{}, h.groupService.SetGroupMuteAll(ctx, req)
+}
+
+// PostGroupAnnouncement 发布群公告
+func (h *GroupHandler) PostGroupAnnouncement(ctx context.Context, req *pb.PostGroupAnnouncementRequest) (*pb.PostGroupAnnouncementResponse, error) {
+	return h.groupService.PostGroupAnnouncement(ctx, req)
+}
+
+// GetGroupAnnouncements 获取群公告历史
+func (h *GroupHandler) GetGroupAnnouncements(ctx context.Context, req *pb.GetGroupAnnouncementsRequest) (*pb.GetGroupAnnouncementsResponse, error) {
+	return h.groupService.GetGroupAnnouncements(ctx, req)
+}
+
+// PinGroupAnnouncement 置顶/取消置顶群公告
+func (h *GroupHandler) PinGroupAnnouncement(ctx context.Context, req *pb.PinGroupAnnouncementRequest) (*pb.PinGroupAnnouncementResponse, error) {
+	return &pb.PinGroupAnnouncementResponse{}, h.groupService.PinGroupAnnouncement(ctx, req)
+}
+
+// AckGroupAnnouncement 确认群公告
+func (h *GroupHandler) AckGroupAnnouncement(ctx context.Context, req *pb.AckGroupAnnouncementRequest) (*pb.AckGroupAnnouncementResponse, error) {
+	return h.groupService.AckGroupAnnouncement(ctx, req)
+}
+
+// CreateGroupInvite 创建群邀请
+func (h *GroupHandler) CreateGroupInvite(ctx context.Context, req *pb.CreateGroupInviteRequest) (*pb.CreateGroupInviteResponse, error) {
+	return h.groupService.CreateGroupInvite(ctx, req)
+}
+
+// GetGroupInviteList 获取群邀请列表
+func (h *GroupHandler) GetGroupInviteList(ctx context.Context, req *pb.GetGroupInviteListRequest) (*pb.GetGroupInviteListResponse, error) {
+	return h.groupService.GetGroupInviteList(ctx, req)
+}
+
+// RevokeGroupInvite 撤销群邀请
+func (h *GroupHandler) RevokeGroupInvite(ctx context.Context, req *pb.RevokeGroupInviteRequest) (*pb.RevokeGroupInviteResponse, error) {
+	return &pb.RevokeGroupInviteResponse{}, h.groupService.RevokeGroupInvite(ctx, req)
+}
+
+// JoinByInviteToken 通过邀请令牌入群
+func (h *GroupHandler) JoinByInviteToken(ctx context.Context, req *pb.JoinByInviteTokenRequest) (*pb.JoinByInviteTokenResponse, error) {
+	return h.groupService.JoinByInviteToken(ctx, req)
+}
+
+// QuitGroup 退出群组
+func (h *GroupHandler) QuitGroup(ctx context.Context, req *pb.QuitGroupRequest) (*pb.QuitGroupResponse, error) {
+	return &pb.QuitGroupResponse{}, h.groupService.QuitGroup(ctx, req)
+}
+
+// DismissGroup 解散群组
+func (h *GroupHandler) DismissGroup(ctx context.Context, req *pb.DismissGroupRequest) (*pb.DismissGroupResponse, error) {
+	return &pb.DismissGroupResponse{}, h.groupService.DismissGroup(ctx, req)
+}
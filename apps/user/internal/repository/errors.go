@@ -25,6 +25,21 @@ var (
 
 	// ErrRedis Redis 操作错误
 	ErrRedis = errors.New("redis error")
+
+	// ErrGroupFull 群成员已达上限
+	ErrGroupFull = errors.New("group full")
+
+	// ErrAdminLimitExceeded 群管理员已达上限
+	ErrAdminLimitExceeded = errors.New("admin limit exceeded")
+
+	// ErrGroupDismissed 群组已解散
+	ErrGroupDismissed = errors.New("group dismissed")
+
+	// ErrInviteExpired 群邀请已过期
+	ErrInviteExpired = errors.New("invite expired")
+
+	// ErrInviteExhausted 群邀请使用次数已达上限
+	ErrInviteExhausted = errors.New("invite exhausted")
 )
 
 // ==================== 核心包装函数 ====================
@@ -56,6 +71,11 @@ var (
 	dbErrorRules = map[error]error{
 		gorm.ErrRecordNotFound: ErrRecordNotFound,
 		gorm.ErrDuplicatedKey:  ErrDuplicateKey,
+		ErrGroupFull:           ErrGroupFull,
+		ErrAdminLimitExceeded:  ErrAdminLimitExceeded,
+		ErrGroupDismissed:      ErrGroupDismissed,
+		ErrInviteExpired:       ErrInviteExpired,
+		ErrInviteExhausted:     ErrInviteExhausted,
 	}
 
 	// redisErrorRules Redis 错误映射规则
@@ -0,0 +1,696 @@
+package repository
+
+import (
+	"ChatServer/model"
+	"context"
+	"errors"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// groupRepositoryImpl 群组数据访问层实现
+type groupRepositoryImpl struct {
+	db          *gorm.DB
+	redisClient *redis.Client
+}
+
+// NewGroupRepository 创建群组仓储实例
+func NewGroupRepository(db *gorm.DB, redisClient *redis.Client) IGroupRepository {
+	return &groupRepositoryImpl{db: db, redisClient: redisClient}
+}
+
+// CreateWithMembers 创建群组与初始成员
+func (r *groupRepositoryImpl) CreateWithMembers(ctx context.Context, group *model.GroupInfo, members []*model.GroupMember) error {
+	group.MemberCnt = len(members)
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(group).Error; err != nil {
+			return err
+		}
+		return tx.Create(&members).Error
+	})
+	if err != nil {
+		return WrapDBError(err)
+	}
+	return nil
+}
+
+// GetByUUID 根据群uuid查询群资料
+func (r *groupRepositoryImpl) GetByUUID(ctx context.Context, groupUUID string) (*model.GroupInfo, error) {
+	var group model.GroupInfo
+	err := r.db.WithContext(ctx).Where("uuid = ?", groupUUID).First(&group).Error
+	if err != nil {
+		return nil, WrapDBError(err)
+	}
+	return &group, nil
+}
+
+// GetMember 查询群成员记录
+func (r *groupRepositoryImpl) GetMember(ctx context.Context, groupUUID, userUUID string) (*model.GroupMember, error) {
+	var member model.GroupMember
+	err := r.db.WithContext(ctx).
+		Where("group_uuid = ? AND user_uuid = ?", groupUUID, userUUID).
+		First(&member).Error
+	if err != nil {
+		return nil, WrapDBError(err)
+	}
+	return &member, nil
+}
+
+// ListMembers 分页查询正常群成员
+func (r *groupRepositoryImpl) ListMembers(ctx context.Context, groupUUID string, page, pageSize int) ([]*model.GroupMember, int64, error) {
+	query := r.db.WithContext(ctx).Model(&model.GroupMember{}).
+		Where("group_uuid = ? AND status = ?", groupUUID, 0).
+		Session(&gorm.Session{})
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, WrapDBError(err)
+	}
+	if total == 0 {
+		return []*model.GroupMember{}, 0, nil
+	}
+
+	var members []*model.GroupMember
+	err := query.
+		Order("role DESC").
+		Order("joined_at ASC").
+		Order("id ASC").
+		Offset((page - 1) * pageSize).
+		Limit(pageSize).
+		Find(&members).Error
+	if err != nil {
+		return nil, 0, WrapDBError(err)
+	}
+	return members, total, nil
+}
+
+// AddMembers 批量加入成员
+// 先对群记录加行锁（SELECT ... FOR UPDATE），并发邀请时串行校验人数上限，避免超员与 member_cnt 不一致
+func (r *groupRepositoryImpl) AddMembers(ctx context.Context, groupUUID, inviterUUID string, userUUIDs []string, maxMembers int) ([]string, int, error) {
+	var (
+		added     []string
+		memberCnt int
+	)
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var err error
+		added, memberCnt, err = addMembersTx(tx, groupUUID, inviterUUID, userUUIDs, maxMembers)
+		return err
+	})
+	if err != nil {
+		return nil, 0, WrapDBError(err)
+	}
+	return added, memberCnt, nil
+}
+
+// addMembersTx 在事务内加入成员，返回实际加入的成员与加入后的群人数
+func addMembersTx(tx *gorm.DB, groupUUID, inviterUUID string, userUUIDs []string, maxMembers int) ([]string, int, error) {
+	// 1. 锁定群记录
+	var group model.GroupInfo
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("uuid = ? AND status = ?", groupUUID, 0).
+		First(&group).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// 2. 过滤已是正常成员的用户
+	var existing []string
+	err = tx.Model(&model.GroupMember{}).
+		Where("group_uuid = ? AND user_uuid IN ? AND status = ?", groupUUID, userUUIDs, 0).
+		Pluck("user_uuid", &existing).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	skip := make(map[string]struct{}, len(existing))
+	for _, uuid := range existing {
+		skip[uuid] = struct{}{}
+	}
+	var added []string
+	for _, uuid := range userUUIDs {
+		if _, ok := skip[uuid]; ok {
+			continue
+		}
+		skip[uuid] = struct{}{}
+		added = append(added, uuid)
+	}
+	if len(added) == 0 {
+		return nil, group.MemberCnt, nil
+	}
+
+	// 3. 校验人数上限
+	if group.MemberCnt+len(added) > maxMembers {
+		return nil, 0, ErrGroupFull
+	}
+
+	// 4. 写入成员（曾退出/被踢出的成员复用原记录，重置角色、禁言与入群时间）
+	now := time.Now()
+	members := make([]*model.GroupMember, 0, len(added))
+	for _, uuid := range added {
+		members = append(members, &model.GroupMember{
+			GroupUuid: groupUUID,
+			UserUuid:  uuid,
+			Role:      0,
+			Status:    0,
+			Inviter:   inviterUUID,
+			JoinedAt:  now,
+		})
+	}
+	err = tx.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "group_uuid"}, {Name: "user_uuid"}},
+		DoUpdates: clause.AssignmentColumns([]string{"role", "status", "mute_until", "inviter_uuid", "joined_at", "updated_at", "deleted_at"}),
+	}).Create(&members).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// 5. 更新群人数
+	memberCnt := group.MemberCnt + len(added)
+	err = tx.Model(&model.GroupInfo{}).
+		Where("uuid = ?", groupUUID).
+		Update("member_cnt", memberCnt).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return added, memberCnt, nil
+}
+
+// RemoveMember 移除正常成员并扣减群人数
+// 先按群状态正常条件扣减群人数（同时锁定群记录，与解散串行），群已解散返回 ErrGroupDismissed
+func (r *groupRepositoryImpl) RemoveMember(ctx context.Context, groupUUID, userUUID string, status int8) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&model.GroupInfo{}).
+			Where("uuid = ? AND status = ?", groupUUID, 0).
+			Update("member_cnt", gorm.Expr("member_cnt - 1"))
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrGroupDismissed
+		}
+		result = tx.Model(&model.GroupMember{}).
+			Where("group_uuid = ? AND user_uuid = ? AND status = ?", groupUUID, userUUID, 0).
+			Update("status", status)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return nil
+	})
+	if err != nil {
+		return WrapDBError(err)
+	}
+	return nil
+}
+
+// UpdateRole 更新正常成员角色
+// 设为管理员时先锁定群记录，并发设置时串行校验管理员人数上限
+func (r *groupRepositoryImpl) UpdateRole(ctx context.Context, groupUUID, userUUID string, fromRole, toRole int8, maxAdmins int) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if toRole == 1 {
+			var group model.GroupInfo
+			err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+				Where("uuid = ?", groupUUID).
+				First(&group).Error
+			if err != nil {
+				return err
+			}
+
+			var admins int64
+			err = tx.Model(&model.GroupMember{}).
+				Where("group_uuid = ? AND role = ? AND status = ?", groupUUID, 1, 0).
+				Count(&admins).Error
+			if err != nil {
+				return err
+			}
+			if int(admins) >= maxAdmins {
+				return ErrAdminLimitExceeded
+			}
+		}
+
+		result := tx.Model(&model.GroupMember{}).
+			Where("group_uuid = ? AND user_uuid = ? AND role = ? AND status = ?", groupUUID, userUUID, fromRole, 0).
+			Update("role", toRole)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return nil
+	})
+	if err != nil {
+		return WrapDBError(err)
+	}
+	return nil
+}
+
+// TransferOwnership 转让群主
+// 锁定群记录并确认群主未变更后交换两人角色，三处更新在同一事务内完成
+func (r *groupRepositoryImpl) TransferOwnership(ctx context.Context, groupUUID, oldOwnerUUID, newOwnerUUID string) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// 1. 锁定群记录并校验当前群主
+		var group model.GroupInfo
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("uuid = ? AND owner_uuid = ? AND status = ?", groupUUID, oldOwnerUUID, 0).
+			First(&group).Error
+		if err != nil {
+			return err
+		}
+
+		// 2. 新群主
+		result := tx.Model(&model.GroupMember{}).
+			Where("group_uuid = ? AND user_uuid = ? AND status = ?", groupUUID, newOwnerUUID, 0).
+			Update("role", 2)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		// 3. 原群主降为普通成员
+		err = tx.Model(&model.GroupMember{}).
+			Where("group_uuid = ? AND user_uuid = ?", groupUUID, oldOwnerUUID).
+			Update("role", 0).Error
+		if err != nil {
+			return err
+		}
+
+		// 4. 更新群资料
+		return tx.Model(&model.GroupInfo{}).
+			Where("uuid = ?", groupUUID).
+			Update("owner_uuid", newOwnerUUID).Error
+	})
+	if err != nil {
+		return WrapDBError(err)
+	}
+	return nil
+}
+
+// Dismiss 解散群组
+func (r *groupRepositoryImpl) Dismiss(ctx context.Context, groupUUID string) error {
+	result := r.db.WithContext(ctx).Model(&model.GroupInfo{}).
+		Where("uuid = ? AND status = ?", groupUUID, 0).
+		Update("status", 2)
+	if result.Error != nil {
+		return WrapDBError(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return ErrRecordNotFound
+	}
+	return nil
+}
+
+// SetMuteUntil 设置正常成员的禁言到期时间
+// 到期后无需清理，发送消息时按 mute_until 与当前时间比较判定
+func (r *groupRepositoryImpl) SetMuteUntil(ctx context.Context, groupUUID, userUUID string, muteUntil *time.Time) error {
+	result := r.db.WithContext(ctx).Model(&model.GroupMember{}).
+		Where("group_uuid = ? AND user_uuid = ? AND status = ?", groupUUID, userUUID, 0).
+		Update("mute_until", muteUntil)
+	if result.Error != nil {
+		return WrapDBError(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return ErrRecordNotFound
+	}
+	return nil
+}
+
+// SetMuteAll 开启/关闭全员禁言
+func (r *groupRepositoryImpl) SetMuteAll(ctx context.Context, groupUUID string, muteAll bool) error {
+	result := r.db.WithContext(ctx).Model(&model.GroupInfo{}).
+		Where("uuid = ? AND status = ?", groupUUID, 0).
+		Update("mute_all", muteAll)
+	if result.Error != nil {
+		return WrapDBError(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return ErrRecordNotFound
+	}
+	return nil
+}
+
+// ListMemberUUIDs 查询全部正常成员 uuid
+func (r *groupRepositoryImpl) ListMemberUUIDs(ctx context.Context, groupUUID string) ([]string, error) {
+	var uuids []string
+	err := r.db.WithContext(ctx).Model(&model.GroupMember{}).
+		Where("group_uuid = ? AND status = ?", groupUUID, 0).
+		Pluck("user_uuid", &uuids).Error
+	if err != nil {
+		return nil, WrapDBError(err)
+	}
+	return uuids, nil
+}
+
+// SaveJoinApply 保存入群申请
+// 同一申请人对同一群已有待处理申请时复用该记录（刷新附言、过期时间并重置为未读），避免审核列表出现重复申请
+func (r *groupRepositoryImpl) SaveJoinApply(ctx context.Context, apply *model.ApplyRequest) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		_, err := saveJoinApplyTx(tx, apply)
+		return err
+	})
+	if err != nil {
+		return WrapDBError(err)
+	}
+	return nil
+}
+
+// saveJoinApplyTx 在事务内保存入群申请，返回是否新增了申请记录（false 表示复用了待处理申请）
+func saveJoinApplyTx(tx *gorm.DB, apply *model.ApplyRequest) (bool, error) {
+	var pending model.ApplyRequest
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("apply_type = ? AND applicant_uuid = ? AND target_uuid = ? AND status = ?",
+			apply.ApplyType, apply.ApplicantUuid, apply.TargetUuid, 0).
+		First(&pending).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return true, tx.Create(apply).Error
+	}
+	if err != nil {
+		return false, err
+	}
+
+	apply.Id = pending.Id
+	return false, tx.Model(&pending).Updates(map[string]interface{}{
+		"reason":     apply.Reason,
+		"is_read":    false,
+		"expired_at": apply.ExpiredAt,
+	}).Error
+}
+
+// GetJoinApply 根据ID查询入群申请
+func (r *groupRepositoryImpl) GetJoinApply(ctx context.Context, applyID int64) (*model.ApplyRequest, error) {
+	var apply model.ApplyRequest
+	err := r.db.WithContext(ctx).
+		Where("id = ? AND apply_type = ?", applyID, 1).
+		First(&apply).Error
+	if err != nil {
+		return nil, WrapDBError(err)
+	}
+	return &apply, nil
+}
+
+// ListJoinApplies 分页查询群的入群申请（status 为 -1 时不过滤状态）
+func (r *groupRepositoryImpl) ListJoinApplies(ctx context.Context, groupUUID string, status, page, pageSize int) ([]*model.ApplyRequest, int64, error) {
+	query := r.db.WithContext(ctx).Model(&model.ApplyRequest{}).
+		Where("apply_type = ? AND target_uuid = ?", 1, groupUUID)
+	if status >= 0 {
+		query = query.Where("status = ?", status)
+	}
+	query = query.Session(&gorm.Session{})
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, WrapDBError(err)
+	}
+	if total == 0 {
+		return []*model.ApplyRequest{}, 0, nil
+	}
+
+	var applies []*model.ApplyRequest
+	err := query.
+		Order("updated_at DESC").
+		Order("id DESC").
+		Offset((page - 1) * pageSize).
+		Limit(pageSize).
+		Find(&applies).Error
+	if err != nil {
+		return nil, 0, WrapDBError(err)
+	}
+	return applies, total, nil
+}
+
+// ApproveJoinApply 通过入群申请并写入成员
+// 同一事务内将待处理申请置为通过、记录处理人，并按 AddMembers 的规则加入成员（申请人已在群内时只更新申请状态）
+func (r *groupRepositoryImpl) ApproveJoinApply(ctx context.Context, applyID int64, handlerUUID, remark string, maxMembers int) ([]string, int, error) {
+	var added []string
+	var memberCnt int
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var apply model.ApplyRequest
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ? AND apply_type = ? AND status = ?", applyID, 1, 0).
+			First(&apply).Error
+		if err != nil {
+			return err
+		}
+
+		err = tx.Model(&apply).Updates(map[string]interface{}{
+			"status":           1,
+			"handle_user_uuid": handlerUUID,
+			"handle_remark":    remark,
+		}).Error
+		if err != nil {
+			return err
+		}
+
+		added, memberCnt, err = addMembersTx(tx, apply.TargetUuid, handlerUUID, []string{apply.ApplicantUuid}, maxMembers)
+		return err
+	})
+	if err != nil {
+		return nil, 0, WrapDBError(err)
+	}
+	return added, memberCnt, nil
+}
+
+// FinishJoinApply 将待处理的入群申请置为拒绝或过期
+func (r *groupRepositoryImpl) FinishJoinApply(ctx context.Context, applyID int64, status int8, handlerUUID, remark string) error {
+	result := r.db.WithContext(ctx).Model(&model.ApplyRequest{}).
+		Where("id = ? AND apply_type = ? AND status = ?", applyID, 1, 0).
+		Updates(map[string]interface{}{
+			"status":           status,
+			"handle_user_uuid": handlerUUID,
+			"handle_remark":    remark,
+		})
+	if result.Error != nil {
+		return WrapDBError(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return ErrRecordNotFound
+	}
+	return nil
+}
+
+// CreateAnnouncement 发布群公告
+// 同一事务内写入公告历史并将 group_info.notice 更新为最新公告内容
+func (r *groupRepositoryImpl) CreateAnnouncement(ctx context.Context, announcement *model.GroupAnnouncement) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&model.GroupInfo{}).
+			Where("uuid = ? AND status = ?", announcement.GroupUuid, 0).
+			Update("notice", announcement.Content)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return tx.Create(announcement).Error
+	})
+	if err != nil {
+		return WrapDBError(err)
+	}
+	return nil
+}
+
+// GetAnnouncement 查询群公告
+func (r *groupRepositoryImpl) GetAnnouncement(ctx context.Context, groupUUID string, announcementID int64) (*model.GroupAnnouncement, error) {
+	var announcement model.GroupAnnouncement
+	err := r.db.WithContext(ctx).
+		Where("id = ? AND group_uuid = ?", announcementID, groupUUID).
+		First(&announcement).Error
+	if err != nil {
+		return nil, WrapDBError(err)
+	}
+	return &announcement, nil
+}
+
+// ListAnnouncements 分页查询群公告历史（置顶在前，其余按发布时间倒序）
+func (r *groupRepositoryImpl) ListAnnouncements(ctx context.Context, groupUUID string, page, pageSize int) ([]*model.GroupAnnouncement, int64, error) {
+	query := r.db.WithContext(ctx).Model(&model.GroupAnnouncement{}).
+		Where("group_uuid = ?", groupUUID).
+		Session(&gorm.Session{})
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, WrapDBError(err)
+	}
+	if total == 0 {
+		return []*model.GroupAnnouncement{}, 0, nil
+	}
+
+	var announcements []*model.GroupAnnouncement
+	err := query.
+		Order("pinned DESC").
+		Order("id DESC").
+		Offset((page - 1) * pageSize).
+		Limit(pageSize).
+		Find(&announcements).Error
+	if err != nil {
+		return nil, 0, WrapDBError(err)
+	}
+	return announcements, total, nil
+}
+
+// SetAnnouncementPinned 置顶/取消置顶群公告
+func (r *groupRepositoryImpl) SetAnnouncementPinned(ctx context.Context, groupUUID string, announcementID int64, pinned bool) error {
+	result := r.db.WithContext(ctx).Model(&model.GroupAnnouncement{}).
+		Where("id = ? AND group_uuid = ?", announcementID, groupUUID).
+		Update("pinned", pinned)
+	if result.Error != nil {
+		return WrapDBError(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return ErrRecordNotFound
+	}
+	return nil
+}
+
+// AckAnnouncement 确认群公告
+// 确认记录按 (announcement_id, user_uuid) 唯一，重复确认不写入也不累加确认人数
+func (r *groupRepositoryImpl) AckAnnouncement(ctx context.Context, announcementID int64, userUUID string) (bool, error) {
+	acked := false
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Clauses(clause.OnConflict{DoNothing: true}).
+			Create(&model.GroupAnnouncementAck{
+				AnnouncementId: announcementID,
+				UserUuid:       userUUID,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+		acked = true
+		return tx.Model(&model.GroupAnnouncement{}).
+			Where("id = ?", announcementID).
+			Update("ack_count", gorm.Expr("ack_count + 1")).Error
+	})
+	if err != nil {
+		return false, WrapDBError(err)
+	}
+	return acked, nil
+}
+
+// ListAckedAnnouncementIDs 查询用户已确认的公告ID集合
+func (r *groupRepositoryImpl) ListAckedAnnouncementIDs(ctx context.Context, userUUID string, announcementIDs []int64) (map[int64]bool, error) {
+	result := make(map[int64]bool, len(announcementIDs))
+	if len(announcementIDs) == 0 {
+		return result, nil
+	}
+
+	var ids []int64
+	err := r.db.WithContext(ctx).Model(&model.GroupAnnouncementAck{}).
+		Where("user_uuid = ? AND announcement_id IN ?", userUUID, announcementIDs).
+		Pluck("announcement_id", &ids).Error
+	if err != nil {
+		return nil, WrapDBError(err)
+	}
+	for _, id := range ids {
+		result[id] = true
+	}
+	return result, nil
+}
+
+// CreateInvite 创建群邀请
+func (r *groupRepositoryImpl) CreateInvite(ctx context.Context, invite *model.GroupInvite) error {
+	if err := r.db.WithContext(ctx).Create(invite).Error; err != nil {
+		return WrapDBError(err)
+	}
+	return nil
+}
+
+// ListInvites 查询群内仍有效（未撤销、未过期）的邀请，按创建时间倒序
+func (r *groupRepositoryImpl) ListInvites(ctx context.Context, groupUUID string) ([]*model.GroupInvite, error) {
+	var invites []*model.GroupInvite
+	err := r.db.WithContext(ctx).
+		Where("group_uuid = ? AND revoked = ? AND expire_at > ?", groupUUID, false, time.Now()).
+		Order("id DESC").
+		Find(&invites).Error
+	if err != nil {
+		return nil, WrapDBError(err)
+	}
+	return invites, nil
+}
+
+// RevokeInvite 撤销群邀请
+func (r *groupRepositoryImpl) RevokeInvite(ctx context.Context, groupUUID string, inviteID int64) error {
+	result := r.db.WithContext(ctx).Model(&model.GroupInvite{}).
+		Where("id = ? AND group_uuid = ? AND revoked = ?", inviteID, groupUUID, false).
+		Update("revoked", true)
+	if result.Error != nil {
+		return WrapDBError(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return ErrRecordNotFound
+	}
+	return nil
+}
+
+// JoinByInvite 通过邀请直接入群
+// 同一事务内锁定邀请记录校验可用性，按 AddMembers 的规则加入成员（邀请人记为邀请创建人），实际加入时使用次数 +1
+func (r *groupRepositoryImpl) JoinByInvite(ctx context.Context, inviteID int64, groupUUID, userUUID string, maxMembers int) ([]string, int, error) {
+	var (
+		added     []string
+		memberCnt int
+	)
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		invite, err := lockUsableInviteTx(tx, inviteID, groupUUID)
+		if err != nil {
+			return err
+		}
+		added, memberCnt, err = addMembersTx(tx, groupUUID, invite.CreatorUuid, []string{userUUID}, maxMembers)
+		if err != nil || len(added) == 0 {
+			return err
+		}
+		return tx.Model(invite).Update("used_count", gorm.Expr("used_count + 1")).Error
+	})
+	if err != nil {
+		return nil, 0, WrapDBError(err)
+	}
+	return added, memberCnt, nil
+}
+
+// ApplyByInvite 通过邀请提交入群申请（需审核的群）
+// 同一事务内锁定邀请记录校验可用性并按 SaveJoinApply 的规则保存申请，仅新增申请记录时使用次数 +1，
+// 同一申请人重复提交只刷新待处理申请，不重复消耗邀请次数
+func (r *groupRepositoryImpl) ApplyByInvite(ctx context.Context, inviteID int64, apply *model.ApplyRequest) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		invite, err := lockUsableInviteTx(tx, inviteID, apply.TargetUuid)
+		if err != nil {
+			return err
+		}
+		created, err := saveJoinApplyTx(tx, apply)
+		if err != nil || !created {
+			return err
+		}
+		return tx.Model(invite).Update("used_count", gorm.Expr("used_count + 1")).Error
+	})
+	if err != nil {
+		return WrapDBError(err)
+	}
+	return nil
+}
+
+// lockUsableInviteTx 在事务内锁定邀请记录并校验可用性
+// 不存在或已撤销返回 gorm.ErrRecordNotFound，已过期返回 ErrInviteExpired，次数用尽返回 ErrInviteExhausted
+func lockUsableInviteTx(tx *gorm.DB, inviteID int64, groupUUID string) (*model.GroupInvite, error) {
+	var invite model.GroupInvite
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id = ? AND group_uuid = ? AND revoked = ?", inviteID, groupUUID, false).
+		First(&invite).Error
+	if err != nil {
+		return nil, err
+	}
+	if !time.Now().Before(invite.ExpireAt) {
+		return nil, ErrInviteExpired
+	}
+	if invite.MaxUses > 0 && invite.UsedCount >= invite.MaxUses {
+		return nil, ErrInviteExhausted
+	}
+	return &invite, nil
+}
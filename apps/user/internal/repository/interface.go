@@ -233,3 +233,99 @@ type IDeviceRepository interface {
 	// DeleteTokens 删除设备的所有 Token（用于踢出设备）
 	DeleteTokens(ctx context.Context, userUUID, deviceID string) error
 }
+
+// ==================== 群组 Repository ====================
+
+// IGroupRepository 群组数据访问接口
+// 群人数 member_cnt 只在成员变更的同一事务中增减，保证与正常成员数一致
+type IGroupRepository interface {
+	// CreateWithMembers 在同一事务中创建群组与初始成员（含群主），group.MemberCnt 取 len(members)
+	CreateWithMembers(ctx context.Context, group *model.GroupInfo, members []*model.GroupMember) error
+
+	// GetByUUID 根据群uuid查询群资料，不存在返回 ErrRecordNotFound
+	GetByUUID(ctx context.Context, groupUUID string) (*model.GroupInfo, error)
+
+	// GetMember 查询群成员记录（含已退出/被踢出），不存在返回 ErrRecordNotFound
+	GetMember(ctx context.Context, groupUUID, userUUID string) (*model.GroupMember, error)
+
+	// ListMembers 分页查询正常群成员（群主、管理员在前，其余按入群时间升序）
+	ListMembers(ctx context.Context, groupUUID string, page, pageSize int) ([]*model.GroupMember, int64, error)
+
+	// AddMembers 锁定群记录后批量加入成员，已是正常成员的跳过；曾退出/被踢出的成员重新入群
+	// 超过 maxMembers 返回 ErrGroupFull，群不存在或非正常状态返回 ErrRecordNotFound
+	// 返回本次新加入的成员与加入后的群人数
+	AddMembers(ctx context.Context, groupUUID, inviterUUID string, userUUIDs []string, maxMembers int) ([]string, int, error)
+
+	// RemoveMember 将正常成员标记为 status（1退出 2踢出）并扣减群人数，成员不存在返回 ErrRecordNotFound，群已解散返回 ErrGroupDismissed
+	RemoveMember(ctx context.Context, groupUUID, userUUID string, status int8) error
+
+	// UpdateRole 将角色为 fromRole 的正常成员改为 toRole
+	// 设为管理员时锁定群记录并校验管理员人数不超过 maxAdmins（超过返回 ErrAdminLimitExceeded）；成员不存在或角色不符返回 ErrRecordNotFound
+	UpdateRole(ctx context.Context, groupUUID, userUUID string, fromRole, toRole int8, maxAdmins int) error
+
+	// TransferOwnership 同一事务内将群主由 oldOwnerUUID 转给 newOwnerUUID（原群主降为普通成员）
+	// 群主已变更或新群主不是正常成员返回 ErrRecordNotFound
+	TransferOwnership(ctx context.Context, groupUUID, oldOwnerUUID, newOwnerUUID string) error
+
+	// Dismiss 解散群组（status=2），群不存在或已解散返回 ErrRecordNotFound
+	Dismiss(ctx context.Context, groupUUID string) error
+
+	// SetMuteUntil 设置正常成员的禁言到期时间（nil 表示解除禁言），成员不存在返回 ErrRecordNotFound
+	SetMuteUntil(ctx context.Context, groupUUID, userUUID string, muteUntil *time.Time) error
+
+	// SetMuteAll 开启/关闭全员禁言，群不存在或非正常状态返回 ErrRecordNotFound
+	SetMuteAll(ctx context.Context, groupUUID string, muteAll bool) error
+
+	// ListMemberUUIDs 查询全部正常成员 uuid
+	ListMemberUUIDs(ctx context.Context, groupUUID string) ([]string, error)
+
+	// SaveJoinApply 保存入群申请（已有待处理申请时刷新该记录并回填 ID）
+	SaveJoinApply(ctx context.Context, apply *model.ApplyRequest) error
+
+	// GetJoinApply 根据ID查询入群申请
+	GetJoinApply(ctx context.Context, applyID int64) (*model.ApplyRequest, error)
+
+	// ListJoinApplies 分页查询群的入群申请（按申请时间倒序，status 为 -1 时不过滤状态）
+	ListJoinApplies(ctx context.Context, groupUUID string, status, page, pageSize int) ([]*model.ApplyRequest, int64, error)
+
+	// ApproveJoinApply 通过待处理的入群申请并写入成员，返回本次新加入的成员（申请人已在群内时为空）与加入后的群人数
+	// 申请不存在或已处理返回 ErrRecordNotFound（群非正常状态同样返回 ErrRecordNotFound），超过人数上限返回 ErrGroupFull
+	ApproveJoinApply(ctx context.Context, applyID int64, handlerUUID, remark string, maxMembers int) ([]string, int, error)
+
+	// FinishJoinApply 将待处理的入群申请置为拒绝或过期（申请不存在或已处理返回 ErrRecordNotFound）
+	FinishJoinApply(ctx context.Context, applyID int64, status int8, handlerUUID, remark string) error
+
+	// CreateAnnouncement 发布群公告并同步更新 group_info.notice，群不存在或非正常状态返回 ErrRecordNotFound
+	CreateAnnouncement(ctx context.Context, announcement *model.GroupAnnouncement) error
+
+	// GetAnnouncement 查询群公告，不存在返回 ErrRecordNotFound
+	GetAnnouncement(ctx context.Context, groupUUID string, announcementID int64) (*model.GroupAnnouncement, error)
+
+	// ListAnnouncements 分页查询群公告历史（置顶在前，其余按发布时间倒序）
+	ListAnnouncements(ctx context.Context, groupUUID string, page, pageSize int) ([]*model.GroupAnnouncement, int64, error)
+
+	// SetAnnouncementPinned 置顶/取消置顶群公告，不存在返回 ErrRecordNotFound
+	SetAnnouncementPinned(ctx context.Context, groupUUID string, announcementID int64, pinned bool) error
+
+	// AckAnnouncement 确认群公告，返回本次是否新增确认（重复确认返回 false）
+	AckAnnouncement(ctx context.Context, announcementID int64, userUUID string) (bool, error)
+
+	// ListAckedAnnouncementIDs 查询用户在给定公告中已确认的公告ID
+	ListAckedAnnouncementIDs(ctx context.Context, userUUID string, announcementIDs []int64) (map[int64]bool, error)
+
+	// CreateInvite 创建群邀请（回填 ID 与创建时间）
+	CreateInvite(ctx context.Context, invite *model.GroupInvite) error
+
+	// ListInvites 查询群内未撤销且未过期的邀请（按创建时间倒序）
+	ListInvites(ctx context.Context, groupUUID string) ([]*model.GroupInvite, error)
+
+	// RevokeInvite 撤销群邀请，不存在或已撤销返回 ErrRecordNotFound
+	RevokeInvite(ctx context.Context, groupUUID string, inviteID int64) error
+
+	// JoinByInvite 通过邀请直接入群，返回本次新加入的成员与加入后的群人数（已是成员时不消耗使用次数）
+	// 邀请不存在或已撤销返回 ErrRecordNotFound，已过期返回 ErrInviteExpired，次数用尽返回 ErrInviteExhausted，群满返回 ErrGroupFull
+	JoinByInvite(ctx context.Context, inviteID int64, groupUUID, userUUID string, maxMembers int) ([]string, int, error)
+
+	// ApplyByInvite 校验邀请可用并保存入群申请（apply.TargetUuid 为群 UUID），仅新增申请时消耗一次使用次数，错误同 JoinByInvite
+	ApplyByInvite(ctx context.Context, inviteID int64, apply *model.ApplyRequest) error
+}
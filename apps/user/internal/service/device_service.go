@@ -1,9 +1,14 @@
 package service
 
 import (
+	"ChatServer/apps/connect/nodeclient"
 	"ChatServer/apps/user/internal/repository"
 	pb "ChatServer/apps/user/pb"
+	"ChatServer/consts"
+	"ChatServer/pkg/logger"
+	"ChatServer/pkg/util"
 	"context"
+	"strconv"
 
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -11,13 +16,16 @@ import (
 
 // deviceServiceImpl 设备会话服务实现
 type deviceServiceImpl struct {
-	deviceRepo repository.IDeviceRepository
+	deviceRepo    repository.IDeviceRepository
+	connectClient *nodeclient.Client
 }
 
 // NewDeviceService 创建设备服务实例
-func NewDeviceService(deviceRepo repository.IDeviceRepository) DeviceService {
+// connectClient 可为 nil（Redis 不可用时无法查询连接路由，踢设备只删除 Token）
+func NewDeviceService(deviceRepo repository.IDeviceRepository, connectClient *nodeclient.Client) DeviceService {
 	return &deviceServiceImpl{
-		deviceRepo: deviceRepo,
+		deviceRepo:    deviceRepo,
+		connectClient: connectClient,
 	}
 }
 
@@ -27,8 +35,58 @@ func (s *deviceServiceImpl) GetDeviceList(ctx context.Context, req *pb.GetDevice
 }
 
 // KickDevice 踢出设备
+// 业务流程：
+//  1. 从 context 中获取 user_uuid 与当前设备ID
+//  2. 不允许踢出当前设备
+//  3. 删除目标设备的 Token（阻止其续期与重连）
+//  4. 按连接路由通知目标设备所在 Connect 节点下发踢下线通知并断开长连接
+//
+// 错误码映射：
+//   - codes.FailedPrecondition: 不能踢出当前设备
+//   - codes.Internal: 系统内部错误
 func (s *deviceServiceImpl) KickDevice(ctx context.Context, req *pb.KickDeviceRequest) error {
-	return status.Error(codes.Unimplemented, "踢出设备功能暂未实现")
+	// 1. 获取当前用户与设备
+	userUUID := util.GetUserUUIDFromContext(ctx)
+	if userUUID == "" {
+		logger.Error(ctx, "从 context 中获取 user_uuid 失败")
+		return status.Error(codes.Internal, strconv.Itoa(consts.CodeInternalError))
+	}
+
+	// 2. 不能踢出当前设备
+	if req.DeviceId == util.GetDeviceIDFromContext(ctx) {
+		return status.Error(codes.FailedPrecondition, strconv.Itoa(consts.CodeCannotKickCurrent))
+	}
+
+	// 3. 删除 Token，长连接断开后无法再用旧 Token 重连
+	if err := s.deviceRepo.DeleteTokens(ctx, userUUID, req.DeviceId); err != nil {
+		logger.Error(ctx, "删除 Token 失败",
+			logger.String("user_uuid", userUUID),
+			logger.String("device_id", req.DeviceId),
+			logger.ErrorField("error", err),
+		)
+		return status.Error(codes.Internal, strconv.Itoa(consts.CodeInternalError))
+	}
+
+	// 4. 断开长连接（失败不影响结果：Token 已失效，连接断开后无法恢复）
+	if s.connectClient != nil {
+		kicked, err := s.connectClient.Kick(ctx, userUUID, req.DeviceId,
+			int32(consts.CodeDeviceKicked), consts.GetMessage(consts.CodeDeviceKicked))
+		if err != nil {
+			logger.Warn(ctx, "通知 Connect 节点断开长连接失败",
+				logger.String("user_uuid", userUUID),
+				logger.String("device_id", req.DeviceId),
+				logger.ErrorField("error", err),
+			)
+		} else {
+			logger.Info(ctx, "设备已被踢出",
+				logger.String("user_uuid", userUUID),
+				logger.String("device_id", req.DeviceId),
+				logger.Bool("online", kicked),
+			)
+		}
+	}
+
+	return nil
 }
 
 // GetOnlineStatus 获取用户在线状态
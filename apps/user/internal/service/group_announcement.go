@@ -0,0 +1,275 @@
+package service
+
+import (
+	msgpb "ChatServer/apps/msg/pb"
+	"ChatServer/apps/user/internal/converter"
+	"ChatServer/apps/user/internal/repository"
+	pb "ChatServer/apps/user/pb"
+	"ChatServer/consts"
+	"ChatServer/model"
+	"ChatServer/pkg/logger"
+	"ChatServer/pkg/msgcontent"
+	"ChatServer/pkg/util"
+	"context"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// maxGroupNoticeLen 群公告最大字符数（与 group_info.notice、group_announcement.content varchar(500) 一致）
+const maxGroupNoticeLen = 500
+
+// PostGroupAnnouncement 发布群公告
+// 业务流程：
+//  1. 校验公告内容长度（最长 500 字符），校验操作人为群主或管理员
+//  2. 同一事务内写入公告历史并将 group_info.notice 更新为最新公告
+//  3. 通过消息服务向群会话写入公告系统消息（尽力而为，失败只记录日志）
+//
+// 错误码映射：
+//   - codes.InvalidArgument: 参数错误、群公告过长
+//   - codes.NotFound: 群组不存在
+//   - codes.PermissionDenied: 不是群成员、没有权限
+//   - codes.FailedPrecondition: 群组已解散
+//   - codes.Internal: 系统内部错误
+func (s *groupServiceImpl) PostGroupAnnouncement(ctx context.Context, req *pb.PostGroupAnnouncementRequest) (*pb.PostGroupAnnouncementResponse, error) {
+	// 1. 校验参数与权限
+	userUUID := util.GetUserUUIDFromContext(ctx)
+	if userUUID == "" {
+		logger.Error(ctx, "从 context 中获取 user_uuid 失败")
+		return nil, status.Error(codes.Internal, strconv.Itoa(consts.CodeInternalError))
+	}
+	content := strings.TrimSpace(req.Content)
+	if content == "" {
+		return nil, status.Error(codes.InvalidArgument, strconv.Itoa(consts.CodeParamError))
+	}
+	if utf8.RuneCountInString(content) > maxGroupNoticeLen {
+		return nil, status.Error(codes.InvalidArgument, strconv.Itoa(consts.CodeGroupNoticeTooLong))
+	}
+	if _, err := s.getActiveGroup(ctx, req.GroupUuid); err != nil {
+		return nil, err
+	}
+	if _, err := s.authorize(ctx, req.GroupUuid, userUUID, groupActionAnnounce, nil); err != nil {
+		return nil, err
+	}
+
+	// 2. 写入公告
+	announcement := &model.GroupAnnouncement{
+		GroupUuid:     req.GroupUuid,
+		PublisherUuid: userUUID,
+		Content:       content,
+		Pinned:        req.Pinned,
+		RequireAck:    req.RequireAck,
+	}
+	if err := s.groupRepo.CreateAnnouncement(ctx, announcement); err != nil {
+		if errors.Is(err, repository.ErrRecordNotFound) {
+			return nil, status.Error(codes.NotFound, strconv.Itoa(consts.CodeGroupNotFound))
+		}
+		logger.Error(ctx, "发布群公告失败",
+			logger.String("group_uuid", req.GroupUuid),
+			logger.ErrorField("error", err),
+		)
+		return nil, status.Error(codes.Internal, strconv.Itoa(consts.CodeInternalError))
+	}
+
+	logger.Info(ctx, "发布群公告成功",
+		logger.String("group_uuid", req.GroupUuid),
+		logger.String("publisher_uuid", userUUID),
+		logger.Int64("announcement_id", announcement.Id),
+	)
+
+	// 3. 群内下发公告系统消息
+	s.sendAnnouncementMessage(ctx, announcement)
+
+	return &pb.PostGroupAnnouncementResponse{
+		Announcement: converter.ModelToProtoGroupAnnouncement(announcement, false),
+	}, nil
+}
+
+// GetGroupAnnouncements 分页获取群公告历史（仅群成员可查看）
+//
+// 错误码映射：
+//   - codes.InvalidArgument: 参数错误
+//   - codes.NotFound: 群组不存在
+//   - codes.PermissionDenied: 不是群成员
+//   - codes.Internal: 系统内部错误
+func (s *groupServiceImpl) GetGroupAnnouncements(ctx context.Context, req *pb.GetGroupAnnouncementsRequest) (*pb.GetGroupAnnouncementsResponse, error) {
+	userUUID := util.GetUserUUIDFromContext(ctx)
+	if userUUID == "" {
+		logger.Error(ctx, "从 context 中获取 user_uuid 失败")
+		return nil, status.Error(codes.Internal, strconv.Itoa(consts.CodeInternalError))
+	}
+	if req.Page < 1 || req.PageSize < 1 || req.PageSize > 100 {
+		return nil, status.Error(codes.InvalidArgument, strconv.Itoa(consts.CodeParamError))
+	}
+	if _, err := s.getGroup(ctx, req.GroupUuid); err != nil {
+		return nil, err
+	}
+	if _, err := s.authorize(ctx, req.GroupUuid, userUUID, groupActionViewAnnouncement, nil); err != nil {
+		return nil, err
+	}
+
+	announcements, total, err := s.groupRepo.ListAnnouncements(ctx, req.GroupUuid, int(req.Page), int(req.PageSize))
+	if err != nil {
+		logger.Error(ctx, "查询群公告列表失败",
+			logger.String("group_uuid", req.GroupUuid),
+			logger.ErrorField("error", err),
+		)
+		return nil, status.Error(codes.Internal, strconv.Itoa(consts.CodeInternalError))
+	}
+
+	// 查询当前用户的确认状态（失败时按未确认展示）
+	ids := make([]int64, 0, len(announcements))
+	for _, announcement := range announcements {
+		if announcement.RequireAck {
+			ids = append(ids, announcement.Id)
+		}
+	}
+	acked, err := s.groupRepo.ListAckedAnnouncementIDs(ctx, userUUID, ids)
+	if err != nil {
+		logger.Warn(ctx, "查询群公告确认状态失败", logger.ErrorField("error", err))
+		acked = map[int64]bool{}
+	}
+
+	items := make([]*pb.GroupAnnouncement, 0, len(announcements))
+	for _, announcement := range announcements {
+		items = append(items, converter.ModelToProtoGroupAnnouncement(announcement, acked[announcement.Id]))
+	}
+	return &pb.GetGroupAnnouncementsResponse{
+		Items: items,
+		Pagination: &pb.PaginationInfo{
+			Page:       req.Page,
+			PageSize:   req.PageSize,
+			Total:      total,
+			TotalPages: int32((total + int64(req.PageSize) - 1) / int64(req.PageSize)),
+		},
+	}, nil
+}
+
+// PinGroupAnnouncement 置顶/取消置顶群公告（群主、管理员）
+//
+// 错误码映射：
+//   - codes.NotFound: 群组不存在、群公告不存在
+//   - codes.PermissionDenied: 不是群成员、没有权限
+//   - codes.FailedPrecondition: 群组已解散
+//   - codes.Internal: 系统内部错误
+func (s *groupServiceImpl) PinGroupAnnouncement(ctx context.Context, req *pb.PinGroupAnnouncementRequest) error {
+	userUUID := util.GetUserUUIDFromContext(ctx)
+	if userUUID == "" {
+		logger.Error(ctx, "从 context 中获取 user_uuid 失败")
+		return status.Error(codes.Internal, strconv.Itoa(consts.CodeInternalError))
+	}
+	if _, err := s.getActiveGroup(ctx, req.GroupUuid); err != nil {
+		return err
+	}
+	if _, err := s.authorize(ctx, req.GroupUuid, userUUID, groupActionAnnounce, nil); err != nil {
+		return err
+	}
+
+	if err := s.groupRepo.SetAnnouncementPinned(ctx, req.GroupUuid, req.AnnouncementId, req.Pinned); err != nil {
+		if errors.Is(err, repository.ErrRecordNotFound) {
+			return status.Error(codes.NotFound, strconv.Itoa(consts.CodeGroupAnnouncementNotFound))
+		}
+		logger.Error(ctx, "置顶群公告失败",
+			logger.String("group_uuid", req.GroupUuid),
+			logger.Int64("announcement_id", req.AnnouncementId),
+			logger.ErrorField("error", err),
+		)
+		return status.Error(codes.Internal, strconv.Itoa(consts.CodeInternalError))
+	}
+	return nil
+}
+
+// AckGroupAnnouncement 确认已读群公告
+// 仅需确认的公告可确认；重复确认幂等，返回当前已确认人数
+//
+// 错误码映射：
+//   - codes.NotFound: 群组不存在、群公告不存在
+//   - codes.PermissionDenied: 不是群成员
+//   - codes.FailedPrecondition: 群组已解散、群公告无需确认
+//   - codes.Internal: 系统内部错误
+func (s *groupServiceImpl) AckGroupAnnouncement(ctx context.Context, req *pb.AckGroupAnnouncementRequest) (*pb.AckGroupAnnouncementResponse, error) {
+	userUUID := util.GetUserUUIDFromContext(ctx)
+	if userUUID == "" {
+		logger.Error(ctx, "从 context 中获取 user_uuid 失败")
+		return nil, status.Error(codes.Internal, strconv.Itoa(consts.CodeInternalError))
+	}
+	if _, err := s.getActiveGroup(ctx, req.GroupUuid); err != nil {
+		return nil, err
+	}
+	if _, err := s.authorize(ctx, req.GroupUuid, userUUID, groupActionViewAnnouncement, nil); err != nil {
+		return nil, err
+	}
+
+	announcement, err := s.groupRepo.GetAnnouncement(ctx, req.GroupUuid, req.AnnouncementId)
+	if err != nil {
+		if errors.Is(err, repository.ErrRecordNotFound) {
+			return nil, status.Error(codes.NotFound, strconv.Itoa(consts.CodeGroupAnnouncementNotFound))
+		}
+		logger.Error(ctx, "查询群公告失败",
+			logger.Int64("announcement_id", req.AnnouncementId),
+			logger.ErrorField("error", err),
+		)
+		return nil, status.Error(codes.Internal, strconv.Itoa(consts.CodeInternalError))
+	}
+	if !announcement.RequireAck {
+		return nil, status.Error(codes.FailedPrecondition, strconv.Itoa(consts.CodeGroupAnnouncementNoAck))
+	}
+
+	added, err := s.groupRepo.AckAnnouncement(ctx, announcement.Id, userUUID)
+	if err != nil {
+		logger.Error(ctx, "确认群公告失败",
+			logger.Int64("announcement_id", announcement.Id),
+			logger.ErrorField("error", err),
+		)
+		return nil, status.Error(codes.Internal, strconv.Itoa(consts.CodeInternalError))
+	}
+	ackCount := announcement.AckCount
+	if added {
+		ackCount++
+	}
+	return &pb.AckGroupAnnouncementResponse{AckCount: int32(ackCount)}, nil
+}
+
+// sendAnnouncementMessage 异步通过消息服务向群会话写入公告系统消息
+// 以公告ID作为幂等键；msgClient 为 nil 时跳过（客户端通过公告列表获取）
+func (s *groupServiceImpl) sendAnnouncementMessage(ctx context.Context, announcement *model.GroupAnnouncement) {
+	if s.msgClient == nil {
+		return
+	}
+	content, err := json.Marshal(msgcontent.GroupAnnouncementContent{
+		AnnouncementId: announcement.Id,
+		Content:        announcement.Content,
+		PublisherUuid:  announcement.PublisherUuid,
+		Pinned:         announcement.Pinned,
+		RequireAck:     announcement.RequireAck,
+	})
+	if err != nil {
+		logger.Error(ctx, "编码群公告通知失败", logger.ErrorField("error", err))
+		return
+	}
+	req := &msgpb.SendSystemMessageRequest{
+		ConvType:    consts.ConvTypeGroup,
+		TargetUuid:  announcement.GroupUuid,
+		FromUuid:    announcement.PublisherUuid,
+		ClientMsgId: "announcement_" + strconv.FormatInt(announcement.Id, 10),
+		MsgType:     consts.MsgTypeGroupAnnouncement,
+		Content:     string(content),
+	}
+
+	sendCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), groupPushTimeout)
+	go func() {
+		defer cancel()
+		if _, err := s.msgClient.SendSystemMessage(sendCtx, req); err != nil {
+			logger.Warn(sendCtx, "群公告系统消息写入失败",
+				logger.String("group_uuid", announcement.GroupUuid),
+				logger.Int64("announcement_id", announcement.Id),
+				logger.ErrorField("error", err),
+			)
+		}
+	}()
+}
@@ -0,0 +1,277 @@
+package service
+
+import (
+	"ChatServer/apps/user/internal/converter"
+	"ChatServer/apps/user/internal/repository"
+	pb "ChatServer/apps/user/pb"
+	"ChatServer/consts"
+	"ChatServer/model"
+	"ChatServer/pkg/logger"
+	"ChatServer/pkg/util"
+	"context"
+	"errors"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// groupInviteQRCodePrefix 群邀请二维码内容前缀，客户端扫码后取 token 调用 JoinByInviteToken
+const groupInviteQRCodePrefix = "chatserver://group/join?token="
+
+// CreateGroupInvite 创建群邀请（群主、管理员）
+// 业务流程：
+//  1. 校验有效期（0 使用默认有效期，不超过最长有效期）与最大使用次数
+//  2. 写入邀请记录（使用次数、撤销状态以记录为准）
+//  3. 签发携带邀请ID与过期时间的邀请令牌，并生成二维码内容
+//
+// 错误码映射：
+//   - codes.InvalidArgument: 参数错误
+//   - codes.NotFound: 群组不存在
+//   - codes.PermissionDenied: 不是群成员、没有权限
+//   - codes.FailedPrecondition: 群组已解散
+//   - codes.Internal: 系统内部错误
+func (s *groupServiceImpl) CreateGroupInvite(ctx context.Context, req *pb.CreateGroupInviteRequest) (*pb.CreateGroupInviteResponse, error) {
+	// 1. 校验参数与权限
+	userUUID := util.GetUserUUIDFromContext(ctx)
+	if userUUID == "" {
+		logger.Error(ctx, "从 context 中获取 user_uuid 失败")
+		return nil, status.Error(codes.Internal, strconv.Itoa(consts.CodeInternalError))
+	}
+	ttl := s.cfg.InviteTTL
+	if req.ExpireSeconds > 0 {
+		ttl = time.Duration(req.ExpireSeconds) * time.Second
+	}
+	if req.ExpireSeconds < 0 || ttl > s.cfg.MaxInviteTTL || req.MaxUses < 0 {
+		return nil, status.Error(codes.InvalidArgument, strconv.Itoa(consts.CodeParamError))
+	}
+	if _, err := s.getActiveGroup(ctx, req.GroupUuid); err != nil {
+		return nil, err
+	}
+	if _, err := s.authorize(ctx, req.GroupUuid, userUUID, groupActionManageInvite, nil); err != nil {
+		return nil, err
+	}
+
+	// 2. 写入邀请记录
+	invite := &model.GroupInvite{
+		GroupUuid:   req.GroupUuid,
+		CreatorUuid: userUUID,
+		MaxUses:     int(req.MaxUses),
+		ExpireAt:    time.Now().Add(ttl),
+	}
+	if err := s.groupRepo.CreateInvite(ctx, invite); err != nil {
+		logger.Error(ctx, "创建群邀请失败",
+			logger.String("group_uuid", req.GroupUuid),
+			logger.ErrorField("error", err),
+		)
+		return nil, status.Error(codes.Internal, strconv.Itoa(consts.CodeInternalError))
+	}
+
+	// 3. 签发邀请令牌
+	item, err := s.buildGroupInvite(ctx, invite)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Info(ctx, "创建群邀请成功",
+		logger.String("group_uuid", req.GroupUuid),
+		logger.String("creator_uuid", userUUID),
+		logger.Int64("invite_id", invite.Id),
+	)
+	return &pb.CreateGroupInviteResponse{Invite: item}, nil
+}
+
+// GetGroupInviteList 获取群内未撤销且未过期的邀请（群主、管理员）
+//
+// 错误码映射：
+//   - codes.NotFound: 群组不存在
+//   - codes.PermissionDenied: 不是群成员、没有权限
+//   - codes.Internal: 系统内部错误
+func (s *groupServiceImpl) GetGroupInviteList(ctx context.Context, req *pb.GetGroupInviteListRequest) (*pb.GetGroupInviteListResponse, error) {
+	userUUID := util.GetUserUUIDFromContext(ctx)
+	if userUUID == "" {
+		logger.Error(ctx, "从 context 中获取 user_uuid 失败")
+		return nil, status.Error(codes.Internal, strconv.Itoa(consts.CodeInternalError))
+	}
+	if _, err := s.getGroup(ctx, req.GroupUuid); err != nil {
+		return nil, err
+	}
+	if _, err := s.authorize(ctx, req.GroupUuid, userUUID, groupActionManageInvite, nil); err != nil {
+		return nil, err
+	}
+
+	invites, err := s.groupRepo.ListInvites(ctx, req.GroupUuid)
+	if err != nil {
+		logger.Error(ctx, "查询群邀请列表失败",
+			logger.String("group_uuid", req.GroupUuid),
+			logger.ErrorField("error", err),
+		)
+		return nil, status.Error(codes.Internal, strconv.Itoa(consts.CodeInternalError))
+	}
+
+	items := make([]*pb.GroupInvite, 0, len(invites))
+	for _, invite := range invites {
+		item, err := s.buildGroupInvite(ctx, invite)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	return &pb.GetGroupInviteListResponse{Items: items}, nil
+}
+
+// RevokeGroupInvite 撤销群邀请（群主、管理员），撤销后已分发的令牌立即失效
+//
+// 错误码映射：
+//   - codes.NotFound: 群组不存在、群邀请无效
+//   - codes.PermissionDenied: 不是群成员、没有权限
+//   - codes.FailedPrecondition: 群组已解散
+//   - codes.Internal: 系统内部错误
+func (s *groupServiceImpl) RevokeGroupInvite(ctx context.Context, req *pb.RevokeGroupInviteRequest) error {
+	userUUID := util.GetUserUUIDFromContext(ctx)
+	if userUUID == "" {
+		logger.Error(ctx, "从 context 中获取 user_uuid 失败")
+		return status.Error(codes.Internal, strconv.Itoa(consts.CodeInternalError))
+	}
+	if _, err := s.getActiveGroup(ctx, req.GroupUuid); err != nil {
+		return err
+	}
+	if _, err := s.authorize(ctx, req.GroupUuid, userUUID, groupActionManageInvite, nil); err != nil {
+		return err
+	}
+
+	if err := s.groupRepo.RevokeInvite(ctx, req.GroupUuid, req.InviteId); err != nil {
+		if errors.Is(err, repository.ErrRecordNotFound) {
+			return status.Error(codes.NotFound, strconv.Itoa(consts.CodeGroupInviteInvalid))
+		}
+		logger.Error(ctx, "撤销群邀请失败",
+			logger.String("group_uuid", req.GroupUuid),
+			logger.Int64("invite_id", req.InviteId),
+			logger.ErrorField("error", err),
+		)
+		return status.Error(codes.Internal, strconv.Itoa(consts.CodeInternalError))
+	}
+
+	logger.Info(ctx, "撤销群邀请成功",
+		logger.String("group_uuid", req.GroupUuid),
+		logger.String("operator_uuid", userUUID),
+		logger.Int64("invite_id", req.InviteId),
+	)
+	return nil
+}
+
+// JoinByInviteToken 通过邀请令牌入群
+// 业务流程：
+//  1. 校验令牌签名与过期时间，校验群状态正常、当前用户不是群成员
+//  2. 直接加入的群：同一事务内校验邀请可用（未撤销、未过期、次数未用尽）并入群，受群人数上限限制
+//  3. 需审核的群：同一事务内校验邀请可用并提交入群申请，由群主/管理员审核；
+//     仅新增申请时消耗一次邀请使用次数，重复提交只刷新待处理申请
+//
+// 错误码映射：
+//   - codes.InvalidArgument: 群邀请无效
+//   - codes.NotFound: 群组不存在、群邀请无效
+//   - codes.FailedPrecondition: 群邀请已过期、使用次数已达上限、已经是群成员、群成员已满、群组已解散
+//   - codes.Internal: 系统内部错误
+func (s *groupServiceImpl) JoinByInviteToken(ctx context.Context, req *pb.JoinByInviteTokenRequest) (*pb.JoinByInviteTokenResponse, error) {
+	// 1. 校验令牌
+	userUUID := util.GetUserUUIDFromContext(ctx)
+	if userUUID == "" {
+		logger.Error(ctx, "从 context 中获取 user_uuid 失败")
+		return nil, status.Error(codes.Internal, strconv.Itoa(consts.CodeInternalError))
+	}
+	claims, err := util.ParseGroupInviteToken(strings.TrimSpace(req.Token))
+	if err != nil {
+		if errors.Is(err, util.ErrGroupInviteExpired) {
+			return nil, status.Error(codes.FailedPrecondition, strconv.Itoa(consts.CodeGroupInviteExpired))
+		}
+		return nil, status.Error(codes.InvalidArgument, strconv.Itoa(consts.CodeGroupInviteInvalid))
+	}
+	group, err := s.getActiveGroup(ctx, claims.GroupUUID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkNotMember(ctx, group.Uuid, userUUID); err != nil {
+		return nil, err
+	}
+
+	// 2. 直接加入
+	if group.AddMode == consts.GroupAddModeDirect {
+		added, _, err := s.groupRepo.JoinByInvite(ctx, claims.InviteID, group.Uuid, userUUID, s.cfg.MaxMembers)
+		if err != nil {
+			if inviteErr := inviteError(err); inviteErr != nil {
+				return nil, inviteErr
+			}
+			return nil, s.addMembersError(ctx, group.Uuid, err)
+		}
+		if len(added) == 0 {
+			return nil, status.Error(codes.FailedPrecondition, strconv.Itoa(consts.CodeAlreadyGroupMember))
+		}
+		s.joinGroupConversations(ctx, group.Uuid, added)
+		logger.Info(ctx, "通过群邀请入群成功",
+			logger.String("group_uuid", group.Uuid),
+			logger.String("user_uuid", userUUID),
+			logger.Int64("invite_id", claims.InviteID),
+		)
+		return &pb.JoinByInviteTokenResponse{GroupUuid: group.Uuid, Joined: true}, nil
+	}
+
+	// 3. 需审核：提交入群申请，新增申请时消耗邀请次数
+	expiredAt := time.Now().Add(s.cfg.ApplyTTL)
+	apply := &model.ApplyRequest{
+		ApplyType:     consts.ApplyTypeGroup,
+		ApplicantUuid: userUUID,
+		TargetUuid:    group.Uuid,
+		Status:        consts.ApplyStatusPending,
+		Reason:        strings.TrimSpace(req.Reason),
+		ExpiredAt:     &expiredAt,
+	}
+	if err := s.groupRepo.ApplyByInvite(ctx, claims.InviteID, apply); err != nil {
+		if inviteErr := inviteError(err); inviteErr != nil {
+			return nil, inviteErr
+		}
+		logger.Error(ctx, "通过群邀请提交入群申请失败",
+			logger.String("group_uuid", group.Uuid),
+			logger.Int64("invite_id", claims.InviteID),
+			logger.ErrorField("error", err),
+		)
+		return nil, status.Error(codes.Internal, strconv.Itoa(consts.CodeInternalError))
+	}
+
+	logger.Info(ctx, "通过群邀请提交入群申请成功",
+		logger.String("group_uuid", group.Uuid),
+		logger.String("user_uuid", userUUID),
+		logger.Int64("invite_id", claims.InviteID),
+		logger.Int64("apply_id", apply.Id),
+	)
+	return &pb.JoinByInviteTokenResponse{GroupUuid: group.Uuid, Joined: false, ApplyId: apply.Id}, nil
+}
+
+// buildGroupInvite 签发邀请令牌并转换为 Proto
+func (s *groupServiceImpl) buildGroupInvite(ctx context.Context, invite *model.GroupInvite) (*pb.GroupInvite, error) {
+	token, err := util.GenerateGroupInviteToken(invite.GroupUuid, invite.Id, invite.CreatedAt, invite.ExpireAt)
+	if err != nil {
+		logger.Error(ctx, "签发群邀请令牌失败",
+			logger.Int64("invite_id", invite.Id),
+			logger.ErrorField("error", err),
+		)
+		return nil, status.Error(codes.Internal, strconv.Itoa(consts.CodeInternalError))
+	}
+	return converter.ModelToProtoGroupInvite(invite, token, groupInviteQRCodePrefix+url.QueryEscape(token)), nil
+}
+
+// inviteError 将邀请可用性相关的仓储错误映射为业务错误，其他错误返回 nil
+func inviteError(err error) error {
+	switch {
+	case errors.Is(err, repository.ErrInviteExpired):
+		return status.Error(codes.FailedPrecondition, strconv.Itoa(consts.CodeGroupInviteExpired))
+	case errors.Is(err, repository.ErrInviteExhausted):
+		return status.Error(codes.FailedPrecondition, strconv.Itoa(consts.CodeGroupInviteExhausted))
+	case errors.Is(err, repository.ErrRecordNotFound):
+		// 邀请不存在或已撤销（群状态已在入口校验）
+		return status.Error(codes.NotFound, strconv.Itoa(consts.CodeGroupInviteInvalid))
+	}
+	return nil
+}
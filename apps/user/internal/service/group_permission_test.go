@@ -0,0 +1,100 @@
+package service
+
+import (
+	"ChatServer/consts"
+	"ChatServer/model"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/status"
+)
+
+// errCode 提取 status 错误中的业务错误码，nil 返回 0
+func errCode(t *testing.T, err error) int {
+	t.Helper()
+	if err == nil {
+		return 0
+	}
+	st, ok := status.FromError(err)
+	if !ok {
+		t.Fatalf("非 gRPC status 错误: %v", err)
+	}
+	code, convErr := strconv.Atoi(st.Message())
+	if convErr != nil {
+		t.Fatalf("错误信息不是业务错误码: %q", st.Message())
+	}
+	return code
+}
+
+// TestCheckGroupPermission 测试群权限矩阵：各角色对各操作的允许/拒绝及对应错误码
+func TestCheckGroupPermission(t *testing.T) {
+	owner := &model.GroupMember{Role: consts.GroupRoleOwner}
+	admin := &model.GroupMember{Role: consts.GroupRoleAdmin}
+	member := &model.GroupMember{Role: consts.GroupRoleMember}
+
+	tests := []struct {
+		name   string
+		actor  *model.GroupMember
+		action groupAction
+		target *model.GroupMember
+		want   int
+	}{
+		{"成员查看成员列表", member, groupActionViewMembers, nil, 0},
+		{"成员邀请", member, groupActionInvite, nil, 0},
+		{"成员处理入群申请", member, groupActionHandleApply, nil, consts.CodeNoPermission},
+		{"管理员处理入群申请", admin, groupActionHandleApply, nil, 0},
+		{"群主处理入群申请", owner, groupActionHandleApply, nil, 0},
+
+		{"成员踢成员", member, groupActionKick, member, consts.CodeNoPermission},
+		{"管理员踢成员", admin, groupActionKick, member, 0},
+		{"管理员踢管理员", admin, groupActionKick, admin, consts.CodeCannotKickAdmin},
+		{"管理员踢群主", admin, groupActionKick, owner, consts.CodeCannotKickOwner},
+		{"群主踢管理员", owner, groupActionKick, admin, 0},
+		{"群主踢成员", owner, groupActionKick, member, 0},
+
+		{"管理员设置管理员", admin, groupActionSetAdmin, member, consts.CodeNoPermission},
+		{"群主设置管理员", owner, groupActionSetAdmin, member, 0},
+		{"群主取消管理员", owner, groupActionSetAdmin, admin, 0},
+		{"群主对自己设置管理员", owner, groupActionSetAdmin, owner, consts.CodeNoPermission},
+
+		{"管理员转让群主", admin, groupActionTransferOwner, member, consts.CodeNoPermission},
+		{"群主转让给管理员", owner, groupActionTransferOwner, admin, 0},
+		{"管理员解散群", admin, groupActionDismiss, nil, consts.CodeNoPermission},
+		{"群主解散群", owner, groupActionDismiss, nil, 0},
+
+		{"成员禁言成员", member, groupActionMute, member, consts.CodeNoPermission},
+		{"管理员禁言成员", admin, groupActionMute, member, 0},
+		{"管理员禁言管理员", admin, groupActionMute, admin, consts.CodeNoPermission},
+		{"群主禁言管理员", owner, groupActionMute, admin, 0},
+		{"成员开启全员禁言", member, groupActionMuteAll, nil, consts.CodeNoPermission},
+		{"管理员开启全员禁言", admin, groupActionMuteAll, nil, 0},
+		{"成员确认公告", member, groupActionViewAnnouncement, nil, 0},
+		{"成员发布公告", member, groupActionAnnounce, nil, consts.CodeNoPermission},
+		{"管理员发布公告", admin, groupActionAnnounce, nil, 0},
+		{"成员创建群邀请", member, groupActionManageInvite, nil, consts.CodeNoPermission},
+		{"管理员创建群邀请", admin, groupActionManageInvite, nil, 0},
+
+		{"成员退群", member, groupActionQuit, nil, 0},
+		{"管理员退群", admin, groupActionQuit, nil, 0},
+		{"群主退群", owner, groupActionQuit, nil, consts.CodeCannotQuitAsOwner},
+
+		{"未知操作", owner, groupAction(0), nil, consts.CodeNoPermission},
+		{"操作人为空", nil, groupActionInvite, nil, consts.CodeNoPermission},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkGroupPermission(tt.actor, tt.action, tt.target)
+			assert.Equal(t, tt.want, errCode(t, err))
+		})
+	}
+}
+
+// TestGroupPermissionMatrix_Complete 测试所有群操作都在权限矩阵中登记
+func TestGroupPermissionMatrix_Complete(t *testing.T) {
+	for action := groupActionViewMembers; action <= groupActionQuit; action++ {
+		_, ok := groupPermissionMatrix[action]
+		assert.True(t, ok, "操作 %d 未登记到权限矩阵", action)
+	}
+}
@@ -0,0 +1,1170 @@
+package service
+
+import (
+	"ChatServer/apps/connect/nodeclient"
+	msgpb "ChatServer/apps/msg/pb"
+	"ChatServer/apps/user/internal/converter"
+	"ChatServer/apps/user/internal/repository"
+	pb "ChatServer/apps/user/pb"
+	"ChatServer/config"
+	"ChatServer/consts"
+	"ChatServer/model"
+	"ChatServer/pkg/logger"
+	"ChatServer/pkg/util"
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// maxGroupNameLen 群名称最大字符数（与 group_info.name varchar(64) 一致）
+	maxGroupNameLen = 64
+	// groupPushTimeout 群事件实时下发超时
+	groupPushTimeout = 3 * time.Second
+)
+
+// groupServiceImpl 群组服务实现
+type groupServiceImpl struct {
+	cfg           config.GroupConfig
+	groupRepo     repository.IGroupRepository
+	userRepo      repository.IUserRepository
+	connectClient *nodeclient.Client
+	msgClient     msgpb.MessageServiceClient
+	convClient    msgpb.ConversationServiceClient
+}
+
+// NewGroupService 创建群组服务实例
+// connectClient 可为 nil（Redis 不可用时不下发群事件，客户端通过查询接口获取结果）
+// msgClient 可为 nil（不写入群公告等系统消息）
+// convClient 可为 nil（入群时不创建群会话，仅用于测试）
+func NewGroupService(cfg config.GroupConfig, groupRepo repository.IGroupRepository, userRepo repository.IUserRepository, connectClient *nodeclient.Client, msgClient msgpb.MessageServiceClient, convClient msgpb.ConversationServiceClient) GroupService {
+	return &groupServiceImpl{
+		cfg:           cfg,
+		groupRepo:     groupRepo,
+		userRepo:      userRepo,
+		connectClient: connectClient,
+		msgClient:     msgClient,
+		convClient:    convClient,
+	}
+}
+
+// CreateGroup 创建群组
+// 业务流程：
+//  1. 校验群名称长度，初始成员去重（排除创建者）并校验单次邀请上限、群人数上限
+//  2. 过滤不存在的用户
+//  3. 同一事务中写入群资料、群主（role=2）与初始成员，member_cnt 为实际写入的成员数
+//  4. 通过消息服务为全体成员创建群会话
+//
+// 错误码映射：
+//   - codes.InvalidArgument: 参数错误、群名称过长、邀请人数超限
+//   - codes.FailedPrecondition: 群成员已满
+//   - codes.Internal: 系统内部错误
+func (s *groupServiceImpl) CreateGroup(ctx context.Context, req *pb.CreateGroupRequest) (*pb.CreateGroupResponse, error) {
+	// 1. 校验参数
+	ownerUUID := util.GetUserUUIDFromContext(ctx)
+	if ownerUUID == "" {
+		logger.Error(ctx, "从 context 中获取 user_uuid 失败")
+		return nil, status.Error(codes.Internal, strconv.Itoa(consts.CodeInternalError))
+	}
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, status.Error(codes.InvalidArgument, strconv.Itoa(consts.CodeParamError))
+	}
+	if utf8.RuneCountInString(name) > maxGroupNameLen {
+		return nil, status.Error(codes.InvalidArgument, strconv.Itoa(consts.CodeGroupNameTooLong))
+	}
+	memberUUIDs := dedupUUIDs(req.MemberUuids, ownerUUID)
+	if err := s.checkInviteCount(len(memberUUIDs), 1); err != nil {
+		return nil, err
+	}
+
+	// 2. 过滤不存在的用户
+	memberUUIDs, _, err := s.filterExistingUsers(ctx, memberUUIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	// 3. 落库
+	now := time.Now()
+	group := &model.GroupInfo{
+		Uuid:      util.GenIDString(),
+		Name:      name,
+		Avatar:    req.Avatar,
+		OwnerUuid: ownerUUID,
+		AddMode:   0,
+		Status:    consts.GroupStatusNormal,
+	}
+	members := make([]*model.GroupMember, 0, len(memberUUIDs)+1)
+	members = append(members, &model.GroupMember{
+		GroupUuid: group.Uuid,
+		UserUuid:  ownerUUID,
+		Role:      consts.GroupRoleOwner,
+		Status:    consts.GroupMemberStatusNormal,
+		JoinedAt:  now,
+	})
+	for _, uuid := range memberUUIDs {
+		members = append(members, &model.GroupMember{
+			GroupUuid: group.Uuid,
+			UserUuid:  uuid,
+			Role:      consts.GroupRoleMember,
+			Status:    consts.GroupMemberStatusNormal,
+			Inviter:   ownerUUID,
+			JoinedAt:  now,
+		})
+	}
+	if err := s.groupRepo.CreateWithMembers(ctx, group, members); err != nil {
+		logger.Error(ctx, "创建群组失败",
+			logger.String("owner_uuid", ownerUUID),
+			logger.ErrorField("error", err),
+		)
+		return nil, status.Error(codes.Internal, strconv.Itoa(consts.CodeInternalError))
+	}
+
+	logger.Info(ctx, "创建群组成功",
+		logger.String("group_uuid", group.Uuid),
+		logger.String("owner_uuid", ownerUUID),
+		logger.Int("member_cnt", group.MemberCnt),
+	)
+
+	// 4. 创建群会话
+	s.joinGroupConversations(ctx, group.Uuid, append([]string{ownerUUID}, memberUUIDs...))
+
+	// 回查一次，返回数据库默认值（如默认头像）
+	if created, err := s.groupRepo.GetByUUID(ctx, group.Uuid); err == nil {
+		group = created
+	}
+	return &pb.CreateGroupResponse{Group: converter.ModelToProtoGroupInfo(group)}, nil
+}
+
+// GetGroupInfo 获取群资料
+// 已解散的群也可查询（客户端据 status 展示）
+//
+// 错误码映射：
+//   - codes.NotFound: 群组不存在
+//   - codes.Internal: 系统内部错误
+func (s *groupServiceImpl) GetGroupInfo(ctx context.Context, req *pb.GetGroupInfoRequest) (*pb.GetGroupInfoResponse, error) {
+	group, err := s.getGroup(ctx, req.GroupUuid)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.GetGroupInfoResponse{Group: converter.ModelToProtoGroupInfo(group)}, nil
+}
+
+// GetGroupMembers 分页获取群成员列表（仅群成员可查看）
+//
+// 错误码映射：
+//   - codes.NotFound: 群组不存在
+//   - codes.PermissionDenied: 不是群成员
+//   - codes.Internal: 系统内部错误
+func (s *groupServiceImpl) GetGroupMembers(ctx context.Context, req *pb.GetGroupMembersRequest) (*pb.GetGroupMembersResponse, error) {
+	userUUID := util.GetUserUUIDFromContext(ctx)
+	if userUUID == "" {
+		logger.Error(ctx, "从 context 中获取 user_uuid 失败")
+		return nil, status.Error(codes.Internal, strconv.Itoa(consts.CodeInternalError))
+	}
+	if req.Page < 1 || req.PageSize < 1 || req.PageSize > 100 {
+		return nil, status.Error(codes.InvalidArgument, strconv.Itoa(consts.CodeParamError))
+	}
+	if _, err := s.getGroup(ctx, req.GroupUuid); err != nil {
+		return nil, err
+	}
+	if _, err := s.authorize(ctx, req.GroupUuid, userUUID, groupActionViewMembers, nil); err != nil {
+		return nil, err
+	}
+
+	members, total, err := s.groupRepo.ListMembers(ctx, req.GroupUuid, int(req.Page), int(req.PageSize))
+	if err != nil {
+		logger.Error(ctx, "查询群成员列表失败",
+			logger.String("group_uuid", req.GroupUuid),
+			logger.ErrorField("error", err),
+		)
+		return nil, status.Error(codes.Internal, strconv.Itoa(consts.CodeInternalError))
+	}
+
+	return &pb.GetGroupMembersResponse{
+		Items: converter.ModelsToProtoGroupMemberItemList(members),
+		Pagination: &pb.PaginationInfo{
+			Page:       req.Page,
+			PageSize:   req.PageSize,
+			Total:      total,
+			TotalPages: int32((total + int64(req.PageSize) - 1) / int64(req.PageSize)),
+		},
+	}, nil
+}
+
+// InviteMembers 邀请成员入群
+// 业务流程：
+//  1. 邀请列表去重（排除自己），校验单次邀请上限
+//  2. 校验群状态正常、邀请人为正常成员
+//  3. 过滤不存在的用户，已是群成员的跳过
+//  4. 锁定群记录后写入成员并更新 member_cnt（超过群人数上限整体失败）
+//
+// 错误码映射：
+//   - codes.InvalidArgument: 参数错误、邀请人数超限
+//   - codes.NotFound: 群组不存在
+//   - codes.PermissionDenied: 不是群成员、群组被禁用
+//   - codes.FailedPrecondition: 群组已解散、群成员已满
+//   - codes.Internal: 系统内部错误
+func (s *groupServiceImpl) InviteMembers(ctx context.Context, req *pb.InviteMembersRequest) (*pb.InviteMembersResponse, error) {
+	// 1. 校验参数
+	inviterUUID := util.GetUserUUIDFromContext(ctx)
+	if inviterUUID == "" {
+		logger.Error(ctx, "从 context 中获取 user_uuid 失败")
+		return nil, status.Error(codes.Internal, strconv.Itoa(consts.CodeInternalError))
+	}
+	memberUUIDs := dedupUUIDs(req.MemberUuids, inviterUUID)
+	if len(memberUUIDs) == 0 {
+		return nil, status.Error(codes.InvalidArgument, strconv.Itoa(consts.CodeParamError))
+	}
+	if err := s.checkInviteCount(len(memberUUIDs), 0); err != nil {
+		return nil, err
+	}
+
+	// 2. 校验群状态与邀请人身份
+	if _, err := s.getActiveGroup(ctx, req.GroupUuid); err != nil {
+		return nil, err
+	}
+	if _, err := s.authorize(ctx, req.GroupUuid, inviterUUID, groupActionInvite, nil); err != nil {
+		return nil, err
+	}
+
+	// 3. 过滤不存在的用户
+	existing, skipped, err := s.filterExistingUsers(ctx, memberUUIDs)
+	if err != nil {
+		return nil, err
+	}
+	if len(existing) == 0 {
+		return &pb.InviteMembersResponse{AddedUuids: []string{}, SkippedUuids: skipped}, nil
+	}
+
+	// 4. 写入成员
+	added, memberCnt, err := s.groupRepo.AddMembers(ctx, req.GroupUuid, inviterUUID, existing, s.cfg.MaxMembers)
+	if err != nil {
+		return nil, s.addMembersError(ctx, req.GroupUuid, err)
+	}
+
+	addedSet := make(map[string]struct{}, len(added))
+	for _, uuid := range added {
+		addedSet[uuid] = struct{}{}
+	}
+	for _, uuid := range existing {
+		if _, ok := addedSet[uuid]; !ok {
+			skipped = append(skipped, uuid)
+		}
+	}
+	if added == nil {
+		added = []string{}
+	}
+	s.joinGroupConversations(ctx, req.GroupUuid, added)
+
+	logger.Info(ctx, "邀请群成员成功",
+		logger.String("group_uuid", req.GroupUuid),
+		logger.String("inviter_uuid", inviterUUID),
+		logger.Int("added", len(added)),
+	)
+	return &pb.InviteMembersResponse{
+		AddedUuids:   added,
+		SkippedUuids: skipped,
+		MemberCnt:    int32(memberCnt),
+	}, nil
+}
+
+// JoinGroup 申请入群
+// 业务流程：
+//  1. 校验群状态正常、当前用户不是群成员
+//  2. 直接加入（add_mode=0）的群立即入群
+//  3. 需审核（add_mode=1）的群创建加群申请（apply_type=1），已有待处理申请时刷新原申请
+//
+// 错误码映射：
+//   - codes.InvalidArgument: 参数错误
+//   - codes.NotFound: 群组不存在
+//   - codes.PermissionDenied: 群组被禁用
+//   - codes.FailedPrecondition: 群组已解散、已经是群成员、群成员已满
+//   - codes.Internal: 系统内部错误
+func (s *groupServiceImpl) JoinGroup(ctx context.Context, req *pb.JoinGroupRequest) (*pb.JoinGroupResponse, error) {
+	// 1. 校验参数
+	userUUID := util.GetUserUUIDFromContext(ctx)
+	if userUUID == "" {
+		logger.Error(ctx, "从 context 中获取 user_uuid 失败")
+		return nil, status.Error(codes.Internal, strconv.Itoa(consts.CodeInternalError))
+	}
+	group, err := s.getActiveGroup(ctx, req.GroupUuid)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkNotMember(ctx, req.GroupUuid, userUUID); err != nil {
+		return nil, err
+	}
+
+	// 2. 直接加入
+	if group.AddMode == consts.GroupAddModeDirect {
+		added, _, err := s.groupRepo.AddMembers(ctx, req.GroupUuid, "", []string{userUUID}, s.cfg.MaxMembers)
+		if err != nil {
+			return nil, s.addMembersError(ctx, req.GroupUuid, err)
+		}
+		if len(added) == 0 {
+			return nil, status.Error(codes.FailedPrecondition, strconv.Itoa(consts.CodeAlreadyGroupMember))
+		}
+		s.joinGroupConversations(ctx, req.GroupUuid, added)
+		logger.Info(ctx, "加入群组成功",
+			logger.String("group_uuid", req.GroupUuid),
+			logger.String("user_uuid", userUUID),
+		)
+		return &pb.JoinGroupResponse{Joined: true}, nil
+	}
+
+	// 3. 提交入群申请
+	expiredAt := time.Now().Add(s.cfg.ApplyTTL)
+	apply := &model.ApplyRequest{
+		ApplyType:     consts.ApplyTypeGroup,
+		ApplicantUuid: userUUID,
+		TargetUuid:    req.GroupUuid,
+		Status:        consts.ApplyStatusPending,
+		Reason:        strings.TrimSpace(req.Reason),
+		ExpiredAt:     &expiredAt,
+	}
+	if err := s.groupRepo.SaveJoinApply(ctx, apply); err != nil {
+		logger.Error(ctx, "保存入群申请失败",
+			logger.String("group_uuid", req.GroupUuid),
+			logger.ErrorField("error", err),
+		)
+		return nil, status.Error(codes.Internal, strconv.Itoa(consts.CodeInternalError))
+	}
+
+	logger.Info(ctx, "提交入群申请成功",
+		logger.String("group_uuid", req.GroupUuid),
+		logger.String("user_uuid", userUUID),
+		logger.Int64("apply_id", apply.Id),
+	)
+	return &pb.JoinGroupResponse{Joined: false, ApplyId: apply.Id}, nil
+}
+
+// GetGroupApplyList 分页获取入群申请列表（仅群主/管理员）
+//
+// 错误码映射：
+//   - codes.InvalidArgument: 参数错误
+//   - codes.NotFound: 群组不存在
+//   - codes.PermissionDenied: 不是群成员、没有权限
+//   - codes.Internal: 系统内部错误
+func (s *groupServiceImpl) GetGroupApplyList(ctx context.Context, req *pb.GetGroupApplyListRequest) (*pb.GetGroupApplyListResponse, error) {
+	userUUID := util.GetUserUUIDFromContext(ctx)
+	if userUUID == "" {
+		logger.Error(ctx, "从 context 中获取 user_uuid 失败")
+		return nil, status.Error(codes.Internal, strconv.Itoa(consts.CodeInternalError))
+	}
+	if req.Status < -1 || req.Status > consts.ApplyStatusExpired || req.Page < 1 || req.PageSize < 1 || req.PageSize > 100 {
+		return nil, status.Error(codes.InvalidArgument, strconv.Itoa(consts.CodeParamError))
+	}
+	if _, err := s.getGroup(ctx, req.GroupUuid); err != nil {
+		return nil, err
+	}
+	if _, err := s.authorize(ctx, req.GroupUuid, userUUID, groupActionHandleApply, nil); err != nil {
+		return nil, err
+	}
+
+	applies, total, err := s.groupRepo.ListJoinApplies(ctx, req.GroupUuid, int(req.Status), int(req.Page), int(req.PageSize))
+	if err != nil {
+		logger.Error(ctx, "查询入群申请列表失败",
+			logger.String("group_uuid", req.GroupUuid),
+			logger.ErrorField("error", err),
+		)
+		return nil, status.Error(codes.Internal, strconv.Itoa(consts.CodeInternalError))
+	}
+
+	var users []*model.UserInfo
+	if len(applies) > 0 {
+		applicantUUIDs := make([]string, 0, len(applies))
+		for _, apply := range applies {
+			applicantUUIDs = append(applicantUUIDs, apply.ApplicantUuid)
+		}
+		users, err = s.userRepo.BatchGetByUUIDs(ctx, applicantUUIDs)
+		if err != nil {
+			// 申请人资料只用于展示，查询失败时仍返回申请列表
+			logger.Warn(ctx, "批量查询申请人信息失败", logger.ErrorField("error", err))
+		}
+	}
+
+	return &pb.GetGroupApplyListResponse{
+		Items: converter.ModelsToProtoGroupApplyItemList(applies, users),
+		Pagination: &pb.PaginationInfo{
+			Page:       req.Page,
+			PageSize:   req.PageSize,
+			Total:      total,
+			TotalPages: int32((total + int64(req.PageSize) - 1) / int64(req.PageSize)),
+		},
+	}, nil
+}
+
+// HandleGroupApply 处理入群申请（仅群主/管理员）
+// 业务流程：
+//  1. 校验申请存在且待处理、处理人为该群群主/管理员
+//  2. 已过期的申请置为过期（status=3）后返回 CodeApplyExpired
+//  3. 同意：同一事务内将申请置为通过并写入群成员；拒绝：将申请置为拒绝。处理人记录在 handle_user_uuid
+//  4. 向申请人下发审核结果（GroupEvent）
+//
+// 错误码映射：
+//   - codes.InvalidArgument: 参数错误
+//   - codes.NotFound: 入群申请不存在、群组不存在
+//   - codes.PermissionDenied: 不是群成员、没有权限
+//   - codes.FailedPrecondition: 申请已处理、申请已过期、群组已解散、群成员已满
+//   - codes.Internal: 系统内部错误
+func (s *groupServiceImpl) HandleGroupApply(ctx context.Context, req *pb.HandleGroupApplyRequest) error {
+	// 1. 校验参数
+	handlerUUID := util.GetUserUUIDFromContext(ctx)
+	if handlerUUID == "" {
+		logger.Error(ctx, "从 context 中获取 user_uuid 失败")
+		return status.Error(codes.Internal, strconv.Itoa(consts.CodeInternalError))
+	}
+	if req.ApplyId <= 0 || (req.Action != 1 && req.Action != 2) {
+		return status.Error(codes.InvalidArgument, strconv.Itoa(consts.CodeParamError))
+	}
+
+	apply, err := s.groupRepo.GetJoinApply(ctx, req.ApplyId)
+	if err != nil {
+		if errors.Is(err, repository.ErrRecordNotFound) {
+			return status.Error(codes.NotFound, strconv.Itoa(consts.CodeGroupApplyNotFound))
+		}
+		logger.Error(ctx, "查询入群申请失败",
+			logger.Int64("apply_id", req.ApplyId),
+			logger.ErrorField("error", err),
+		)
+		return status.Error(codes.Internal, strconv.Itoa(consts.CodeInternalError))
+	}
+	if _, err := s.getActiveGroup(ctx, apply.TargetUuid); err != nil {
+		return err
+	}
+	if _, err := s.authorize(ctx, apply.TargetUuid, handlerUUID, groupActionHandleApply, nil); err != nil {
+		return err
+	}
+	if apply.Status != consts.ApplyStatusPending {
+		return status.Error(codes.FailedPrecondition, strconv.Itoa(consts.CodeApplyNotFoundOrHandle))
+	}
+
+	// 2. 过期处理
+	if apply.ExpiredAt != nil && time.Now().After(*apply.ExpiredAt) {
+		if err := s.groupRepo.FinishJoinApply(ctx, apply.Id, consts.ApplyStatusExpired, "", ""); err != nil && !errors.Is(err, repository.ErrRecordNotFound) {
+			logger.Warn(ctx, "标记入群申请过期失败",
+				logger.Int64("apply_id", apply.Id),
+				logger.ErrorField("error", err),
+			)
+		}
+		return status.Error(codes.FailedPrecondition, strconv.Itoa(consts.CodeApplyExpired))
+	}
+
+	// 3. 同意/拒绝
+	remark := strings.TrimSpace(req.Remark)
+	eventType := int32(consts.GroupEventApplyRejected)
+	if req.Action == 1 {
+		eventType = consts.GroupEventApplyAccepted
+		added, _, err := s.groupRepo.ApproveJoinApply(ctx, apply.Id, handlerUUID, remark, s.cfg.MaxMembers)
+		if err != nil {
+			if errors.Is(err, repository.ErrGroupFull) {
+				return status.Error(codes.FailedPrecondition, strconv.Itoa(consts.CodeGroupFull))
+			}
+			if errors.Is(err, repository.ErrRecordNotFound) {
+				// 并发处理或群在校验之后被解散
+				return status.Error(codes.FailedPrecondition, strconv.Itoa(consts.CodeApplyNotFoundOrHandle))
+			}
+			logger.Error(ctx, "通过入群申请失败",
+				logger.Int64("apply_id", apply.Id),
+				logger.ErrorField("error", err),
+			)
+			return status.Error(codes.Internal, strconv.Itoa(consts.CodeInternalError))
+		}
+		s.joinGroupConversations(ctx, apply.TargetUuid, added)
+	} else {
+		if err := s.groupRepo.FinishJoinApply(ctx, apply.Id, consts.ApplyStatusRejected, handlerUUID, remark); err != nil {
+			if errors.Is(err, repository.ErrRecordNotFound) {
+				return status.Error(codes.FailedPrecondition, strconv.Itoa(consts.CodeApplyNotFoundOrHandle))
+			}
+			logger.Error(ctx, "拒绝入群申请失败",
+				logger.Int64("apply_id", apply.Id),
+				logger.ErrorField("error", err),
+			)
+			return status.Error(codes.Internal, strconv.Itoa(consts.CodeInternalError))
+		}
+	}
+
+	logger.Info(ctx, "处理入群申请成功",
+		logger.Int64("apply_id", apply.Id),
+		logger.String("group_uuid", apply.TargetUuid),
+		logger.String("handler_uuid", handlerUUID),
+		logger.Int("action", int(req.Action)),
+	)
+
+	// 4. 通知申请人
+	s.pushGroupEvent(ctx, []string{apply.ApplicantUuid}, &msgpb.GroupEvent{
+		GroupUuid:    apply.TargetUuid,
+		EventType:    eventType,
+		OperatorUuid: handlerUUID,
+		MemberUuids:  []string{apply.ApplicantUuid},
+		ApplyId:      apply.Id,
+		EventTime:    time.Now().UnixMilli(),
+	})
+	return nil
+}
+
+// QuitGroup 退出群组
+// 群主不能直接退群，需先转让群主或解散群组；已解散的群不能退出（成员记录保留，群人数不再变化）
+//
+// 错误码映射：
+//   - codes.NotFound: 群组不存在
+//   - codes.PermissionDenied: 不是群成员
+//   - codes.FailedPrecondition: 群主不能退群、群组已解散
+//   - codes.Internal: 系统内部错误
+func (s *groupServiceImpl) QuitGroup(ctx context.Context, req *pb.QuitGroupRequest) error {
+	userUUID := util.GetUserUUIDFromContext(ctx)
+	if userUUID == "" {
+		logger.Error(ctx, "从 context 中获取 user_uuid 失败")
+		return status.Error(codes.Internal, strconv.Itoa(consts.CodeInternalError))
+	}
+	if _, err := s.getActiveGroup(ctx, req.GroupUuid); err != nil {
+		return err
+	}
+	if _, err := s.authorize(ctx, req.GroupUuid, userUUID, groupActionQuit, nil); err != nil {
+		return err
+	}
+
+	if err := s.groupRepo.RemoveMember(ctx, req.GroupUuid, userUUID, consts.GroupMemberStatusQuit); err != nil {
+		if errors.Is(err, repository.ErrRecordNotFound) {
+			return status.Error(codes.PermissionDenied, strconv.Itoa(consts.CodeNotGroupMember))
+		}
+		if errors.Is(err, repository.ErrGroupDismissed) {
+			return status.Error(codes.FailedPrecondition, strconv.Itoa(consts.CodeGroupAlreadyDismiss))
+		}
+		logger.Error(ctx, "退出群组失败",
+			logger.String("group_uuid", req.GroupUuid),
+			logger.ErrorField("error", err),
+		)
+		return status.Error(codes.Internal, strconv.Itoa(consts.CodeInternalError))
+	}
+
+	logger.Info(ctx, "退出群组成功",
+		logger.String("group_uuid", req.GroupUuid),
+		logger.String("user_uuid", userUUID),
+	)
+	return nil
+}
+
+// SetAdmin 设置管理员（仅群主）
+// 管理员人数受 GroupConfig.MaxAdmins 限制，目标已是管理员时直接返回成功
+//
+// 错误码映射：
+//   - codes.InvalidArgument: 参数错误
+//   - codes.NotFound: 群组不存在、群成员不存在
+//   - codes.PermissionDenied: 不是群成员、没有权限
+//   - codes.FailedPrecondition: 群组已解散、管理员数量已达上限
+//   - codes.Internal: 系统内部错误
+func (s *groupServiceImpl) SetAdmin(ctx context.Context, req *pb.SetAdminRequest) error {
+	return s.changeRole(ctx, req.GroupUuid, req.UserUuid, consts.GroupRoleMember, consts.GroupRoleAdmin)
+}
+
+// UnsetAdmin 取消管理员（仅群主）
+// 目标不是管理员时直接返回成功
+//
+// 错误码映射：
+//   - codes.InvalidArgument: 参数错误
+//   - codes.NotFound: 群组不存在、群成员不存在
+//   - codes.PermissionDenied: 不是群成员、没有权限
+//   - codes.FailedPrecondition: 群组已解散
+//   - codes.Internal: 系统内部错误
+func (s *groupServiceImpl) UnsetAdmin(ctx context.Context, req *pb.UnsetAdminRequest) error {
+	return s.changeRole(ctx, req.GroupUuid, req.UserUuid, consts.GroupRoleAdmin, consts.GroupRoleMember)
+}
+
+// changeRole 在普通成员与管理员之间切换角色
+func (s *groupServiceImpl) changeRole(ctx context.Context, groupUUID, targetUUID string, fromRole, toRole int8) error {
+	// 1. 校验参数与权限
+	userUUID := util.GetUserUUIDFromContext(ctx)
+	if userUUID == "" {
+		logger.Error(ctx, "从 context 中获取 user_uuid 失败")
+		return status.Error(codes.Internal, strconv.Itoa(consts.CodeInternalError))
+	}
+	if _, err := s.getActiveGroup(ctx, groupUUID); err != nil {
+		return err
+	}
+	target, err := s.getTargetMember(ctx, groupUUID, targetUUID)
+	if err != nil {
+		return err
+	}
+	if _, err := s.authorize(ctx, groupUUID, userUUID, groupActionSetAdmin, target); err != nil {
+		return err
+	}
+	if target.Role == toRole {
+		return nil
+	}
+
+	// 2. 更新角色（设为管理员时在群记录锁内校验管理员上限）
+	if err := s.groupRepo.UpdateRole(ctx, groupUUID, targetUUID, fromRole, toRole, s.cfg.MaxAdmins); err != nil {
+		if errors.Is(err, repository.ErrAdminLimitExceeded) {
+			return status.Error(codes.FailedPrecondition, strconv.Itoa(consts.CodeAdminLimitExceeded))
+		}
+		if errors.Is(err, repository.ErrRecordNotFound) {
+			// 并发变更：成员已退出或角色已被修改
+			return status.Error(codes.NotFound, strconv.Itoa(consts.CodeGroupMemberNotFound))
+		}
+		logger.Error(ctx, "更新群成员角色失败",
+			logger.String("group_uuid", groupUUID),
+			logger.String("target_uuid", targetUUID),
+			logger.ErrorField("error", err),
+		)
+		return status.Error(codes.Internal, strconv.Itoa(consts.CodeInternalError))
+	}
+
+	logger.Info(ctx, "更新群成员角色成功",
+		logger.String("group_uuid", groupUUID),
+		logger.String("target_uuid", targetUUID),
+		logger.Int("role", int(toRole)),
+	)
+
+	eventType := int32(consts.GroupEventAdminSet)
+	if toRole == consts.GroupRoleMember {
+		eventType = consts.GroupEventAdminUnset
+	}
+	s.broadcastGroupEvent(ctx, groupUUID, nil, &msgpb.GroupEvent{
+		GroupUuid:    groupUUID,
+		EventType:    eventType,
+		OperatorUuid: userUUID,
+		MemberUuids:  []string{targetUUID},
+		EventTime:    time.Now().UnixMilli(),
+	})
+	return nil
+}
+
+// KickMember 踢出群成员
+// 群主可踢管理员与普通成员，管理员只能踢普通成员（见权限矩阵）
+//
+// 错误码映射：
+//   - codes.InvalidArgument: 参数错误
+//   - codes.NotFound: 群组不存在、群成员不存在
+//   - codes.PermissionDenied: 不是群成员、没有权限、不能踢出群主、不能踢出管理员
+//   - codes.FailedPrecondition: 群组已解散
+//   - codes.Internal: 系统内部错误
+func (s *groupServiceImpl) KickMember(ctx context.Context, req *pb.KickMemberRequest) error {
+	// 1. 校验参数与权限
+	userUUID := util.GetUserUUIDFromContext(ctx)
+	if userUUID == "" {
+		logger.Error(ctx, "从 context 中获取 user_uuid 失败")
+		return status.Error(codes.Internal, strconv.Itoa(consts.CodeInternalError))
+	}
+	if req.UserUuid == userUUID {
+		return status.Error(codes.InvalidArgument, strconv.Itoa(consts.CodeParamError))
+	}
+	if _, err := s.getActiveGroup(ctx, req.GroupUuid); err != nil {
+		return err
+	}
+	target, err := s.getTargetMember(ctx, req.GroupUuid, req.UserUuid)
+	if err != nil {
+		return err
+	}
+	if _, err := s.authorize(ctx, req.GroupUuid, userUUID, groupActionKick, target); err != nil {
+		return err
+	}
+
+	// 2. 移除成员
+	if err := s.groupRepo.RemoveMember(ctx, req.GroupUuid, req.UserUuid, consts.GroupMemberStatusKicked); err != nil {
+		if errors.Is(err, repository.ErrRecordNotFound) {
+			return status.Error(codes.NotFound, strconv.Itoa(consts.CodeGroupMemberNotFound))
+		}
+		if errors.Is(err, repository.ErrGroupDismissed) {
+			return status.Error(codes.FailedPrecondition, strconv.Itoa(consts.CodeGroupAlreadyDismiss))
+		}
+		logger.Error(ctx, "踢出群成员失败",
+			logger.String("group_uuid", req.GroupUuid),
+			logger.String("target_uuid", req.UserUuid),
+			logger.ErrorField("error", err),
+		)
+		return status.Error(codes.Internal, strconv.Itoa(consts.CodeInternalError))
+	}
+
+	logger.Info(ctx, "踢出群成员成功",
+		logger.String("group_uuid", req.GroupUuid),
+		logger.String("operator_uuid", userUUID),
+		logger.String("target_uuid", req.UserUuid),
+	)
+
+	// 被踢出的成员已不在正常成员中，单独追加
+	s.broadcastGroupEvent(ctx, req.GroupUuid, []string{req.UserUuid}, &msgpb.GroupEvent{
+		GroupUuid:    req.GroupUuid,
+		EventType:    consts.GroupEventMemberKicked,
+		OperatorUuid: userUUID,
+		MemberUuids:  []string{req.UserUuid},
+		EventTime:    time.Now().UnixMilli(),
+	})
+	return nil
+}
+
+// TransferOwnership 转让群主（仅群主）
+// 同一事务内：新群主角色置为 2、原群主降为普通成员、更新 group_info.owner_uuid
+//
+// 错误码映射：
+//   - codes.InvalidArgument: 参数错误
+//   - codes.NotFound: 群组不存在、群成员不存在
+//   - codes.PermissionDenied: 不是群成员、没有权限
+//   - codes.FailedPrecondition: 群组已解散
+//   - codes.Internal: 系统内部错误
+func (s *groupServiceImpl) TransferOwnership(ctx context.Context, req *pb.TransferOwnershipRequest) error {
+	// 1. 校验参数与权限
+	userUUID := util.GetUserUUIDFromContext(ctx)
+	if userUUID == "" {
+		logger.Error(ctx, "从 context 中获取 user_uuid 失败")
+		return status.Error(codes.Internal, strconv.Itoa(consts.CodeInternalError))
+	}
+	if req.NewOwnerUuid == userUUID {
+		return status.Error(codes.InvalidArgument, strconv.Itoa(consts.CodeParamError))
+	}
+	if _, err := s.getActiveGroup(ctx, req.GroupUuid); err != nil {
+		return err
+	}
+	target, err := s.getTargetMember(ctx, req.GroupUuid, req.NewOwnerUuid)
+	if err != nil {
+		return err
+	}
+	if _, err := s.authorize(ctx, req.GroupUuid, userUUID, groupActionTransferOwner, target); err != nil {
+		return err
+	}
+
+	// 2. 原子交换角色
+	if err := s.groupRepo.TransferOwnership(ctx, req.GroupUuid, userUUID, req.NewOwnerUuid); err != nil {
+		if errors.Is(err, repository.ErrRecordNotFound) {
+			// 并发变更：新群主已退出或群主已变更
+			return status.Error(codes.NotFound, strconv.Itoa(consts.CodeGroupMemberNotFound))
+		}
+		logger.Error(ctx, "转让群主失败",
+			logger.String("group_uuid", req.GroupUuid),
+			logger.String("new_owner_uuid", req.NewOwnerUuid),
+			logger.ErrorField("error", err),
+		)
+		return status.Error(codes.Internal, strconv.Itoa(consts.CodeInternalError))
+	}
+
+	logger.Info(ctx, "转让群主成功",
+		logger.String("group_uuid", req.GroupUuid),
+		logger.String("old_owner_uuid", userUUID),
+		logger.String("new_owner_uuid", req.NewOwnerUuid),
+	)
+
+	s.broadcastGroupEvent(ctx, req.GroupUuid, nil, &msgpb.GroupEvent{
+		GroupUuid:    req.GroupUuid,
+		EventType:    consts.GroupEventOwnerTransferred,
+		OperatorUuid: userUUID,
+		MemberUuids:  []string{req.NewOwnerUuid},
+		EventTime:    time.Now().UnixMilli(),
+	})
+	return nil
+}
+
+// MuteMember 禁言/解除禁言成员
+// duration 为 0 时解除禁言；禁言到期后无需定时任务解除，发送消息时按 mute_until 判定
+//
+// 错误码映射：
+//   - codes.InvalidArgument: 参数错误
+//   - codes.NotFound: 群组不存在、群成员不存在
+//   - codes.PermissionDenied: 不是群成员、没有权限
+//   - codes.FailedPrecondition: 群组已解散
+//   - codes.Internal: 系统内部错误
+func (s *groupServiceImpl) MuteMember(ctx context.Context, req *pb.MuteMemberRequest) (*pb.MuteMemberResponse, error) {
+	// 1. 校验参数与权限
+	userUUID := util.GetUserUUIDFromContext(ctx)
+	if userUUID == "" {
+		logger.Error(ctx, "从 context 中获取 user_uuid 失败")
+		return nil, status.Error(codes.Internal, strconv.Itoa(consts.CodeInternalError))
+	}
+	if req.UserUuid == userUUID {
+		return nil, status.Error(codes.InvalidArgument, strconv.Itoa(consts.CodeParamError))
+	}
+	if _, err := s.getActiveGroup(ctx, req.GroupUuid); err != nil {
+		return nil, err
+	}
+	target, err := s.getTargetMember(ctx, req.GroupUuid, req.UserUuid)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.authorize(ctx, req.GroupUuid, userUUID, groupActionMute, target); err != nil {
+		return nil, err
+	}
+
+	// 2. 更新禁言到期时间
+	var muteUntil *time.Time
+	if req.Duration > 0 {
+		until := time.Now().Add(time.Duration(req.Duration) * time.Second)
+		muteUntil = &until
+	}
+	if err := s.groupRepo.SetMuteUntil(ctx, req.GroupUuid, req.UserUuid, muteUntil); err != nil {
+		if errors.Is(err, repository.ErrRecordNotFound) {
+			return nil, status.Error(codes.NotFound, strconv.Itoa(consts.CodeGroupMemberNotFound))
+		}
+		logger.Error(ctx, "设置成员禁言失败",
+			logger.String("group_uuid", req.GroupUuid),
+			logger.String("target_uuid", req.UserUuid),
+			logger.ErrorField("error", err),
+		)
+		return nil, status.Error(codes.Internal, strconv.Itoa(consts.CodeInternalError))
+	}
+
+	logger.Info(ctx, "设置成员禁言成功",
+		logger.String("group_uuid", req.GroupUuid),
+		logger.String("operator_uuid", userUUID),
+		logger.String("target_uuid", req.UserUuid),
+		logger.Int64("duration", req.Duration),
+	)
+
+	muteUntilMillis := converter.TimePointerToMillis(muteUntil)
+	s.pushGroupEvent(ctx, []string{req.UserUuid}, &msgpb.GroupEvent{
+		GroupUuid:    req.GroupUuid,
+		EventType:    consts.GroupEventMemberMuted,
+		OperatorUuid: userUUID,
+		MemberUuids:  []string{req.UserUuid},
+		MuteUntil:    muteUntilMillis,
+		EventTime:    time.Now().UnixMilli(),
+	})
+	return &pb.MuteMemberResponse{MuteUntil: muteUntilMillis}, nil
+}
+
+// SetGroupMuteAll 开启/关闭全员禁言（群主、管理员）
+// 开启后仅群主与管理员可发言，事件下发给全体成员
+//
+// 错误码映射：
+//   - codes.NotFound: 群组不存在
+//   - codes.PermissionDenied: 不是群成员、没有权限
+//   - codes.FailedPrecondition: 群组已解散
+//   - codes.Internal: 系统内部错误
+func (s *groupServiceImpl) SetGroupMuteAll(ctx context.Context, req *pb.SetGroupMuteAllRequest) error {
+	// 1. 校验权限
+	userUUID := util.GetUserUUIDFromContext(ctx)
+	if userUUID == "" {
+		logger.Error(ctx, "从 context 中获取 user_uuid 失败")
+		return status.Error(codes.Internal, strconv.Itoa(consts.CodeInternalError))
+	}
+	if _, err := s.getActiveGroup(ctx, req.GroupUuid); err != nil {
+		return err
+	}
+	if _, err := s.authorize(ctx, req.GroupUuid, userUUID, groupActionMuteAll, nil); err != nil {
+		return err
+	}
+
+	// 2. 更新全员禁言状态
+	if err := s.groupRepo.SetMuteAll(ctx, req.GroupUuid, req.MuteAll); err != nil {
+		if errors.Is(err, repository.ErrRecordNotFound) {
+			return status.Error(codes.NotFound, strconv.Itoa(consts.CodeGroupNotFound))
+		}
+		logger.Error(ctx, "设置全员禁言失败",
+			logger.String("group_uuid", req.GroupUuid),
+			logger.ErrorField("error", err),
+		)
+		return status.Error(codes.Internal, strconv.Itoa(consts.CodeInternalError))
+	}
+
+	logger.Info(ctx, "设置全员禁言成功",
+		logger.String("group_uuid", req.GroupUuid),
+		logger.String("operator_uuid", userUUID),
+		logger.Bool("mute_all", req.MuteAll),
+	)
+
+	// 3. 通知全体成员
+	s.broadcastGroupEvent(ctx, req.GroupUuid, nil, &msgpb.GroupEvent{
+		GroupUuid:    req.GroupUuid,
+		EventType:    consts.GroupEventMuteAllChanged,
+		OperatorUuid: userUUID,
+		MuteAll:      req.MuteAll,
+		EventTime:    time.Now().UnixMilli(),
+	})
+	return nil
+}
+
+// DismissGroup 解散群组（仅群主）
+// 解散后群状态置为 2，成员记录保留，群内不能再发送消息；解散成功后通知全体成员
+//
+// 错误码映射：
+//   - codes.NotFound: 群组不存在
+//   - codes.PermissionDenied: 不是群主
+//   - codes.FailedPrecondition: 群组已解散
+//   - codes.Internal: 系统内部错误
+func (s *groupServiceImpl) DismissGroup(ctx context.Context, req *pb.DismissGroupRequest) error {
+	userUUID := util.GetUserUUIDFromContext(ctx)
+	if userUUID == "" {
+		logger.Error(ctx, "从 context 中获取 user_uuid 失败")
+		return status.Error(codes.Internal, strconv.Itoa(consts.CodeInternalError))
+	}
+	group, err := s.getGroup(ctx, req.GroupUuid)
+	if err != nil {
+		return err
+	}
+	if group.Status == consts.GroupStatusDismissed {
+		return status.Error(codes.FailedPrecondition, strconv.Itoa(consts.CodeGroupAlreadyDismiss))
+	}
+	if _, err := s.authorize(ctx, req.GroupUuid, userUUID, groupActionDismiss, nil); err != nil {
+		return err
+	}
+
+	if err := s.groupRepo.Dismiss(ctx, req.GroupUuid); err != nil {
+		if errors.Is(err, repository.ErrRecordNotFound) {
+			return status.Error(codes.FailedPrecondition, strconv.Itoa(consts.CodeGroupAlreadyDismiss))
+		}
+		logger.Error(ctx, "解散群组失败",
+			logger.String("group_uuid", req.GroupUuid),
+			logger.ErrorField("error", err),
+		)
+		return status.Error(codes.Internal, strconv.Itoa(consts.CodeInternalError))
+	}
+
+	logger.Info(ctx, "解散群组成功",
+		logger.String("group_uuid", req.GroupUuid),
+		logger.String("owner_uuid", userUUID),
+	)
+
+	// 通知全体成员（解散后成员记录保留）
+	s.broadcastGroupEvent(ctx, req.GroupUuid, nil, &msgpb.GroupEvent{
+		GroupUuid:    req.GroupUuid,
+		EventType:    consts.GroupEventDismissed,
+		OperatorUuid: userUUID,
+		EventTime:    time.Now().UnixMilli(),
+	})
+	return nil
+}
+
+// getGroup 查询群资料（任意状态）
+func (s *groupServiceImpl) getGroup(ctx context.Context, groupUUID string) (*model.GroupInfo, error) {
+	if groupUUID == "" {
+		return nil, status.Error(codes.InvalidArgument, strconv.Itoa(consts.CodeParamError))
+	}
+	group, err := s.groupRepo.GetByUUID(ctx, groupUUID)
+	if err != nil {
+		if errors.Is(err, repository.ErrRecordNotFound) {
+			return nil, status.Error(codes.NotFound, strconv.Itoa(consts.CodeGroupNotFound))
+		}
+		logger.Error(ctx, "查询群组失败",
+			logger.String("group_uuid", groupUUID),
+			logger.ErrorField("error", err),
+		)
+		return nil, status.Error(codes.Internal, strconv.Itoa(consts.CodeInternalError))
+	}
+	return group, nil
+}
+
+// getActiveGroup 查询状态正常的群
+func (s *groupServiceImpl) getActiveGroup(ctx context.Context, groupUUID string) (*model.GroupInfo, error) {
+	group, err := s.getGroup(ctx, groupUUID)
+	if err != nil {
+		return nil, err
+	}
+	switch group.Status {
+	case consts.GroupStatusNormal:
+		return group, nil
+	case consts.GroupStatusDismissed:
+		return nil, status.Error(codes.FailedPrecondition, strconv.Itoa(consts.CodeGroupAlreadyDismiss))
+	default:
+		return nil, status.Error(codes.PermissionDenied, strconv.Itoa(consts.CodeNoPermission))
+	}
+}
+
+// getActiveMember 查询正常群成员，非成员返回 CodeNotGroupMember
+func (s *groupServiceImpl) getActiveMember(ctx context.Context, groupUUID, userUUID string) (*model.GroupMember, error) {
+	member, err := s.groupRepo.GetMember(ctx, groupUUID, userUUID)
+	if err != nil {
+		if errors.Is(err, repository.ErrRecordNotFound) {
+			return nil, status.Error(codes.PermissionDenied, strconv.Itoa(consts.CodeNotGroupMember))
+		}
+		logger.Error(ctx, "查询群成员失败",
+			logger.String("group_uuid", groupUUID),
+			logger.ErrorField("error", err),
+		)
+		return nil, status.Error(codes.Internal, strconv.Itoa(consts.CodeInternalError))
+	}
+	if member.Status != consts.GroupMemberStatusNormal {
+		return nil, status.Error(codes.PermissionDenied, strconv.Itoa(consts.CodeNotGroupMember))
+	}
+	return member, nil
+}
+
+// authorize 查询操作人的正常成员记录并按权限矩阵校验 action（target 为目标成员，可为 nil）
+func (s *groupServiceImpl) authorize(ctx context.Context, groupUUID, userUUID string, action groupAction, target *model.GroupMember) (*model.GroupMember, error) {
+	member, err := s.getActiveMember(ctx, groupUUID, userUUID)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkGroupPermission(member, action, target); err != nil {
+		return nil, err
+	}
+	return member, nil
+}
+
+// getTargetMember 查询被操作的正常成员，不存在返回 CodeGroupMemberNotFound
+func (s *groupServiceImpl) getTargetMember(ctx context.Context, groupUUID, userUUID string) (*model.GroupMember, error) {
+	if userUUID == "" {
+		return nil, status.Error(codes.InvalidArgument, strconv.Itoa(consts.CodeParamError))
+	}
+	member, err := s.groupRepo.GetMember(ctx, groupUUID, userUUID)
+	if err != nil {
+		if errors.Is(err, repository.ErrRecordNotFound) {
+			return nil, status.Error(codes.NotFound, strconv.Itoa(consts.CodeGroupMemberNotFound))
+		}
+		logger.Error(ctx, "查询群成员失败",
+			logger.String("group_uuid", groupUUID),
+			logger.ErrorField("error", err),
+		)
+		return nil, status.Error(codes.Internal, strconv.Itoa(consts.CodeInternalError))
+	}
+	if member.Status != consts.GroupMemberStatusNormal {
+		return nil, status.Error(codes.NotFound, strconv.Itoa(consts.CodeGroupMemberNotFound))
+	}
+	return member, nil
+}
+
+// checkNotMember 校验用户不是群的正常成员
+func (s *groupServiceImpl) checkNotMember(ctx context.Context, groupUUID, userUUID string) error {
+	member, err := s.groupRepo.GetMember(ctx, groupUUID, userUUID)
+	if err != nil {
+		if errors.Is(err, repository.ErrRecordNotFound) {
+			return nil
+		}
+		logger.Error(ctx, "查询群成员失败",
+			logger.String("group_uuid", groupUUID),
+			logger.ErrorField("error", err),
+		)
+		return status.Error(codes.Internal, strconv.Itoa(consts.CodeInternalError))
+	}
+	if member.Status == consts.GroupMemberStatusNormal {
+		return status.Error(codes.FailedPrecondition, strconv.Itoa(consts.CodeAlreadyGroupMember))
+	}
+	return nil
+}
+
+// addMembersError 转换 AddMembers 返回的错误
+func (s *groupServiceImpl) addMembersError(ctx context.Context, groupUUID string, err error) error {
+	if errors.Is(err, repository.ErrGroupFull) {
+		return status.Error(codes.FailedPrecondition, strconv.Itoa(consts.CodeGroupFull))
+	}
+	if errors.Is(err, repository.ErrRecordNotFound) {
+		// 校验之后群被解散
+		return status.Error(codes.FailedPrecondition, strconv.Itoa(consts.CodeGroupAlreadyDismiss))
+	}
+	logger.Error(ctx, "加入群成员失败",
+		logger.String("group_uuid", groupUUID),
+		logger.ErrorField("error", err),
+	)
+	return status.Error(codes.Internal, strconv.Itoa(consts.CodeInternalError))
+}
+
+// broadcastGroupEvent 向群内全部正常成员及 extraUUIDs（如被踢出的成员）下发群事件
+// 成员变动与角色变更需全员感知，各端据此刷新缓存的成员列表与权限；操作已生效，查询成员失败只记录日志
+func (s *groupServiceImpl) broadcastGroupEvent(ctx context.Context, groupUUID string, extraUUIDs []string, event *msgpb.GroupEvent) {
+	memberUUIDs, err := s.groupRepo.ListMemberUUIDs(ctx, groupUUID)
+	if err != nil {
+		logger.Warn(ctx, "查询群成员失败，跳过群事件通知",
+			logger.String("group_uuid", groupUUID),
+			logger.Int("event_type", int(event.EventType)),
+			logger.ErrorField("error", err),
+		)
+		return
+	}
+	s.pushGroupEvent(ctx, append(memberUUIDs, extraUUIDs...), event)
+}
+
+// joinGroupConversations 通过消息服务为新入群成员创建群会话（会话表由消息服务维护）
+// 入群已生效，调用失败只记录日志；convClient 为 nil 或 memberUUIDs 为空时跳过
+func (s *groupServiceImpl) joinGroupConversations(ctx context.Context, groupUUID string, memberUUIDs []string) {
+	if s.convClient == nil || len(memberUUIDs) == 0 {
+		return
+	}
+	rpcCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), groupPushTimeout)
+	defer cancel()
+	_, err := s.convClient.JoinGroupConversations(rpcCtx, &msgpb.JoinGroupConversationsRequest{
+		GroupUuid:   groupUUID,
+		MemberUuids: memberUUIDs,
+	})
+	if err != nil {
+		logger.Warn(ctx, "创建入群会话失败",
+			logger.String("group_uuid", groupUUID),
+			logger.Int("member_count", len(memberUUIDs)),
+			logger.ErrorField("error", err),
+		)
+	}
+}
+
+// pushGroupEvent 异步向目标用户的在线设备下发群事件
+// 尽力而为：下发失败只记录日志，客户端通过查询接口兜底
+func (s *groupServiceImpl) pushGroupEvent(ctx context.Context, userUUIDs []string, event *msgpb.GroupEvent) {
+	if s.connectClient == nil || len(userUUIDs) == 0 {
+		return
+	}
+	body, err := proto.Marshal(&msgpb.PushEnvelope{
+		Payload: &msgpb.PushEnvelope_GroupEvent{GroupEvent: event},
+	})
+	if err != nil {
+		logger.Error(ctx, "编码群事件失败", logger.ErrorField("error", err))
+		return
+	}
+
+	pushCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), groupPushTimeout)
+	go func() {
+		defer cancel()
+		if _, err := s.connectClient.Push(pushCtx, userUUIDs, body); err != nil {
+			logger.Warn(pushCtx, "群事件下发失败",
+				logger.String("group_uuid", event.GroupUuid),
+				logger.ErrorField("error", err),
+			)
+		}
+	}()
+}
+
+// checkInviteCount 校验单次拉入人数与群人数上限（base 为已占用的名额，建群时为群主 1 人）
+func (s *groupServiceImpl) checkInviteCount(n, base int) error {
+	if n > s.cfg.MaxInvitePerRequest {
+		return status.Error(codes.InvalidArgument, strconv.Itoa(consts.CodeGroupInviteLimit))
+	}
+	if base+n > s.cfg.MaxMembers {
+		return status.Error(codes.FailedPrecondition, strconv.Itoa(consts.CodeGroupFull))
+	}
+	return nil
+}
+
+// filterExistingUsers 过滤不存在（或已注销）的用户，返回存在与不存在的 uuid（保持原顺序）
+func (s *groupServiceImpl) filterExistingUsers(ctx context.Context, uuids []string) ([]string, []string, error) {
+	if len(uuids) == 0 {
+		return []string{}, []string{}, nil
+	}
+	users, err := s.userRepo.BatchGetByUUIDs(ctx, uuids)
+	if err != nil {
+		logger.Error(ctx, "批量查询用户失败", logger.ErrorField("error", err))
+		return nil, nil, status.Error(codes.Internal, strconv.Itoa(consts.CodeInternalError))
+	}
+	found := make(map[string]struct{}, len(users))
+	for _, user := range users {
+		found[user.Uuid] = struct{}{}
+	}
+	existing := make([]string, 0, len(uuids))
+	missing := make([]string, 0)
+	for _, uuid := range uuids {
+		if _, ok := found[uuid]; ok {
+			existing = append(existing, uuid)
+		} else {
+			missing = append(missing, uuid)
+		}
+	}
+	return existing, missing, nil
+}
+
+// dedupUUIDs 去重并排除空串与 exclude
+func dedupUUIDs(uuids []string, exclude string) []string {
+	seen := make(map[string]struct{}, len(uuids))
+	result := make([]string, 0, len(uuids))
+	for _, uuid := range uuids {
+		if uuid == "" || uuid == exclude {
+			continue
+		}
+		if _, ok := seen[uuid]; ok {
+			continue
+		}
+		seen[uuid] = struct{}{}
+		result = append(result, uuid)
+	}
+	return result
+}
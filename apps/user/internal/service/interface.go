@@ -161,6 +161,81 @@ type IDeviceService interface {
 	BatchGetOnlineStatus(ctx context.Context, req *pb.BatchGetOnlineStatusRequest) (*pb.BatchGetOnlineStatusResponse, error)
 }
 
+// ==================== 群组服务接口 ====================
+
+// IGroupService 群组服务接口
+// 职责：建群、群资料、群成员列表、邀请成员、申请入群与审核、管理员与踢人、转让群主、退群、解散群
+type IGroupService interface {
+	// CreateGroup 创建群组
+	CreateGroup(ctx context.Context, req *pb.CreateGroupRequest) (*pb.CreateGroupResponse, error)
+
+	// GetGroupInfo 获取群资料
+	GetGroupInfo(ctx context.Context, req *pb.GetGroupInfoRequest) (*pb.GetGroupInfoResponse, error)
+
+	// GetGroupMembers 分页获取群成员列表
+	GetGroupMembers(ctx context.Context, req *pb.GetGroupMembersRequest) (*pb.GetGroupMembersResponse, error)
+
+	// InviteMembers 邀请成员入群
+	InviteMembers(ctx context.Context, req *pb.InviteMembersRequest) (*pb.InviteMembersResponse, error)
+
+	// JoinGroup 申请入群
+	JoinGroup(ctx context.Context, req *pb.JoinGroupRequest) (*pb.JoinGroupResponse, error)
+
+	// GetGroupApplyList 分页获取入群申请列表
+	GetGroupApplyList(ctx context.Context, req *pb.GetGroupApplyListRequest) (*pb.GetGroupApplyListResponse, error)
+
+	// HandleGroupApply 处理入群申请
+	HandleGroupApply(ctx context.Context, req *pb.HandleGroupApplyRequest) error
+
+	// SetAdmin 设置管理员
+	SetAdmin(ctx context.Context, req *pb.SetAdminRequest) error
+
+	// UnsetAdmin 取消管理员
+	UnsetAdmin(ctx context.Context, req *pb.UnsetAdminRequest) error
+
+	// KickMember 踢出群成员
+	KickMember(ctx context.Context, req *pb.KickMemberRequest) error
+
+	// TransferOwnership 转让群主
+	TransferOwnership(ctx context.Context, req *pb.TransferOwnershipRequest) error
+
+	// MuteMember 禁言/解除禁言成员
+	MuteMember(ctx context.Context, req *pb.MuteMemberRequest) (*pb.MuteMemberResponse, error)
+
+	// SetGroupMuteAll 开启/关闭全员禁言
+	SetGroupMuteAll(ctx context.Context, req *pb.SetGroupMuteAllRequest) error
+
+	// PostGroupAnnouncement 发布群公告
+	PostGroupAnnouncement(ctx context.Context, req *pb.PostGroupAnnouncementRequest) (*pb.PostGroupAnnouncementResponse, error)
+
+	// GetGroupAnnouncements 获取群公告历史
+	GetGroupAnnouncements(ctx context.Context, req *pb.GetGroupAnnouncementsRequest) (*pb.GetGroupAnnouncementsResponse, error)
+
+	// PinGroupAnnouncement 置顶/取消置顶群公告
+	PinGroupAnnouncement(ctx context.Context, req *pb.PinGroupAnnouncementRequest) error
+
+	// AckGroupAnnouncement 确认群公告
+	AckGroupAnnouncement(ctx context.Context, req *pb.AckGroupAnnouncementRequest) (*pb.AckGroupAnnouncementResponse, error)
+
+	// CreateGroupInvite 创建群邀请
+	CreateGroupInvite(ctx context.Context, req *pb.CreateGroupInviteRequest) (*pb.CreateGroupInviteResponse, error)
+
+	// GetGroupInviteList 获取群邀请列表
+	GetGroupInviteList(ctx context.Context, req *pb.GetGroupInviteListRequest) (*pb.GetGroupInviteListResponse, error)
+
+	// RevokeGroupInvite 撤销群邀请
+	RevokeGroupInvite(ctx context.Context, req *pb.RevokeGroupInviteRequest) error
+
+	// JoinByInviteToken 通过邀请令牌入群
+	JoinByInviteToken(ctx context.Context, req *pb.JoinByInviteTokenRequest) (*pb.JoinByInviteTokenResponse, error)
+
+	// QuitGroup 退出群组
+	QuitGroup(ctx context.Context, req *pb.QuitGroupRequest) error
+
+	// DismissGroup 解散群组
+	DismissGroup(ctx context.Context, req *pb.DismissGroupRequest) error
+}
+
 // ==================== 别名类型定义（用于向后兼容）====================
 
 // AuthService 别名 IAuthService
@@ -177,3 +252,6 @@ type BlacklistService = IBlacklistService
 
 // DeviceService 别名 IDeviceService
 type DeviceService = IDeviceService
+
+// GroupService 别名 IGroupService
+type GroupService = IGroupService
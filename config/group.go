@@ -0,0 +1,26 @@
+package config
+
+import "time"
+
+// GroupConfig 群组业务参数。
+type GroupConfig struct {
+	MaxMembers          int           `json:"maxMembers" yaml:"maxMembers"`                   // 群成员上限（含群主）
+	MaxInvitePerRequest int           `json:"maxInvitePerRequest" yaml:"maxInvitePerRequest"` // 建群/单次邀请最多拉入的人数
+	MaxAdmins           int           `json:"maxAdmins" yaml:"maxAdmins"`                     // 管理员人数上限（不含群主）
+	ApplyTTL            time.Duration `json:"applyTTL" yaml:"applyTTL"`                       // 入群申请有效期
+	InviteTTL           time.Duration `json:"inviteTTL" yaml:"inviteTTL"`                     // 群邀请默认有效期（未指定有效期时使用）
+	MaxInviteTTL        time.Duration `json:"maxInviteTTL" yaml:"maxInviteTTL"`               // 群邀请最长有效期
+}
+
+// DefaultGroupConfig 返回默认群组业务参数：群上限 500 人，单次最多邀请 50 人，管理员最多 10 人，入群申请 7 天过期，
+// 群邀请默认 7 天、最长 30 天有效。
+func DefaultGroupConfig() GroupConfig {
+	return GroupConfig{
+		MaxMembers:          500,
+		MaxInvitePerRequest: 50,
+		MaxAdmins:           10,
+		ApplyTTL:            7 * 24 * time.Hour,
+		InviteTTL:           7 * 24 * time.Hour,
+		MaxInviteTTL:        30 * 24 * time.Hour,
+	}
+}
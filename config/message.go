@@ -0,0 +1,22 @@
+package config
+
+import "time"
+
+// MessageConfig 消息服务业务参数。
+type MessageConfig struct {
+	RevokeWindow           time.Duration `json:"revokeWindow" yaml:"revokeWindow"`                     // 发送者可撤回自己消息的时限（群主/管理员不受限）
+	EditWindow             time.Duration `json:"editWindow" yaml:"editWindow"`                         // 发送者可编辑自己文本消息的时限
+	ReadDiffusionThreshold int64         `json:"readDiffusionThreshold" yaml:"readDiffusionThreshold"` // 群成员数达到该值时改用读扩散（<=0 表示始终写扩散）
+	ScheduleMaxDelay       time.Duration `json:"scheduleMaxDelay" yaml:"scheduleMaxDelay"`             // 定时消息最远可预约的发送时间（距当前时间）
+}
+
+// DefaultMessageConfig 返回默认消息业务参数：发送后 2 分钟内可撤回、15 分钟内可编辑，200 人及以上的群使用读扩散
+// （须低于 GroupConfig.MaxMembers，否则没有群能达到阈值），定时消息最远预约 30 天。
+func DefaultMessageConfig() MessageConfig {
+	return MessageConfig{
+		RevokeWindow:           2 * time.Minute,
+		EditWindow:             15 * time.Minute,
+		ReadDiffusionThreshold: 200,
+		ScheduleMaxDelay:       30 * 24 * time.Hour,
+	}
+}
@@ -138,6 +138,30 @@ const (
 	CodeMessageRevoked = 13007 // 消息已撤回
 	// 消息已删除
 	CodeMessageDeleted = 13008 // 消息已删除
+	// 超过撤回时限
+	CodeMessageRevokeTimeout = 13009 // 超过撤回时限
+	// 无权@所有人
+	CodeMessageAtAllDenied = 13010 // 无权@所有人
+	// 消息内容格式错误
+	CodeMessageContentInvalid = 13011 // 消息内容格式错误
+	// 表情回应种类已达上限
+	CodeReactionLimitExceeded = 13012 // 表情回应种类已达上限
+	// 超过编辑时限
+	CodeMessageEditTimeout = 13013 // 超过编辑时限
+	// 消息已被编辑（并发编辑冲突）
+	CodeMessageEditConflict = 13014 // 消息已被编辑
+	// 置顶消息数量已达上限
+	CodePinLimitExceeded = 13015 // 置顶消息数量已达上限
+	// 定时发送时间无效
+	CodeScheduleTimeInvalid = 13016 // 定时发送时间无效
+	// 待发送的定时消息数量已达上限
+	CodeScheduleLimitExceeded = 13017 // 待发送的定时消息数量已达上限
+	// 定时消息不存在
+	CodeScheduleNotFound = 13018 // 定时消息不存在
+	// 定时消息已发送或正在发送，不可取消
+	CodeScheduleNotCancelable = 13019 // 定时消息不可取消
+	// 消息存活时间（定时销毁）超出允许范围
+	CodeMessageTTLInvalid = 13020 // 消息存活时间无效
 )
 
 // 群组模块错误 (14xxx)
@@ -172,6 +196,20 @@ const (
 	CodeCannotQuitAsOwner = 14014 // 群主不能退群
 	// 管理员数量已达上限
 	CodeAdminLimitExceeded = 14015 // 管理员数量已达上限
+	// 你已被禁言
+	CodeGroupMemberMuted = 14016 // 你已被禁言
+	// 群组全员禁言中
+	CodeGroupAllMuted = 14017 // 群组全员禁言中
+	// 群公告不存在
+	CodeGroupAnnouncementNotFound = 14018 // 群公告不存在
+	// 群公告无需确认
+	CodeGroupAnnouncementNoAck = 14019 // 群公告无需确认
+	// 群邀请无效（签名错误、不存在或已撤销）
+	CodeGroupInviteInvalid = 14020 // 群邀请无效
+	// 群邀请已过期
+	CodeGroupInviteExpired = 14021 // 群邀请已过期
+	// 群邀请使用次数已达上限
+	CodeGroupInviteExhausted = 14022 // 群邀请使用次数已达上限
 )
 
 // 设备会话错误 (15xxx)
@@ -194,6 +232,8 @@ const (
 	CodeDeviceInfoInvalid = 15008 // 设备信息无效
 	// 平台不支持
 	CodePlatformNotSupport = 15009 // 平台不支持
+	// 设备已被踢下线
+	CodeDeviceKicked = 15010 // 设备已被踢下线
 )
 
 // 黑名单错误 (16xxx)
@@ -290,23 +330,42 @@ var CodeMessage = map[int]string{
 	CodeMessageTooLong:        "消息内容过长",
 	CodeMessageRevoked:        "消息已撤回",
 	CodeMessageDeleted:        "消息已删除",
+	CodeMessageRevokeTimeout:  "已超过可撤回时间",
+	CodeMessageAtAllDenied:    "仅群主和管理员可以@所有人",
+	CodeMessageContentInvalid: "消息内容格式错误",
+	CodeReactionLimitExceeded: "该消息的表情回应种类已达上限",
+	CodeMessageEditTimeout:    "已超过可编辑时间",
+	CodeMessageEditConflict:   "消息已被编辑，请刷新后重试",
+	CodePinLimitExceeded:      "置顶消息数量已达上限",
+	CodeScheduleTimeInvalid:   "定时发送时间无效",
+	CodeScheduleLimitExceeded: "待发送的定时消息数量已达上限",
+	CodeScheduleNotFound:      "定时消息不存在",
+	CodeScheduleNotCancelable: "定时消息已发送或正在发送，无法取消",
+	CodeMessageTTLInvalid:     "消息存活时间无效",
 
 	// 群组模块
-	CodeGroupNotFound:       "群组不存在",
-	CodeNotGroupMember:      "不是群成员",
-	CodeNoPermission:        "没有权限",
-	CodeGroupFull:           "群成员已满",
-	CodeGroupNameTooLong:    "群名称过长",
-	CodeGroupNoticeTooLong:  "群公告过长",
-	CodeGroupAlreadyDismiss: "群组已解散",
-	CodeGroupMemberNotFound: "群成员不存在",
-	CodeCannotKickOwner:     "不能踢出群主",
-	CodeCannotKickAdmin:     "不能踢出管理员",
-	CodeAlreadyGroupMember:  "已经是群成员",
-	CodeGroupApplyNotFound:  "入群申请不存在",
-	CodeGroupInviteLimit:    "邀请人数超限",
-	CodeCannotQuitAsOwner:   "群主不能退群",
-	CodeAdminLimitExceeded:  "管理员数量已达上限",
+	CodeGroupNotFound:             "群组不存在",
+	CodeNotGroupMember:            "不是群成员",
+	CodeNoPermission:              "没有权限",
+	CodeGroupFull:                 "群成员已满",
+	CodeGroupNameTooLong:          "群名称过长",
+	CodeGroupNoticeTooLong:        "群公告过长",
+	CodeGroupAlreadyDismiss:       "群组已解散",
+	CodeGroupMemberNotFound:       "群成员不存在",
+	CodeCannotKickOwner:           "不能踢出群主",
+	CodeCannotKickAdmin:           "不能踢出管理员",
+	CodeAlreadyGroupMember:        "已经是群成员",
+	CodeGroupApplyNotFound:        "入群申请不存在",
+	CodeGroupInviteLimit:          "邀请人数超限",
+	CodeCannotQuitAsOwner:         "群主不能退群",
+	CodeAdminLimitExceeded:        "管理员数量已达上限",
+	CodeGroupMemberMuted:          "你已被禁言",
+	CodeGroupAllMuted:             "群组全员禁言中",
+	CodeGroupAnnouncementNotFound: "群公告不存在",
+	CodeGroupAnnouncementNoAck:    "群公告无需确认",
+	CodeGroupInviteInvalid:        "群邀请无效",
+	CodeGroupInviteExpired:        "群邀请已过期",
+	CodeGroupInviteExhausted:      "群邀请使用次数已达上限",
 
 	// 设备会话
 	CodeDeviceCreateFail:    "设备会话创建失败",
@@ -318,6 +377,7 @@ var CodeMessage = map[int]string{
 	CodeDeviceOffline:       "设备已离线",
 	CodeDeviceInfoInvalid:   "设备信息无效",
 	CodePlatformNotSupport:  "平台不支持",
+	CodeDeviceKicked:        "设备已被踢下线",
 
 	// 黑名单
 	CodePeerBlacklistYou:    "对方已将你拉黑",
@@ -348,3 +408,120 @@ func IsNonServerError(code int) bool {
 const (
 	VerifyCodeExpireMinutes = 10
 )
+
+// 会话类型（model.Conversation.Type）
+const (
+	ConvTypeP2P   = 0 // 单聊
+	ConvTypeGroup = 1 // 群聊
+)
+
+// @提及（model.MessageMention.UserUuid）
+const (
+	MentionAllUUID = "@all" // @所有人的保留 uuid
+	MaxMentions    = 50     // 单条消息最多@的成员数
+)
+
+// 表情回应（model.MessageReaction）
+const (
+	MaxReactionKinds = 20 // 单条消息最多的表情种类数
+)
+
+// 置顶消息（model.PinnedMessage）
+const (
+	MaxPinnedMessages = 10 // 单个会话最多置顶的消息数
+)
+
+// 定时消息状态（model.ScheduledMessage.Status）
+const (
+	ScheduledStatusPending     = 0 // 待发送
+	ScheduledStatusDispatching = 1 // 发送中（已被发送任务认领，认领到期未完成时重新认领）
+	ScheduledStatusSent        = 2 // 已发送
+	ScheduledStatusCanceled    = 3 // 已取消
+	ScheduledStatusFailed      = 4 // 发送失败（发送时校验不通过或多次重试失败）
+
+	MaxPendingScheduled = 100 // 单个用户最多待发送的定时消息数
+)
+
+// 消息定时销毁（model.Message.ExpireAt / model.DisappearingTimer），单位秒，0 表示不销毁
+const (
+	MinMessageTTL = 5             // 最短存活时间 5 秒
+	MaxMessageTTL = 7 * 24 * 3600 // 最长存活时间 7 天
+)
+
+// 群组状态（model.GroupInfo.Status）
+const (
+	GroupStatusNormal    = 0 // 正常
+	GroupStatusDisabled  = 1 // 禁用
+	GroupStatusDismissed = 2 // 已解散
+)
+
+// 群成员角色（model.GroupMember.Role）
+const (
+	GroupRoleMember = 0 // 普通成员
+	GroupRoleAdmin  = 1 // 管理员
+	GroupRoleOwner  = 2 // 群主
+)
+
+// 群成员状态（model.GroupMember.Status）
+const (
+	GroupMemberStatusNormal  = 0 // 正常
+	GroupMemberStatusQuit    = 1 // 已退出
+	GroupMemberStatusKicked  = 2 // 被踢出
+	GroupMemberStatusPending = 3 // 待审核
+)
+
+// 申请类型与状态（model.ApplyRequest）
+const (
+	ApplyTypeFriend = 0 // 好友申请
+	ApplyTypeGroup  = 1 // 加群申请
+
+	ApplyStatusPending  = 0 // 待处理
+	ApplyStatusAccepted = 1 // 通过
+	ApplyStatusRejected = 2 // 拒绝
+	ApplyStatusExpired  = 3 // 已过期
+)
+
+// 群加入方式（model.GroupInfo.AddMode）
+const (
+	GroupAddModeDirect = 0 // 直接加入
+	GroupAddModeReview = 1 // 需审核
+)
+
+// 群事件类型（msg.GroupEvent.EventType）
+const (
+	GroupEventApplyAccepted    = 1 // 入群申请通过（下发给申请人）
+	GroupEventApplyRejected    = 2 // 入群申请被拒绝（下发给申请人）
+	GroupEventMemberKicked     = 3 // 成员被踢出群（下发给全体成员与被踢成员）
+	GroupEventAdminSet         = 4 // 成员被设为管理员（下发给全体成员）
+	GroupEventAdminUnset       = 5 // 成员被取消管理员（下发给全体成员）
+	GroupEventOwnerTransferred = 6 // 群主转让，operator_uuid 为原群主、member_uuids 为新群主（下发给全体成员）
+	GroupEventMemberMuted      = 7 // 被禁言/解除禁言（下发给被禁言成员）
+	GroupEventMuteAllChanged   = 8 // 全员禁言开启/关闭（下发给全体成员）
+	GroupEventDismissed        = 9 // 群被解散（下发给全体成员）
+)
+
+// 消息类型（model.Message.MsgType）：0-99 普通气泡消息，100+ 系统控制消息
+// content 结构与校验规则见 pkg/msgcontent，未登记的类型一律拒绝
+const (
+	MsgTypeText         = 1 // 文本，content: {"text"}
+	MsgTypeImage        = 2 // 图片，content: {"url","thumb_url","width","height","size","format"}
+	MsgTypeVoice        = 3 // 语音，content: {"url","duration","size"}
+	MsgTypeVideo        = 4 // 视频，content: {"url","cover_url","duration","width","height","size"}
+	MsgTypeFile         = 5 // 文件，content: {"url","name","size","mime_type"}
+	MsgTypeLocation     = 6 // 位置，content: {"latitude","longitude","title","address"}
+	MsgTypeCard         = 7 // 名片，content: {"user_uuid","nickname","avatar"}
+	MsgTypeQuote        = 8 // 引用回复，content: {"text","quote_msg_id"}，服务端补充被引用消息快照
+	MsgTypeMergeForward = 9 // 合并转发的聊天记录（仅由转发接口生成），content: {"title","src_conv_id","items"}
+
+	MsgTypeControlBase = 100 // 控制类消息起始值（仅服务端生成，客户端不可发送）
+	MsgTypeRevoke      = 100 // 撤回通知，content: {"msg_id","seq","operator_uuid"}
+	// 群公告通知，content: {"announcement_id","content","publisher_uuid","pinned","require_ack"}
+	MsgTypeGroupAnnouncement = 101
+	MsgTypeSystemNotice      = 102 // 系统提示（灰条），content: {"text"}
+	// 编辑通知，content: {"msg_id","seq","version","content"}，客户端仅在 version 大于本地版本时应用
+	MsgTypeEdit  = 103
+	MsgTypePin   = 104 // 置顶消息通知，content: {"msg_id","seq","operator_uuid"}
+	MsgTypeUnpin = 105 // 取消置顶通知，content: {"msg_id","seq","operator_uuid"}
+	// 会话定时销毁设置变更通知，content: {"ttl","operator_uuid"}，ttl 为 0 表示关闭
+	MsgTypeDisappearTimer = 106
+)
@@ -0,0 +1,11 @@
+package model
+
+// ConversationVersion 记录每个用户的会话变更版本计数器（每个用户一条）。
+// 会话变更在同一事务内将计数器加一并写入 Conversation.Version，计数器行锁使同一用户的变更按版本号顺序提交，
+// 增量同步按 version 游标拉取时不会漏掉晚提交的小版本号。
+type ConversationVersion struct {
+	OwnerUuid string `gorm:"column:owner_uuid;type:char(20);primaryKey;comment:用户uuid"`
+	Version   int64  `gorm:"column:version;not null;default:0;comment:当前最大会话版本号"`
+}
+
+func (ConversationVersion) TableName() string { return "conversation_version" }
@@ -0,0 +1,15 @@
+package model
+
+import "time"
+
+// DisappearingTimer 记录会话的定时销毁设置（每个会话一条，关闭时 Ttl 置 0）。
+// 开启后会话内新发送的普通消息按 Ttl 计算 Message.ExpireAt，发送时指定了 ttl 的以发送请求为准。
+type DisappearingTimer struct {
+	Id           int64     `gorm:"column:id;primaryKey;autoIncrement;comment:自增id"`
+	ConvId       string    `gorm:"column:conv_id;type:char(40);not null;uniqueIndex;comment:会话ID"`
+	Ttl          int32     `gorm:"column:ttl;not null;default:0;comment:消息存活时间(秒),0表示关闭"`
+	OperatorUuid string    `gorm:"column:operator_uuid;type:char(20);not null;comment:最后修改人uuid"`
+	UpdatedAt    time.Time `gorm:"column:updated_at;autoUpdateTime"`
+}
+
+func (DisappearingTimer) TableName() string { return "disappearing_timer" }
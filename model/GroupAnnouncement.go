@@ -0,0 +1,33 @@
+package model
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// GroupAnnouncement 群公告历史（group_info.notice 仅保存最新一条，便于群资料直接展示）
+type GroupAnnouncement struct {
+	Id            int64          `gorm:"column:id;primaryKey;autoIncrement;comment:自增id"`
+	GroupUuid     string         `gorm:"column:group_uuid;type:char(20);not null;index:idx_group_pinned,priority:1;comment:群uuid"`
+	PublisherUuid string         `gorm:"column:publisher_uuid;type:char(20);not null;comment:发布人uuid"`
+	Content       string         `gorm:"column:content;type:varchar(500);not null;comment:公告内容"`
+	Pinned        bool           `gorm:"column:pinned;not null;default:false;index:idx_group_pinned,priority:2;comment:是否置顶"`
+	RequireAck    bool           `gorm:"column:require_ack;not null;default:false;comment:是否需要成员确认已读"`
+	AckCount      int            `gorm:"column:ack_count;not null;default:0;comment:已确认人数"`
+	CreatedAt     time.Time      `gorm:"column:created_at;autoCreateTime"`
+	UpdatedAt     time.Time      `gorm:"column:updated_at;autoUpdateTime"`
+	DeletedAt     gorm.DeletedAt `gorm:"column:deleted_at;index"`
+}
+
+func (GroupAnnouncement) TableName() string { return "group_announcement" }
+
+// GroupAnnouncementAck 群公告确认记录（每人每条公告一条）
+type GroupAnnouncementAck struct {
+	Id             int64     `gorm:"column:id;primaryKey;autoIncrement;comment:自增id"`
+	AnnouncementId int64     `gorm:"column:announcement_id;not null;uniqueIndex:uidx_announcement_user;comment:公告id"`
+	UserUuid       string    `gorm:"column:user_uuid;type:char(20);not null;uniqueIndex:uidx_announcement_user;index;comment:确认人uuid"`
+	CreatedAt      time.Time `gorm:"column:created_at;autoCreateTime;comment:确认时间"`
+}
+
+func (GroupAnnouncementAck) TableName() string { return "group_announcement_ack" }
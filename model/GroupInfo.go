@@ -16,6 +16,7 @@ type GroupInfo struct {
 	AddMode   int8           `gorm:"column:add_mode;not null;default:0;comment:加群方式,0.直接 1.审核"`
 	Avatar    string         `gorm:"column:avatar;type:varchar(255);not null;default:https://cube.elemecdn.com/0/88/03b0d39583f48206768a7534e55bcpng.png;comment:群头像URL"`
 	Status    int8           `gorm:"column:status;not null;default:0;comment:状态,0.正常 1.禁用 2.解散"`
+	MuteAll   bool           `gorm:"column:mute_all;not null;default:false;comment:全员禁言"`
 	CreatedAt time.Time      `gorm:"column:created_at;autoCreateTime"`
 	UpdatedAt time.Time      `gorm:"column:updated_at;autoUpdateTime"`
 	DeletedAt gorm.DeletedAt `gorm:"column:deleted_at;index"`
@@ -0,0 +1,24 @@
+package model
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// GroupInvite 群邀请链接/二维码。
+// 邀请令牌为签名令牌（携带 invite id 与过期时间），使用次数与撤销状态以本表为准。
+type GroupInvite struct {
+	Id          int64          `gorm:"column:id;primaryKey;autoIncrement;comment:自增id"`
+	GroupUuid   string         `gorm:"column:group_uuid;type:char(20);not null;index;comment:群uuid"`
+	CreatorUuid string         `gorm:"column:creator_uuid;type:char(20);not null;comment:创建人uuid"`
+	MaxUses     int            `gorm:"column:max_uses;not null;default:0;comment:最大使用次数,0表示不限"`
+	UsedCount   int            `gorm:"column:used_count;not null;default:0;comment:已使用次数"`
+	ExpireAt    time.Time      `gorm:"column:expire_at;not null;comment:过期时间"`
+	Revoked     bool           `gorm:"column:revoked;not null;default:false;comment:是否已撤销"`
+	CreatedAt   time.Time      `gorm:"column:created_at;autoCreateTime"`
+	UpdatedAt   time.Time      `gorm:"column:updated_at;autoUpdateTime"`
+	DeletedAt   gorm.DeletedAt `gorm:"column:deleted_at;index"`
+}
+
+func (GroupInvite) TableName() string { return "group_invite" }
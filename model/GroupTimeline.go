@@ -0,0 +1,22 @@
+package model
+
+import "time"
+
+// GroupTimeline 读扩散群的公共时间线（每群一条），成员的会话记录只维护已读游标。
+// 大群每条消息只更新这一行，成员拉取会话列表时以此覆盖最后消息与未读数。
+// BubbleCount 只统计普通气泡消息（不含控制类通知，消息到期销毁时扣减），
+// 成员未读数为 BubbleCount - Conversation.ReadBubble。
+type GroupTimeline struct {
+	Id          int64      `gorm:"column:id;primaryKey;autoIncrement;comment:自增id"`
+	ConvId      string     `gorm:"column:conv_id;type:char(40);not null;uniqueIndex;comment:会话ID(群uuid)"`
+	LastSeq     int64      `gorm:"column:last_seq;not null;default:0;comment:最后消息的会话内序号"`
+	LastMsgId   string     `gorm:"column:last_msg_id;type:char(64);comment:最后消息ID"`
+	LastMsgAt   *time.Time `gorm:"column:last_msg_at;comment:最后消息时间"`
+	LastMsgPrev string     `gorm:"column:last_msg_preview;type:varchar(255);comment:最后消息预览"`
+	AtAllSeq    int64      `gorm:"column:at_all_seq;not null;default:0;comment:最近一条@所有人消息的会话内序号"`
+	BubbleCount int64      `gorm:"column:bubble_count;not null;default:0;comment:气泡消息数(不含控制类通知与已销毁消息)"`
+	Version     int64      `gorm:"column:version;not null;default:0;index;comment:变更版本号(雪花ID，用于会话增量同步)"`
+	UpdatedAt   time.Time  `gorm:"column:updated_at;autoUpdateTime"`
+}
+
+func (GroupTimeline) TableName() string { return "group_timeline" }
@@ -0,0 +1,16 @@
+package model
+
+import "time"
+
+// MessageMention 记录群消息的 @提及（每条消息每个被@的成员一条，@所有人只记一条保留 uuid）。
+// 按 (conv_id, user_uuid, seq) 查询某人在会话内已读游标之后的提及。
+type MessageMention struct {
+	Id        int64     `gorm:"column:id;primaryKey;autoIncrement;comment:自增id"`
+	ConvId    string    `gorm:"column:conv_id;type:char(40);not null;uniqueIndex:uidx_conv_user_seq,priority:1;comment:会话ID(群uuid)"`
+	UserUuid  string    `gorm:"column:user_uuid;type:char(20);not null;uniqueIndex:uidx_conv_user_seq,priority:2;comment:被@的成员uuid(@所有人为保留值@all)"`
+	Seq       int64     `gorm:"column:seq;not null;uniqueIndex:uidx_conv_user_seq,priority:3;comment:消息的会话内序号"`
+	MsgId     string    `gorm:"column:msg_id;type:char(64);not null;comment:消息ID"`
+	CreatedAt time.Time `gorm:"column:created_at;autoCreateTime"`
+}
+
+func (MessageMention) TableName() string { return "message_mention" }
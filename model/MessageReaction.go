@@ -0,0 +1,16 @@
+package model
+
+import "time"
+
+// MessageReaction 记录消息的表情回应（每个用户对每条消息的每个表情一条）。
+// 回应单独成表，不改写 Message.Content；按 msg_id 聚合出各表情的回应人数。
+type MessageReaction struct {
+	Id        int64     `gorm:"column:id;primaryKey;autoIncrement;comment:自增id"`
+	MsgId     string    `gorm:"column:msg_id;type:char(64);not null;uniqueIndex:uidx_msg_user_emoji,priority:1;comment:被回应的消息ID"`
+	UserUuid  string    `gorm:"column:user_uuid;type:char(20);not null;uniqueIndex:uidx_msg_user_emoji,priority:2;comment:回应用户uuid"`
+	Emoji     string    `gorm:"column:emoji;type:varchar(32);not null;uniqueIndex:uidx_msg_user_emoji,priority:3;comment:表情"`
+	ConvId    string    `gorm:"column:conv_id;type:char(40);not null;comment:会话ID"`
+	CreatedAt time.Time `gorm:"column:created_at;autoCreateTime"`
+}
+
+func (MessageReaction) TableName() string { return "message_reaction" }
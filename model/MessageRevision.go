@@ -0,0 +1,19 @@
+package model
+
+import "time"
+
+// MessageRevision 记录消息的编辑历史（审计用）。
+// 首次编辑时同时写入原始版本（version=0），之后每次编辑写入一个新版本；
+// message 表只保存最新版本的内容。
+type MessageRevision struct {
+	Id         int64     `gorm:"column:id;primaryKey;autoIncrement;comment:自增id"`
+	MsgId      string    `gorm:"column:msg_id;type:char(64);not null;uniqueIndex:uidx_msg_version,priority:1;comment:消息ID"`
+	Version    int32     `gorm:"column:version;not null;uniqueIndex:uidx_msg_version,priority:2;comment:版本号(原始内容为0)"`
+	ConvId     string    `gorm:"column:conv_id;type:char(40);not null;comment:会话ID"`
+	Content    string    `gorm:"column:content;type:json;not null;comment:该版本的消息内容"`
+	EditorUuid string    `gorm:"column:editor_uuid;type:char(20);not null;comment:编辑人uuid(原始版本为发送者)"`
+	RevisedAt  time.Time `gorm:"column:revised_at;not null;comment:该版本生效时间(原始版本为发送时间)"`
+	CreatedAt  time.Time `gorm:"column:created_at;autoCreateTime"`
+}
+
+func (MessageRevision) TableName() string { return "message_revision" }
@@ -0,0 +1,16 @@
+package model
+
+import "time"
+
+// PinnedMessage 记录会话内被置顶的消息（每个会话每条消息最多一条，取消置顶时删除）。
+// 会话置顶数量上限见 consts.MaxPinnedMessages。
+type PinnedMessage struct {
+	Id       int64     `gorm:"column:id;primaryKey;autoIncrement;comment:自增id"`
+	ConvId   string    `gorm:"column:conv_id;type:char(40);not null;uniqueIndex:uidx_conv_msg,priority:1;comment:会话ID"`
+	MsgId    string    `gorm:"column:msg_id;type:char(64);not null;uniqueIndex:uidx_conv_msg,priority:2;comment:被置顶的消息ID"`
+	Seq      int64     `gorm:"column:seq;not null;comment:被置顶消息的会话内序号"`
+	PinnedBy string    `gorm:"column:pinned_by;type:char(20);not null;comment:置顶操作人uuid"`
+	PinnedAt time.Time `gorm:"column:pinned_at;not null;comment:置顶时间"`
+}
+
+func (PinnedMessage) TableName() string { return "pinned_message" }
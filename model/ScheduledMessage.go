@@ -0,0 +1,33 @@
+package model
+
+import "time"
+
+// ScheduledMessage 记录用户预约的定时消息（发送前的持久化副本）。
+// 设计要点：
+// - 字段与发送请求一一对应，到期后由发送任务走正常发送流程，幂等ID由定时消息ID派生（sched_<id>），重复发送由消息表幂等去重。
+// - (from_uuid, client_msg_id) 唯一，预约请求重试返回首次预约的结果。
+// - 发送任务按 (status, send_at) 扫描到期记录；认领后 status=1 并写入 LeaseUntil，认领到期未完成的记录会被重新认领。
+// - 发送成功后回填 MsgId/Seq，发送失败记录 FailCode（业务错误码）。
+type ScheduledMessage struct {
+	Id          int64      `gorm:"column:id;primaryKey;autoIncrement;comment:自增id(定时消息ID)"`
+	FromUuid    string     `gorm:"column:from_uuid;type:char(20);not null;uniqueIndex:uidx_sender_client,priority:1;index:idx_from_status,priority:1;comment:发送者uuid"`
+	ClientMsgId string     `gorm:"column:client_msg_id;type:char(64);not null;uniqueIndex:uidx_sender_client,priority:2;comment:客户端幂等ID(预约去重)"`
+	ConvType    int8       `gorm:"column:conv_type;not null;comment:会话类型 0单聊 1群聊"`
+	TargetUuid  string     `gorm:"column:target_uuid;type:char(20);not null;comment:单聊为对端uuid,群聊为群uuid"`
+	MsgType     int16      `gorm:"column:msg_type;not null;comment:消息类型(参考 const.go)"`
+	Content     string     `gorm:"column:content;type:json;not null;comment:消息内容(JSON,根据msg_type解析)"`
+	AtUuids     []string   `gorm:"column:at_uuids;type:json;serializer:json;comment:@的成员uuid列表"`
+	AtAll       bool       `gorm:"column:at_all;not null;default:false;comment:是否@所有人"`
+	SendAt      time.Time  `gorm:"column:send_at;not null;index:idx_status_send_at,priority:2;comment:预约发送时间"`
+	Status      int8       `gorm:"column:status;not null;default:0;index:idx_status_send_at,priority:1;index:idx_from_status,priority:2;comment:0待发送 1发送中 2已发送 3已取消 4发送失败"`
+	LeaseUntil  *time.Time `gorm:"column:lease_until;comment:发送中状态的认领到期时间"`
+	Attempts    int        `gorm:"column:attempts;not null;default:0;comment:已尝试发送次数"`
+	MsgId       string     `gorm:"column:msg_id;type:char(64);not null;default:'';comment:发送成功后的消息ID"`
+	Seq         int64      `gorm:"column:seq;not null;default:0;comment:发送成功后的会话内序号"`
+	FailCode    int        `gorm:"column:fail_code;not null;default:0;comment:发送失败的业务错误码"`
+	SentAt      *time.Time `gorm:"column:sent_at;comment:实际发送时间"`
+	CreatedAt   time.Time  `gorm:"column:created_at;autoCreateTime"`
+	UpdatedAt   time.Time  `gorm:"column:updated_at;autoUpdateTime"`
+}
+
+func (ScheduledMessage) TableName() string { return "scheduled_message" }
@@ -0,0 +1,167 @@
+// Package msgcontent 消息类型登记表与 content 结构校验
+//
+// model.Message.Content 为 JSON，结构由 MsgType 决定。每种消息类型在此登记一个 Go 结构，
+// 发送时按类型解析并校验必填字段与大小限制，未登记的类型一律拒绝；会话预览也由此生成。
+package msgcontent
+
+import (
+	"ChatServer/consts"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"unicode/utf8"
+)
+
+var (
+	// ErrTypeNotSupport 消息类型未登记
+	ErrTypeNotSupport = errors.New("message type not supported")
+	// ErrContentEmpty 内容为空或必填字段为空
+	ErrContentEmpty = errors.New("message content empty")
+	// ErrContentTooLong 内容超过长度/大小/时长限制
+	ErrContentTooLong = errors.New("message content too long")
+	// ErrContentInvalid 内容不是合法 JSON 或字段取值非法
+	ErrContentInvalid = errors.New("message content invalid")
+)
+
+const (
+	// PreviewMaxRunes 会话预览最大字符数
+	PreviewMaxRunes = 50
+	// DefaultPreview 无法解析内容时的会话预览
+	DefaultPreview = "[消息]"
+
+	// maxURLLen 资源地址最大长度
+	maxURLLen = 1024
+)
+
+// Content 消息内容（各消息类型 content JSON 对应的结构）
+type Content interface {
+	// Validate 校验必填字段与长度、大小限制
+	Validate() error
+	// Preview 会话列表预览文本（未截断）
+	Preview() string
+}
+
+// registry 消息类型 -> content 结构构造函数
+var registry = map[int32]func() Content{
+	consts.MsgTypeText:         func() Content { return &TextContent{} },
+	consts.MsgTypeImage:        func() Content { return &ImageContent{} },
+	consts.MsgTypeVoice:        func() Content { return &VoiceContent{} },
+	consts.MsgTypeVideo:        func() Content { return &VideoContent{} },
+	consts.MsgTypeFile:         func() Content { return &FileContent{} },
+	consts.MsgTypeLocation:     func() Content { return &LocationContent{} },
+	consts.MsgTypeCard:         func() Content { return &CardContent{} },
+	consts.MsgTypeQuote:        func() Content { return &QuoteContent{} },
+	consts.MsgTypeMergeForward: func() Content { return &MergeForwardContent{} },
+
+	consts.MsgTypeRevoke:            func() Content { return &RevokeContent{} },
+	consts.MsgTypeGroupAnnouncement: func() Content { return &GroupAnnouncementContent{} },
+	consts.MsgTypeSystemNotice:      func() Content { return &SystemNoticeContent{} },
+	consts.MsgTypeEdit:              func() Content { return &EditContent{} },
+	consts.MsgTypePin:               func() Content { return &PinContent{} },
+	consts.MsgTypeUnpin:             func() Content { return &UnpinContent{} },
+	consts.MsgTypeDisappearTimer:    func() Content { return &DisappearTimerContent{} },
+}
+
+// IsRegistered 判断消息类型是否已登记
+func IsRegistered(msgType int32) bool {
+	_, ok := registry[msgType]
+	return ok
+}
+
+// IsControl 判断是否为控制类消息（仅服务端生成）
+func IsControl(msgType int32) bool {
+	return msgType >= consts.MsgTypeControlBase
+}
+
+// Parse 按消息类型解析并校验 content
+func Parse(msgType int32, raw string) (Content, error) {
+	newContent, ok := registry[msgType]
+	if !ok {
+		return nil, ErrTypeNotSupport
+	}
+	if strings.TrimSpace(raw) == "" {
+		return nil, ErrContentEmpty
+	}
+
+	content := newContent()
+	if err := json.Unmarshal([]byte(raw), content); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrContentInvalid, err)
+	}
+	if err := content.Validate(); err != nil {
+		return nil, err
+	}
+	return content, nil
+}
+
+// Preview 生成会话预览，超过 PreviewMaxRunes 截断；无法解析时返回 DefaultPreview
+func Preview(msgType int32, raw string) string {
+	newContent, ok := registry[msgType]
+	if !ok {
+		return DefaultPreview
+	}
+	content := newContent()
+	if err := json.Unmarshal([]byte(raw), content); err != nil {
+		return DefaultPreview
+	}
+	preview := content.Preview()
+	if preview == "" {
+		return DefaultPreview
+	}
+	return Truncate(preview)
+}
+
+// Truncate 按 PreviewMaxRunes 截断预览文本
+func Truncate(text string) string {
+	runes := []rune(text)
+	if len(runes) > PreviewMaxRunes {
+		return string(runes[:PreviewMaxRunes]) + "..."
+	}
+	return text
+}
+
+// checkText 校验必填文本：去除首尾空白后不能为空，字符数不超过 maxRunes
+func checkText(field, value string, maxRunes int) error {
+	if strings.TrimSpace(value) == "" {
+		return fmt.Errorf("%w: %s", ErrContentEmpty, field)
+	}
+	return checkOptionalText(field, value, maxRunes)
+}
+
+// checkOptionalText 校验可选文本的字符数
+func checkOptionalText(field, value string, maxRunes int) error {
+	if utf8.RuneCountInString(value) > maxRunes {
+		return fmt.Errorf("%w: %s", ErrContentTooLong, field)
+	}
+	return nil
+}
+
+// checkURL 校验资源地址：必填时不能为空，非空时须为 http/https 绝对地址
+func checkURL(field, value string, required bool) error {
+	if value == "" {
+		if required {
+			return fmt.Errorf("%w: %s", ErrContentEmpty, field)
+		}
+		return nil
+	}
+	if len(value) > maxURLLen {
+		return fmt.Errorf("%w: %s", ErrContentTooLong, field)
+	}
+	u, err := url.Parse(value)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("%w: %s", ErrContentInvalid, field)
+	}
+	return nil
+}
+
+// checkLimit 校验数值范围：小于 min 视为非法，超过 max 视为过长
+func checkLimit(field string, value, min, max int64) error {
+	if value < min {
+		return fmt.Errorf("%w: %s", ErrContentInvalid, field)
+	}
+	if value > max {
+		return fmt.Errorf("%w: %s", ErrContentTooLong, field)
+	}
+	return nil
+}
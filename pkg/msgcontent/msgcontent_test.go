@@ -0,0 +1,128 @@
+package msgcontent
+
+import (
+	"ChatServer/consts"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestParse 测试各消息类型 content 的解析与校验：通过、空内容、超长、格式错误、未登记类型
+func TestParse(t *testing.T) {
+	longText := strings.Repeat("字", maxTextRunes+1)
+
+	tests := []struct {
+		name    string
+		msgType int32
+		content string
+		wantErr error
+	}{
+		{"文本", consts.MsgTypeText, `{"text":"你好"}`, nil},
+		{"文本为空", consts.MsgTypeText, `{"text":"  "}`, ErrContentEmpty},
+		{"文本超长", consts.MsgTypeText, `{"text":"` + longText + `"}`, ErrContentTooLong},
+		{"content 为空串", consts.MsgTypeText, "", ErrContentEmpty},
+		{"content 非 JSON", consts.MsgTypeText, "hello", ErrContentInvalid},
+		{"字段类型错误", consts.MsgTypeText, `{"text":1}`, ErrContentInvalid},
+
+		{"图片", consts.MsgTypeImage, `{"url":"https://cdn.example.com/a.jpg","width":100,"height":80,"size":1024}`, nil},
+		{"图片缺少地址", consts.MsgTypeImage, `{"size":1024}`, ErrContentEmpty},
+		{"图片地址非 http", consts.MsgTypeImage, `{"url":"file:///etc/passwd"}`, ErrContentInvalid},
+		{"图片超过 20MB", consts.MsgTypeImage, `{"url":"https://cdn.example.com/a.jpg","size":20971521}`, ErrContentTooLong},
+
+		{"语音", consts.MsgTypeVoice, `{"url":"https://cdn.example.com/a.amr","duration":12}`, nil},
+		{"语音时长为 0", consts.MsgTypeVoice, `{"url":"https://cdn.example.com/a.amr","duration":0}`, ErrContentInvalid},
+		{"语音超过 60 秒", consts.MsgTypeVoice, `{"url":"https://cdn.example.com/a.amr","duration":61}`, ErrContentTooLong},
+
+		{"视频", consts.MsgTypeVideo, `{"url":"https://cdn.example.com/a.mp4","cover_url":"https://cdn.example.com/a.jpg","duration":30}`, nil},
+		{"视频超过 5 分钟", consts.MsgTypeVideo, `{"url":"https://cdn.example.com/a.mp4","duration":301}`, ErrContentTooLong},
+
+		{"文件", consts.MsgTypeFile, `{"url":"https://cdn.example.com/a.pdf","name":"a.pdf","size":2048}`, nil},
+		{"文件缺少文件名", consts.MsgTypeFile, `{"url":"https://cdn.example.com/a.pdf","size":2048}`, ErrContentEmpty},
+		{"文件超过 1GB", consts.MsgTypeFile, `{"url":"https://cdn.example.com/a.pdf","name":"a.pdf","size":1073741825}`, ErrContentTooLong},
+
+		{"位置", consts.MsgTypeLocation, `{"latitude":31.23,"longitude":121.47,"title":"外滩"}`, nil},
+		{"位置纬度越界", consts.MsgTypeLocation, `{"latitude":91,"longitude":121.47}`, ErrContentInvalid},
+
+		{"名片", consts.MsgTypeCard, `{"user_uuid":"u1","nickname":"张三"}`, nil},
+		{"名片缺少用户", consts.MsgTypeCard, `{"nickname":"张三"}`, ErrContentEmpty},
+
+		{"引用回复", consts.MsgTypeQuote, `{"text":"同意","quote_msg_id":"123"}`, nil},
+		{"引用回复缺少被引用消息", consts.MsgTypeQuote, `{"text":"同意"}`, ErrContentEmpty},
+
+		{"合并转发", consts.MsgTypeMergeForward, `{"title":"聊天记录","items":[{"msg_id":"1","msg_type":1,"content":{"text":"hi"}}]}`, nil},
+		{"合并转发无条目", consts.MsgTypeMergeForward, `{"title":"聊天记录","items":[]}`, ErrContentEmpty},
+		{"合并转发包含控制类消息", consts.MsgTypeMergeForward, `{"title":"聊天记录","items":[{"msg_id":"1","msg_type":100,"content":{}}]}`, ErrContentInvalid},
+
+		{"撤回通知", consts.MsgTypeRevoke, `{"msg_id":"123","seq":5,"operator_uuid":"u1"}`, nil},
+		{"撤回通知缺少序号", consts.MsgTypeRevoke, `{"msg_id":"123","operator_uuid":"u1"}`, ErrContentInvalid},
+		{"编辑通知", consts.MsgTypeEdit, `{"msg_id":"123","seq":5,"version":1,"content":{"text":"改"}}`, nil},
+		{"编辑通知缺少版本号", consts.MsgTypeEdit, `{"msg_id":"123","seq":5,"content":{"text":"改"}}`, ErrContentInvalid},
+		{"置顶通知", consts.MsgTypePin, `{"msg_id":"123","seq":5,"operator_uuid":"u1"}`, nil},
+		{"取消置顶通知缺少操作人", consts.MsgTypeUnpin, `{"msg_id":"123","seq":5}`, ErrContentEmpty},
+		{"定时销毁关闭", consts.MsgTypeDisappearTimer, `{"ttl":0,"operator_uuid":"u1"}`, nil},
+		{"定时销毁时长过短", consts.MsgTypeDisappearTimer, `{"ttl":1,"operator_uuid":"u1"}`, ErrContentInvalid},
+		{"群公告通知", consts.MsgTypeGroupAnnouncement, `{"announcement_id":1,"content":"明天开会","publisher_uuid":"u1"}`, nil},
+		{"系统提示", consts.MsgTypeSystemNotice, `{"text":"张三加入了群聊"}`, nil},
+
+		{"未登记类型", 99, `{"text":"hi"}`, ErrTypeNotSupport},
+		{"类型为 0", 0, `{"text":"hi"}`, ErrTypeNotSupport},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			content, err := Parse(tt.msgType, tt.content)
+			if tt.wantErr == nil {
+				require.NoError(t, err)
+				assert.NotNil(t, content)
+				return
+			}
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Nil(t, content)
+		})
+	}
+}
+
+// TestPreview 测试会话预览：文本截断、媒体占位符、控制类消息与无法解析的内容
+func TestPreview(t *testing.T) {
+	tests := []struct {
+		name    string
+		msgType int32
+		content string
+		want    string
+	}{
+		{"文本", consts.MsgTypeText, `{"text":"你好"}`, "你好"},
+		{"文本截断", consts.MsgTypeText, `{"text":"` + strings.Repeat("a", PreviewMaxRunes+5) + `"}`, strings.Repeat("a", PreviewMaxRunes) + "..."},
+		{"图片", consts.MsgTypeImage, `{"url":"https://cdn.example.com/a.jpg"}`, "[图片]"},
+		{"文件", consts.MsgTypeFile, `{"name":"a.pdf"}`, "[文件] a.pdf"},
+		{"群公告", consts.MsgTypeGroupAnnouncement, `{"content":"明天开会"}`, "[群公告]明天开会"},
+		{"取消置顶", consts.MsgTypeUnpin, `{"msg_id":"123","seq":5,"operator_uuid":"u1"}`, "[取消置顶了一条消息]"},
+		{"开启定时销毁", consts.MsgTypeDisappearTimer, `{"ttl":30,"operator_uuid":"u1"}`, "[开启了消息定时销毁]"},
+		{"文本为空", consts.MsgTypeText, `{"text":""}`, DefaultPreview},
+		{"非 JSON", consts.MsgTypeText, "hello", DefaultPreview},
+		{"未登记类型", 99, `{"text":"hi"}`, DefaultPreview},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Preview(tt.msgType, tt.content))
+		})
+	}
+}
+
+// TestRegistry_Complete 测试 consts 中定义的消息类型均已登记，且控制类消息不可由客户端发送
+func TestRegistry_Complete(t *testing.T) {
+	for _, msgType := range []int32{
+		consts.MsgTypeText, consts.MsgTypeImage, consts.MsgTypeVoice, consts.MsgTypeVideo,
+		consts.MsgTypeFile, consts.MsgTypeLocation, consts.MsgTypeCard, consts.MsgTypeQuote, consts.MsgTypeMergeForward,
+		consts.MsgTypeRevoke, consts.MsgTypeGroupAnnouncement, consts.MsgTypeSystemNotice, consts.MsgTypeEdit,
+		consts.MsgTypePin, consts.MsgTypeUnpin, consts.MsgTypeDisappearTimer,
+	} {
+		assert.True(t, IsRegistered(msgType), "消息类型 %d 未登记", msgType)
+	}
+
+	assert.False(t, IsControl(consts.MsgTypeQuote))
+	assert.True(t, IsControl(consts.MsgTypeRevoke))
+	assert.True(t, IsControl(consts.MsgTypeSystemNotice))
+}
@@ -0,0 +1,403 @@
+package msgcontent
+
+import (
+	"ChatServer/consts"
+	"encoding/json"
+	"fmt"
+)
+
+// 各类型的长度与大小限制
+const (
+	maxTextRunes      = 5000      // 文本、引用回复最大字符数
+	maxImageSize      = 20 << 20  // 图片最大 20MB
+	maxVoiceDuration  = 60        // 语音最长 60 秒
+	maxVoiceSize      = 2 << 20   // 语音最大 2MB
+	maxVideoDuration  = 300       // 视频最长 5 分钟
+	maxVideoSize      = 200 << 20 // 视频最大 200MB
+	maxFileSize       = 1 << 30   // 文件最大 1GB
+	maxFileNameRunes  = 255       // 文件名最大字符数
+	maxMimeTypeLen    = 128       // 文件 MIME 类型最大长度
+	maxImageDimension = 20000     // 图片/视频宽高最大像素
+	maxLocationRunes  = 100       // 位置名称最大字符数
+	maxAddressRunes   = 255       // 详细地址最大字符数
+	maxNicknameRunes  = 64        // 名片昵称最大字符数
+	maxUUIDLen        = 20        // 用户 uuid 长度上限
+	maxMsgIDLen       = 64        // 消息ID长度上限
+	maxNoticeRunes    = 500       // 群公告、系统提示最大字符数
+	maxImageFormatLen = 16        // 图片格式最大长度
+	maxTitleRunes     = 64        // 合并转发标题最大字符数
+
+	// MaxForwardItems 单次转发（合并转发的条目数）最多消息数
+	MaxForwardItems = 100
+)
+
+// ==================== 普通气泡消息 ====================
+
+// TextContent 文本消息（MsgTypeText）
+type TextContent struct {
+	Text string `json:"text"` // 文本内容
+}
+
+// Validate 校验文本非空且不超过 5000 字符
+func (c *TextContent) Validate() error {
+	return checkText("text", c.Text, maxTextRunes)
+}
+
+// Preview 预览为文本本身
+func (c *TextContent) Preview() string { return c.Text }
+
+// ImageContent 图片消息（MsgTypeImage）
+type ImageContent struct {
+	Url      string `json:"url"`       // 原图地址
+	ThumbUrl string `json:"thumb_url"` // 缩略图地址
+	Width    int64  `json:"width"`     // 宽（像素）
+	Height   int64  `json:"height"`    // 高（像素）
+	Size     int64  `json:"size"`      // 文件大小（字节）
+	Format   string `json:"format"`    // 格式（jpg/png/gif/webp 等）
+}
+
+// Validate 校验地址、尺寸与大小（最大 20MB）
+func (c *ImageContent) Validate() error {
+	if err := checkURL("url", c.Url, true); err != nil {
+		return err
+	}
+	if err := checkURL("thumb_url", c.ThumbUrl, false); err != nil {
+		return err
+	}
+	if err := checkLimit("width", c.Width, 0, maxImageDimension); err != nil {
+		return err
+	}
+	if err := checkLimit("height", c.Height, 0, maxImageDimension); err != nil {
+		return err
+	}
+	if err := checkLimit("size", c.Size, 0, maxImageSize); err != nil {
+		return err
+	}
+	return checkOptionalText("format", c.Format, maxImageFormatLen)
+}
+
+// Preview 预览为 [图片]
+func (c *ImageContent) Preview() string { return "[图片]" }
+
+// VoiceContent 语音消息（MsgTypeVoice）
+type VoiceContent struct {
+	Url      string `json:"url"`      // 语音地址
+	Duration int64  `json:"duration"` // 时长（秒）
+	Size     int64  `json:"size"`     // 文件大小（字节）
+}
+
+// Validate 校验地址、时长（1-60 秒）与大小（最大 2MB）
+func (c *VoiceContent) Validate() error {
+	if err := checkURL("url", c.Url, true); err != nil {
+		return err
+	}
+	if err := checkLimit("duration", c.Duration, 1, maxVoiceDuration); err != nil {
+		return err
+	}
+	return checkLimit("size", c.Size, 0, maxVoiceSize)
+}
+
+// Preview 预览为 [语音]
+func (c *VoiceContent) Preview() string { return "[语音]" }
+
+// VideoContent 视频消息（MsgTypeVideo）
+type VideoContent struct {
+	Url      string `json:"url"`       // 视频地址
+	CoverUrl string `json:"cover_url"` // 封面地址
+	Duration int64  `json:"duration"`  // 时长（秒）
+	Width    int64  `json:"width"`     // 宽（像素）
+	Height   int64  `json:"height"`    // 高（像素）
+	Size     int64  `json:"size"`      // 文件大小（字节）
+}
+
+// Validate 校验地址、时长（1-300 秒）、尺寸与大小（最大 200MB）
+func (c *VideoContent) Validate() error {
+	if err := checkURL("url", c.Url, true); err != nil {
+		return err
+	}
+	if err := checkURL("cover_url", c.CoverUrl, false); err != nil {
+		return err
+	}
+	if err := checkLimit("duration", c.Duration, 1, maxVideoDuration); err != nil {
+		return err
+	}
+	if err := checkLimit("width", c.Width, 0, maxImageDimension); err != nil {
+		return err
+	}
+	if err := checkLimit("height", c.Height, 0, maxImageDimension); err != nil {
+		return err
+	}
+	return checkLimit("size", c.Size, 0, maxVideoSize)
+}
+
+// Preview 预览为 [视频]
+func (c *VideoContent) Preview() string { return "[视频]" }
+
+// FileContent 文件消息（MsgTypeFile）
+type FileContent struct {
+	Url      string `json:"url"`       // 文件地址
+	Name     string `json:"name"`      // 文件名
+	Size     int64  `json:"size"`      // 文件大小（字节）
+	MimeType string `json:"mime_type"` // MIME 类型
+}
+
+// Validate 校验地址、文件名与大小（最大 1GB）
+func (c *FileContent) Validate() error {
+	if err := checkURL("url", c.Url, true); err != nil {
+		return err
+	}
+	if err := checkText("name", c.Name, maxFileNameRunes); err != nil {
+		return err
+	}
+	if err := checkLimit("size", c.Size, 1, maxFileSize); err != nil {
+		return err
+	}
+	return checkOptionalText("mime_type", c.MimeType, maxMimeTypeLen)
+}
+
+// Preview 预览为 [文件] 文件名
+func (c *FileContent) Preview() string { return "[文件] " + c.Name }
+
+// LocationContent 位置消息（MsgTypeLocation）
+type LocationContent struct {
+	Latitude  float64 `json:"latitude"`  // 纬度
+	Longitude float64 `json:"longitude"` // 经度
+	Title     string  `json:"title"`     // 位置名称
+	Address   string  `json:"address"`   // 详细地址
+}
+
+// Validate 校验经纬度范围与名称、地址长度
+func (c *LocationContent) Validate() error {
+	if c.Latitude < -90 || c.Latitude > 90 {
+		return fmt.Errorf("%w: latitude", ErrContentInvalid)
+	}
+	if c.Longitude < -180 || c.Longitude > 180 {
+		return fmt.Errorf("%w: longitude", ErrContentInvalid)
+	}
+	if err := checkOptionalText("title", c.Title, maxLocationRunes); err != nil {
+		return err
+	}
+	return checkOptionalText("address", c.Address, maxAddressRunes)
+}
+
+// Preview 预览为 [位置] 名称
+func (c *LocationContent) Preview() string { return "[位置] " + c.Title }
+
+// CardContent 名片消息（MsgTypeCard）
+type CardContent struct {
+	UserUuid string `json:"user_uuid"` // 名片用户uuid
+	Nickname string `json:"nickname"`  // 昵称
+	Avatar   string `json:"avatar"`    // 头像地址
+}
+
+// Validate 校验用户 uuid、昵称与头像地址
+func (c *CardContent) Validate() error {
+	if err := checkText("user_uuid", c.UserUuid, maxUUIDLen); err != nil {
+		return err
+	}
+	if err := checkOptionalText("nickname", c.Nickname, maxNicknameRunes); err != nil {
+		return err
+	}
+	return checkURL("avatar", c.Avatar, false)
+}
+
+// Preview 预览为 [名片] 昵称
+func (c *CardContent) Preview() string { return "[名片] " + c.Nickname }
+
+// QuoteContent 引用回复消息（MsgTypeQuote）
+// 客户端只需填写 text 与 quote_msg_id，被引用消息的快照由服务端发送时写入，原消息撤回后快照仍保留
+type QuoteContent struct {
+	Text          string `json:"text"`            // 回复内容
+	QuoteMsgId    string `json:"quote_msg_id"`    // 被引用的消息ID
+	QuoteSeq      int64  `json:"quote_seq"`       // 被引用消息的会话内序号（服务端填写）
+	QuoteFromUuid string `json:"quote_from_uuid"` // 被引用消息的发送者（服务端填写）
+	QuoteMsgType  int32  `json:"quote_msg_type"`  // 被引用消息的类型（服务端填写）
+	QuotePreview  string `json:"quote_preview"`   // 被引用消息的预览快照（服务端填写）
+}
+
+// Validate 校验回复内容与被引用的消息ID
+func (c *QuoteContent) Validate() error {
+	if err := checkText("text", c.Text, maxTextRunes); err != nil {
+		return err
+	}
+	return checkText("quote_msg_id", c.QuoteMsgId, maxMsgIDLen)
+}
+
+// Preview 预览为回复内容
+func (c *QuoteContent) Preview() string { return c.Text }
+
+// MergeForwardContent 合并转发的聊天记录（MsgTypeMergeForward），仅由转发接口生成
+type MergeForwardContent struct {
+	Title     string             `json:"title"`       // 标题（如“群聊的聊天记录”）
+	SrcConvId string             `json:"src_conv_id"` // 来源会话ID
+	Items     []MergeForwardItem `json:"items"`       // 被转发的消息快照（按原会话 seq 升序）
+}
+
+// MergeForwardItem 合并转发中的单条消息快照
+type MergeForwardItem struct {
+	MsgId    string          `json:"msg_id"`    // 原消息ID
+	FromUuid string          `json:"from_uuid"` // 原发送者
+	MsgType  int32           `json:"msg_type"`  // 原消息类型
+	Content  json.RawMessage `json:"content"`   // 原消息内容
+	SendTime int64           `json:"send_time"` // 原发送时间（毫秒时间戳）
+}
+
+// Validate 校验标题与条目（1-100 条，均为已登记的普通消息）
+func (c *MergeForwardContent) Validate() error {
+	if err := checkText("title", c.Title, maxTitleRunes); err != nil {
+		return err
+	}
+	if len(c.Items) == 0 {
+		return fmt.Errorf("%w: items", ErrContentEmpty)
+	}
+	if len(c.Items) > MaxForwardItems {
+		return fmt.Errorf("%w: items", ErrContentTooLong)
+	}
+	for _, item := range c.Items {
+		if !IsRegistered(item.MsgType) || IsControl(item.MsgType) {
+			return fmt.Errorf("%w: items.msg_type", ErrContentInvalid)
+		}
+	}
+	return nil
+}
+
+// Preview 预览为 [聊天记录] 标题
+func (c *MergeForwardContent) Preview() string { return "[聊天记录] " + c.Title }
+
+// ==================== 控制类消息（仅服务端生成） ====================
+
+// RevokeContent 撤回通知（MsgTypeRevoke）
+type RevokeContent struct {
+	MsgId        string `json:"msg_id"`        // 被撤回的消息ID
+	Seq          int64  `json:"seq"`           // 被撤回消息的会话内序号
+	OperatorUuid string `json:"operator_uuid"` // 撤回操作人
+}
+
+// Validate 校验被撤回的消息与操作人
+func (c *RevokeContent) Validate() error {
+	if err := checkText("msg_id", c.MsgId, maxMsgIDLen); err != nil {
+		return err
+	}
+	if c.Seq <= 0 {
+		return fmt.Errorf("%w: seq", ErrContentInvalid)
+	}
+	return checkText("operator_uuid", c.OperatorUuid, maxUUIDLen)
+}
+
+// Preview 预览为 [消息已撤回]
+func (c *RevokeContent) Preview() string { return "[消息已撤回]" }
+
+// GroupAnnouncementContent 群公告通知（MsgTypeGroupAnnouncement）
+type GroupAnnouncementContent struct {
+	AnnouncementId int64  `json:"announcement_id"` // 公告ID
+	Content        string `json:"content"`         // 公告内容
+	PublisherUuid  string `json:"publisher_uuid"`  // 发布人
+	Pinned         bool   `json:"pinned"`          // 是否置顶
+	RequireAck     bool   `json:"require_ack"`     // 是否需要确认
+}
+
+// Validate 校验公告ID与内容（最长 500 字符）
+func (c *GroupAnnouncementContent) Validate() error {
+	if c.AnnouncementId <= 0 {
+		return fmt.Errorf("%w: announcement_id", ErrContentInvalid)
+	}
+	if err := checkText("content", c.Content, maxNoticeRunes); err != nil {
+		return err
+	}
+	return checkText("publisher_uuid", c.PublisherUuid, maxUUIDLen)
+}
+
+// Preview 预览为 [群公告] 内容
+func (c *GroupAnnouncementContent) Preview() string { return "[群公告]" + c.Content }
+
+// SystemNoticeContent 系统提示（MsgTypeSystemNotice），客户端以灰条展示
+type SystemNoticeContent struct {
+	Text string `json:"text"` // 提示文本
+}
+
+// Validate 校验提示文本（最长 500 字符）
+func (c *SystemNoticeContent) Validate() error {
+	return checkText("text", c.Text, maxNoticeRunes)
+}
+
+// Preview 预览为提示文本
+func (c *SystemNoticeContent) Preview() string { return c.Text }
+
+// EditContent 编辑通知（MsgTypeEdit），携带编辑后的完整内容，客户端按 version 取最新
+type EditContent struct {
+	MsgId   string          `json:"msg_id"`  // 被编辑的消息ID
+	Seq     int64           `json:"seq"`     // 被编辑消息的会话内序号
+	Version int32           `json:"version"` // 编辑后的版本号
+	Content json.RawMessage `json:"content"` // 编辑后的消息内容
+}
+
+// Validate 校验被编辑的消息、版本号与内容
+func (c *EditContent) Validate() error {
+	if err := checkText("msg_id", c.MsgId, maxMsgIDLen); err != nil {
+		return err
+	}
+	if c.Seq <= 0 {
+		return fmt.Errorf("%w: seq", ErrContentInvalid)
+	}
+	if c.Version <= 0 {
+		return fmt.Errorf("%w: version", ErrContentInvalid)
+	}
+	if len(c.Content) == 0 {
+		return fmt.Errorf("%w: content", ErrContentEmpty)
+	}
+	return nil
+}
+
+// Preview 预览为 [消息已编辑]
+func (c *EditContent) Preview() string { return "[消息已编辑]" }
+
+// PinContent 置顶消息通知（MsgTypePin）
+type PinContent struct {
+	MsgId        string `json:"msg_id"`        // 被置顶的消息ID
+	Seq          int64  `json:"seq"`           // 被置顶消息的会话内序号
+	OperatorUuid string `json:"operator_uuid"` // 操作人
+}
+
+// Validate 校验被置顶的消息与操作人
+func (c *PinContent) Validate() error {
+	if err := checkText("msg_id", c.MsgId, maxMsgIDLen); err != nil {
+		return err
+	}
+	if c.Seq <= 0 {
+		return fmt.Errorf("%w: seq", ErrContentInvalid)
+	}
+	return checkText("operator_uuid", c.OperatorUuid, maxUUIDLen)
+}
+
+// Preview 预览为 [置顶了一条消息]
+func (c *PinContent) Preview() string { return "[置顶了一条消息]" }
+
+// UnpinContent 取消置顶通知（MsgTypeUnpin），结构与置顶通知相同
+type UnpinContent struct {
+	PinContent
+}
+
+// Preview 预览为 [取消置顶了一条消息]
+func (c *UnpinContent) Preview() string { return "[取消置顶了一条消息]" }
+
+// DisappearTimerContent 会话定时销毁设置变更通知（MsgTypeDisappearTimer）
+type DisappearTimerContent struct {
+	Ttl          int64  `json:"ttl"`           // 新的存活时间（秒），0 表示关闭
+	OperatorUuid string `json:"operator_uuid"` // 操作人
+}
+
+// Validate 校验存活时间范围与操作人
+func (c *DisappearTimerContent) Validate() error {
+	if c.Ttl < 0 || c.Ttl > consts.MaxMessageTTL || (c.Ttl > 0 && c.Ttl < consts.MinMessageTTL) {
+		return fmt.Errorf("%w: ttl", ErrContentInvalid)
+	}
+	return checkText("operator_uuid", c.OperatorUuid, maxUUIDLen)
+}
+
+// Preview 按开启/关闭返回不同的提示
+func (c *DisappearTimerContent) Preview() string {
+	if c.Ttl > 0 {
+		return "[开启了消息定时销毁]"
+	}
+	return "[关闭了消息定时销毁]"
+}
@@ -39,6 +39,8 @@ func Build(cfg config.MySQLConfig) (*gorm.DB, error) {
 	db, err := gorm.Open(gmysql.Open(cfg.DSN), &gorm.Config{
 		Logger: gormLog,
 		DisableForeignKeyConstraintWhenMigrating: true,
+		// 将驱动错误翻译为 gorm.ErrDuplicatedKey 等通用错误，便于 Repository 层识别唯一键冲突
+		TranslateError: true,
 	})
 	if err != nil {
 		return nil, err
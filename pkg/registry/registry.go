@@ -0,0 +1,223 @@
+// Package registry 长连接路由注册表：记录 (user_uuid, device_id) 当前连接在哪个 Connect 节点上。
+//
+// 存储结构（Redis）：
+//
+//	connect:route:{user_uuid}:{device_id}  STRING  node_id        带 TTL 的租约，由持有连接的节点定期续期
+//	connect:devices:{user_uuid}            SET     device_id      用户的在线设备索引，随路由一起续期
+//	connect:node:{node_id}                 STRING  grpc_addr      节点地址租约，供投递/踢人时回连节点
+//
+// 节点宕机后不再续期，路由与节点地址在 TTL 后自行过期；设备索引中残留的成员在 Lookup 时惰性清理。
+// 注销与续期都会先比对 node_id，避免旧节点续期或删除已迁移到新节点的路由。
+package registry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	// DefaultTTL 默认租约时长，续期间隔建议为 TTL 的 1/3
+	DefaultTTL = 90 * time.Second
+)
+
+// ErrNotFound 路由或节点不存在（设备不在线 / 节点已下线）
+var ErrNotFound = errors.New("registry: not found")
+
+// Route 一条连接路由
+type Route struct {
+	UserUUID string
+	DeviceID string
+	NodeID   string
+}
+
+// unregisterScript 比对 node_id 后删除路由并移出设备索引
+var unregisterScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	redis.call("DEL", KEYS[1])
+	redis.call("SREM", KEYS[2], ARGV[2])
+	return 1
+end
+return 0
+`)
+
+// refreshScript 续期路由与设备索引
+// 路由不存在（注册失败、Redis 重启）时补写；已被其他节点接管时不做任何事
+var refreshScript = redis.NewScript(`
+local current = redis.call("GET", KEYS[1])
+if current == ARGV[1] or not current then
+	redis.call("SET", KEYS[1], ARGV[1], "PX", ARGV[2])
+	redis.call("SADD", KEYS[2], ARGV[3])
+	redis.call("PEXPIRE", KEYS[2], ARGV[2])
+	return 1
+end
+return 0
+`)
+
+// Registry 连接路由注册表
+type Registry struct {
+	client *redis.Client
+	ttl    time.Duration
+}
+
+// New 创建连接路由注册表，ttl 为 0 时使用 DefaultTTL
+func New(client *redis.Client, ttl time.Duration) *Registry {
+	if ttl <= 0 {
+		ttl = DefaultTTL
+	}
+	return &Registry{client: client, ttl: ttl}
+}
+
+// TTL 租约时长
+func (r *Registry) TTL() time.Duration { return r.ttl }
+
+func routeKey(userUUID, deviceID string) string {
+	return fmt.Sprintf("connect:route:%s:%s", userUUID, deviceID)
+}
+
+func devicesKey(userUUID string) string {
+	return fmt.Sprintf("connect:devices:%s", userUUID)
+}
+
+func nodeKey(nodeID string) string {
+	return fmt.Sprintf("connect:node:%s", nodeID)
+}
+
+// Register 写入路由（连接建立时调用），同一设备的旧路由直接被覆盖
+func (r *Registry) Register(ctx context.Context, nodeID, userUUID, deviceID string) error {
+	pipe := r.client.TxPipeline()
+	pipe.Set(ctx, routeKey(userUUID, deviceID), nodeID, r.ttl)
+	pipe.SAdd(ctx, devicesKey(userUUID), deviceID)
+	pipe.PExpire(ctx, devicesKey(userUUID), r.ttl)
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// Unregister 删除路由（连接关闭时调用），路由已被其他节点接管时不做任何事
+func (r *Registry) Unregister(ctx context.Context, nodeID, userUUID, deviceID string) error {
+	keys := []string{routeKey(userUUID, deviceID), devicesKey(userUUID)}
+	return unregisterScript.Run(ctx, r.client, keys, nodeID, deviceID).Err()
+}
+
+// Refresh 批量续期本节点持有的路由，路由丢失时顺带补写
+func (r *Registry) Refresh(ctx context.Context, nodeID string, routes []Route) error {
+	if len(routes) == 0 {
+		return nil
+	}
+	ttlMillis := r.ttl.Milliseconds()
+
+	// 先确保脚本已加载，pipeline 中统一走 EVALSHA
+	if err := refreshScript.Load(ctx, r.client).Err(); err != nil {
+		return err
+	}
+	pipe := r.client.Pipeline()
+	for _, route := range routes {
+		keys := []string{routeKey(route.UserUUID, route.DeviceID), devicesKey(route.UserUUID)}
+		refreshScript.EvalSha(ctx, pipe, keys, nodeID, ttlMillis, route.DeviceID)
+	}
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// LookupDevice 查询设备所在节点，不在线返回 ErrNotFound
+func (r *Registry) LookupDevice(ctx context.Context, userUUID, deviceID string) (string, error) {
+	nodeID, err := r.client.Get(ctx, routeKey(userUUID, deviceID)).Result()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return "", ErrNotFound
+		}
+		return "", err
+	}
+	return nodeID, nil
+}
+
+// Lookup 查询用户所有在线设备的路由，不在线返回空切片
+func (r *Registry) Lookup(ctx context.Context, userUUID string) ([]Route, error) {
+	routes, err := r.BatchLookup(ctx, []string{userUUID})
+	if err != nil {
+		return nil, err
+	}
+	return routes[userUUID], nil
+}
+
+// BatchLookup 批量查询多个用户的在线路由（消息扇出用），结果只包含在线用户
+func (r *Registry) BatchLookup(ctx context.Context, userUUIDs []string) (map[string][]Route, error) {
+	result := make(map[string][]Route, len(userUUIDs))
+	if len(userUUIDs) == 0 {
+		return result, nil
+	}
+
+	// 1. 批量读取设备索引
+	pipe := r.client.Pipeline()
+	memberCmds := make([]*redis.StringSliceCmd, len(userUUIDs))
+	for i, userUUID := range userUUIDs {
+		memberCmds[i] = pipe.SMembers(ctx, devicesKey(userUUID))
+	}
+	if _, err := pipe.Exec(ctx); err != nil && !errors.Is(err, redis.Nil) {
+		return nil, err
+	}
+
+	// 2. 批量读取路由
+	var candidates []Route
+	var keys []string
+	for i, userUUID := range userUUIDs {
+		for _, deviceID := range memberCmds[i].Val() {
+			candidates = append(candidates, Route{UserUUID: userUUID, DeviceID: deviceID})
+			keys = append(keys, routeKey(userUUID, deviceID))
+		}
+	}
+	if len(keys) == 0 {
+		return result, nil
+	}
+	values, err := r.client.MGet(ctx, keys...).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	// 3. 过滤已过期的路由，并惰性清理设备索引
+	var stale []Route
+	for i, v := range values {
+		nodeID, ok := v.(string)
+		if !ok || nodeID == "" {
+			stale = append(stale, candidates[i])
+			continue
+		}
+		route := candidates[i]
+		route.NodeID = nodeID
+		result[route.UserUUID] = append(result[route.UserUUID], route)
+	}
+	if len(stale) > 0 {
+		pipe := r.client.Pipeline()
+		for _, route := range stale {
+			pipe.SRem(ctx, devicesKey(route.UserUUID), route.DeviceID)
+		}
+		// 清理失败不影响查询结果，下次查询会再次清理
+		_, _ = pipe.Exec(ctx)
+	}
+	return result, nil
+}
+
+// RegisterNode 写入/续期节点地址租约
+func (r *Registry) RegisterNode(ctx context.Context, nodeID, addr string) error {
+	return r.client.Set(ctx, nodeKey(nodeID), addr, r.ttl).Err()
+}
+
+// UnregisterNode 删除节点地址（优雅停机时调用）
+func (r *Registry) UnregisterNode(ctx context.Context, nodeID string) error {
+	return r.client.Del(ctx, nodeKey(nodeID)).Err()
+}
+
+// NodeAddr 查询节点地址，节点已下线返回 ErrNotFound
+func (r *Registry) NodeAddr(ctx context.Context, nodeID string) (string, error) {
+	addr, err := r.client.Get(ctx, nodeKey(nodeID)).Result()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return "", ErrNotFound
+		}
+		return "", err
+	}
+	return addr, nil
+}
@@ -0,0 +1,128 @@
+package registry
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestRegistry 基于 miniredis 创建注册表
+func newTestRegistry(t *testing.T, ttl time.Duration) (*Registry, *miniredis.Miniredis) {
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+	return New(client, ttl), mr
+}
+
+// TestRegistry_RegisterLookup 测试注册与查询
+func TestRegistry_RegisterLookup(t *testing.T) {
+	ctx := context.Background()
+	r, _ := newTestRegistry(t, time.Minute)
+
+	require.NoError(t, r.Register(ctx, "node-a", "u1", "d1"))
+	require.NoError(t, r.Register(ctx, "node-b", "u1", "d2"))
+
+	nodeID, err := r.LookupDevice(ctx, "u1", "d1")
+	require.NoError(t, err)
+	assert.Equal(t, "node-a", nodeID)
+
+	routes, err := r.Lookup(ctx, "u1")
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []Route{
+		{UserUUID: "u1", DeviceID: "d1", NodeID: "node-a"},
+		{UserUUID: "u1", DeviceID: "d2", NodeID: "node-b"},
+	}, routes)
+
+	_, err = r.LookupDevice(ctx, "u2", "d1")
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	batch, err := r.BatchLookup(ctx, []string{"u1", "u2"})
+	require.NoError(t, err)
+	assert.Len(t, batch["u1"], 2)
+	assert.NotContains(t, batch, "u2", "不在线用户不应该出现在结果中")
+}
+
+// TestRegistry_UnregisterComparesNode 测试设备迁移到新节点后，旧节点的注销不影响新路由
+func TestRegistry_UnregisterComparesNode(t *testing.T) {
+	ctx := context.Background()
+	r, _ := newTestRegistry(t, time.Minute)
+
+	require.NoError(t, r.Register(ctx, "node-a", "u1", "d1"))
+	require.NoError(t, r.Register(ctx, "node-b", "u1", "d1"))
+
+	require.NoError(t, r.Unregister(ctx, "node-a", "u1", "d1"))
+	nodeID, err := r.LookupDevice(ctx, "u1", "d1")
+	require.NoError(t, err)
+	assert.Equal(t, "node-b", nodeID)
+
+	require.NoError(t, r.Unregister(ctx, "node-b", "u1", "d1"))
+	_, err = r.LookupDevice(ctx, "u1", "d1")
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	routes, err := r.Lookup(ctx, "u1")
+	require.NoError(t, err)
+	assert.Empty(t, routes)
+}
+
+// TestRegistry_LeaseExpire 测试节点宕机（不再续期）后路由自行过期，续期的路由保留
+func TestRegistry_LeaseExpire(t *testing.T) {
+	ctx := context.Background()
+	r, mr := newTestRegistry(t, 30*time.Second)
+
+	require.NoError(t, r.Register(ctx, "node-a", "u1", "d1"))
+	require.NoError(t, r.Register(ctx, "node-b", "u1", "d2"))
+	require.NoError(t, r.RegisterNode(ctx, "node-a", "10.0.0.1:9092"))
+
+	// node-b 正常续期，node-a 宕机
+	mr.FastForward(20 * time.Second)
+	require.NoError(t, r.Refresh(ctx, "node-b", []Route{{UserUUID: "u1", DeviceID: "d2"}}))
+	mr.FastForward(20 * time.Second)
+
+	_, err := r.LookupDevice(ctx, "u1", "d1")
+	assert.ErrorIs(t, err, ErrNotFound, "未续期的路由应该过期")
+	_, err = r.NodeAddr(ctx, "node-a")
+	assert.ErrorIs(t, err, ErrNotFound, "未续期的节点地址应该过期")
+
+	routes, err := r.Lookup(ctx, "u1")
+	require.NoError(t, err)
+	assert.Equal(t, []Route{{UserUUID: "u1", DeviceID: "d2", NodeID: "node-b"}}, routes)
+
+	members, err := mr.SMembers(devicesKey("u1"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"d2"}, members, "过期设备应该从索引中清理")
+}
+
+// TestRegistry_RefreshComparesNode 测试旧节点不能续期已迁移的路由
+func TestRegistry_RefreshComparesNode(t *testing.T) {
+	ctx := context.Background()
+	r, mr := newTestRegistry(t, 30*time.Second)
+
+	require.NoError(t, r.Register(ctx, "node-a", "u1", "d1"))
+	mr.FastForward(20 * time.Second)
+	require.NoError(t, r.Register(ctx, "node-b", "u1", "d1"))
+	require.NoError(t, r.Refresh(ctx, "node-a", []Route{{UserUUID: "u1", DeviceID: "d1"}}))
+
+	nodeID, err := r.LookupDevice(ctx, "u1", "d1")
+	require.NoError(t, err)
+	assert.Equal(t, "node-b", nodeID)
+}
+
+// TestRegistry_RefreshRestoresMissing 测试路由丢失（如 Redis 重启）后续期时补写
+func TestRegistry_RefreshRestoresMissing(t *testing.T) {
+	ctx := context.Background()
+	r, mr := newTestRegistry(t, 30*time.Second)
+
+	require.NoError(t, r.Register(ctx, "node-a", "u1", "d1"))
+	mr.FlushAll()
+
+	require.NoError(t, r.Refresh(ctx, "node-a", []Route{{UserUUID: "u1", DeviceID: "d1"}}))
+	routes, err := r.Lookup(ctx, "u1")
+	require.NoError(t, err)
+	assert.Equal(t, []Route{{UserUUID: "u1", DeviceID: "d1", NodeID: "node-a"}}, routes)
+	assert.True(t, mr.TTL(routeKey("u1", "d1")) > 0, "补写的路由应该带 TTL")
+}
@@ -79,9 +79,16 @@ func parseIPFromAddr(addr string) string {
 }
 
 // GetUserUUIDFromContext 从 context 中获取用户 UUID（用于认证后的接口）
+// 进程内优先取 context value；跨服务调用时由调用方（Gateway）通过 gRPC metadata 透传
 func GetUserUUIDFromContext(ctx context.Context) string {
-	if userUUID, ok := ctx.Value(ContextKeyUserUUID).(string); ok {
+	if userUUID, ok := ctx.Value(ContextKeyUserUUID).(string); ok && userUUID != "" {
 		return userUUID
 	}
+
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get("user-uuid"); len(values) > 0 {
+			return values[0]
+		}
+	}
 	return ""
 }
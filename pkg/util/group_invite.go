@@ -0,0 +1,64 @@
+package util
+
+import (
+	"errors"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// 群邀请令牌配置常量
+const (
+	// TODO: 生产环境应从配置文件或环境变量读取
+	GroupInviteSecret = "your-group-invite-secret-change-in-production" // 群邀请令牌签名密钥
+	groupInviteIssuer = "ChatServer-GroupInvite"                        // 群邀请令牌签发者
+)
+
+// ErrGroupInviteExpired 群邀请令牌已过期
+var ErrGroupInviteExpired = errors.New("group invite token expired")
+
+// GroupInviteClaims 群邀请令牌 Claims
+type GroupInviteClaims struct {
+	GroupUUID string `json:"group_uuid"` // 群uuid
+	InviteID  int64  `json:"invite_id"`  // 群邀请记录ID（使用次数、撤销状态以数据库记录为准）
+	jwt.RegisteredClaims
+}
+
+// GenerateGroupInviteToken 生成群邀请令牌
+// issuedAt 取邀请记录的创建时间，同一邀请多次生成的令牌一致
+func GenerateGroupInviteToken(groupUUID string, inviteID int64, issuedAt, expireAt time.Time) (string, error) {
+	claims := GroupInviteClaims{
+		GroupUUID: groupUUID,
+		InviteID:  inviteID,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(expireAt),
+			IssuedAt:  jwt.NewNumericDate(issuedAt),
+			Issuer:    groupInviteIssuer,
+		},
+	}
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	return token.SignedString([]byte(GroupInviteSecret))
+}
+
+// ParseGroupInviteToken 解析并校验群邀请令牌
+// 令牌过期返回 ErrGroupInviteExpired，签名错误或格式非法返回其他错误
+func ParseGroupInviteToken(tokenString string) (*GroupInviteClaims, error) {
+	token, err := jwt.ParseWithClaims(tokenString, &GroupInviteClaims{}, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, errors.New("unexpected signing method")
+		}
+		return []byte(GroupInviteSecret), nil
+	}, jwt.WithIssuer(groupInviteIssuer))
+	if err != nil {
+		if errors.Is(err, jwt.ErrTokenExpired) {
+			return nil, ErrGroupInviteExpired
+		}
+		return nil, err
+	}
+
+	claims, ok := token.Claims.(*GroupInviteClaims)
+	if !ok || !token.Valid || claims.GroupUUID == "" || claims.InviteID <= 0 {
+		return nil, errors.New("invalid group invite token")
+	}
+	return claims, nil
+}